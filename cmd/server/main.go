@@ -56,25 +56,48 @@ func main() {
 	// Command-line flags to control the application's behavior.
 	var login bool
 	var codexLogin bool
+	var claudeLogin bool
 	var qwenLogin bool
 	var iflowLogin bool
 	var iflowCookie bool
+	var kiroLogin bool
 	var noBrowser bool
 	var oauthCallbackPort int
 	var antigravityLogin bool
 	var githubCopilotLogin bool
 	var projectID string
+	var geminiSetProject string
+	var geminiShowTier bool
+	var snapshotCreate string
+	var snapshotRestore string
 	var configPath string
 	var password string
 	var noIncognito bool
 	var useIncognito bool
+	var tui bool
+	var logs bool
+	var top bool
+	var logsFollow bool
+	var logsProvider string
+	var logsMinStatus int
+	var migrateConfig string
+	var genFixtures string
+	var fixturesOut string
+	var replayDir string
+	var benchTranslate bool
+	var benchFrom string
+	var benchTo string
+	var benchPayload string
+	var benchStreamPayload string
 
 	// Define command-line flags for different operation modes.
 	flag.BoolVar(&login, "login", false, "Login Google Account")
 	flag.BoolVar(&codexLogin, "codex-login", false, "Login to Codex using OAuth")
+	flag.BoolVar(&claudeLogin, "claude-login", false, "Login to Claude (claude.ai Pro/Max) using OAuth")
 	flag.BoolVar(&qwenLogin, "qwen-login", false, "Login to Qwen using OAuth")
 	flag.BoolVar(&iflowLogin, "iflow-login", false, "Login to iFlow using OAuth")
 	flag.BoolVar(&iflowCookie, "iflow-cookie", false, "Login to iFlow using Cookie")
+	flag.BoolVar(&kiroLogin, "kiro-login", false, "Login to Kiro using IAM Identity Center device flow")
 	flag.BoolVar(&noBrowser, "no-browser", false, "Don't open browser automatically for OAuth")
 	flag.IntVar(&oauthCallbackPort, "oauth-callback-port", 0, "Override OAuth callback port (defaults to provider-specific port)")
 	flag.BoolVar(&useIncognito, "incognito", false, "Open browser in incognito/private mode for OAuth (useful for multiple accounts)")
@@ -82,7 +105,26 @@ func main() {
 	flag.BoolVar(&antigravityLogin, "antigravity-login", false, "Login to Antigravity using OAuth")
 	flag.BoolVar(&githubCopilotLogin, "github-copilot-login", false, "Login to GitHub Copilot using device flow")
 	flag.StringVar(&projectID, "project_id", "", "Project ID (Gemini only, not required)")
+	flag.StringVar(&geminiSetProject, "gemini-set-project", "", "Switch the GCP project for a stored Gemini auth (comma-separated for multiple)")
+	flag.BoolVar(&geminiShowTier, "gemini-show-tier", false, "Show the Code Assist tier for a stored Gemini auth")
+	flag.StringVar(&snapshotCreate, "snapshot-create", "", "Write a tar.gz snapshot of config, auth files, and usage to the given path")
+	flag.StringVar(&snapshotRestore, "snapshot-restore", "", "Restore config, auth files, and usage from a snapshot archive")
 	flag.StringVar(&configPath, "config", DefaultConfigPath, "Configure File Path")
+	flag.BoolVar(&tui, "tui", false, "Open the terminal dashboard for a running instance")
+	flag.BoolVar(&logs, "logs", false, "Tail server logs from a running instance")
+	flag.BoolVar(&top, "top", false, "Show a refreshing per-model and per-auth usage table for a running instance")
+	flag.BoolVar(&logsFollow, "follow", false, "Keep following new log lines (with -logs)")
+	flag.StringVar(&logsProvider, "log-provider", "", "Only show log lines mentioning this provider (with -logs)")
+	flag.IntVar(&logsMinStatus, "log-min-status", 0, "Only show log lines with an HTTP status >= this value (with -logs)")
+	flag.StringVar(&migrateConfig, "migrate-config", "", "Import a CLIProxyAPI v6 config file or directory and write an equivalent ModelGate setup")
+	flag.StringVar(&genFixtures, "gen-fixtures", "", "Generate translator golden-test fixtures from captured request files in this directory")
+	flag.StringVar(&fixturesOut, "fixtures-out", "", "Fixture output directory (with -gen-fixtures; defaults to the golden testdata directory)")
+	flag.StringVar(&replayDir, "replay", "", "Re-send recorded translator exchanges from this directory and report regressions")
+	flag.BoolVar(&benchTranslate, "bench-translate", false, "Micro-benchmark the registered translators for one format pair")
+	flag.StringVar(&benchFrom, "from", "", "Source API format (with -bench-translate)")
+	flag.StringVar(&benchTo, "to", "", "Target API format (with -bench-translate)")
+	flag.StringVar(&benchPayload, "payload", "", "Path to a JSON request payload in the source format (with -bench-translate)")
+	flag.StringVar(&benchStreamPayload, "stream-payload", "", "Optional path to a captured response stream line (with -bench-translate)")
 	flag.StringVar(&password, "password", "", "")
 
 	flag.CommandLine.Usage = func() {
@@ -115,6 +157,30 @@ func main() {
 	// Parse the command-line flags.
 	flag.Parse()
 
+	// Handle config migration before any stores or config are initialized;
+	// the target config typically does not exist yet.
+	if migrateConfig != "" {
+		cmd.DoMigrateConfig(migrateConfig, configPath)
+		return
+	}
+
+	// Fixture generation only needs the translator registry, not a running server.
+	if genFixtures != "" {
+		cmd.DoGenFixtures(genFixtures, fixturesOut)
+		return
+	}
+
+	// Replay runs recordings through the translator registry offline.
+	if replayDir != "" {
+		cmd.DoReplay(replayDir)
+		return
+	}
+
+	if benchTranslate {
+		cmd.DoBenchTranslate(benchFrom, benchTo, benchPayload, benchStreamPayload)
+		return
+	}
+
 	// Core application variables.
 	var err error
 	var cfg *config.Config
@@ -449,7 +515,20 @@ func main() {
 
 	// Handle different command modes based on the provided flags.
 
-	if login {
+	if tui {
+		// Open the terminal dashboard against the running local instance
+		cmd.DoTUI(cfg, password)
+	} else if top {
+		// Show the refreshing usage table for the running local instance
+		cmd.DoTop(cfg, password)
+	} else if logs {
+		// Tail server logs from the running local instance
+		cmd.DoLogs(cfg, password, cmd.LogsOptions{
+			Follow:    logsFollow,
+			Provider:  logsProvider,
+			MinStatus: logsMinStatus,
+		})
+	} else if login {
 		// Handle Google/Gemini login
 		cmd.DoLogin(cfg, projectID, options)
 	} else if antigravityLogin {
@@ -461,12 +540,27 @@ func main() {
 	} else if codexLogin {
 		// Handle Codex login
 		cmd.DoCodexLogin(cfg, options)
+	} else if claudeLogin {
+		// Handle Claude login
+		cmd.DoClaudeLogin(cfg, options)
 	} else if qwenLogin {
 		cmd.DoQwenLogin(cfg, options)
 	} else if iflowLogin {
 		cmd.DoIFlowLogin(cfg, options)
 	} else if iflowCookie {
 		cmd.DoIFlowCookieAuth(cfg, options)
+	} else if kiroLogin {
+		cmd.DoKiroLogin(cfg, options)
+	} else if geminiSetProject != "" {
+		// Switch the GCP project on a stored Gemini auth without re-authenticating
+		cmd.DoGeminiSetProject(cfg, geminiSetProject, options)
+	} else if geminiShowTier {
+		// Show the Code Assist tier for a stored Gemini auth
+		cmd.DoGeminiShowTier(cfg, options)
+	} else if snapshotCreate != "" {
+		cmd.DoSnapshotCreate(cfg, configFilePath, snapshotCreate, password)
+	} else if snapshotRestore != "" {
+		cmd.DoSnapshotRestore(cfg, configFilePath, snapshotRestore, password)
 	} else {
 		// In cloud deploy mode without config file, just wait for shutdown signals
 		if isCloudDeploy && !configFileExists {
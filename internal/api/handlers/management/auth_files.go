@@ -385,6 +385,7 @@ func (h *Handler) buildAuthFileEntry(auth *coreauth.Auth) gin.H {
 		"status":         auth.Status,
 		"status_message": auth.StatusMessage,
 		"disabled":       auth.Disabled,
+		"draining":       auth.Status == coreauth.StatusDraining,
 		"unavailable":    auth.Unavailable,
 		"runtime_only":   runtimeOnly,
 		"source":         "memory",
@@ -665,6 +666,82 @@ func (h *Handler) DeleteAuthFile(c *gin.Context) {
 	c.JSON(200, gin.H{"status": "ok"})
 }
 
+// PatchAuthFile enables, disables, or drains a runtime auth identified by file
+// name or ID. Draining parks the auth for maintenance: no new requests are
+// routed to it while in-flight streams finish, so it can be safely
+// re-logged-in or removed.
+func (h *Handler) PatchAuthFile(c *gin.Context) {
+	if h == nil || h.authManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "core auth manager unavailable"})
+		return
+	}
+	var payload struct {
+		Name     string `json:"name"`
+		Disabled *bool  `json:"disabled"`
+		Draining *bool  `json:"draining"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(400, gin.H{"error": "invalid json"})
+		return
+	}
+	name := strings.TrimSpace(payload.Name)
+	if name == "" {
+		name = strings.TrimSpace(c.Query("name"))
+	}
+	if name == "" {
+		c.JSON(400, gin.H{"error": "name is required"})
+		return
+	}
+	if payload.Disabled == nil && payload.Draining == nil {
+		c.JSON(400, gin.H{"error": "disabled or draining is required"})
+		return
+	}
+
+	var target *coreauth.Auth
+	for _, auth := range h.authManager.List() {
+		if auth.FileName == name || auth.ID == name {
+			target = auth
+			break
+		}
+	}
+	if target == nil {
+		c.JSON(404, gin.H{"error": "auth not found"})
+		return
+	}
+
+	if payload.Disabled != nil {
+		target.Disabled = *payload.Disabled
+		if target.Disabled {
+			target.Status = coreauth.StatusDisabled
+			target.StatusMessage = "disabled via management API"
+		} else {
+			target.Status = coreauth.StatusActive
+			target.StatusMessage = ""
+		}
+	} else if *payload.Draining {
+		if target.Disabled {
+			c.JSON(400, gin.H{"error": "auth is disabled; enable it before draining"})
+			return
+		}
+		target.Status = coreauth.StatusDraining
+		target.StatusMessage = "draining via management API"
+	} else if target.Status == coreauth.StatusDraining {
+		target.Status = coreauth.StatusActive
+		target.StatusMessage = ""
+	}
+	target.UpdatedAt = time.Now()
+	if _, err := h.authManager.Update(c.Request.Context(), target); err != nil {
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(200, gin.H{
+		"status":   "ok",
+		"name":     name,
+		"disabled": target.Disabled,
+		"draining": target.Status == coreauth.StatusDraining,
+	})
+}
+
 func (h *Handler) authIDForPath(path string) string {
 	path = strings.TrimSpace(path)
 	if path == "" {
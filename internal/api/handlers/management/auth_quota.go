@@ -0,0 +1,135 @@
+package management
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+// blockedModelQuota describes a model that is currently held back under an auth,
+// with the reason and the time it is expected to become available again.
+type blockedModelQuota struct {
+	Model          string    `json:"model"`
+	Reason         string    `json:"reason,omitempty"`
+	NextRetryAfter time.Time `json:"next_retry_after,omitempty"`
+}
+
+// authQuotaEntry aggregates the quota-relevant state for one credential: recent
+// 429/quota signals, tier metadata, configured budget consumption and the
+// estimated time the credential recovers.
+type authQuotaEntry struct {
+	ID          string `json:"id"`
+	Provider    string `json:"provider"`
+	Label       string `json:"label,omitempty"`
+	Tier        string `json:"tier,omitempty"`
+	Disabled    bool   `json:"disabled"`
+	Unavailable bool   `json:"unavailable"`
+
+	Quota            coreauth.QuotaState         `json:"quota"`
+	LastError        *coreauth.Error             `json:"last_error,omitempty"`
+	Budget           *coreauth.KeyBudgetSnapshot `json:"budget,omitempty"`
+	BlockedModels    []blockedModelQuota         `json:"blocked_models,omitempty"`
+	EstimatedResetAt time.Time                   `json:"estimated_reset_at,omitempty"`
+}
+
+// GetAuthQuota reports per-auth quota headroom: which credentials recently hit
+// quota or rate limits, their tier/plan metadata, tracked RPM/RPD/TPM budget
+// consumption, and the estimated reset times, so operators can see at a glance
+// which accounts still have capacity.
+func (h *Handler) GetAuthQuota(c *gin.Context) {
+	manager := h.authManager
+	if manager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "auth manager unavailable"})
+		return
+	}
+
+	now := time.Now()
+	tracker := coreauth.DefaultKeyBudgetTracker()
+	entries := make([]authQuotaEntry, 0)
+	for _, a := range manager.List() {
+		if a == nil {
+			continue
+		}
+		entry := authQuotaEntry{
+			ID:          a.ID,
+			Provider:    a.Provider,
+			Label:       a.Label,
+			Tier:        authTierLabel(a),
+			Disabled:    a.Disabled,
+			Unavailable: a.Unavailable,
+			Quota:       a.Quota,
+			LastError:   a.LastError,
+		}
+
+		if snapshot, ok := tracker.Snapshot(a, now); ok {
+			entry.Budget = &snapshot
+		}
+
+		resetAt := time.Time{}
+		trackReset := func(t time.Time) {
+			if t.IsZero() || !t.After(now) {
+				return
+			}
+			if resetAt.IsZero() || t.Before(resetAt) {
+				resetAt = t
+			}
+		}
+		trackReset(a.NextRetryAfter)
+		trackReset(a.Quota.NextRecoverAt)
+
+		for model, state := range a.ModelStates {
+			if state == nil {
+				continue
+			}
+			blocked := state.Unavailable || state.Quota.Exceeded
+			if !blocked && (state.NextRetryAfter.IsZero() || !state.NextRetryAfter.After(now)) {
+				continue
+			}
+			reason := state.Quota.Reason
+			if reason == "" {
+				reason = state.StatusMessage
+			}
+			entry.BlockedModels = append(entry.BlockedModels, blockedModelQuota{
+				Model:          model,
+				Reason:         reason,
+				NextRetryAfter: state.NextRetryAfter,
+			})
+			trackReset(state.NextRetryAfter)
+			trackReset(state.Quota.NextRecoverAt)
+		}
+		sort.Slice(entry.BlockedModels, func(i, j int) bool {
+			return entry.BlockedModels[i].Model < entry.BlockedModels[j].Model
+		})
+		entry.EstimatedResetAt = resetAt
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Provider != entries[j].Provider {
+			return entries[i].Provider < entries[j].Provider
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	c.JSON(http.StatusOK, gin.H{"auth-quota": entries})
+}
+
+// authTierLabel extracts the subscription tier/plan for a credential from the
+// provider-specific metadata keys written at login time.
+func authTierLabel(a *coreauth.Auth) string {
+	if a == nil || a.Metadata == nil {
+		return ""
+	}
+	for _, key := range []string{"tier", "plan_type", "subscription"} {
+		if v, ok := a.Metadata[key].(string); ok {
+			if tier := strings.TrimSpace(v); tier != "" {
+				return tier
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package management
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+// GetAuthRollouts lists staged credential cutovers, including finished ones
+// that have not been cleared yet.
+func (h *Handler) GetAuthRollouts(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"auth-rollouts": coreauth.AuthRollouts()})
+}
+
+// PostAuthRollout starts a staged cutover from an old auth to its replacement:
+// the new auth serves a canary share of traffic until enough requests verify
+// its health, then it takes over fully and the old entry is retired.
+func (h *Handler) PostAuthRollout(c *gin.Context) {
+	if h == nil || h.authManager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "core auth manager unavailable"})
+		return
+	}
+	var payload struct {
+		Old     string `json:"old"`
+		New     string `json:"new"`
+		Percent int    `json:"percent"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		c.JSON(400, gin.H{"error": "invalid json"})
+		return
+	}
+
+	resolve := func(name string) string {
+		name = strings.TrimSpace(name)
+		for _, auth := range h.authManager.List() {
+			if auth.FileName == name || auth.ID == name {
+				return auth.ID
+			}
+		}
+		return ""
+	}
+	oldID := resolve(payload.Old)
+	newID := resolve(payload.New)
+	if oldID == "" || newID == "" {
+		c.JSON(404, gin.H{"error": "old or new auth not found"})
+		return
+	}
+
+	rollout, err := coreauth.StartAuthRollout(oldID, newID, payload.Percent)
+	if err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "rollout": rollout})
+}
+
+// DeleteAuthRollout cancels or clears the rollout tracked for the given new
+// auth (by ID or file name).
+func (h *Handler) DeleteAuthRollout(c *gin.Context) {
+	name := strings.TrimSpace(c.Query("new"))
+	if name == "" {
+		c.JSON(400, gin.H{"error": "new is required"})
+		return
+	}
+	if h != nil && h.authManager != nil {
+		for _, auth := range h.authManager.List() {
+			if auth.FileName == name {
+				name = auth.ID
+				break
+			}
+		}
+	}
+	if !coreauth.CancelAuthRollout(name) {
+		c.JSON(404, gin.H{"error": "rollout not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
@@ -0,0 +1,15 @@
+package management
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+// GetUpstreamErrorStats reports per-provider error counters grouped by
+// canonical category, plus recent distinct upstream errors, so regressions in
+// upstream behavior are visible without reading request logs.
+func (h *Handler) GetUpstreamErrorStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"upstream-errors": coreauth.SnapshotErrorMetrics()})
+}
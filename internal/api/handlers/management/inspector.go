@@ -0,0 +1,49 @@
+package management
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	runtimeexecutor "github.com/shariqriazz/modelgate/internal/runtime/executor"
+)
+
+// StreamRequestInspector streams sanitized upstream request/response summaries
+// (model, auth, status, latency, token counts) to the client as server-sent
+// events until the client disconnects. Request and response bodies are never
+// included.
+func (h *Handler) StreamRequestInspector(c *gin.Context) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "streaming is not supported"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Writer.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events, cancel := runtimeexecutor.SubscribeInspector()
+	defer cancel()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, open := <-events:
+			if !open {
+				return
+			}
+			payload, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			if _, err = c.Writer.WriteString("data: " + string(payload) + "\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package management
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+// geminiKeyBudget pairs a Gemini API key credential with its current budget consumption.
+type geminiKeyBudget struct {
+	ID     string                     `json:"id"`
+	Source string                     `json:"source,omitempty"`
+	Budget coreauth.KeyBudgetSnapshot `json:"budget"`
+}
+
+// GetGeminiKeyBudgets reports remaining RPM/RPD/TPM budget for every Gemini API
+// key that has budgets configured. Keys without rpm/rpd/tpm settings are omitted.
+func (h *Handler) GetGeminiKeyBudgets(c *gin.Context) {
+	manager := h.authManager
+	if manager == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "auth manager unavailable"})
+		return
+	}
+	now := time.Now()
+	tracker := coreauth.DefaultKeyBudgetTracker()
+	budgets := make([]geminiKeyBudget, 0)
+	for _, a := range manager.List() {
+		if a == nil || !strings.EqualFold(a.Provider, "gemini") {
+			continue
+		}
+		snapshot, ok := tracker.Snapshot(a, now)
+		if !ok {
+			continue
+		}
+		source := ""
+		if a.Attributes != nil {
+			source = a.Attributes["source"]
+		}
+		budgets = append(budgets, geminiKeyBudget{ID: a.ID, Source: source, Budget: snapshot})
+	}
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].ID < budgets[j].ID })
+	c.JSON(http.StatusOK, gin.H{"gemini-key-budgets": budgets})
+}
@@ -3,6 +3,7 @@ package management
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -32,6 +33,52 @@ func (h *Handler) GetUsageStatistics(c *gin.Context) {
 	})
 }
 
+// GetUsageHistory returns persisted usage records from the configured usage
+// store. It accepts optional since/until (RFC 3339), provider, model, api_key,
+// and limit query parameters.
+func (h *Handler) GetUsageHistory(c *gin.Context) {
+	store := usage.ActiveStore()
+	if store == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "usage storage is not configured"})
+		return
+	}
+	query := usage.RecordQuery{
+		Provider: c.Query("provider"),
+		Model:    c.Query("model"),
+		APIKey:   c.Query("api_key"),
+	}
+	if raw := c.Query("since"); raw != "" {
+		since, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid since timestamp; expected RFC 3339"})
+			return
+		}
+		query.Since = since
+	}
+	if raw := c.Query("until"); raw != "" {
+		until, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid until timestamp; expected RFC 3339"})
+			return
+		}
+		query.Until = until
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		query.Limit = limit
+	}
+	records, err := store.QueryRecords(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"records": records, "count": len(records)})
+}
+
 // ExportUsageStatistics returns a complete usage snapshot for backup/migration.
 func (h *Handler) ExportUsageStatistics(c *gin.Context) {
 	var snapshot usage.StatisticsSnapshot
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	runtimeexecutor "github.com/shariqriazz/modelgate/internal/runtime/executor"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+const (
+	// healthProbeTTL is how long a probe result is served from cache before an
+	// auth becomes eligible for re-probing.
+	healthProbeTTL = time.Minute
+	// healthProbeTimeout bounds each individual upstream probe request.
+	healthProbeTimeout = 3 * time.Second
+)
+
+// healthProbeResult is one cached upstream reachability probe.
+type healthProbeResult struct {
+	Reachable bool      `json:"reachable"`
+	Status    int       `json:"status,omitempty"`
+	LatencyMS int64     `json:"latency_ms"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// healthProber caches upstream probes per auth so repeated health checks do
+// not hammer providers: each auth is probed at most once per TTL and stale
+// results are served while a refresh is in flight.
+type healthProber struct {
+	mu       sync.Mutex
+	results  map[string]healthProbeResult
+	inflight map[string]bool
+}
+
+var defaultHealthProber = &healthProber{
+	results:  make(map[string]healthProbeResult),
+	inflight: make(map[string]bool),
+}
+
+// probe returns the cached result for the auth when fresh, refreshing it
+// synchronously otherwise. Concurrent refreshes of the same auth are
+// deduplicated; losers receive whatever is cached.
+func (p *healthProber) probe(ctx context.Context, authID, baseURL string) (healthProbeResult, bool) {
+	now := time.Now()
+	p.mu.Lock()
+	cached, hasCached := p.results[authID]
+	if hasCached && now.Sub(cached.CheckedAt) < healthProbeTTL {
+		p.mu.Unlock()
+		return cached, true
+	}
+	if p.inflight[authID] {
+		p.mu.Unlock()
+		return cached, hasCached
+	}
+	p.inflight[authID] = true
+	p.mu.Unlock()
+
+	result := probeUpstream(ctx, baseURL)
+	p.mu.Lock()
+	p.results[authID] = result
+	delete(p.inflight, authID)
+	p.mu.Unlock()
+	return result, true
+}
+
+// probeUpstream issues a cheap HEAD request against the provider base URL; any
+// HTTP response, regardless of status, proves the upstream is reachable.
+func probeUpstream(ctx context.Context, baseURL string) healthProbeResult {
+	result := healthProbeResult{CheckedAt: time.Now()}
+	reqCtx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodHead, baseURL, nil)
+	if err != nil {
+		return result
+	}
+	start := time.Now()
+	resp, err := (&http.Client{Timeout: healthProbeTimeout}).Do(req)
+	result.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		return result
+	}
+	_ = resp.Body.Close()
+	result.Reachable = true
+	result.Status = resp.StatusCode
+	return result
+}
+
+// healthAuthEntry is the per-auth section of the health response.
+type healthAuthEntry struct {
+	Provider       string             `json:"provider"`
+	ID             string             `json:"id"`
+	Label          string             `json:"label,omitempty"`
+	Usable         bool               `json:"usable"`
+	State          string             `json:"state"`
+	NextRetryAfter *time.Time         `json:"next_retry_after,omitempty"`
+	Probe          *healthProbeResult `json:"probe,omitempty"`
+}
+
+// handleHealthz is the unauthenticated liveness endpoint. It reports only the
+// aggregate counts; per-auth detail lives behind the management secret because
+// auth IDs and labels embed account identifiers.
+func (s *Server) handleHealthz(c *gin.Context) {
+	entries, usableCount := s.healthEntries(c, false)
+	status, code := healthStatus(len(entries), usableCount)
+	c.JSON(code, gin.H{
+		"status": status,
+		"usable": usableCount,
+		"total":  len(entries),
+	})
+}
+
+// handleHealthzDetail reports per-auth usability for management callers. With
+// ?probe=true it additionally performs cheap upstream reachability probes per
+// auth; probe results are cached for a minute so the endpoint stays safe to
+// poll aggressively.
+func (s *Server) handleHealthzDetail(c *gin.Context) {
+	withProbes := false
+	if raw := strings.TrimSpace(c.Query("probe")); raw != "" {
+		withProbes = raw == "1" || strings.EqualFold(raw, "true")
+	}
+	entries, usableCount := s.healthEntries(c, withProbes)
+	status, code := healthStatus(len(entries), usableCount)
+	c.JSON(code, gin.H{
+		"status": status,
+		"usable": usableCount,
+		"total":  len(entries),
+		"auths":  entries,
+	})
+}
+
+// healthStatus maps the usable/total counts onto the response status and HTTP
+// code: a gateway with auths but none usable is degraded.
+func healthStatus(total, usable int) (string, int) {
+	if total > 0 && usable == 0 {
+		return "degraded", http.StatusServiceUnavailable
+	}
+	return "ok", http.StatusOK
+}
+
+// healthEntries classifies every auth known to the manager, optionally
+// augmenting the entries with cached upstream reachability probes.
+func (s *Server) healthEntries(c *gin.Context, withProbes bool) ([]healthAuthEntry, int) {
+	var auths []*coreauth.Auth
+	if s.authManager != nil {
+		auths = s.authManager.List()
+	}
+	sort.Slice(auths, func(i, j int) bool {
+		if auths[i].Provider != auths[j].Provider {
+			return auths[i].Provider < auths[j].Provider
+		}
+		return auths[i].ID < auths[j].ID
+	})
+
+	now := time.Now()
+	entries := make([]healthAuthEntry, 0, len(auths))
+	usableCount := 0
+	var wg sync.WaitGroup
+	probeResults := make([]*healthProbeResult, len(auths))
+	if withProbes {
+		for idx, auth := range auths {
+			baseURL := runtimeexecutor.UpstreamBaseURL(auth.Provider, auth)
+			if strings.TrimSpace(baseURL) == "" {
+				continue
+			}
+			wg.Add(1)
+			go func(idx int, authID, baseURL string) {
+				defer wg.Done()
+				if result, ok := defaultHealthProber.probe(c.Request.Context(), authID, baseURL); ok {
+					probeResults[idx] = &result
+				}
+			}(idx, auth.ID, baseURL)
+		}
+		wg.Wait()
+	}
+
+	for idx, auth := range auths {
+		entry := healthAuthEntry{
+			Provider: auth.Provider,
+			ID:       auth.ID,
+			Label:    strings.TrimSpace(auth.Label),
+			Probe:    probeResults[idx],
+		}
+		switch {
+		case auth.Disabled:
+			entry.State = "disabled"
+		case auth.Unavailable || now.Before(auth.NextRetryAfter):
+			entry.State = "cooldown"
+			if !auth.NextRetryAfter.IsZero() {
+				retryAt := auth.NextRetryAfter
+				entry.NextRetryAfter = &retryAt
+			}
+		case entry.Probe != nil && !entry.Probe.Reachable:
+			entry.State = "unreachable"
+		default:
+			entry.State = "ok"
+			entry.Usable = true
+			usableCount++
+		}
+		entries = append(entries, entry)
+	}
+	return entries, usableCount
+}
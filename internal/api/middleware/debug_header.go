@@ -0,0 +1,163 @@
+// This file contains the per-request debug middleware. Privileged clients can
+// send `X-ModelGate-Debug: true` to receive the translated upstream payload,
+// chosen auth, base URL, and retry trace as response headers, which greatly
+// simplifies translator troubleshooting without enabling request-log globally.
+package middleware
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+const (
+	// DebugHeader is the request header that opts into per-request debug output.
+	DebugHeader = "X-ModelGate-Debug"
+
+	// debugMarkerKey flags the Gin context so executors record a debug trace.
+	debugMarkerKey = "API_DEBUG"
+
+	// debugTraceKey holds the per-attempt trace lines recorded by executors.
+	debugTraceKey = "API_DEBUG_TRACE"
+
+	// debugPayloadKey holds the translated upstream payload of the last attempt.
+	debugPayloadKey = "API_DEBUG_PAYLOAD"
+
+	// debugPayloadHeaderLimit caps the base64 payload header size.
+	debugPayloadHeaderLimit = 4096
+)
+
+// DebugHeaderMiddleware enables per-request debug output for privileged keys.
+// Requests without the header, or whose credential is not listed in
+// debug-api-keys, pass through unchanged.
+func DebugHeaderMiddleware(cfgFn func() *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !debugRequested(c.Request) {
+			c.Next()
+			return
+		}
+		cfg := cfgFn()
+		if cfg == nil || !debugKeyAllowed(cfg.DebugAPIKeys, c.Request) {
+			c.Next()
+			return
+		}
+
+		c.Set(debugMarkerKey, true)
+		writer := &debugResponseWriter{ResponseWriter: c.Writer, ctx: c}
+		c.Writer = writer
+		c.Next()
+		// Handle responses that finished without writing a body.
+		writer.inject()
+	}
+}
+
+func debugRequested(r *http.Request) bool {
+	value := strings.TrimSpace(r.Header.Get(DebugHeader))
+	if value == "" {
+		return false
+	}
+	enabled, err := strconv.ParseBool(value)
+	return err == nil && enabled
+}
+
+// debugKeyAllowed checks the request credential against the configured
+// debug-api-keys list, accepting the same credential locations as the inline
+// API key access provider.
+func debugKeyAllowed(allowed []string, r *http.Request) bool {
+	if len(allowed) == 0 {
+		return false
+	}
+	candidates := []string{
+		extractBearerToken(r.Header.Get("Authorization")),
+		r.Header.Get("X-Goog-Api-Key"),
+		r.Header.Get("X-Api-Key"),
+	}
+	if r.URL != nil {
+		candidates = append(candidates, r.URL.Query().Get("key"), r.URL.Query().Get("auth_token"))
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		for _, key := range allowed {
+			if key != "" && candidate == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func extractBearerToken(header string) string {
+	if header == "" {
+		return ""
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 {
+		return header
+	}
+	if strings.ToLower(parts[0]) != "bearer" {
+		return header
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// debugResponseWriter injects the debug headers immediately before the first
+// byte of the response is written, when the executor trace is complete (or,
+// for streaming, covers everything up to the first chunk).
+type debugResponseWriter struct {
+	gin.ResponseWriter
+	ctx      *gin.Context
+	injected bool
+}
+
+func (w *debugResponseWriter) WriteHeader(code int) {
+	w.inject()
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *debugResponseWriter) Write(data []byte) (int, error) {
+	w.inject()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *debugResponseWriter) WriteString(s string) (int, error) {
+	w.inject()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *debugResponseWriter) inject() {
+	if w.injected || w.ResponseWriter.Written() {
+		return
+	}
+	w.injected = true
+	header := w.ResponseWriter.Header()
+
+	if value, exists := w.ctx.Get(debugTraceKey); exists {
+		if lines, ok := value.([]string); ok {
+			for i, line := range lines {
+				header.Set(fmt.Sprintf("%s-Trace-%d", DebugHeader, i+1), sanitizeDebugHeaderValue(line))
+			}
+		}
+	}
+	if value, exists := w.ctx.Get(debugPayloadKey); exists {
+		if payload, ok := value.([]byte); ok && len(payload) > 0 {
+			encoded := base64.StdEncoding.EncodeToString(payload)
+			if len(encoded) > debugPayloadHeaderLimit {
+				encoded = encoded[:debugPayloadHeaderLimit]
+				header.Set(DebugHeader+"-Payload-Truncated", "true")
+			}
+			header.Set(DebugHeader+"-Payload", encoded)
+		}
+	}
+}
+
+func sanitizeDebugHeaderValue(value string) string {
+	value = strings.ReplaceAll(value, "\r", " ")
+	return strings.ReplaceAll(value, "\n", " ")
+}
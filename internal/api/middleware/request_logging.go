@@ -20,6 +20,8 @@ import (
 // logger, it still captures data so that upstream errors can be persisted.
 func RequestLoggingMiddleware(logger logging.RequestLogger) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		logging.ObserveRequestVolume()
+
 		if logger == nil {
 			c.Next()
 			return
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	runtimeexecutor "github.com/shariqriazz/modelgate/internal/runtime/executor"
+	coreusage "github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// realtimeUpgrader upgrades client connections on /v1/realtime. Origin checks
+// are delegated to the gateway auth middleware, matching the wsrelay manager.
+var realtimeUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// handleRealtimeProxy relays a Realtime API websocket session to the upstream
+// serving the requested model. The upstream socket is dialed with pooled
+// credentials injected through the provider's PrepareRequest hook, events are
+// forwarded in both directions unchanged, and token usage reported in
+// response.done events is accumulated and published when the session ends.
+func (s *Server) handleRealtimeProxy(c *gin.Context) {
+	model := strings.TrimSpace(c.Query("model"))
+	provider := strings.ToLower(strings.TrimSpace(c.Query("provider")))
+	if provider == "" && model != "" {
+		for _, candidate := range registry.GetGlobalRegistry().GetModelProviders(model) {
+			if s.pickUpstreamAuth(candidate) != nil {
+				provider = strings.ToLower(candidate)
+				break
+			}
+		}
+	}
+	if provider == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing model or provider"})
+		return
+	}
+	auth := s.pickUpstreamAuth(provider)
+	if auth == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no available credential for provider: " + provider})
+		return
+	}
+	baseURL := runtimeexecutor.UpstreamBaseURL(provider, auth)
+	if baseURL == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "realtime proxy is not supported for provider: " + provider})
+		return
+	}
+
+	target := strings.TrimSuffix(baseURL, "/") + "/realtime"
+	if rawQuery := c.Request.URL.RawQuery; rawQuery != "" {
+		target += "?" + rawQuery
+	}
+
+	// Run the target through the provider's PrepareRequest hook so the dial
+	// carries the same credential headers as modeled HTTP requests.
+	prep, err := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, target, nil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errPrepare := s.handlers.AuthManager.PrepareHttpRequest(c.Request.Context(), auth, prep); errPrepare != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": errPrepare.Error()})
+		return
+	}
+	dialHeader := prep.Header.Clone()
+	if beta := c.GetHeader("OpenAI-Beta"); beta != "" {
+		dialHeader.Set("OpenAI-Beta", beta)
+	}
+
+	wsTarget := target
+	switch {
+	case strings.HasPrefix(wsTarget, "https://"):
+		wsTarget = "wss://" + strings.TrimPrefix(wsTarget, "https://")
+	case strings.HasPrefix(wsTarget, "http://"):
+		wsTarget = "ws://" + strings.TrimPrefix(wsTarget, "http://")
+	}
+
+	upstreamConn, dialResp, err := websocket.DefaultDialer.DialContext(c.Request.Context(), wsTarget, dialHeader)
+	if err != nil {
+		status := http.StatusBadGateway
+		if dialResp != nil && dialResp.StatusCode > 0 {
+			status = dialResp.StatusCode
+		}
+		log.Debugf("realtime proxy dial to %s failed: %v", provider, err)
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	clientConn, err := realtimeUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Warnf("realtime proxy: upgrade failed: %v", err)
+		_ = upstreamConn.Close()
+		return
+	}
+
+	session := &realtimeSession{started: time.Now()}
+	done := make(chan struct{}, 2)
+
+	// Client -> upstream: forward events unchanged.
+	go func() {
+		defer func() { done <- struct{}{} }()
+		for {
+			messageType, data, errRead := clientConn.ReadMessage()
+			if errRead != nil {
+				return
+			}
+			if errWrite := upstreamConn.WriteMessage(messageType, data); errWrite != nil {
+				return
+			}
+		}
+	}()
+
+	// Upstream -> client: forward events and harvest usage from response.done.
+	go func() {
+		defer func() { done <- struct{}{} }()
+		for {
+			messageType, data, errRead := upstreamConn.ReadMessage()
+			if errRead != nil {
+				return
+			}
+			if messageType == websocket.TextMessage {
+				session.observe(data)
+			}
+			if errWrite := clientConn.WriteMessage(messageType, data); errWrite != nil {
+				return
+			}
+		}
+	}()
+
+	<-done
+	_ = clientConn.Close()
+	_ = upstreamConn.Close()
+	<-done
+
+	session.publish(c, provider, model, auth.ID)
+}
+
+// realtimeSession accumulates per-session token usage from Realtime API events.
+type realtimeSession struct {
+	mu      sync.Mutex
+	started time.Time
+	detail  coreusage.Detail
+}
+
+// observe extracts usage from a response.done event when present.
+func (s *realtimeSession) observe(data []byte) {
+	root := gjson.ParseBytes(data)
+	if root.Get("type").String() != "response.done" {
+		return
+	}
+	usage := root.Get("response.usage")
+	if !usage.Exists() {
+		return
+	}
+	s.mu.Lock()
+	s.detail.InputTokens += usage.Get("input_tokens").Int()
+	s.detail.OutputTokens += usage.Get("output_tokens").Int()
+	s.detail.CachedTokens += usage.Get("input_token_details.cached_tokens").Int()
+	s.detail.TotalTokens += usage.Get("total_tokens").Int()
+	s.mu.Unlock()
+}
+
+// publish emits the accumulated session usage once the relay has finished.
+// The request context is already torn down with the hijacked connection, so
+// the record is published on a background context.
+func (s *realtimeSession) publish(_ *gin.Context, provider, model, authID string) {
+	s.mu.Lock()
+	detail := s.detail
+	s.mu.Unlock()
+	coreusage.PublishRecord(context.Background(), coreusage.Record{
+		Provider:    provider,
+		Model:       model,
+		AuthID:      authID,
+		Source:      "realtime",
+		RequestedAt: s.started,
+		Detail:      detail,
+	})
+}
@@ -23,18 +23,24 @@ import (
 	"github.com/shariqriazz/modelgate/internal/api/middleware"
 	"github.com/shariqriazz/modelgate/internal/api/modules"
 	ampmodule "github.com/shariqriazz/modelgate/internal/api/modules/amp"
+	"github.com/shariqriazz/modelgate/internal/audit"
 	"github.com/shariqriazz/modelgate/internal/config"
 	"github.com/shariqriazz/modelgate/internal/logging"
 	"github.com/shariqriazz/modelgate/internal/managementasset"
+	"github.com/shariqriazz/modelgate/internal/recording"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	runtimeexecutor "github.com/shariqriazz/modelgate/internal/runtime/executor"
 	"github.com/shariqriazz/modelgate/internal/usage"
 	"github.com/shariqriazz/modelgate/internal/util"
 	sdkaccess "github.com/shariqriazz/modelgate/sdk/access"
 	"github.com/shariqriazz/modelgate/sdk/api/handlers"
 	"github.com/shariqriazz/modelgate/sdk/api/handlers/claude"
 	"github.com/shariqriazz/modelgate/sdk/api/handlers/gemini"
+	"github.com/shariqriazz/modelgate/sdk/api/handlers/ollama"
 	"github.com/shariqriazz/modelgate/sdk/api/handlers/openai"
 	sdkAuth "github.com/shariqriazz/modelgate/sdk/auth"
 	"github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v3"
 )
@@ -122,6 +128,10 @@ type Server struct {
 	// handlers contains the API handlers for processing requests.
 	handlers *handlers.BaseAPIHandler
 
+	// authManager is the core runtime auth manager, used by the health
+	// endpoint to report per-auth usability.
+	authManager *auth.Manager
+
 	// cfg holds the current server configuration.
 	cfg *config.Config
 
@@ -219,6 +229,11 @@ func NewServer(cfg *config.Config, authManager *auth.Manager, accessManager *sdk
 			if setter, ok := requestLogger.(interface{ SetEnabled(bool) }); ok {
 				toggle = setter.SetEnabled
 			}
+			if retainer, ok := requestLogger.(interface {
+				SetRetention(config.RequestLogRetentionConfig)
+			}); ok {
+				retainer.SetRetention(cfg.RequestLogRetention)
+			}
 		}
 	}
 
@@ -236,6 +251,7 @@ func NewServer(cfg *config.Config, authManager *auth.Manager, accessManager *sdk
 	s := &Server{
 		engine:              engine,
 		handlers:            handlers.NewBaseAPIHandlers(&cfg.SDKConfig, authManager),
+		authManager:         authManager,
 		cfg:                 cfg,
 		accessManager:       accessManager,
 		requestLogger:       requestLogger,
@@ -254,6 +270,25 @@ func NewServer(cfg *config.Config, authManager *auth.Manager, accessManager *sdk
 	}
 	managementasset.SetCurrentConfig(cfg)
 	auth.SetQuotaCooldownDisabled(cfg.DisableCooling)
+	registry.SetCatalogWebhook(cfg.ModelCatalogWebhookURL)
+	registry.SetModelPricingOverrides(pricingOverrideRules(cfg.Pricing))
+	usage.SetClientBudgets(cfg.ClientBudgets)
+	audit.Configure(cfg.AuditLog.Enabled, cfg.AuditLog.File)
+	recording.Configure(cfg.Recording.Enabled, cfg.Recording.Dir)
+	runtimeexecutor.SetUsageHeadersEnabled(cfg.UsageHeaders)
+	runtimeexecutor.SetStreamScannerLimits(cfg.StreamScannerLimitMB)
+	auth.SetFakeStreamingModels(cfg.FakeStreamingModels)
+	auth.SetStreamUpstreamModels(cfg.StreamUpstreamModels)
+	auth.SetModelTransports(cfg.ModelTransport)
+	auth.SetAuthDailyCaps(cfg.AuthDailyCaps)
+	auth.SetRoutingTimeWindows(routingTimeWindowRules(cfg.Routing.TimeWindows))
+	auth.SetRequestCoalescingEnabled(cfg.CoalesceIdenticalRequests)
+	sdktranslator.SetUnknownFieldPassthrough(cfg.PassthroughUnknownFields)
+	sdktranslator.SetSameFormatPassthrough(cfg.SameFormatPassthrough)
+	sdktranslator.SetPassthroughModels(cfg.PassthroughModels)
+	auth.SetBurstSmoothingRPS(cfg.BurstSmoothingRPS)
+	auth.SetQuotaWaitRetryThreshold(time.Duration(cfg.QuotaExceeded.MaxWaitSeconds) * time.Second)
+	auth.SetUpstreamRetryPolicy(cfg.UpstreamRetry.Attempts, time.Duration(cfg.UpstreamRetry.BudgetSeconds)*time.Second)
 	// Initialize management handler
 	s.mgmt = managementHandlers.NewHandler(cfg, configFilePath, authManager)
 	if optionState.localPassword != "" {
@@ -266,6 +301,9 @@ func NewServer(cfg *config.Config, authManager *auth.Manager, accessManager *sdk
 	s.mgmt.SetLogDirectory(logDir)
 	s.localPassword = optionState.localPassword
 
+	// Per-request debug output for privileged keys (X-ModelGate-Debug header).
+	engine.Use(middleware.DebugHeaderMiddleware(func() *config.Config { return s.cfg }))
+
 	// Setup routes
 	s.setupRoutes()
 
@@ -315,6 +353,7 @@ func (s *Server) setupRoutes() {
 	geminiCLIHandlers := gemini.NewGeminiCLIAPIHandler(s.handlers)
 	claudeCodeHandlers := claude.NewClaudeCodeAPIHandler(s.handlers)
 	openaiResponsesHandlers := openai.NewOpenAIResponsesAPIHandler(s.handlers)
+	ollamaHandlers := ollama.NewOllamaAPIHandler(s.handlers)
 
 	// OpenAI compatible API routes
 	v1 := s.engine.Group("/v1")
@@ -327,6 +366,12 @@ func (s *Server) setupRoutes() {
 		v1.POST("/messages/count_tokens", claudeCodeHandlers.ClaudeCountTokens)
 		v1.POST("/responses", openaiResponsesHandlers.Responses)
 		v1.POST("/responses/compact", openaiResponsesHandlers.Compact)
+		v1.POST("/moderations", openaiHandlers.Moderations)
+		v1.POST("/embeddings", openaiHandlers.Embeddings)
+		v1.POST("/images/generations", openaiHandlers.ImagesGenerations)
+		v1.POST("/audio/transcriptions", openaiHandlers.AudioTranscriptions)
+		v1.POST("/audio/speech", openaiHandlers.AudioSpeech)
+		v1.GET("/realtime", s.handleRealtimeProxy)
 	}
 
 	// Gemini compatible API routes
@@ -338,6 +383,25 @@ func (s *Server) setupRoutes() {
 		v1beta.GET("/models/*action", geminiHandlers.GeminiGetHandler)
 	}
 
+	// Ollama-compatible API routes for tools that only speak the Ollama protocol
+	ollamaGroup := s.engine.Group("/api")
+	ollamaGroup.Use(AuthMiddleware(s.accessManager))
+	{
+		ollamaGroup.GET("/tags", ollamaHandlers.OllamaTags)
+		ollamaGroup.POST("/chat", ollamaHandlers.OllamaChat)
+		ollamaGroup.POST("/generate", ollamaHandlers.OllamaGenerate)
+	}
+
+	// Raw upstream proxy routes: forward arbitrary provider paths with
+	// pooled credentials injected.
+	upstream := s.engine.Group("/upstream")
+	upstream.Use(AuthMiddleware(s.accessManager))
+	upstream.Any("/:provider/*path", s.handleUpstreamProxy)
+
+	// Liveness check: aggregate auth usability counts only. The per-auth
+	// detail lives under the management group.
+	s.engine.GET("/healthz", s.handleHealthz)
+
 	// Root endpoint
 	s.engine.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -495,11 +559,15 @@ func (s *Server) registerManagementRoutes() {
 	log.Info("management routes registered after secret key configuration")
 
 	mgmt := s.engine.Group("/v0/management")
-	mgmt.Use(s.managementAvailabilityMiddleware(), s.mgmt.Middleware())
+	mgmt.Use(s.managementAvailabilityMiddleware(), s.mgmt.Middleware(), auditMiddleware())
 	{
+		mgmt.GET("/upstream-errors", s.mgmt.GetUpstreamErrorStats)
 		mgmt.GET("/usage", s.mgmt.GetUsageStatistics)
 		mgmt.GET("/usage/export", s.mgmt.ExportUsageStatistics)
+		mgmt.GET("/usage/history", s.mgmt.GetUsageHistory)
 		mgmt.POST("/usage/import", s.mgmt.ImportUsageStatistics)
+		mgmt.GET("/request-inspector", s.mgmt.StreamRequestInspector)
+		mgmt.GET("/healthz", s.handleHealthzDetail)
 		mgmt.GET("/config", s.mgmt.GetConfig)
 		mgmt.GET("/config.yaml", s.mgmt.GetConfigYAML)
 		mgmt.PUT("/config.yaml", s.mgmt.PutConfigYAML)
@@ -541,6 +609,14 @@ func (s *Server) registerManagementRoutes() {
 		mgmt.PATCH("/api-keys", s.mgmt.PatchAPIKeys)
 		mgmt.DELETE("/api-keys", s.mgmt.DeleteAPIKeys)
 
+		mgmt.GET("/gemini-api-key-budgets", s.mgmt.GetGeminiKeyBudgets)
+
+		mgmt.GET("/auth-quota", s.mgmt.GetAuthQuota)
+
+		mgmt.GET("/auth-rollouts", s.mgmt.GetAuthRollouts)
+		mgmt.POST("/auth-rollouts", s.mgmt.PostAuthRollout)
+		mgmt.DELETE("/auth-rollouts", s.mgmt.DeleteAuthRollout)
+
 		mgmt.GET("/gemini-api-key", s.mgmt.GetGeminiKeys)
 		mgmt.PUT("/gemini-api-key", s.mgmt.PutGeminiKeys)
 		mgmt.PATCH("/gemini-api-key", s.mgmt.PatchGeminiKey)
@@ -637,6 +713,7 @@ func (s *Server) registerManagementRoutes() {
 		mgmt.GET("/model-definitions/:channel", s.mgmt.GetStaticModelDefinitions)
 		mgmt.GET("/auth-files/download", s.mgmt.DownloadAuthFile)
 		mgmt.POST("/auth-files", s.mgmt.UploadAuthFile)
+		mgmt.PATCH("/auth-files", s.mgmt.PatchAuthFile)
 		mgmt.DELETE("/auth-files", s.mgmt.DeleteAuthFile)
 
 		mgmt.GET("/codex-auth-url", s.mgmt.RequestCodexToken)
@@ -903,7 +980,16 @@ func (s *Server) UpdateClients(cfg *config.Config) {
 		}
 	}
 
-	if oldCfg == nil || oldCfg.LoggingToFile != cfg.LoggingToFile || oldCfg.LogsMaxTotalSizeMB != cfg.LogsMaxTotalSizeMB {
+	if s.requestLogger != nil && oldCfg != nil && oldCfg.RequestLogRetention != cfg.RequestLogRetention {
+		if retainer, ok := s.requestLogger.(interface {
+			SetRetention(config.RequestLogRetentionConfig)
+		}); ok {
+			retainer.SetRetention(cfg.RequestLogRetention)
+			log.Debug("request log retention rules updated")
+		}
+	}
+
+	if oldCfg == nil || oldCfg.LoggingToFile != cfg.LoggingToFile || oldCfg.LogsMaxTotalSizeMB != cfg.LogsMaxTotalSizeMB || oldCfg.LoggingFormat != cfg.LoggingFormat {
 		if err := logging.ConfigureLogOutput(cfg); err != nil {
 			log.Errorf("failed to reconfigure log output: %v", err)
 		} else {
@@ -916,10 +1002,16 @@ func (s *Server) UpdateClients(cfg *config.Config) {
 				if oldCfg.LogsMaxTotalSizeMB != cfg.LogsMaxTotalSizeMB {
 					log.Debugf("logs_max_total_size_mb updated from %d to %d", oldCfg.LogsMaxTotalSizeMB, cfg.LogsMaxTotalSizeMB)
 				}
+				if oldCfg.LoggingFormat != cfg.LoggingFormat {
+					log.Debugf("logging_format updated from %q to %q", oldCfg.LoggingFormat, cfg.LoggingFormat)
+				}
 			}
 		}
 	}
 
+	// SetLogSampling is a no-op when the sampling configuration is unchanged.
+	logging.SetLogSampling(cfg.LogSampling)
+
 	if oldCfg == nil || oldCfg.UsageStatisticsEnabled != cfg.UsageStatisticsEnabled {
 		usage.SetStatisticsEnabled(cfg.UsageStatisticsEnabled)
 		if oldCfg != nil {
@@ -929,6 +1021,72 @@ func (s *Server) UpdateClients(cfg *config.Config) {
 		}
 	}
 
+	if oldCfg == nil || oldCfg.ModelCatalogWebhookURL != cfg.ModelCatalogWebhookURL {
+		registry.SetCatalogWebhook(cfg.ModelCatalogWebhookURL)
+	}
+
+	// These setters replace their rule sets wholesale; no diff needed.
+	runtimeexecutor.SetStreamScannerLimits(cfg.StreamScannerLimitMB)
+	auth.SetFakeStreamingModels(cfg.FakeStreamingModels)
+	auth.SetStreamUpstreamModels(cfg.StreamUpstreamModels)
+	auth.SetModelTransports(cfg.ModelTransport)
+	auth.SetAuthDailyCaps(cfg.AuthDailyCaps)
+	auth.SetRoutingTimeWindows(routingTimeWindowRules(cfg.Routing.TimeWindows))
+	registry.SetModelPricingOverrides(pricingOverrideRules(cfg.Pricing))
+	usage.SetClientBudgets(cfg.ClientBudgets)
+	audit.Configure(cfg.AuditLog.Enabled, cfg.AuditLog.File)
+	recording.Configure(cfg.Recording.Enabled, cfg.Recording.Dir)
+	sdktranslator.SetPassthroughModels(cfg.PassthroughModels)
+
+	if oldCfg == nil || oldCfg.UsageHeaders != cfg.UsageHeaders {
+		runtimeexecutor.SetUsageHeadersEnabled(cfg.UsageHeaders)
+		if oldCfg != nil {
+			log.Debugf("usage_headers updated from %t to %t", oldCfg.UsageHeaders, cfg.UsageHeaders)
+		}
+	}
+
+	if oldCfg == nil || oldCfg.CoalesceIdenticalRequests != cfg.CoalesceIdenticalRequests {
+		auth.SetRequestCoalescingEnabled(cfg.CoalesceIdenticalRequests)
+		if oldCfg != nil {
+			log.Debugf("coalesce_identical_requests updated from %t to %t", oldCfg.CoalesceIdenticalRequests, cfg.CoalesceIdenticalRequests)
+		}
+	}
+
+	if oldCfg == nil || oldCfg.PassthroughUnknownFields != cfg.PassthroughUnknownFields {
+		sdktranslator.SetUnknownFieldPassthrough(cfg.PassthroughUnknownFields)
+		if oldCfg != nil {
+			log.Debugf("passthrough_unknown_fields updated from %t to %t", oldCfg.PassthroughUnknownFields, cfg.PassthroughUnknownFields)
+		}
+	}
+
+	if oldCfg == nil || oldCfg.SameFormatPassthrough != cfg.SameFormatPassthrough {
+		sdktranslator.SetSameFormatPassthrough(cfg.SameFormatPassthrough)
+		if oldCfg != nil {
+			log.Debugf("same_format_passthrough updated from %t to %t", oldCfg.SameFormatPassthrough, cfg.SameFormatPassthrough)
+		}
+	}
+
+	if oldCfg == nil || oldCfg.BurstSmoothingRPS != cfg.BurstSmoothingRPS {
+		auth.SetBurstSmoothingRPS(cfg.BurstSmoothingRPS)
+		if oldCfg != nil {
+			log.Debugf("burst_smoothing_rps updated from %g to %g", oldCfg.BurstSmoothingRPS, cfg.BurstSmoothingRPS)
+		}
+	}
+
+	if oldCfg == nil || oldCfg.QuotaExceeded.MaxWaitSeconds != cfg.QuotaExceeded.MaxWaitSeconds {
+		auth.SetQuotaWaitRetryThreshold(time.Duration(cfg.QuotaExceeded.MaxWaitSeconds) * time.Second)
+		if oldCfg != nil {
+			log.Debugf("quota_exceeded.max_wait_seconds updated from %d to %d", oldCfg.QuotaExceeded.MaxWaitSeconds, cfg.QuotaExceeded.MaxWaitSeconds)
+		}
+	}
+
+	if oldCfg == nil || oldCfg.UpstreamRetry != cfg.UpstreamRetry {
+		auth.SetUpstreamRetryPolicy(cfg.UpstreamRetry.Attempts, time.Duration(cfg.UpstreamRetry.BudgetSeconds)*time.Second)
+		if oldCfg != nil {
+			log.Debugf("upstream_retry updated to attempts=%d budget=%ds", cfg.UpstreamRetry.Attempts, cfg.UpstreamRetry.BudgetSeconds)
+		}
+	}
+
 	if oldCfg == nil || oldCfg.DisableCooling != cfg.DisableCooling {
 		auth.SetQuotaCooldownDisabled(cfg.DisableCooling)
 		if oldCfg != nil {
@@ -1051,6 +1209,19 @@ func (s *Server) SetWebsocketAuthChangeHandler(fn func(bool, bool)) {
 
 // (management handlers moved to internal/api/handlers/management)
 
+// auditMiddleware records mutating management API calls in the audit log
+// once the handler has run. Read-only methods are not audited.
+func auditMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			return
+		}
+		audit.Record("management@"+c.ClientIP(), strings.ToLower(c.Request.Method), c.Request.URL.Path, fmt.Sprintf("status=%d", c.Writer.Status()))
+	}
+}
+
 // AuthMiddleware returns a Gin middleware handler that authenticates requests
 // using the configured authentication providers. When no providers are available,
 // it allows all requests (legacy behaviour).
@@ -1064,6 +1235,10 @@ func AuthMiddleware(manager *sdkaccess.Manager) gin.HandlerFunc {
 		result, err := manager.Authenticate(c.Request.Context(), c.Request)
 		if err == nil {
 			if result != nil {
+				if exceeded := usage.CheckClientBudget(result.Principal); exceeded != nil {
+					c.AbortWithStatusJSON(exceeded.StatusCode(), gin.H{"error": exceeded.Error()})
+					return
+				}
 				c.Set("apiKey", result.Principal)
 				c.Set("accessProvider", result.Provider)
 				if len(result.Metadata) > 0 {
@@ -1085,3 +1260,28 @@ func AuthMiddleware(manager *sdkaccess.Manager) gin.HandlerFunc {
 		}
 	}
 }
+
+// pricingOverrideRules converts configured pricing rules into registry overrides.
+func pricingOverrideRules(rules []config.PricingRule) []registry.ModelPricingOverride {
+	overrides := make([]registry.ModelPricingOverride, 0, len(rules))
+	for _, rule := range rules {
+		overrides = append(overrides, registry.ModelPricingOverride{
+			Pattern: rule.Model,
+			Pricing: registry.ModelPricing{
+				InputUSDPerMillion:  rule.Input,
+				OutputUSDPerMillion: rule.Output,
+				CachedUSDPerMillion: rule.Cached,
+			},
+		})
+	}
+	return overrides
+}
+
+// routingTimeWindowRules converts configured time windows into selector rules.
+func routingTimeWindowRules(windows []config.RoutingTimeWindow) []auth.TimeWindowRule {
+	rules := make([]auth.TimeWindowRule, 0, len(windows))
+	for _, window := range windows {
+		rules = append(rules, auth.TimeWindowRule{Start: window.Start, End: window.End, Prefer: window.Prefer})
+	}
+	return rules
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	runtimeexecutor "github.com/shariqriazz/modelgate/internal/runtime/executor"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// strippedUpstreamHeaders are request headers never forwarded to the provider:
+// the client's gateway credentials (replaced by pooled provider credentials)
+// and hop-by-hop headers owned by the HTTP transport.
+var strippedUpstreamHeaders = []string{
+	"Authorization",
+	"X-Api-Key",
+	"X-Goog-Api-Key",
+	"Cookie",
+	"Host",
+	"Connection",
+	"Proxy-Authorization",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// handleUpstreamProxy forwards an arbitrary request path to the provider named
+// in the route with pooled credentials injected, giving authenticated clients
+// access to upstream endpoints the gateway does not model (files, caches,
+// tuning). The body and remaining headers pass through untouched.
+func (s *Server) handleUpstreamProxy(c *gin.Context) {
+	provider := strings.ToLower(strings.TrimSpace(c.Param("provider")))
+	if provider == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing provider"})
+		return
+	}
+	auth := s.pickUpstreamAuth(provider)
+	if auth == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no available credential for provider: " + provider})
+		return
+	}
+	baseURL := runtimeexecutor.UpstreamBaseURL(provider, auth)
+	if baseURL == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "raw upstream proxy is not supported for provider: " + provider})
+		return
+	}
+
+	target := strings.TrimSuffix(baseURL, "/") + c.Param("path")
+	if rawQuery := c.Request.URL.RawQuery; rawQuery != "" {
+		target += "?" + rawQuery
+	}
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), c.Request.Method, target, c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Header = c.Request.Header.Clone()
+	for _, name := range strippedUpstreamHeaders {
+		req.Header.Del(name)
+	}
+
+	resp, err := s.handlers.AuthManager.HttpRequest(c.Request.Context(), auth, req)
+	if err != nil {
+		log.Debugf("upstream proxy request to %s failed: %v", provider, err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	for name, values := range resp.Header {
+		for _, value := range values {
+			c.Writer.Header().Add(name, value)
+		}
+	}
+	c.Status(resp.StatusCode)
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := c.Writer.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+		if readErr != nil {
+			return
+		}
+	}
+}
+
+// pickUpstreamAuth selects a pooled credential for the provider, preferring
+// entries not flagged unavailable by recent failures.
+func (s *Server) pickUpstreamAuth(provider string) *coreauth.Auth {
+	if s == nil || s.handlers == nil || s.handlers.AuthManager == nil {
+		return nil
+	}
+	var fallback *coreauth.Auth
+	for _, a := range s.handlers.AuthManager.List() {
+		if a == nil || a.Disabled || !strings.EqualFold(a.Provider, provider) {
+			continue
+		}
+		if !a.Unavailable {
+			return a
+		}
+		if fallback == nil {
+			fallback = a
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,92 @@
+// Package audit provides an append-only audit log for administrative and
+// credential lifecycle actions: auth additions and updates, token refreshes,
+// config reloads, and mutating management API calls. Entries are JSON lines
+// with actor, action, target, and a short detail summary.
+package audit
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultFile is the audit log path used when audit logging is enabled
+// without an explicit file.
+const defaultFile = "audit.log"
+
+// Entry is one audit log line.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	path    string
+	file    *os.File
+)
+
+// Configure enables or disables audit logging and sets the log file path.
+// It is wired to the audit-log config key and safe to call on reload; an
+// empty path falls back to the default file.
+func Configure(enable bool, filePath string) {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		filePath = defaultFile
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if file != nil && (!enable || filePath != path) {
+		_ = file.Close()
+		file = nil
+	}
+	enabled = enable
+	path = filePath
+}
+
+// Record appends one entry to the audit log. It is a no-op when audit
+// logging is disabled; write failures are logged but never propagate to the
+// action being audited.
+func Record(actor, action, target, detail string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if !enabled {
+		return
+	}
+	if file == nil {
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				log.Errorf("audit: failed to create log directory: %v", err)
+				return
+			}
+		}
+		handle, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			log.Errorf("audit: failed to open log file: %v", err)
+			return
+		}
+		file = handle
+	}
+	line, err := json.Marshal(Entry{
+		Timestamp: time.Now().UTC(),
+		Actor:     actor,
+		Action:    action,
+		Target:    target,
+		Detail:    detail,
+	})
+	if err != nil {
+		return
+	}
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		log.Errorf("audit: failed to append entry: %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+// Package claude implements Anthropic's consumer OAuth flow (claude.ai Pro/Max
+// subscriptions) so those accounts can be pooled alongside API-key credentials.
+// The flow mirrors the Claude Code CLI: an authorization code is issued on the
+// claude.ai console and pasted back into the terminal, then exchanged for
+// bearer tokens against the Anthropic console token endpoint.
+package claude
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/util"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	claudeOAuthAuthorizeEndpoint = "https://claude.ai/oauth/authorize"
+	claudeOAuthTokenEndpoint     = "https://console.anthropic.com/v1/oauth/token"
+	claudeOAuthRedirectURI       = "https://console.anthropic.com/oauth/code/callback"
+	claudeOAuthClientID          = "9d1c250a-e61b-44d9-88ed-5944d1962f5e"
+	claudeOAuthScopes            = "org:create_api_key user:profile user:inference"
+)
+
+// DefaultAPIBaseURL is the canonical Anthropic API endpoint for OAuth-backed requests.
+const DefaultAPIBaseURL = "https://api.anthropic.com"
+
+// OAuthBetaHeader is the anthropic-beta value required for consumer OAuth bearer tokens.
+const OAuthBetaHeader = "oauth-2025-04-20"
+
+// ClaudeAuth encapsulates the HTTP client helpers for the OAuth flow.
+type ClaudeAuth struct {
+	httpClient *http.Client
+}
+
+// NewClaudeAuth constructs a new ClaudeAuth with proxy-aware transport.
+func NewClaudeAuth(cfg *config.Config) *ClaudeAuth {
+	client := &http.Client{Timeout: 30 * time.Second}
+	return &ClaudeAuth{httpClient: util.SetProxy(&cfg.SDKConfig, client)}
+}
+
+// PKCECodes holds a PKCE verifier and its derived S256 challenge.
+type PKCECodes struct {
+	CodeVerifier  string
+	CodeChallenge string
+}
+
+// GeneratePKCECodes creates a cryptographically random verifier and matching
+// S256 challenge as specified in RFC 7636.
+func GeneratePKCECodes() (*PKCECodes, error) {
+	raw := make([]byte, 64)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("claude pkce: generate random bytes failed: %w", err)
+	}
+	verifier := base64.URLEncoding.WithPadding(base64.NoPadding).EncodeToString(raw)
+	hash := sha256.Sum256([]byte(verifier))
+	challenge := base64.URLEncoding.WithPadding(base64.NoPadding).EncodeToString(hash[:])
+	return &PKCECodes{CodeVerifier: verifier, CodeChallenge: challenge}, nil
+}
+
+// AuthorizationURL builds the claude.ai authorization URL for the manual-paste flow.
+// The console callback page displays a "code#state" string the user copies back.
+func (ca *ClaudeAuth) AuthorizationURL(state string, pkce *PKCECodes) string {
+	values := url.Values{}
+	values.Set("code", "true")
+	values.Set("client_id", claudeOAuthClientID)
+	values.Set("response_type", "code")
+	values.Set("redirect_uri", claudeOAuthRedirectURI)
+	values.Set("scope", claudeOAuthScopes)
+	values.Set("code_challenge", pkce.CodeChallenge)
+	values.Set("code_challenge_method", "S256")
+	values.Set("state", state)
+	return fmt.Sprintf("%s?%s", claudeOAuthAuthorizeEndpoint, values.Encode())
+}
+
+// ExchangeCode exchanges a pasted authorization code for access and refresh tokens.
+// The code may carry the state suffixed after '#' as shown on the callback page.
+func (ca *ClaudeAuth) ExchangeCode(ctx context.Context, code, state string, pkce *PKCECodes) (*ClaudeTokenData, error) {
+	trimmed := strings.TrimSpace(code)
+	if idx := strings.Index(trimmed, "#"); idx >= 0 {
+		if returned := strings.TrimSpace(trimmed[idx+1:]); returned != "" {
+			state = returned
+		}
+		trimmed = strings.TrimSpace(trimmed[:idx])
+	}
+	if trimmed == "" {
+		return nil, fmt.Errorf("claude token: authorization code is empty")
+	}
+
+	payload := map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          trimmed,
+		"state":         state,
+		"client_id":     claudeOAuthClientID,
+		"redirect_uri":  claudeOAuthRedirectURI,
+		"code_verifier": pkce.CodeVerifier,
+	}
+	return ca.doTokenRequest(ctx, payload)
+}
+
+// RefreshTokens exchanges a refresh token for a new access token.
+func (ca *ClaudeAuth) RefreshTokens(ctx context.Context, refreshToken string) (*ClaudeTokenData, error) {
+	if strings.TrimSpace(refreshToken) == "" {
+		return nil, fmt.Errorf("claude token: refresh token is empty")
+	}
+	payload := map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+		"client_id":     claudeOAuthClientID,
+	}
+	return ca.doTokenRequest(ctx, payload)
+}
+
+func (ca *ClaudeAuth) doTokenRequest(ctx context.Context, payload map[string]string) (*ClaudeTokenData, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("claude token: marshal request failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, claudeOAuthTokenEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("claude token: create request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := ca.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("claude token: request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("claude token: read response failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		log.Debugf("claude token request failed: status=%d body=%s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("claude token: %d %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var tokenResp claudeTokenResponse
+	if err = json.Unmarshal(respBody, &tokenResp); err != nil {
+		return nil, fmt.Errorf("claude token: decode response failed: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		log.Debug(string(respBody))
+		return nil, fmt.Errorf("claude token: missing access token in response")
+	}
+
+	data := &ClaudeTokenData{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		Scope:        tokenResp.Scope,
+		Expire:       time.Now().Add(time.Duration(tokenResp.ExpiresIn) * time.Second).Format(time.RFC3339),
+		Email:        strings.TrimSpace(tokenResp.Account.EmailAddress),
+		Subscription: strings.TrimSpace(tokenResp.Account.SubscriptionType),
+	}
+	return data, nil
+}
+
+// CreateTokenStorage converts token data into persistence storage.
+func (ca *ClaudeAuth) CreateTokenStorage(data *ClaudeTokenData) *ClaudeTokenStorage {
+	if data == nil {
+		return nil
+	}
+	return &ClaudeTokenStorage{
+		AccessToken:  data.AccessToken,
+		RefreshToken: data.RefreshToken,
+		LastRefresh:  time.Now().Format(time.RFC3339),
+		Expire:       data.Expire,
+		Email:        data.Email,
+		Scope:        data.Scope,
+		Subscription: data.Subscription,
+		Type:         "claude",
+	}
+}
+
+// UpdateTokenStorage updates the persisted token storage with latest token data.
+func (ca *ClaudeAuth) UpdateTokenStorage(storage *ClaudeTokenStorage, data *ClaudeTokenData) {
+	if storage == nil || data == nil {
+		return
+	}
+	storage.AccessToken = data.AccessToken
+	if data.RefreshToken != "" {
+		storage.RefreshToken = data.RefreshToken
+	}
+	storage.LastRefresh = time.Now().Format(time.RFC3339)
+	storage.Expire = data.Expire
+	if data.Email != "" {
+		storage.Email = data.Email
+	}
+	if data.Subscription != "" {
+		storage.Subscription = data.Subscription
+	}
+}
+
+// claudeTokenResponse models the OAuth token endpoint response.
+type claudeTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Scope        string `json:"scope"`
+	Account      struct {
+		EmailAddress     string `json:"email_address"`
+		SubscriptionType string `json:"subscription_type"`
+	} `json:"account"`
+}
+
+// ClaudeTokenData captures processed token details.
+type ClaudeTokenData struct {
+	AccessToken  string
+	RefreshToken string
+	Scope        string
+	Expire       string
+	Email        string
+	Subscription string
+}
@@ -0,0 +1,332 @@
+// Package kiro provides AWS IAM Identity Center (SSO) authentication for the
+// Kiro provider. It implements the SSO-OIDC device authorization flow:
+// registering a public client, starting a device authorization against the
+// organisation start URL, polling for tokens, and refreshing the SSO session.
+package kiro
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/util"
+)
+
+const (
+	// DefaultSSORegion is the IAM Identity Center region used when none is supplied.
+	DefaultSSORegion = "us-east-1"
+	// DefaultStartURL is the AWS access portal used when no organisation start URL is supplied.
+	DefaultStartURL = "https://view.awsapps.com/start"
+	// ssoOIDCClientName identifies the registered OIDC client.
+	ssoOIDCClientName = "modelgate"
+	// ssoOIDCGrantType is the RFC 8628 device code grant.
+	ssoOIDCGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+// ssoOIDCScopes are the CodeWhisperer scopes Kiro requests during device authorization.
+var ssoOIDCScopes = []string{"codewhisperer:completions", "codewhisperer:analysis"}
+
+// SSOTokenData holds the credentials produced by the device flow, including
+// the registered client pair needed for refreshes.
+type SSOTokenData struct {
+	// AccessToken is the bearer token used for CodeWhisperer API requests.
+	AccessToken string `json:"access_token"`
+	// RefreshToken exchanges for a new access token when the current one expires.
+	RefreshToken string `json:"refresh_token,omitempty"`
+	// ClientID identifies the registered OIDC client.
+	ClientID string `json:"client_id"`
+	// ClientSecret authenticates the registered OIDC client.
+	ClientSecret string `json:"client_secret"`
+	// Region is the IAM Identity Center region the client was registered in.
+	Region string `json:"region"`
+	// StartURL is the organisation AWS access portal URL.
+	StartURL string `json:"start_url"`
+	// Expire is the access token expiration timestamp in RFC3339.
+	Expire string `json:"expire"`
+}
+
+// SSODeviceFlow captures the state needed to complete a device authorization.
+type SSODeviceFlow struct {
+	// DeviceCode is the code the client polls the token endpoint with.
+	DeviceCode string `json:"deviceCode"`
+	// UserCode is the short code the user confirms in the browser.
+	UserCode string `json:"userCode"`
+	// VerificationURI is where the user enters the user code.
+	VerificationURI string `json:"verificationUri"`
+	// VerificationURIComplete embeds the user code for one-click confirmation.
+	VerificationURIComplete string `json:"verificationUriComplete"`
+	// ExpiresIn is the lifetime of the device code in seconds.
+	ExpiresIn int `json:"expiresIn"`
+	// Interval is the minimum polling interval in seconds.
+	Interval int `json:"interval"`
+
+	// ClientID and ClientSecret carry the registered client through polling.
+	ClientID     string `json:"-"`
+	ClientSecret string `json:"-"`
+	// Region and StartURL record where the authorization was started.
+	Region   string `json:"-"`
+	StartURL string `json:"-"`
+}
+
+// SSOAuth manages the IAM Identity Center device flow for Kiro.
+type SSOAuth struct {
+	httpClient *http.Client
+}
+
+// NewSSOAuth creates a new SSOAuth instance with a proxy-configured HTTP client.
+func NewSSOAuth(cfg *config.Config) *SSOAuth {
+	return &SSOAuth{
+		httpClient: util.SetProxy(&cfg.SDKConfig, &http.Client{}),
+	}
+}
+
+// oidcEndpoint builds the SSO-OIDC service URL for a region.
+func oidcEndpoint(region, path string) string {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		region = DefaultSSORegion
+	}
+	return fmt.Sprintf("https://oidc.%s.amazonaws.com%s", region, path)
+}
+
+// postJSON sends a JSON request to the SSO-OIDC service and returns the raw body.
+func (sa *SSOAuth) postJSON(ctx context.Context, endpoint string, payload any) ([]byte, int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := sa.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return respBody, resp.StatusCode, nil
+}
+
+// RegisterClient registers a public OIDC client for the device flow.
+func (sa *SSOAuth) RegisterClient(ctx context.Context, region string) (clientID, clientSecret string, err error) {
+	body, status, err := sa.postJSON(ctx, oidcEndpoint(region, "/client/register"), map[string]any{
+		"clientName": ssoOIDCClientName,
+		"clientType": "public",
+		"scopes":     ssoOIDCScopes,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	if status != http.StatusOK {
+		return "", "", fmt.Errorf("client registration failed: %d. Response: %s", status, string(body))
+	}
+	var result struct {
+		ClientID     string `json:"clientId"`
+		ClientSecret string `json:"clientSecret"`
+	}
+	if err = json.Unmarshal(body, &result); err != nil {
+		return "", "", fmt.Errorf("failed to parse client registration response: %w", err)
+	}
+	if result.ClientID == "" || result.ClientSecret == "" {
+		return "", "", fmt.Errorf("client registration failed: clientId not found in response")
+	}
+	return result.ClientID, result.ClientSecret, nil
+}
+
+// InitiateDeviceFlow registers a client and starts a device authorization
+// against the supplied start URL, returning the details needed for polling.
+func (sa *SSOAuth) InitiateDeviceFlow(ctx context.Context, region, startURL string) (*SSODeviceFlow, error) {
+	region = strings.TrimSpace(region)
+	if region == "" {
+		region = DefaultSSORegion
+	}
+	startURL = strings.TrimSpace(startURL)
+	if startURL == "" {
+		startURL = DefaultStartURL
+	}
+
+	clientID, clientSecret, err := sa.RegisterClient(ctx, region)
+	if err != nil {
+		return nil, fmt.Errorf("sso client registration failed: %w", err)
+	}
+
+	body, status, err := sa.postJSON(ctx, oidcEndpoint(region, "/device_authorization"), map[string]any{
+		"clientId":     clientID,
+		"clientSecret": clientSecret,
+		"startUrl":     startURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("device authorization failed: %d. Response: %s", status, string(body))
+	}
+
+	var result SSODeviceFlow
+	if err = json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization response: %w", err)
+	}
+	if result.DeviceCode == "" {
+		return nil, fmt.Errorf("device authorization failed: deviceCode not found in response")
+	}
+	result.ClientID = clientID
+	result.ClientSecret = clientSecret
+	result.Region = region
+	result.StartURL = startURL
+	return &result, nil
+}
+
+// PollForToken polls the token endpoint with the device code until the user
+// completes the authorization, following the RFC 8628 pending/slow_down protocol.
+func (sa *SSOAuth) PollForToken(ctx context.Context, flow *SSODeviceFlow) (*SSOTokenData, error) {
+	if flow == nil {
+		return nil, fmt.Errorf("device flow is nil")
+	}
+	pollInterval := 5 * time.Second
+	if flow.Interval > 0 {
+		pollInterval = time.Duration(flow.Interval) * time.Second
+	}
+	deadline := time.Now().Add(10 * time.Minute)
+	if flow.ExpiresIn > 0 {
+		deadline = time.Now().Add(time.Duration(flow.ExpiresIn) * time.Second)
+	}
+
+	for time.Now().Before(deadline) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		body, status, err := sa.postJSON(ctx, oidcEndpoint(flow.Region, "/token"), map[string]any{
+			"clientId":     flow.ClientID,
+			"clientSecret": flow.ClientSecret,
+			"grantType":    ssoOIDCGrantType,
+			"deviceCode":   flow.DeviceCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if status == http.StatusOK {
+			return sa.tokenDataFromResponse(body, flow.ClientID, flow.ClientSecret, flow.Region, flow.StartURL)
+		}
+
+		var errorData struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if err = json.Unmarshal(body, &errorData); err != nil {
+			return nil, fmt.Errorf("device token poll failed: %d. Response: %s", status, string(body))
+		}
+		switch errorData.Error {
+		case "authorization_pending", "AuthorizationPendingException":
+			time.Sleep(pollInterval)
+			continue
+		case "slow_down", "SlowDownException":
+			pollInterval += 5 * time.Second
+			time.Sleep(pollInterval)
+			continue
+		case "expired_token", "ExpiredTokenException":
+			return nil, fmt.Errorf("device code expired. Please restart the authentication process")
+		case "access_denied", "AccessDeniedException":
+			return nil, fmt.Errorf("authorization denied by user. Please restart the authentication process")
+		}
+		return nil, fmt.Errorf("device token poll failed: %s - %s", errorData.Error, errorData.Description)
+	}
+
+	return nil, fmt.Errorf("authentication timeout. Please restart the authentication process")
+}
+
+// RefreshTokens exchanges the stored refresh token for a new SSO access token.
+func (sa *SSOAuth) RefreshTokens(ctx context.Context, token *SSOTokenData) (*SSOTokenData, error) {
+	if token == nil || token.RefreshToken == "" {
+		return nil, fmt.Errorf("no refresh token available")
+	}
+	body, status, err := sa.postJSON(ctx, oidcEndpoint(token.Region, "/token"), map[string]any{
+		"clientId":     token.ClientID,
+		"clientSecret": token.ClientSecret,
+		"grantType":    "refresh_token",
+		"refreshToken": token.RefreshToken,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("token refresh request failed: %w", err)
+	}
+	if status != http.StatusOK {
+		var errorData struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if err = json.Unmarshal(body, &errorData); err == nil && errorData.Error != "" {
+			return nil, fmt.Errorf("token refresh failed: %s - %s", errorData.Error, errorData.Description)
+		}
+		return nil, fmt.Errorf("token refresh failed: %d. Response: %s", status, string(body))
+	}
+	refreshed, err := sa.tokenDataFromResponse(body, token.ClientID, token.ClientSecret, token.Region, token.StartURL)
+	if err != nil {
+		return nil, err
+	}
+	// Identity Center may omit the refresh token on rotation; keep the old one.
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	return refreshed, nil
+}
+
+// tokenDataFromResponse parses a token endpoint response into SSOTokenData.
+func (sa *SSOAuth) tokenDataFromResponse(body []byte, clientID, clientSecret, region, startURL string) (*SSOTokenData, error) {
+	var response struct {
+		AccessToken  string `json:"accessToken"`
+		RefreshToken string `json:"refreshToken"`
+		ExpiresIn    int    `json:"expiresIn"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if response.AccessToken == "" {
+		return nil, fmt.Errorf("token response missing accessToken")
+	}
+	return &SSOTokenData{
+		AccessToken:  response.AccessToken,
+		RefreshToken: response.RefreshToken,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Region:       region,
+		StartURL:     startURL,
+		Expire:       time.Now().Add(time.Duration(response.ExpiresIn) * time.Second).Format(time.RFC3339),
+	}, nil
+}
+
+// CreateTokenStorage creates a KiroTokenStorage object from an SSOTokenData object.
+func (sa *SSOAuth) CreateTokenStorage(tokenData *SSOTokenData) *KiroTokenStorage {
+	return &KiroTokenStorage{
+		AccessToken:  tokenData.AccessToken,
+		RefreshToken: tokenData.RefreshToken,
+		ClientID:     tokenData.ClientID,
+		ClientSecret: tokenData.ClientSecret,
+		Region:       tokenData.Region,
+		StartURL:     tokenData.StartURL,
+		AuthMethod:   "idc",
+		LastRefresh:  time.Now().Format(time.RFC3339),
+		Expire:       tokenData.Expire,
+	}
+}
+
+// UpdateTokenStorage updates an existing token storage with refreshed token data.
+func (sa *SSOAuth) UpdateTokenStorage(storage *KiroTokenStorage, tokenData *SSOTokenData) {
+	storage.AccessToken = tokenData.AccessToken
+	storage.RefreshToken = tokenData.RefreshToken
+	storage.LastRefresh = time.Now().Format(time.RFC3339)
+	storage.Expire = tokenData.Expire
+}
@@ -0,0 +1,63 @@
+package kiro
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/shariqriazz/modelgate/internal/misc"
+)
+
+// KiroTokenStorage stores IAM Identity Center session credentials for the Kiro
+// provider. Alongside the tokens it keeps the registered OIDC client pair and
+// the start URL/region so refreshes can be replayed against the same identity
+// source.
+type KiroTokenStorage struct {
+	// AccessToken is the bearer token used for authenticating API requests.
+	AccessToken string `json:"access_token"`
+	// RefreshToken is used to obtain new access tokens when the current one expires.
+	RefreshToken string `json:"refresh_token"`
+	// ClientID identifies the registered SSO-OIDC client.
+	ClientID string `json:"client_id"`
+	// ClientSecret authenticates the registered SSO-OIDC client.
+	ClientSecret string `json:"client_secret"`
+	// Region is the IAM Identity Center region the session was established in.
+	Region string `json:"region"`
+	// StartURL is the organisation AWS access portal URL.
+	StartURL string `json:"start_url"`
+	// AuthMethod distinguishes Identity Center sessions ("idc") from social logins.
+	AuthMethod string `json:"auth_method"`
+	// LastRefresh is the timestamp of the last token refresh operation.
+	LastRefresh string `json:"last_refresh"`
+	// Email is the account label associated with this session.
+	Email string `json:"email,omitempty"`
+	// Type indicates the authentication provider type, always "kiro" for this storage.
+	Type string `json:"type"`
+	// Expire is the timestamp when the current access token expires.
+	Expire string `json:"expired"`
+}
+
+// SaveTokenToFile serializes the Kiro token storage to a JSON file.
+// It creates the necessary directory structure and writes the token data to
+// the specified path for persistent storage.
+func (ts *KiroTokenStorage) SaveTokenToFile(authFilePath string) error {
+	misc.LogSavingCredentials(authFilePath)
+	ts.Type = "kiro"
+	if err := os.MkdirAll(filepath.Dir(authFilePath), 0700); err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	f, err := os.Create(authFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to create token file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err = json.NewEncoder(f).Encode(ts); err != nil {
+		return fmt.Errorf("failed to write token to file: %w", err)
+	}
+	return nil
+}
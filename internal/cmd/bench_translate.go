@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	_ "github.com/shariqriazz/modelgate/internal/translator"
+	"github.com/shariqriazz/modelgate/internal/translator/translator"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+)
+
+// DoBenchTranslate micro-benchmarks the registered translators for one format
+// pair and prints ns/op plus allocation counts, so performance-oriented
+// translator changes can be validated without writing a one-off benchmark.
+// The payload file holds the request in the source format; when streamPayload
+// is given (a captured SSE line in the target format's response schema) the
+// response stream translator is benchmarked as well.
+//
+// Parameters:
+//   - from: Source API format identifier (the client side)
+//   - to: Target API format identifier (the upstream side)
+//   - payloadPath: Path to a JSON request payload in the source format
+//   - streamPayloadPath: Optional path to a captured response stream line
+func DoBenchTranslate(from, to, payloadPath, streamPayloadPath string) {
+	if from == "" || to == "" || payloadPath == "" {
+		log.Error("bench-translate: -from, -to and -payload are required")
+		return
+	}
+	payload, err := os.ReadFile(payloadPath)
+	if err != nil {
+		log.Errorf("bench-translate: failed to read payload: %v", err)
+		return
+	}
+	model := gjson.GetBytes(payload, "model").String()
+	if model == "" {
+		model = "bench-model"
+	}
+
+	requestResult := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			translator.Request(from, to, model, payload, false)
+		}
+	})
+	printBenchResult(fmt.Sprintf("TranslateRequest %s -> %s", from, to), requestResult)
+
+	if streamPayloadPath == "" {
+		return
+	}
+	streamPayload, errStream := os.ReadFile(streamPayloadPath)
+	if errStream != nil {
+		log.Errorf("bench-translate: failed to read stream payload: %v", errStream)
+		return
+	}
+	translated := translator.Request(from, to, model, payload, true)
+	streamResult := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var param any
+			translator.Response(to, from, context.Background(), model, payload, translated, streamPayload, &param)
+		}
+	})
+	printBenchResult(fmt.Sprintf("TranslateStream %s -> %s", to, from), streamResult)
+}
+
+// printBenchResult renders one benchmark line in `go test -bench` style.
+func printBenchResult(name string, result testing.BenchmarkResult) {
+	fmt.Printf("%-40s %10d iterations %12d ns/op %10d B/op %8d allocs/op\n",
+		name, result.N, result.NsPerOp(), result.AllocedBytesPerOp(), result.AllocsPerOp())
+}
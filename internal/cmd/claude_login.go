@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	sdkAuth "github.com/shariqriazz/modelgate/sdk/auth"
+)
+
+// DoClaudeLogin triggers the Anthropic consumer OAuth flow through the shared
+// authentication manager. It opens the claude.ai authorization page, prompts
+// for the pasted authorization code, and saves the resulting tokens to the
+// configured auth directory so Pro/Max subscriptions can be pooled.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - options: Login options including browser behavior and prompts
+func DoClaudeLogin(cfg *config.Config, options *LoginOptions) {
+	if options == nil {
+		options = &LoginOptions{}
+	}
+
+	promptFn := options.Prompt
+	if promptFn == nil {
+		promptFn = defaultProjectPrompt()
+	}
+
+	manager := newAuthManager()
+
+	authOpts := &sdkAuth.LoginOptions{
+		NoBrowser:    options.NoBrowser,
+		CallbackPort: options.CallbackPort,
+		Metadata:     map[string]string{},
+		Prompt:       promptFn,
+	}
+
+	_, savedPath, err := manager.Login(context.Background(), "claude", cfg, authOpts)
+	if err != nil {
+		fmt.Printf("Claude authentication failed: %v\n", err)
+		return
+	}
+
+	if savedPath != "" {
+		fmt.Printf("Authentication saved to %s\n", savedPath)
+	}
+	fmt.Println("Claude authentication successful!")
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/auth/gemini"
+	"github.com/shariqriazz/modelgate/internal/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// storedGeminiAuth pairs a Gemini credential file on disk with its parsed storage.
+type storedGeminiAuth struct {
+	path    string
+	storage *gemini.GeminiTokenStorage
+}
+
+// DoGeminiSetProject switches the GCP project bound to a stored Gemini auth file.
+// It runs the same loadCodeAssist/onboardUser setup as the login flow against the
+// already-saved credentials, so users can change projects (or onboard a project
+// they skipped during login) without deleting the auth file and re-authenticating.
+// projectID may be a single project ID or a comma-separated list.
+//
+// Parameters:
+//   - cfg: The application configuration containing the auth directory
+//   - projectID: The Google Cloud project ID(s) to activate
+//   - options: Login options including browser behavior and prompts
+func DoGeminiSetProject(cfg *config.Config, projectID string, options *LoginOptions) {
+	trimmed := strings.TrimSpace(projectID)
+	if trimmed == "" {
+		log.Error("A project ID is required. Usage: -gemini-set-project <project-id>")
+		return
+	}
+
+	ctx := context.Background()
+	selected, httpClient, ok := authenticateStoredGeminiAuth(ctx, cfg, options)
+	if !ok {
+		return
+	}
+	storage := selected.storage
+
+	requested := make([]string, 0)
+	for _, candidate := range strings.Split(trimmed, ",") {
+		if candidate = strings.TrimSpace(candidate); candidate != "" {
+			requested = append(requested, candidate)
+		}
+	}
+
+	activatedProjects := make([]string, 0, len(requested))
+	for _, candidateID := range requested {
+		log.Infof("Activating project %s", candidateID)
+		if errSetup := performGeminiCLISetup(ctx, httpClient, storage, candidateID); errSetup != nil {
+			log.Errorf("Failed to complete user setup: %v", errSetup)
+			return
+		}
+		finalID := strings.TrimSpace(storage.ProjectID)
+		if finalID == "" {
+			finalID = candidateID
+		}
+		activatedProjects = append(activatedProjects, finalID)
+	}
+
+	storage.Auto = false
+	storage.ProjectID = strings.Join(activatedProjects, ",")
+
+	for _, pid := range activatedProjects {
+		isChecked, errCheck := checkCloudAPIIsEnabled(ctx, httpClient, pid)
+		if errCheck != nil {
+			log.Errorf("Failed to check if Cloud AI API is enabled for %s: %v", pid, errCheck)
+			return
+		}
+		if !isChecked {
+			log.Errorf("Failed to check if Cloud AI API is enabled for project %s. If you encounter an error message, please create an issue.", pid)
+			return
+		}
+	}
+	storage.Checked = true
+
+	if errSave := saveStoredGeminiAuth(cfg, selected, storage); errSave != nil {
+		log.Errorf("Failed to save updated credentials: %v", errSave)
+		return
+	}
+
+	fmt.Printf("Project updated. Now using: %s\n", storage.ProjectID)
+}
+
+// DoGeminiShowTier prints the Code Assist tier information for a stored Gemini auth.
+// It calls loadCodeAssist with the stored project and lists the current tier plus
+// all tiers the account is allowed to use, which helps diagnose onboarding state
+// without re-running the login flow.
+//
+// Parameters:
+//   - cfg: The application configuration containing the auth directory
+//   - options: Login options including browser behavior and prompts
+func DoGeminiShowTier(cfg *config.Config, options *LoginOptions) {
+	ctx := context.Background()
+	selected, httpClient, ok := authenticateStoredGeminiAuth(ctx, cfg, options)
+	if !ok {
+		return
+	}
+	storage := selected.storage
+
+	loadReqBody := map[string]any{
+		"metadata": map[string]string{
+			"ideType":    "IDE_UNSPECIFIED",
+			"platform":   "PLATFORM_UNSPECIFIED",
+			"pluginType": "GEMINI",
+		},
+	}
+	// A multi-project file stores a comma-separated list; query with the first entry.
+	if firstProject := strings.TrimSpace(strings.SplitN(storage.ProjectID, ",", 2)[0]); firstProject != "" {
+		loadReqBody["cloudaicompanionProject"] = firstProject
+	}
+
+	var loadResp map[string]any
+	if errLoad := callGeminiCLI(ctx, httpClient, "loadCodeAssist", loadReqBody, &loadResp); errLoad != nil {
+		log.Errorf("Failed to load Code Assist info: %v", errLoad)
+		return
+	}
+
+	fmt.Printf("Account: %s\n", storage.Email)
+	if strings.TrimSpace(storage.ProjectID) != "" {
+		fmt.Printf("Project: %s\n", storage.ProjectID)
+	} else {
+		fmt.Println("Project: (none onboarded)")
+	}
+
+	currentTierID := ""
+	if currentTier, okTier := loadResp["currentTier"].(map[string]any); okTier {
+		if id, okID := currentTier["id"].(string); okID {
+			currentTierID = strings.TrimSpace(id)
+		}
+		fmt.Printf("Current tier: %s\n", formatGeminiTier(currentTier))
+	} else {
+		fmt.Println("Current tier: (not onboarded)")
+	}
+
+	tiers, okTiers := loadResp["allowedTiers"].([]any)
+	if !okTiers || len(tiers) == 0 {
+		return
+	}
+	fmt.Println("Allowed tiers:")
+	for _, rawTier := range tiers {
+		tier, okTier := rawTier.(map[string]any)
+		if !okTier {
+			continue
+		}
+		markers := make([]string, 0, 2)
+		if id, okID := tier["id"].(string); okID && currentTierID != "" && strings.EqualFold(strings.TrimSpace(id), currentTierID) {
+			markers = append(markers, "current")
+		}
+		if isDefault, okDefault := tier["isDefault"].(bool); okDefault && isDefault {
+			markers = append(markers, "default")
+		}
+		suffix := ""
+		if len(markers) > 0 {
+			suffix = fmt.Sprintf(" [%s]", strings.Join(markers, ", "))
+		}
+		fmt.Printf("  - %s%s\n", formatGeminiTier(tier), suffix)
+	}
+}
+
+// formatGeminiTier renders a tier object from loadCodeAssist as "id (name)".
+func formatGeminiTier(tier map[string]any) string {
+	id, _ := tier["id"].(string)
+	id = strings.TrimSpace(id)
+	if id == "" {
+		id = "unknown"
+	}
+	if name, okName := tier["name"].(string); okName && strings.TrimSpace(name) != "" {
+		return fmt.Sprintf("%s (%s)", id, strings.TrimSpace(name))
+	}
+	return id
+}
+
+// authenticateStoredGeminiAuth selects a stored Gemini credential file and returns
+// an authenticated HTTP client for it. It reports failures itself and returns
+// ok=false so callers can simply bail out.
+func authenticateStoredGeminiAuth(ctx context.Context, cfg *config.Config, options *LoginOptions) (*storedGeminiAuth, *http.Client, bool) {
+	if options == nil {
+		options = &LoginOptions{}
+	}
+
+	promptFn := options.Prompt
+	if promptFn == nil {
+		reader := bufio.NewReader(os.Stdin)
+		promptFn = func(prompt string) (string, error) {
+			fmt.Print(prompt)
+			value, err := reader.ReadString('\n')
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(value), nil
+		}
+	}
+
+	selected, errSelect := selectStoredGeminiAuth(cfg, promptFn)
+	if errSelect != nil {
+		log.Errorf("Failed to select Gemini credentials: %v", errSelect)
+		return nil, nil, false
+	}
+
+	geminiSvc := gemini.NewGeminiAuth()
+	httpClient, errClient := geminiSvc.GetAuthenticatedClient(ctx, selected.storage, cfg, &gemini.WebLoginOptions{
+		NoBrowser:    options.NoBrowser,
+		CallbackPort: options.CallbackPort,
+	})
+	if errClient != nil {
+		log.Errorf("Failed to authenticate with stored credentials: %v", errClient)
+		return nil, nil, false
+	}
+	return selected, httpClient, true
+}
+
+// selectStoredGeminiAuth lists the Gemini credential files in the auth directory
+// and prompts the user to pick one when more than one account is stored.
+func selectStoredGeminiAuth(cfg *config.Config, promptFn func(string) (string, error)) (*storedGeminiAuth, error) {
+	entries, errRead := os.ReadDir(cfg.AuthDir)
+	if errRead != nil {
+		return nil, fmt.Errorf("read auth directory: %w", errRead)
+	}
+
+	stored := make([]*storedGeminiAuth, 0)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		path := filepath.Join(cfg.AuthDir, entry.Name())
+		data, errFile := os.ReadFile(path)
+		if errFile != nil {
+			continue
+		}
+		var storage gemini.GeminiTokenStorage
+		if errUnmarshal := json.Unmarshal(data, &storage); errUnmarshal != nil {
+			continue
+		}
+		if storage.Type != "gemini" || storage.Token == nil {
+			continue
+		}
+		stored = append(stored, &storedGeminiAuth{path: path, storage: &storage})
+	}
+
+	if len(stored) == 0 {
+		return nil, fmt.Errorf("no Gemini credentials found in %s; run -login first", cfg.AuthDir)
+	}
+	if len(stored) == 1 {
+		return stored[0], nil
+	}
+
+	fmt.Println("Multiple Gemini accounts found:")
+	for i, candidate := range stored {
+		project := strings.TrimSpace(candidate.storage.ProjectID)
+		if project == "" {
+			project = "no project"
+		}
+		fmt.Printf("  %d) %s (%s)\n", i+1, candidate.storage.Email, project)
+	}
+
+	answer, errPrompt := promptFn("Select account [1]: ")
+	if errPrompt != nil {
+		return nil, fmt.Errorf("read selection: %w", errPrompt)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return stored[0], nil
+	}
+	index, errParse := strconv.Atoi(answer)
+	if errParse != nil || index < 1 || index > len(stored) {
+		return nil, fmt.Errorf("invalid selection %q", answer)
+	}
+	return stored[index-1], nil
+}
+
+// saveStoredGeminiAuth writes the updated storage back to disk. Changing the
+// project changes the canonical credential file name, so the old file is removed
+// when the rename leaves it behind.
+func saveStoredGeminiAuth(cfg *config.Config, selected *storedGeminiAuth, storage *gemini.GeminiTokenStorage) error {
+	finalName := gemini.CredentialFileName(storage.Email, storage.ProjectID, false)
+	finalPath := filepath.Join(cfg.AuthDir, finalName)
+	if errSave := storage.SaveTokenToFile(finalPath); errSave != nil {
+		return errSave
+	}
+	if filepath.Clean(finalPath) != filepath.Clean(selected.path) {
+		if errRemove := os.Remove(selected.path); errRemove != nil && !os.IsNotExist(errRemove) {
+			log.Warnf("Failed to remove old credential file %s: %v", selected.path, errRemove)
+		}
+	}
+	fmt.Printf("Authentication saved to %s\n", finalPath)
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/shariqriazz/modelgate/internal/translator"
+	"github.com/shariqriazz/modelgate/internal/translator/golden"
+	"github.com/shariqriazz/modelgate/internal/translator/translator"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultFixtureDir is where generated golden fixtures land when no output
+// directory is given; it is the directory the golden test harness reads.
+const defaultFixtureDir = "internal/translator/golden/testdata"
+
+// capture mirrors the JSON layout of a captured request file: the translator
+// pair, the model, and the raw upstream request payload.
+type capture struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Model   string          `json:"model"`
+	Stream  bool            `json:"stream"`
+	Request json.RawMessage `json:"request"`
+}
+
+// DoGenFixtures turns captured request files into golden-test fixtures. Every
+// *.json file in captureDir must contain a capture (from/to/model/stream and
+// the raw request). The request is sanitized, run through the registered
+// request translator, and written together with the translator output to
+// outDir so the golden harness replays it on every test run. Existing
+// fixtures with the same name are overwritten.
+//
+// Parameters:
+//   - captureDir: Directory containing captured request JSON files
+//   - outDir: Fixture output directory (defaults to the golden testdata dir)
+func DoGenFixtures(captureDir string, outDir string) {
+	if outDir == "" {
+		outDir = defaultFixtureDir
+	}
+	entries, err := os.ReadDir(captureDir)
+	if err != nil {
+		log.Errorf("gen-fixtures: failed to read capture directory: %v", err)
+		return
+	}
+	if errMkdir := os.MkdirAll(outDir, 0o755); errMkdir != nil {
+		log.Errorf("gen-fixtures: failed to create output directory: %v", errMkdir)
+		return
+	}
+
+	generated := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, errRead := os.ReadFile(filepath.Join(captureDir, entry.Name()))
+		if errRead != nil {
+			log.Errorf("gen-fixtures: failed to read %s: %v", entry.Name(), errRead)
+			continue
+		}
+		var cpt capture
+		if errJSON := json.Unmarshal(data, &cpt); errJSON != nil {
+			log.Errorf("gen-fixtures: %s is not a valid capture file: %v", entry.Name(), errJSON)
+			continue
+		}
+		if cpt.From == "" || cpt.To == "" || len(cpt.Request) == 0 {
+			log.Errorf("gen-fixtures: %s is missing from/to/request", entry.Name())
+			continue
+		}
+
+		request := golden.Sanitize(cpt.Request)
+		translated := translator.Request(cpt.From, cpt.To, cpt.Model, request, cpt.Stream)
+		goldenOut, errNorm := golden.Normalize(translated)
+		if errNorm != nil {
+			log.Errorf("gen-fixtures: %s: translator output is not valid JSON: %v", entry.Name(), errNorm)
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		fixture := &golden.Fixture{
+			From:    cpt.From,
+			To:      cpt.To,
+			Model:   cpt.Model,
+			Stream:  cpt.Stream,
+			Request: request,
+			Golden:  goldenOut,
+		}
+		if errSave := golden.Save(outDir, name, fixture); errSave != nil {
+			log.Errorf("gen-fixtures: failed to write fixture %s: %v", name, errSave)
+			continue
+		}
+		generated++
+	}
+	log.Infof("gen-fixtures: wrote %d fixture(s) to %s", generated, outDir)
+}
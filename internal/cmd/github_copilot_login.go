@@ -12,6 +12,8 @@ import (
 // DoGitHubCopilotLogin triggers the OAuth device flow for GitHub Copilot and saves tokens.
 // It initiates the device flow authentication, displays the user code for the user to enter
 // at GitHub's verification URL, and waits for authorization before saving the tokens.
+// The flow polls GitHub directly and never listens for a callback, so it works
+// on headless servers with -no-browser.
 //
 // Parameters:
 //   - cfg: The application configuration containing proxy and auth directory settings
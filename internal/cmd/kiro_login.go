@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	sdkAuth "github.com/shariqriazz/modelgate/sdk/auth"
+)
+
+// DoKiroLogin handles the Kiro IAM Identity Center (SSO) device flow using the
+// shared authentication manager. It prompts for the organisation start URL and
+// region, runs the device authorization, and saves the SSO session to the
+// configured auth directory.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - options: Login options including browser behavior and prompts
+func DoKiroLogin(cfg *config.Config, options *LoginOptions) {
+	if options == nil {
+		options = &LoginOptions{}
+	}
+
+	manager := newAuthManager()
+
+	promptFn := options.Prompt
+	if promptFn == nil {
+		// Empty answers are valid here (they select the defaults), so read
+		// whole lines instead of using fmt.Scanln.
+		reader := bufio.NewReader(os.Stdin)
+		promptFn = func(prompt string) (string, error) {
+			fmt.Println()
+			fmt.Println(prompt)
+			value, err := reader.ReadString('\n')
+			if err != nil && strings.TrimSpace(value) == "" {
+				return "", nil
+			}
+			return strings.TrimSpace(value), nil
+		}
+	}
+
+	authOpts := &sdkAuth.LoginOptions{
+		NoBrowser:    options.NoBrowser,
+		CallbackPort: options.CallbackPort,
+		Metadata:     map[string]string{},
+		Prompt:       promptFn,
+	}
+
+	_, savedPath, err := manager.Login(context.Background(), "kiro", cfg, authOpts)
+	if err != nil {
+		fmt.Printf("Kiro authentication failed: %v\n", err)
+		return
+	}
+
+	if savedPath != "" {
+		fmt.Printf("Authentication saved to %s\n", savedPath)
+	}
+
+	fmt.Println("Kiro authentication successful!")
+}
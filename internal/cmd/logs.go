@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/sdk/management"
+	log "github.com/sirupsen/logrus"
+)
+
+const logsPollInterval = 2 * time.Second
+
+// LogsOptions controls the log tail command.
+type LogsOptions struct {
+	// Follow keeps polling for new lines until interrupted.
+	Follow bool
+	// Provider filters lines to those mentioning the given provider key.
+	Provider string
+	// MinStatus drops lines whose detected HTTP status is below the value.
+	MinStatus int
+}
+
+var logsStatusPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\|\s*(\d{3})\s*\|`),
+	regexp.MustCompile(`status[ =:]+(\d{3})`),
+}
+
+// DoLogs tails server logs from a running gateway over the management API,
+// applying optional provider and status filters. The management key is taken
+// from the --password flag or the MANAGEMENT_PASSWORD environment variable.
+//
+// Parameters:
+//   - cfg: The application configuration (used to resolve the local base URL)
+//   - password: Management key used to authenticate against the local instance
+//   - opts: Tail behavior and filters
+func DoLogs(cfg *config.Config, password string, opts LogsOptions) {
+	key := strings.TrimSpace(password)
+	if key == "" {
+		key = strings.TrimSpace(os.Getenv("MANAGEMENT_PASSWORD"))
+	}
+	if key == "" {
+		log.Error("logs: management key required; pass --password or set MANAGEMENT_PASSWORD")
+		return
+	}
+	port := 8317
+	if cfg != nil && cfg.Port > 0 {
+		port = cfg.Port
+	}
+	scheme := "http"
+	if cfg != nil && cfg.TLS.Enable {
+		scheme = "https"
+	}
+	client, err := management.NewClient(fmt.Sprintf("%s://127.0.0.1:%d", scheme, port), key)
+	if err != nil {
+		log.Errorf("logs: %v", err)
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	var cursor int64
+	for {
+		page, errLogs := client.Logs(ctx, cursor, 0)
+		if errLogs != nil {
+			log.Errorf("logs: %v", errLogs)
+			return
+		}
+		for _, line := range page.Lines {
+			if logLineMatches(line, opts) {
+				fmt.Println(line)
+			}
+		}
+		cursor = page.LatestTimestamp
+		if !opts.Follow {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(logsPollInterval):
+		}
+	}
+}
+
+// logLineMatches applies the provider and minimum-status filters to one line.
+func logLineMatches(line string, opts LogsOptions) bool {
+	if provider := strings.TrimSpace(opts.Provider); provider != "" {
+		if !strings.Contains(strings.ToLower(line), strings.ToLower(provider)) {
+			return false
+		}
+	}
+	if opts.MinStatus > 0 {
+		status, ok := detectLogStatus(line)
+		if !ok || status < opts.MinStatus {
+			return false
+		}
+	}
+	return true
+}
+
+// detectLogStatus extracts an HTTP status code from a log line when present.
+func detectLogStatus(line string) (int, bool) {
+	for _, pattern := range logsStatusPatterns {
+		if match := pattern.FindStringSubmatch(line); match != nil {
+			if status, err := strconv.Atoi(match[1]); err == nil {
+				return status, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import "testing"
+
+func TestLogLineMatches(t *testing.T) {
+	cases := []struct {
+		name string
+		line string
+		opts LogsOptions
+		want bool
+	}{
+		{
+			name: "no filters",
+			line: "[GIN] 2026/08/26 - 12:00:00 | 200 | 1.2ms | POST /v1/chat/completions",
+			opts: LogsOptions{},
+			want: true,
+		},
+		{
+			name: "provider match",
+			line: "antigravity executor: request failed",
+			opts: LogsOptions{Provider: "antigravity"},
+			want: true,
+		},
+		{
+			name: "provider mismatch",
+			line: "codex executor: request ok",
+			opts: LogsOptions{Provider: "antigravity"},
+			want: false,
+		},
+		{
+			name: "gin status above threshold",
+			line: "[GIN] 2026/08/26 - 12:00:00 | 502 | 1.2ms | POST /v1/chat/completions",
+			opts: LogsOptions{MinStatus: 400},
+			want: true,
+		},
+		{
+			name: "gin status below threshold",
+			line: "[GIN] 2026/08/26 - 12:00:00 | 200 | 1.2ms | POST /v1/chat/completions",
+			opts: LogsOptions{MinStatus: 400},
+			want: false,
+		},
+		{
+			name: "status keyword",
+			line: "iflow request error: status 429 body ...",
+			opts: LogsOptions{MinStatus: 400},
+			want: true,
+		},
+		{
+			name: "no status with threshold",
+			line: "plain informational line",
+			opts: LogsOptions{MinStatus: 400},
+			want: false,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := logLineMatches(tc.line, tc.opts); got != tc.want {
+				t.Fatalf("logLineMatches(%q) = %v, want %v", tc.line, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/util"
+	log "github.com/sirupsen/logrus"
+)
+
+// cliProxyAPIDefaultAuthDir is the auth directory CLIProxyAPI v6 used when the
+// source config does not set auth-dir explicitly.
+const cliProxyAPIDefaultAuthDir = "~/.cli-proxy-api"
+
+// migratedAuthDir is where imported auth files land; it matches the default
+// shipped in config.example.yaml.
+const migratedAuthDir = "~/.modelgate"
+
+// DoMigrateConfig imports a CLIProxyAPI v6 installation and writes an
+// equivalent ModelGate setup. The source may be the old config file itself or
+// the directory containing it. The config schema is compatible, so migration
+// copies the config to targetConfigPath, lets the normal loader normalize any
+// legacy keys, points auth-dir at the ModelGate default, and copies the auth
+// JSON files across. Existing target files are never overwritten.
+//
+// Parameters:
+//   - source: Path to the CLIProxyAPI config file or its directory
+//   - targetConfigPath: Destination config path (defaults to ./config.yaml)
+func DoMigrateConfig(source string, targetConfigPath string) {
+	sourceConfigPath, err := resolveMigrationSource(source)
+	if err != nil {
+		log.Errorf("migrate-config: %v", err)
+		return
+	}
+
+	if targetConfigPath == "" {
+		wd, errWd := os.Getwd()
+		if errWd != nil {
+			log.Errorf("migrate-config: failed to get working directory: %v", errWd)
+			return
+		}
+		targetConfigPath = filepath.Join(wd, "config.yaml")
+	}
+	if _, errStat := os.Stat(targetConfigPath); errStat == nil {
+		log.Errorf("migrate-config: target config %s already exists; refusing to overwrite", targetConfigPath)
+		return
+	}
+
+	data, err := os.ReadFile(sourceConfigPath)
+	if err != nil {
+		log.Errorf("migrate-config: failed to read source config: %v", err)
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(targetConfigPath), 0o755); err != nil {
+		log.Errorf("migrate-config: failed to create target directory: %v", err)
+		return
+	}
+	if err = os.WriteFile(targetConfigPath, data, 0o600); err != nil {
+		log.Errorf("migrate-config: failed to write target config: %v", err)
+		return
+	}
+
+	// Load the copy through the regular loader so deprecated v6 keys are
+	// normalized and persisted into the new file, leaving the source untouched.
+	cfg, err := config.LoadConfig(targetConfigPath)
+	if err != nil {
+		log.Errorf("migrate-config: failed to load copied config: %v", err)
+		return
+	}
+
+	sourceAuthDir := strings.TrimSpace(cfg.AuthDir)
+	if sourceAuthDir == "" {
+		sourceAuthDir = cliProxyAPIDefaultAuthDir
+	}
+	resolvedSource, err := util.ResolveAuthDir(sourceAuthDir)
+	if err != nil {
+		log.Errorf("migrate-config: %v", err)
+		return
+	}
+	resolvedTarget, err := util.ResolveAuthDir(migratedAuthDir)
+	if err != nil {
+		log.Errorf("migrate-config: %v", err)
+		return
+	}
+
+	cfg.AuthDir = migratedAuthDir
+	if err = config.SaveConfigPreserveComments(targetConfigPath, cfg); err != nil {
+		log.Errorf("migrate-config: failed to persist migrated config: %v", err)
+		return
+	}
+	log.Infof("migrate-config: wrote %s from %s", targetConfigPath, sourceConfigPath)
+
+	if resolvedSource == resolvedTarget {
+		log.Infof("migrate-config: auth directory already at %s; nothing to import", resolvedTarget)
+		return
+	}
+	copied, skipped, err := importAuthFiles(resolvedSource, resolvedTarget)
+	if err != nil {
+		log.Errorf("migrate-config: failed to import auth files: %v", err)
+		return
+	}
+	log.Infof("migrate-config: imported %d auth file(s) into %s (%d already present)", copied, resolvedTarget, skipped)
+}
+
+// resolveMigrationSource accepts either the old config file or the directory
+// holding it and returns the config file path.
+func resolveMigrationSource(source string) (string, error) {
+	source = strings.TrimSpace(source)
+	if source == "" {
+		return "", fmt.Errorf("source path is required")
+	}
+	if strings.HasPrefix(source, "~") {
+		resolved, err := util.ResolveAuthDir(source)
+		if err != nil {
+			return "", err
+		}
+		source = resolved
+	}
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", fmt.Errorf("source %s not found: %w", source, err)
+	}
+	if !info.IsDir() {
+		return source, nil
+	}
+	candidate := filepath.Join(source, "config.yaml")
+	if _, errStat := os.Stat(candidate); errStat != nil {
+		return "", fmt.Errorf("no config.yaml found in %s", source)
+	}
+	return candidate, nil
+}
+
+// importAuthFiles copies the JSON auth files from sourceDir into targetDir,
+// skipping files that already exist so re-running the migration is safe.
+func importAuthFiles(sourceDir, targetDir string) (copied, skipped int, err error) {
+	entries, err := os.ReadDir(sourceDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	if err = os.MkdirAll(targetDir, 0o700); err != nil {
+		return 0, 0, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		targetPath := filepath.Join(targetDir, entry.Name())
+		if _, errStat := os.Stat(targetPath); errStat == nil {
+			skipped++
+			continue
+		}
+		data, errRead := os.ReadFile(filepath.Join(sourceDir, entry.Name()))
+		if errRead != nil {
+			return copied, skipped, errRead
+		}
+		if errWrite := os.WriteFile(targetPath, data, 0o600); errWrite != nil {
+			return copied, skipped, errWrite
+		}
+		copied++
+	}
+	return copied, skipped, nil
+}
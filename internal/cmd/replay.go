@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/shariqriazz/modelgate/internal/recording"
+	_ "github.com/shariqriazz/modelgate/internal/translator"
+	"github.com/shariqriazz/modelgate/internal/translator/golden"
+	"github.com/shariqriazz/modelgate/internal/translator/translator"
+	log "github.com/sirupsen/logrus"
+)
+
+// DoReplay re-sends recorded translator exchanges through the currently
+// registered translators and compares the output against what was recorded,
+// so translation regressions surface after an upgrade. Request entries replay
+// the request translator; response entries replay the non-stream response
+// translator. Outputs are normalized as JSON before comparison when possible.
+//
+// Parameters:
+//   - replayDir: Directory containing recording JSON files
+func DoReplay(replayDir string) {
+	files, err := recording.Load(replayDir)
+	if err != nil {
+		log.Errorf("replay: failed to load recordings: %v", err)
+		return
+	}
+	if len(files) == 0 {
+		log.Infof("replay: no recordings found in %s", replayDir)
+		return
+	}
+
+	passed, failed, skipped := 0, 0, 0
+	for _, file := range files {
+		entry := file.Entry
+		var out string
+		switch entry.Kind {
+		case "request":
+			out = string(translator.Request(entry.From, entry.To, entry.Model, []byte(entry.Input), entry.Stream))
+		case "response":
+			var param any
+			out = translator.ResponseNonStream(entry.From, entry.To, context.Background(), entry.Model, []byte(entry.Original), []byte(entry.Request), []byte(entry.Input), &param)
+		default:
+			log.Warnf("replay: %s: unknown entry kind %q", file.Name, entry.Kind)
+			skipped++
+			continue
+		}
+		if translationMatches(out, entry.Output) {
+			passed++
+			continue
+		}
+		failed++
+		log.Errorf("replay: %s: %s %s->%s output differs from recording", file.Name, entry.Kind, entry.From, entry.To)
+	}
+	log.Infof("replay: %d passed, %d failed, %d skipped (of %d recording(s) in %s)", passed, failed, skipped, len(files), replayDir)
+}
+
+// translationMatches compares a replayed output with the recorded one,
+// normalizing both as JSON when possible so key ordering does not flag a
+// regression.
+func translationMatches(got, want string) bool {
+	if got == want {
+		return true
+	}
+	gotNorm, errGot := golden.Normalize([]byte(got))
+	wantNorm, errWant := golden.Normalize([]byte(want))
+	if errGot != nil || errWant != nil {
+		return false
+	}
+	return bytes.Equal(gotNorm, wantNorm)
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/sdk/management"
+	log "github.com/sirupsen/logrus"
+)
+
+// Snapshot archive entry names. Auth files live under the auths/ prefix with
+// their original file names.
+const (
+	snapshotConfigEntry = "config.yaml"
+	snapshotAuthPrefix  = "auths/"
+	snapshotUsageEntry  = "usage.json"
+	snapshotQuotaEntry  = "quota.json"
+)
+
+const snapshotManagementTimeout = 10 * time.Second
+
+// DoSnapshotCreate writes a single tar.gz archive containing the effective
+// config file, every credential in the auth directory, and — when a running
+// local instance is reachable with a management key — its usage export and
+// per-auth quota report. The archive is self-contained: restoring it on a
+// fresh host brings the gateway back without any re-authentication.
+//
+// Parameters:
+//   - cfg: The application configuration (auth directory and local port)
+//   - configFilePath: Path of the active config file to include
+//   - outPath: Destination path for the snapshot archive
+//   - password: Management key for the optional usage/quota export
+func DoSnapshotCreate(cfg *config.Config, configFilePath, outPath, password string) {
+	outFile, err := os.Create(outPath)
+	if err != nil {
+		log.Errorf("snapshot: create archive: %v", err)
+		return
+	}
+	defer func() { _ = outFile.Close() }()
+	gz := gzip.NewWriter(outFile)
+	tw := tar.NewWriter(gz)
+
+	addEntry := func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		if errHeader := tw.WriteHeader(header); errHeader != nil {
+			return errHeader
+		}
+		_, errWrite := tw.Write(data)
+		return errWrite
+	}
+
+	configData, err := os.ReadFile(configFilePath)
+	if err != nil {
+		log.Errorf("snapshot: read config %s: %v", configFilePath, err)
+		return
+	}
+	if err = addEntry(snapshotConfigEntry, configData); err != nil {
+		log.Errorf("snapshot: add config: %v", err)
+		return
+	}
+
+	authCount := 0
+	entries, err := os.ReadDir(cfg.AuthDir)
+	if err != nil && !os.IsNotExist(err) {
+		log.Errorf("snapshot: read auth dir: %v", err)
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), ".json") {
+			continue
+		}
+		data, errRead := os.ReadFile(filepath.Join(cfg.AuthDir, entry.Name()))
+		if errRead != nil {
+			log.Errorf("snapshot: read auth file %s: %v", entry.Name(), errRead)
+			return
+		}
+		if err = addEntry(snapshotAuthPrefix+entry.Name(), data); err != nil {
+			log.Errorf("snapshot: add auth file %s: %v", entry.Name(), err)
+			return
+		}
+		authCount++
+	}
+
+	// Usage statistics and quota counters only exist in the running process;
+	// fetch them over the management API when an instance is up, and carry on
+	// without them otherwise.
+	if client := snapshotManagementClient(cfg, password); client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), snapshotManagementTimeout)
+		defer cancel()
+		if usageData, errUsage := client.UsageExport(ctx); errUsage != nil {
+			log.Warnf("snapshot: usage export skipped (is the gateway running?): %v", errUsage)
+		} else if err = addEntry(snapshotUsageEntry, usageData); err != nil {
+			log.Errorf("snapshot: add usage export: %v", err)
+			return
+		}
+		if quotaData, errQuota := client.AuthQuota(ctx); errQuota != nil {
+			log.Warnf("snapshot: quota report skipped: %v", errQuota)
+		} else if err = addEntry(snapshotQuotaEntry, quotaData); err != nil {
+			log.Errorf("snapshot: add quota report: %v", err)
+			return
+		}
+	} else {
+		log.Warn("snapshot: no management key; usage statistics and quota counters are not included")
+	}
+
+	if err = tw.Close(); err != nil {
+		log.Errorf("snapshot: finalize archive: %v", err)
+		return
+	}
+	if err = gz.Close(); err != nil {
+		log.Errorf("snapshot: finalize archive: %v", err)
+		return
+	}
+	fmt.Printf("Snapshot written to %s (%d auth files)\n", outPath, authCount)
+}
+
+// DoSnapshotRestore unpacks a snapshot archive: the config file replaces the
+// active one (the previous version is kept as a timestamped .bak), auth files
+// are written into the auth directory, and the usage export is imported into
+// a running instance when one is reachable. The quota report is point-in-time
+// diagnostics and is not restored.
+//
+// Parameters:
+//   - cfg: The application configuration (auth directory and local port)
+//   - configFilePath: Path the restored config file is written to
+//   - inPath: Path of the snapshot archive to restore
+//   - password: Management key for the optional usage import
+func DoSnapshotRestore(cfg *config.Config, configFilePath, inPath, password string) {
+	inFile, err := os.Open(inPath)
+	if err != nil {
+		log.Errorf("snapshot: open archive: %v", err)
+		return
+	}
+	defer func() { _ = inFile.Close() }()
+	gz, err := gzip.NewReader(inFile)
+	if err != nil {
+		log.Errorf("snapshot: read archive: %v", err)
+		return
+	}
+	tr := tar.NewReader(gz)
+
+	var usagePayload []byte
+	authCount := 0
+	for {
+		header, errNext := tr.Next()
+		if errNext == io.EOF {
+			break
+		}
+		if errNext != nil {
+			log.Errorf("snapshot: read archive: %v", errNext)
+			return
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, errRead := io.ReadAll(tr)
+		if errRead != nil {
+			log.Errorf("snapshot: read entry %s: %v", header.Name, errRead)
+			return
+		}
+		switch {
+		case header.Name == snapshotConfigEntry:
+			if existing, errExisting := os.ReadFile(configFilePath); errExisting == nil && string(existing) != string(data) {
+				backupPath := fmt.Sprintf("%s.bak-%d", configFilePath, time.Now().Unix())
+				if errBackup := os.WriteFile(backupPath, existing, 0o644); errBackup != nil {
+					log.Errorf("snapshot: back up existing config: %v", errBackup)
+					return
+				}
+				fmt.Printf("Existing config backed up to %s\n", backupPath)
+			}
+			if errWrite := os.WriteFile(configFilePath, data, 0o644); errWrite != nil {
+				log.Errorf("snapshot: write config: %v", errWrite)
+				return
+			}
+		case strings.HasPrefix(header.Name, snapshotAuthPrefix):
+			if errDir := os.MkdirAll(cfg.AuthDir, 0o700); errDir != nil {
+				log.Errorf("snapshot: create auth dir: %v", errDir)
+				return
+			}
+			// filepath.Base guards against path traversal in crafted archives.
+			name := filepath.Base(header.Name)
+			if errWrite := os.WriteFile(filepath.Join(cfg.AuthDir, name), data, 0o600); errWrite != nil {
+				log.Errorf("snapshot: write auth file %s: %v", name, errWrite)
+				return
+			}
+			authCount++
+		case header.Name == snapshotUsageEntry:
+			usagePayload = data
+		case header.Name == snapshotQuotaEntry:
+			// Point-in-time diagnostics; nothing to restore.
+		default:
+			log.Warnf("snapshot: skipping unknown entry %s", header.Name)
+		}
+	}
+
+	fmt.Printf("Snapshot restored from %s (%d auth files)\n", inPath, authCount)
+
+	if len(usagePayload) == 0 {
+		return
+	}
+	if client := snapshotManagementClient(cfg, password); client != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), snapshotManagementTimeout)
+		defer cancel()
+		if errImport := client.UsageImport(ctx, usagePayload); errImport != nil {
+			log.Warnf("snapshot: usage import failed (start the gateway and re-run restore, or POST /v0/management/usage/import): %v", errImport)
+			return
+		}
+		fmt.Println("Usage statistics imported into the running instance.")
+	} else {
+		log.Warn("snapshot: no management key; import usage later via POST /v0/management/usage/import")
+	}
+}
+
+// snapshotManagementClient builds a client for the running local instance, or
+// nil when no management key is configured.
+func snapshotManagementClient(cfg *config.Config, password string) *management.Client {
+	key := strings.TrimSpace(password)
+	if key == "" {
+		key = strings.TrimSpace(os.Getenv("MANAGEMENT_PASSWORD"))
+	}
+	if key == "" {
+		return nil
+	}
+	port := 8317
+	if cfg != nil && cfg.Port > 0 {
+		port = cfg.Port
+	}
+	scheme := "http"
+	if cfg != nil && cfg.TLS.Enable {
+		scheme = "https"
+	}
+	client, err := management.NewClient(fmt.Sprintf("%s://127.0.0.1:%d", scheme, port), key)
+	if err != nil {
+		log.Warnf("snapshot: management client unavailable: %v", err)
+		return nil
+	}
+	return client
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/usage"
+	"github.com/shariqriazz/modelgate/sdk/management"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	topRefreshInterval = 3 * time.Second
+	topWindow          = time.Minute
+)
+
+// topRow aggregates request activity for one model or auth within the window.
+type topRow struct {
+	name     string
+	requests int64
+	tokens   int64
+	failures int64
+}
+
+// DoTop renders a refreshing per-model and per-auth activity table from the
+// usage store of a running gateway: requests/min, tokens/min, and error rate
+// over the last minute. The management key is taken from the --password flag
+// or the MANAGEMENT_PASSWORD environment variable.
+//
+// Parameters:
+//   - cfg: The application configuration (used to resolve the local base URL)
+//   - password: Management key used to authenticate against the local instance
+func DoTop(cfg *config.Config, password string) {
+	key := strings.TrimSpace(password)
+	if key == "" {
+		key = strings.TrimSpace(os.Getenv("MANAGEMENT_PASSWORD"))
+	}
+	if key == "" {
+		log.Error("top: management key required; pass --password or set MANAGEMENT_PASSWORD")
+		return
+	}
+	port := 8317
+	if cfg != nil && cfg.Port > 0 {
+		port = cfg.Port
+	}
+	scheme := "http"
+	if cfg != nil && cfg.TLS.Enable {
+		scheme = "https"
+	}
+	client, err := management.NewClient(fmt.Sprintf("%s://127.0.0.1:%d", scheme, port), key)
+	if err != nil {
+		log.Errorf("top: %v", err)
+		return
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	for {
+		stats, errUsage := client.Usage(ctx)
+		if errUsage != nil {
+			log.Errorf("top: %v", errUsage)
+			return
+		}
+		var snapshot usage.StatisticsSnapshot
+		if errDecode := json.Unmarshal(stats.Usage, &snapshot); errDecode != nil {
+			log.Errorf("top: decode usage snapshot: %v", errDecode)
+			return
+		}
+		renderTop(&snapshot)
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return
+		case <-time.After(topRefreshInterval):
+		}
+	}
+}
+
+func renderTop(snapshot *usage.StatisticsSnapshot) {
+	models, auths := aggregateTopRows(snapshot, time.Now().Add(-topWindow))
+
+	var sb strings.Builder
+	sb.WriteString("\x1b[2J\x1b[H")
+	sb.WriteString(fmt.Sprintf("ModelGate top — last %s (total requests: %d, failed: %d)\n\n",
+		topWindow, snapshot.TotalRequests, snapshot.FailureCount))
+
+	sb.WriteString(fmt.Sprintf("%-40s %10s %12s %8s\n", "MODEL", "REQ/MIN", "TOKENS/MIN", "ERR%"))
+	writeTopRows(&sb, models)
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%-40s %10s %12s %8s\n", "AUTH", "REQ/MIN", "TOKENS/MIN", "ERR%"))
+	writeTopRows(&sb, auths)
+	sb.WriteString("\nCtrl-C to quit\n")
+	fmt.Print(sb.String())
+}
+
+// aggregateTopRows collapses request details newer than cutoff into per-model
+// and per-auth rows.
+func aggregateTopRows(snapshot *usage.StatisticsSnapshot, cutoff time.Time) (models, auths []topRow) {
+	byModel := make(map[string]*topRow)
+	byAuth := make(map[string]*topRow)
+	for _, api := range snapshot.APIs {
+		for modelName, model := range api.Models {
+			for _, detail := range model.Details {
+				if detail.Timestamp.Before(cutoff) {
+					continue
+				}
+				bump(byModel, modelName, detail)
+				authName := detail.AuthIndex
+				if authName == "" {
+					authName = detail.Source
+				}
+				if authName == "" {
+					authName = "unknown"
+				}
+				bump(byAuth, authName, detail)
+			}
+		}
+	}
+	return sortTopRows(byModel), sortTopRows(byAuth)
+}
+
+func bump(rows map[string]*topRow, name string, detail usage.RequestDetail) {
+	row := rows[name]
+	if row == nil {
+		row = &topRow{name: name}
+		rows[name] = row
+	}
+	row.requests++
+	row.tokens += detail.Tokens.TotalTokens
+	if detail.Failed {
+		row.failures++
+	}
+}
+
+func sortTopRows(rows map[string]*topRow) []topRow {
+	out := make([]topRow, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *row)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].requests != out[j].requests {
+			return out[i].requests > out[j].requests
+		}
+		return out[i].name < out[j].name
+	})
+	return out
+}
+
+func writeTopRows(sb *strings.Builder, rows []topRow) {
+	if len(rows) == 0 {
+		sb.WriteString("  (no activity in window)\n")
+		return
+	}
+	for _, row := range rows {
+		errRate := 0.0
+		if row.requests > 0 {
+			errRate = float64(row.failures) / float64(row.requests) * 100
+		}
+		sb.WriteString(fmt.Sprintf("%-40s %10d %12d %7.1f%%\n",
+			truncateTUI(row.name, 40), row.requests, row.tokens, errRate))
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/sdk/management"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/term"
+)
+
+const tuiRefreshInterval = 2 * time.Second
+
+// DoTUI runs an interactive terminal dashboard against a running gateway.
+// It polls the management API for auth health and usage statistics, renders a
+// refreshing table, and lets the operator enable/disable auths with number
+// keys. The management key is taken from the --password flag or the
+// MANAGEMENT_PASSWORD environment variable.
+//
+// Parameters:
+//   - cfg: The application configuration (used to resolve the local base URL)
+//   - password: Management key used to authenticate against the local instance
+func DoTUI(cfg *config.Config, password string) {
+	key := strings.TrimSpace(password)
+	if key == "" {
+		key = strings.TrimSpace(os.Getenv("MANAGEMENT_PASSWORD"))
+	}
+	if key == "" {
+		log.Error("tui: management key required; pass --password or set MANAGEMENT_PASSWORD")
+		return
+	}
+	port := 8317
+	if cfg != nil && cfg.Port > 0 {
+		port = cfg.Port
+	}
+	scheme := "http"
+	if cfg != nil && cfg.TLS.Enable {
+		scheme = "https"
+	}
+	client, err := management.NewClient(fmt.Sprintf("%s://127.0.0.1:%d", scheme, port), key)
+	if err != nil {
+		log.Errorf("tui: %v", err)
+		return
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		log.Error("tui: stdin is not a terminal")
+		return
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		log.Errorf("tui: failed to enter raw mode: %v", err)
+		return
+	}
+	defer func() { _ = term.Restore(fd, oldState) }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	keys := make(chan byte, 8)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			n, errRead := os.Stdin.Read(buf)
+			if errRead != nil || n == 0 {
+				return
+			}
+			select {
+			case keys <- buf[0]:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ui := &tuiState{client: client}
+	ui.refresh(ctx)
+	ui.render()
+
+	ticker := time.NewTicker(tuiRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			ui.refresh(ctx)
+			ui.render()
+		case key := <-keys:
+			switch {
+			case key == 'q' || key == 3: // q or Ctrl-C
+				fmt.Print("\x1b[2J\x1b[H")
+				return
+			case key == 'r':
+				ui.refresh(ctx)
+				ui.render()
+			case key >= '1' && key <= '9':
+				ui.toggle(ctx, int(key-'1'))
+				ui.refresh(ctx)
+				ui.render()
+			}
+		}
+	}
+}
+
+type tuiState struct {
+	client *management.Client
+	files  []management.AuthFile
+	stats  tuiUsageSnapshot
+
+	lastRequests int64
+	lastTokens   int64
+	lastSampled  time.Time
+	requestsPM   float64
+	tokensPM     float64
+	message      string
+}
+
+type tuiUsageSnapshot struct {
+	TotalRequests int64 `json:"total_requests"`
+	FailureCount  int64 `json:"failure_count"`
+	TotalTokens   int64 `json:"total_tokens"`
+}
+
+func (s *tuiState) refresh(ctx context.Context) {
+	reqCtx, cancel := context.WithTimeout(ctx, tuiRefreshInterval)
+	defer cancel()
+	files, err := s.client.ListAuthFiles(reqCtx)
+	if err != nil {
+		s.message = fmt.Sprintf("list auths: %v", err)
+		return
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+	s.files = files
+
+	usage, err := s.client.Usage(reqCtx)
+	if err != nil {
+		s.message = fmt.Sprintf("usage: %v", err)
+		return
+	}
+	var snapshot tuiUsageSnapshot
+	if err := json.Unmarshal(usage.Usage, &snapshot); err == nil {
+		now := time.Now()
+		if !s.lastSampled.IsZero() {
+			minutes := now.Sub(s.lastSampled).Minutes()
+			if minutes > 0 {
+				s.requestsPM = float64(snapshot.TotalRequests-s.lastRequests) / minutes
+				s.tokensPM = float64(snapshot.TotalTokens-s.lastTokens) / minutes
+			}
+		}
+		s.lastRequests = snapshot.TotalRequests
+		s.lastTokens = snapshot.TotalTokens
+		s.lastSampled = now
+		s.stats = snapshot
+	}
+	s.message = ""
+}
+
+func (s *tuiState) toggle(ctx context.Context, index int) {
+	if index < 0 || index >= len(s.files) {
+		return
+	}
+	file := s.files[index]
+	reqCtx, cancel := context.WithTimeout(ctx, tuiRefreshInterval)
+	defer cancel()
+	if err := s.client.SetAuthDisabled(reqCtx, file.Name, !file.Disabled); err != nil {
+		s.message = fmt.Sprintf("toggle %s: %v", file.Name, err)
+		return
+	}
+	s.message = fmt.Sprintf("%s -> disabled=%v", file.Name, !file.Disabled)
+}
+
+func (s *tuiState) render() {
+	var sb strings.Builder
+	sb.WriteString("\x1b[2J\x1b[H")
+	sb.WriteString("ModelGate — auth & usage dashboard\r\n")
+	sb.WriteString(fmt.Sprintf("requests: %d (%.1f/min)   tokens: %d (%.0f/min)   failed: %d\r\n",
+		s.stats.TotalRequests, s.requestsPM, s.stats.TotalTokens, s.tokensPM, s.stats.FailureCount))
+	sb.WriteString("\r\n")
+	sb.WriteString(fmt.Sprintf("    %-32s %-16s %-12s %s\r\n", "NAME", "PROVIDER", "STATUS", "NOTE"))
+	for i, file := range s.files {
+		status := file.Status
+		if file.Disabled {
+			status = "disabled"
+		} else if file.Unavailable {
+			status = "unavailable"
+		}
+		marker := "  "
+		if i < 9 {
+			marker = fmt.Sprintf("%d.", i+1)
+		}
+		sb.WriteString(fmt.Sprintf("%s  %-32s %-16s %-12s %s\r\n",
+			marker, truncateTUI(file.Name, 32), truncateTUI(file.Provider, 16), status, truncateTUI(file.StatusMessage, 40)))
+	}
+	sb.WriteString("\r\n")
+	if s.message != "" {
+		sb.WriteString(s.message + "\r\n")
+	}
+	sb.WriteString("[1-9] toggle auth   [r] refresh   [q] quit   (re-login via --login/--codex-login/... flags)\r\n")
+	fmt.Print(sb.String())
+}
+
+func truncateTUI(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if max <= 3 {
+		return s[:max]
+	}
+	return s[:max-3] + "..."
+}
@@ -0,0 +1,12 @@
+package config
+
+// AuditLogConfig controls the append-only audit log of administrative and
+// credential lifecycle actions (auth changes, token refreshes, config
+// reloads, mutating management API calls).
+type AuditLogConfig struct {
+	// Enabled turns audit logging on.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// File is the audit log path; defaults to "audit.log" when empty.
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+}
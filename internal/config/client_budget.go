@@ -0,0 +1,29 @@
+package config
+
+// ClientBudgetRule attaches spend caps to inbound client API keys. Rules are
+// evaluated in order and the first matching rule applies; zero limits are
+// unlimited. Windows reset at UTC day and calendar month boundaries.
+type ClientBudgetRule struct {
+	// APIKey is the inbound API key the budget applies to; '*' matches zero
+	// or more characters, so "*" budgets every key.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// DailyTokens caps total tokens consumed per UTC day.
+	DailyTokens int64 `yaml:"daily-tokens,omitempty" json:"daily-tokens,omitempty"`
+
+	// MonthlyTokens caps total tokens consumed per calendar month.
+	MonthlyTokens int64 `yaml:"monthly-tokens,omitempty" json:"monthly-tokens,omitempty"`
+
+	// DailyCostUSD caps estimated spend in USD per UTC day.
+	DailyCostUSD float64 `yaml:"daily-cost-usd,omitempty" json:"daily-cost-usd,omitempty"`
+
+	// MonthlyCostUSD caps estimated spend in USD per calendar month.
+	MonthlyCostUSD float64 `yaml:"monthly-cost-usd,omitempty" json:"monthly-cost-usd,omitempty"`
+
+	// AlertThresholds lists consumption percentages (e.g. 50, 90) at which a
+	// budget alert is logged and, when AlertWebhook is set, delivered.
+	AlertThresholds []int `yaml:"alert-thresholds,omitempty" json:"alert-thresholds,omitempty"`
+
+	// AlertWebhook optionally receives a JSON POST for each triggered alert.
+	AlertWebhook string `yaml:"alert-webhook,omitempty" json:"alert-webhook,omitempty"`
+}
@@ -30,6 +30,11 @@ type Config struct {
 	// Port is the network port on which the API server will listen.
 	Port int `yaml:"port" json:"-"`
 
+	// GRPCAddress optionally exposes the gRPC inference and auth management
+	// interface on the given listen address (e.g. "127.0.0.1:8318"). Empty
+	// disables the gRPC server.
+	GRPCAddress string `yaml:"grpc-address,omitempty" json:"-"`
+
 	// TLS config controls HTTPS server settings.
 	TLS TLSConfig `yaml:"tls" json:"tls"`
 
@@ -48,21 +53,138 @@ type Config struct {
 	// LoggingToFile controls whether application logs are written to rotating files or stdout.
 	LoggingToFile bool `yaml:"logging-to-file" json:"logging-to-file"`
 
+	// LoggingFormat selects the application log encoding: "text" (default) for the
+	// human-readable format or "json" for one JSON object per line with stable
+	// field names, suitable for ingestion into log aggregators.
+	LoggingFormat string `yaml:"logging-format,omitempty" json:"logging-format,omitempty"`
+
+	// DebugAPIKeys lists API keys allowed to request per-request debug output
+	// via the X-ModelGate-Debug header. Empty disables the header entirely.
+	DebugAPIKeys []string `yaml:"debug-api-keys,omitempty" json:"debug-api-keys,omitempty"`
+
+	// ModelCatalogWebhookURL, when set, receives a JSON POST whenever a model
+	// refresh detects added or removed upstream models.
+	ModelCatalogWebhookURL string `yaml:"model-catalog-webhook-url,omitempty" json:"model-catalog-webhook-url,omitempty"`
+
 	// LogsMaxTotalSizeMB limits the total size (in MB) of log files under the logs directory.
 	// When exceeded, the oldest log files are deleted until within the limit. Set to 0 to disable.
 	LogsMaxTotalSizeMB int `yaml:"logs-max-total-size-mb" json:"logs-max-total-size-mb"`
 
+	// LogSampling bounds logging overhead under load by sampling debug and
+	// per-request logs once request volume exceeds a threshold.
+	LogSampling LogSamplingConfig `yaml:"log-sampling,omitempty" json:"log-sampling,omitempty"`
+
+	// AuditLog records administrative and credential lifecycle actions to an
+	// append-only JSON-lines file.
+	AuditLog AuditLogConfig `yaml:"audit-log,omitempty" json:"audit-log,omitempty"`
+
+	// Recording captures translated upstream payloads and responses to disk
+	// for offline replay with the `-replay` command.
+	Recording RecordingConfig `yaml:"recording,omitempty" json:"recording,omitempty"`
+
 	// UsageStatisticsEnabled toggles in-memory usage aggregation; when false, usage data is discarded.
 	UsageStatisticsEnabled bool `yaml:"usage-statistics-enabled" json:"usage-statistics-enabled"`
 
+	// UsageStorage optionally persists every usage record to a SQLite or
+	// Postgres database so accounting survives restarts and can be queried
+	// historically. Empty backend keeps accounting in-memory only.
+	UsageStorage UsageStorageConfig `yaml:"usage-storage,omitempty" json:"usage-storage,omitempty"`
+
+	// Pricing lists per-model token price overrides used for request cost
+	// estimation, taking precedence over the built-in pricing table.
+	Pricing []PricingRule `yaml:"pricing,omitempty" json:"pricing,omitempty"`
+
+	// ClientBudgets attaches daily/monthly token or dollar spend caps to
+	// inbound API keys; exceeded budgets reject requests until the window
+	// resets and alerts fire at configurable consumption thresholds.
+	ClientBudgets []ClientBudgetRule `yaml:"client-budgets,omitempty" json:"client-budgets,omitempty"`
+
+	// UsageHeaders attaches X-ModelGate-Usage-* headers to non-streaming
+	// responses and a terminal usage event to streams so clients can track
+	// consumption without parsing provider-specific usage shapes.
+	UsageHeaders bool `yaml:"usage-headers" json:"usage-headers"`
+
 	// DisableCooling disables quota cooldown scheduling when true.
 	DisableCooling bool `yaml:"disable-cooling" json:"disable-cooling"`
 
+	// FakeStreamingModels lists model name patterns ('*' wildcards allowed)
+	// whose streaming requests are performed non-streamed upstream (more
+	// reliable on some providers) with word-by-word chunks synthesized for
+	// the client.
+	FakeStreamingModels []string `yaml:"fake-streaming-models,omitempty" json:"fake-streaming-models,omitempty"`
+
+	// StreamUpstreamModels lists model name patterns ('*' wildcards allowed)
+	// whose non-streaming requests are executed against the provider's
+	// streaming endpoint and buffered into a single blocking response, for
+	// providers whose non-stream endpoint is flaky.
+	StreamUpstreamModels []string `yaml:"stream-upstream-models,omitempty" json:"stream-upstream-models,omitempty"`
+
+	// ModelTransport maps model name patterns ('*' wildcards allowed) to the
+	// upstream call style to force: "stream", "nonstream" or "auto" (mirror
+	// the client, the default). Entries take precedence over
+	// fake-streaming-models and stream-upstream-models.
+	ModelTransport map[string]string `yaml:"model-transport,omitempty" json:"model-transport,omitempty"`
+
+	// CoalesceIdenticalRequests merges identical concurrent non-streaming
+	// requests (same model and payload, temperature 0) into a single upstream
+	// call and fans the response out to every waiter. Off by default.
+	CoalesceIdenticalRequests bool `yaml:"coalesce-identical-requests" json:"coalesce-identical-requests"`
+
+	// StreamScannerLimitMB caps the size of a single SSE event per provider,
+	// in megabytes. Keys are provider identifiers (e.g. "codex") or "default";
+	// a stream whose event outgrows its cap fails with a descriptive error
+	// instead of buffering unbounded data. Empty means no cap.
+	StreamScannerLimitMB map[string]int `yaml:"stream-scanner-limit-mb,omitempty" json:"stream-scanner-limit-mb,omitempty"`
+
+	// PassthroughUnknownFields forwards allowlisted request fields that a
+	// translator did not map (e.g. seed, logit_bias) to the upstream verbatim,
+	// so new provider parameters work before explicit translator support
+	// lands. Off by default.
+	PassthroughUnknownFields bool `yaml:"passthrough-unknown-fields" json:"passthrough-unknown-fields"`
+
+	// SameFormatPassthrough streams requests and responses through untouched
+	// when the client's format equals the provider's native format (the common
+	// OpenAI -> OpenAI-compatible case), skipping the translate/retranslate
+	// round trip and the identity translators' normalization. Off by default.
+	SameFormatPassthrough bool `yaml:"same-format-passthrough" json:"same-format-passthrough"`
+
+	// PassthroughModels lists model name patterns ('*' wildcards) whose
+	// requests bypass translation, thinking normalization, and payload rules:
+	// the body goes upstream verbatim with only auth injection, for clients
+	// relying on provider-specific features.
+	PassthroughModels []string `yaml:"passthrough-models,omitempty" json:"passthrough-models,omitempty"`
+
+	// BurstSmoothingRPS spaces upstream dispatches per credential at this many
+	// requests per second (leaky bucket), smoothing bursts before they turn
+	// into 429 storms. Zero disables smoothing; a credential can override the
+	// rate with a smoothing_rps attribute.
+	BurstSmoothingRPS float64 `yaml:"burst-smoothing-rps,omitempty" json:"burst-smoothing-rps,omitempty"`
+
+	// AuthDailyCaps maps an auth ID, credential file name, or provider key to
+	// a hard daily token cap (UTC window). Once an auth spends its cap it is
+	// parked until the next day, protecting personal accounts pooled into a
+	// shared gateway from runaway agents. Zero or missing means uncapped.
+	AuthDailyCaps map[string]int64 `yaml:"auth-daily-caps,omitempty" json:"auth-daily-caps,omitempty"`
+
+	// UpstreamRetry retries non-streaming requests that failed with an
+	// upstream 500/502/503 across different credentials and base URLs,
+	// extending the no-capacity retry the Antigravity executor applies to all
+	// executors.
+	UpstreamRetry UpstreamRetryConfig `yaml:"upstream-retry,omitempty" json:"upstream-retry,omitempty"`
+
 	// RequestRetry defines the retry times when the request failed.
 	RequestRetry int `yaml:"request-retry" json:"request-retry"`
 	// MaxRetryInterval defines the maximum wait time in seconds before retrying a cooled-down credential.
 	MaxRetryInterval int `yaml:"max-retry-interval" json:"max-retry-interval"`
 
+	// HTTPTransport tunes connection pooling, TLS session reuse, and dial
+	// timeouts for the shared upstream HTTP transport.
+	HTTPTransport HTTPTransportConfig `yaml:"http-transport" json:"http-transport"`
+
+	// ConnectionWarmup keeps TLS connections to the listed upstream hosts
+	// pre-established at startup and across idle periods.
+	ConnectionWarmup ConnectionWarmupConfig `yaml:"connection-warmup" json:"connection-warmup"`
+
 	// QuotaExceeded defines the behavior when a quota is exceeded.
 	QuotaExceeded QuotaExceeded `yaml:"quota-exceeded" json:"quota-exceeded"`
 
@@ -88,6 +210,33 @@ type Config struct {
 	// ClaudeKey defines a list of Claude API key configurations as specified in the YAML configuration file.
 	ClaudeKey []ClaudeKey `yaml:"claude-api-key" json:"claude-api-key"`
 
+	// CerebrasKey is a list of Cerebras Cloud API key configurations.
+	CerebrasKey []CerebrasKey `yaml:"cerebras-api-key" json:"cerebras-api-key"`
+
+	// AzureOpenAIKey is a list of Azure OpenAI resource configurations.
+	AzureOpenAIKey []AzureOpenAIKey `yaml:"azure-openai" json:"azure-openai"`
+
+	// Ollama is a list of local or remote Ollama server configurations.
+	Ollama []OllamaServer `yaml:"ollama" json:"ollama"`
+
+	// OpenRouterKey is a list of OpenRouter API key configurations.
+	OpenRouterKey []OpenRouterKey `yaml:"openrouter-api-key" json:"openrouter-api-key"`
+
+	// MistralKey is a list of Mistral La Plateforme API key configurations.
+	MistralKey []MistralKey `yaml:"mistral-api-key" json:"mistral-api-key"`
+
+	// GroqKey is a list of Groq Cloud API key configurations.
+	GroqKey []GroqKey `yaml:"groq-api-key" json:"groq-api-key"`
+
+	// DeepSeekKey is a list of DeepSeek API key configurations.
+	DeepSeekKey []DeepSeekKey `yaml:"deepseek-api-key" json:"deepseek-api-key"`
+
+	// CohereKey is a list of Cohere API key configurations.
+	CohereKey []CohereKey `yaml:"cohere-api-key" json:"cohere-api-key"`
+
+	// CloudflareKey is a list of Cloudflare Workers AI account configurations.
+	CloudflareKey []CloudflareKey `yaml:"cloudflare-workers-ai" json:"cloudflare-workers-ai"`
+
 	// OpenAICompatibility defines OpenAI API compatibility configurations for external providers.
 	OpenAICompatibility []OpenAICompatibility `yaml:"openai-compatibility" json:"openai-compatibility"`
 
@@ -95,6 +244,10 @@ type Config struct {
 	// Used for services that use Vertex AI-style paths but with simple API key authentication.
 	VertexCompatAPIKey []VertexCompatKey `yaml:"vertex-api-key" json:"vertex-api-key"`
 
+	// VertexExpressAPIKey defines Vertex AI express-mode API keys (key-only
+	// authentication against Google's own Vertex endpoint, no service account).
+	VertexExpressAPIKey []VertexExpressKey `yaml:"vertex-express-api-key" json:"vertex-express-api-key"`
+
 	// AmpCode contains Amp CLI upstream configuration, management restrictions, and model mappings.
 	AmpCode AmpCode `yaml:"ampcode" json:"ampcode"`
 
@@ -152,6 +305,22 @@ type QuotaExceeded struct {
 
 	// SwitchPreviewModel indicates whether to automatically switch to a preview model when a quota is exceeded.
 	SwitchPreviewModel bool `yaml:"switch-preview-model" json:"switch-preview-model"`
+
+	// MaxWaitSeconds, when positive, holds a request that hit a quota 429 whose
+	// retry hint is at or below this many seconds and retries it (on the same or
+	// another credential) instead of propagating the 429 to the client.
+	MaxWaitSeconds int `yaml:"max-wait-seconds,omitempty" json:"max-wait-seconds,omitempty"`
+}
+
+// UpstreamRetryConfig tunes automatic retries of non-streaming requests that
+// failed with an upstream 500, 502, or 503.
+type UpstreamRetryConfig struct {
+	// Attempts is the number of extra attempts; zero disables 5xx retries.
+	Attempts int `yaml:"attempts,omitempty" json:"attempts,omitempty"`
+
+	// BudgetSeconds caps the total request latency including retries; zero
+	// means no latency cap.
+	BudgetSeconds int `yaml:"budget-seconds,omitempty" json:"budget-seconds,omitempty"`
 }
 
 // RoutingConfig configures how credentials are selected for requests.
@@ -159,6 +328,38 @@ type RoutingConfig struct {
 	// Strategy selects the credential selection strategy.
 	// Supported values: "round-robin" (default), "fill-first".
 	Strategy string `yaml:"strategy,omitempty" json:"strategy,omitempty"`
+
+	// CodexPlanWeights maps a Codex subscription plan (e.g. "plus", "pro",
+	// "team") to a relative routing weight. Accounts on higher-weighted plans
+	// receive proportionally more traffic. Plans not listed fall back to
+	// built-in defaults. Setting any entry enables plan-weighted routing.
+	CodexPlanWeights map[string]int `yaml:"codex-plan-weights,omitempty" json:"codex-plan-weights,omitempty"`
+
+	// CodexPlanMaxConcurrency caps concurrent in-flight requests per Codex
+	// plan bucket. Zero or missing entries mean unlimited. Setting any entry
+	// enables plan-weighted routing.
+	CodexPlanMaxConcurrency map[string]int `yaml:"codex-plan-max-concurrency,omitempty" json:"codex-plan-max-concurrency,omitempty"`
+
+	// TimeWindows applies time-of-day routing preferences, e.g. free-tier keys
+	// overnight when their quotas reset and subscription accounts during the
+	// day. Windows are evaluated in order; the first one containing the current
+	// server-local time wins.
+	TimeWindows []RoutingTimeWindow `yaml:"time-windows,omitempty" json:"time-windows,omitempty"`
+}
+
+// RoutingTimeWindow describes one time-of-day routing preference.
+type RoutingTimeWindow struct {
+	// Start is the inclusive window start in "HH:MM" server-local time.
+	Start string `yaml:"start" json:"start"`
+
+	// End is the exclusive window end in "HH:MM". A window whose end is not
+	// after its start wraps past midnight; equal start and end means all day.
+	End string `yaml:"end" json:"end"`
+
+	// Prefer lists provider keys, auth IDs, credential file names, or labels
+	// to prefer while the window is active. Selection falls back to the other
+	// credentials when none of the preferred ones are available.
+	Prefer []string `yaml:"prefer,omitempty" json:"prefer,omitempty"`
 }
 
 // ModelNameMapping defines a model ID mapping for a specific channel.
@@ -351,6 +552,309 @@ type CodexModel struct {
 func (m CodexModel) GetName() string  { return m.Name }
 func (m CodexModel) GetAlias() string { return m.Alias }
 
+// CerebrasKey represents the configuration for a Cerebras Cloud API key,
+// including optional overrides for upstream base URL, proxy routing, and headers.
+type CerebrasKey struct {
+	// APIKey is the authentication key for accessing Cerebras Cloud services.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/llama-3.3-70b").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the Cerebras API endpoint.
+	// If empty, the default Cerebras Cloud URL will be used.
+	BaseURL string `yaml:"base-url" json:"base-url"`
+
+	// ProxyURL overrides the global proxy setting for this API key if provided.
+	ProxyURL string `yaml:"proxy-url" json:"proxy-url"`
+
+	// Models defines upstream model names and aliases for request routing.
+	Models []CerebrasModel `yaml:"models" json:"models"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// CerebrasModel describes a mapping between an alias and the actual upstream model name.
+type CerebrasModel struct {
+	// Name is the upstream model identifier used when issuing requests.
+	Name string `yaml:"name" json:"name"`
+
+	// Alias is the client-facing model name that maps to Name.
+	Alias string `yaml:"alias" json:"alias"`
+}
+
+func (m CerebrasModel) GetName() string  { return m.Name }
+func (m CerebrasModel) GetAlias() string { return m.Alias }
+
+// AzureOpenAIKey represents the configuration for an Azure OpenAI resource.
+// Azure addresses models through per-resource deployments rather than model
+// names, so each entry carries the resource endpoint, the API version, and a
+// deployment-to-model mapping used to rewrite request paths.
+type AzureOpenAIKey struct {
+	// APIKey is the authentication key for the Azure OpenAI resource.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/gpt-4o").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Endpoint is the resource endpoint, e.g. "https://my-resource.openai.azure.com".
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+
+	// APIVersion is the api-version query parameter sent with every request.
+	// If empty, a stable default is used.
+	APIVersion string `yaml:"api-version,omitempty" json:"api-version,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this resource if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// Deployments maps client-facing model names to deployment names on this resource.
+	Deployments []AzureOpenAIDeployment `yaml:"deployments" json:"deployments"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// OpenRouterKey represents the configuration for an OpenRouter API key. The
+// model catalog is fetched from the API, and the attribution headers OpenRouter
+// requires (HTTP-Referer, X-Title) can be customized per key.
+type OpenRouterKey struct {
+	// APIKey is the authentication key for accessing the OpenRouter API.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/meta-llama/llama-3.3-70b-instruct").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the OpenRouter API endpoint.
+	// If empty, the default OpenRouter URL will be used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this API key if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// SiteURL is sent as the HTTP-Referer attribution header when set.
+	SiteURL string `yaml:"site-url,omitempty" json:"site-url,omitempty"`
+
+	// SiteName is sent as the X-Title attribution header when set.
+	SiteName string `yaml:"site-name,omitempty" json:"site-name,omitempty"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// AzureOpenAIDeployment maps a client-facing model name to an Azure deployment.
+type AzureOpenAIDeployment struct {
+	// Name is the deployment name used in the request path.
+	Name string `yaml:"name" json:"name"`
+
+	// Model is the client-facing model identifier routed to this deployment.
+	Model string `yaml:"model" json:"model"`
+}
+
+func (m AzureOpenAIDeployment) GetName() string  { return m.Model }
+func (m AzureOpenAIDeployment) GetAlias() string { return m.Model }
+
+// GroqKey represents the configuration for a Groq Cloud API key. The model
+// catalog is fetched from the API rather than being declared in the config.
+type GroqKey struct {
+	// APIKey is the authentication key for accessing the Groq API.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/llama-3.3-70b-versatile").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the Groq API endpoint.
+	// If empty, the default Groq Cloud URL will be used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this API key if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// DeepSeekKey represents the configuration for a DeepSeek API key. The model
+// catalog is fetched from the API rather than being declared in the config.
+type DeepSeekKey struct {
+	// APIKey is the authentication key for accessing the DeepSeek API.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/deepseek-reasoner").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the DeepSeek API endpoint.
+	// If empty, the default DeepSeek URL will be used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this API key if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// CohereKey represents the configuration for a Cohere API key. The model
+// catalog is fetched from the API rather than being declared in the config.
+type CohereKey struct {
+	// APIKey is the authentication key for accessing the Cohere API.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/command-r-plus").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the Cohere API endpoint.
+	// If empty, the default Cohere URL will be used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this API key if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// CloudflareKey represents the configuration for a Cloudflare Workers AI
+// account. Requests run against the account-scoped /ai/run endpoint and the
+// model catalog is discovered from the API.
+type CloudflareKey struct {
+	// AccountID is the Cloudflare account identifier the Workers AI requests run under.
+	AccountID string `yaml:"account-id" json:"account-id"`
+
+	// APIToken is the Cloudflare API token with Workers AI permissions.
+	APIToken string `yaml:"api-token" json:"api-token"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/@cf/meta/llama-3.1-8b-instruct").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the Cloudflare API endpoint.
+	// If empty, the default Cloudflare URL will be used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this account if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this token.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// MistralKey represents the configuration for a Mistral La Plateforme API key,
+// including optional overrides for upstream base URL, proxy routing, and headers.
+type MistralKey struct {
+	// APIKey is the authentication key for accessing the Mistral API.
+	APIKey string `yaml:"api-key" json:"api-key"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this credential (e.g., "teamA/mistral-large-latest").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// BaseURL is the base URL for the Mistral API endpoint.
+	// If empty, the default La Plateforme URL will be used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this API key if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// Models defines upstream model names and aliases for request routing.
+	Models []MistralModel `yaml:"models" json:"models"`
+
+	// Headers optionally adds extra HTTP headers for requests sent with this key.
+	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
+// MistralModel describes a mapping between an alias and the actual upstream model name.
+type MistralModel struct {
+	// Name is the upstream model identifier used when issuing requests.
+	Name string `yaml:"name" json:"name"`
+
+	// Alias is the client-facing model name that maps to Name.
+	Alias string `yaml:"alias" json:"alias"`
+}
+
+func (m MistralModel) GetName() string  { return m.Name }
+func (m MistralModel) GetAlias() string { return m.Alias }
+
+// OllamaServer represents the configuration for an Ollama server. The model
+// list is discovered from the server's /api/tags endpoint rather than being
+// declared in the config.
+type OllamaServer struct {
+	// BaseURL is the server address, e.g. "http://127.0.0.1:11434".
+	// If empty, the local default is used.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// APIKey optionally authenticates against a reverse proxy in front of the
+	// server; it is sent as a bearer token. Local servers need no key.
+	APIKey string `yaml:"api-key,omitempty" json:"api-key,omitempty"`
+
+	// Priority controls selection preference when multiple credentials match.
+	// Higher values are preferred; defaults to 0.
+	Priority int `yaml:"priority,omitempty" json:"priority,omitempty"`
+
+	// Prefix optionally namespaces models for this server (e.g., "local/llama3").
+	Prefix string `yaml:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// ProxyURL overrides the global proxy setting for this server if provided.
+	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
+
+	// ExcludedModels lists model IDs that should be excluded for this provider.
+	ExcludedModels []string `yaml:"excluded-models,omitempty" json:"excluded-models,omitempty"`
+}
+
 // GeminiKey represents the configuration for a Gemini API key,
 // including optional overrides for upstream base URL, proxy routing, and headers.
 type GeminiKey struct {
@@ -370,6 +874,20 @@ type GeminiKey struct {
 	// ProxyURL optionally overrides the global proxy for this API key.
 	ProxyURL string `yaml:"proxy-url,omitempty" json:"proxy-url,omitempty"`
 
+	// RPM optionally caps requests per minute for this key (e.g. the documented
+	// free-tier limit). When the budget is spent the key cools down until the
+	// window resets instead of waiting for an upstream 429. Zero disables the cap.
+	RPM int `yaml:"rpm,omitempty" json:"rpm,omitempty"`
+
+	// RPD optionally caps requests per day (UTC) for this key. Zero disables the cap.
+	RPD int `yaml:"rpd,omitempty" json:"rpd,omitempty"`
+
+	// TPM optionally caps tokens per minute for this key. Zero disables the cap.
+	TPM int `yaml:"tpm,omitempty" json:"tpm,omitempty"`
+
+	// TPD optionally caps tokens per day (UTC) for this key. Zero disables the cap.
+	TPD int `yaml:"tpd,omitempty" json:"tpd,omitempty"`
+
 	// Models defines upstream model names and aliases for request routing.
 	Models []GeminiModel `yaml:"models,omitempty" json:"models,omitempty"`
 
@@ -443,6 +961,11 @@ type OpenAICompatibility struct {
 	// Models defines the model configurations including aliases for routing.
 	Models []OpenAICompatibilityModel `yaml:"models" json:"models"`
 
+	// ModelMappings renames gateway model names to this provider's upstream
+	// names (gateway name -> upstream name). Unlike Models, the same gateway
+	// name can map to differently-named models on different upstreams.
+	ModelMappings map[string]string `yaml:"model-mappings,omitempty" json:"model-mappings,omitempty"`
+
 	// Headers optionally adds extra HTTP headers for requests sent to this provider.
 	Headers map[string]string `yaml:"headers,omitempty" json:"headers,omitempty"`
 }
@@ -0,0 +1,32 @@
+package config
+
+// HTTPTransportConfig tunes the shared outbound HTTP transport used for
+// upstream provider calls. All fields are optional; zero values keep the
+// built-in pooling defaults. Raising max-idle-conns-per-host reduces
+// connection churn at high concurrency, and the TLS session cache speeds up
+// reconnect handshakes (faster first bytes after idle periods).
+type HTTPTransportConfig struct {
+	// MaxIdleConnsPerHost caps idle keep-alive connections kept per upstream host.
+	MaxIdleConnsPerHost int `yaml:"max-idle-conns-per-host,omitempty" json:"max-idle-conns-per-host,omitempty"`
+
+	// IdleConnTimeoutSeconds is how long an idle connection stays pooled before closing.
+	IdleConnTimeoutSeconds int `yaml:"idle-conn-timeout-seconds,omitempty" json:"idle-conn-timeout-seconds,omitempty"`
+
+	// TLSSessionCacheSize is the number of TLS session tickets cached for resumption.
+	TLSSessionCacheSize int `yaml:"tls-session-cache-size,omitempty" json:"tls-session-cache-size,omitempty"`
+
+	// DialTimeoutSeconds bounds establishing a new TCP connection to an upstream.
+	DialTimeoutSeconds int `yaml:"dial-timeout-seconds,omitempty" json:"dial-timeout-seconds,omitempty"`
+}
+
+// ConnectionWarmupConfig pre-establishes TLS connections to upstream hosts at
+// startup and re-warms them before the idle pool expires, so interactive
+// clients do not pay connect plus handshake latency on the first request.
+type ConnectionWarmupConfig struct {
+	// Hosts lists upstream hosts or base URLs to keep warm
+	// (e.g. "generativelanguage.googleapis.com" or "https://api.anthropic.com").
+	Hosts []string `yaml:"hosts,omitempty" json:"hosts,omitempty"`
+
+	// ConnectionsPerHost is how many connections to keep warm per host; defaults to 1.
+	ConnectionsPerHost int `yaml:"connections-per-host,omitempty" json:"connections-per-host,omitempty"`
+}
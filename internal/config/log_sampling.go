@@ -0,0 +1,29 @@
+package config
+
+// LogSamplingConfig bounds logging overhead under sustained load. Once the
+// number of inbound requests in the current one-minute window exceeds
+// ThresholdPerMinute, debug-level entries and per-request log files are
+// sampled at a keep-1-in-N rate. Warnings and errors are never sampled.
+type LogSamplingConfig struct {
+	// ThresholdPerMinute is the request volume above which sampling activates.
+	// 0 disables sampling entirely.
+	ThresholdPerMinute int `yaml:"threshold-per-minute,omitempty" json:"threshold-per-minute,omitempty"`
+
+	// Rate is the default keep-1-in-N rate applied while sampling is active.
+	// Values <= 1 keep every entry.
+	Rate int `yaml:"rate,omitempty" json:"rate,omitempty"`
+
+	// Levels optionally overrides the rate per log level (e.g. debug: 20,
+	// info: 5). Levels not listed use Rate; only debug and trace are sampled
+	// by default, info is sampled only when listed here.
+	Levels map[string]int `yaml:"levels,omitempty" json:"levels,omitempty"`
+
+	// Providers optionally overrides the rate for entries carrying a matching
+	// provider field (e.g. gemini: 50).
+	Providers map[string]int `yaml:"providers,omitempty" json:"providers,omitempty"`
+}
+
+// Active reports whether sampling can ever engage with this configuration.
+func (c LogSamplingConfig) Active() bool {
+	return c.ThresholdPerMinute > 0 && (c.Rate > 1 || len(c.Levels) > 0 || len(c.Providers) > 0)
+}
@@ -0,0 +1,19 @@
+package config
+
+// PricingRule overrides or supplies token pricing for models matching a name
+// pattern. Prices are in USD per million tokens; rules are evaluated in order
+// and the first match wins, taking precedence over the built-in pricing table.
+type PricingRule struct {
+	// Model is the model name pattern; '*' matches zero or more characters.
+	Model string `yaml:"model" json:"model"`
+
+	// Input is the price for non-cached prompt tokens.
+	Input float64 `yaml:"input,omitempty" json:"input,omitempty"`
+
+	// Output is the price for completion tokens.
+	Output float64 `yaml:"output,omitempty" json:"output,omitempty"`
+
+	// Cached is the price for cached prompt tokens; zero bills them at the
+	// input rate.
+	Cached float64 `yaml:"cached,omitempty" json:"cached,omitempty"`
+}
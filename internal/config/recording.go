@@ -0,0 +1,13 @@
+package config
+
+// RecordingConfig controls translator exchange recording. When enabled, the
+// exact translated upstream payloads and responses are written to disk so
+// they can be replayed with the `-replay` command after upgrades.
+type RecordingConfig struct {
+	// Enabled turns request recording on.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// Dir is the directory recordings are written to; defaults to
+	// "recordings" when empty.
+	Dir string `yaml:"dir,omitempty" json:"dir,omitempty"`
+}
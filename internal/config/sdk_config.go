@@ -17,6 +17,14 @@ type SDKConfig struct {
 	// RequestLog enables or disables detailed request logging functionality.
 	RequestLog bool `yaml:"request-log" json:"request-log"`
 
+	// RequestLogRedaction controls what the request logger persists when
+	// request-log is enabled.
+	RequestLogRedaction RequestLogRedactionConfig `yaml:"request-log-redaction,omitempty" json:"request-log-redaction,omitempty"`
+
+	// RequestLogRetention controls rotation and retention of persisted request
+	// log files so long-running gateways do not fill the disk.
+	RequestLogRetention RequestLogRetentionConfig `yaml:"request-log-retention,omitempty" json:"request-log-retention,omitempty"`
+
 	// APIKeys is a list of keys for authenticating clients to this proxy server.
 	APIKeys []string `yaml:"api-keys" json:"api-keys"`
 
@@ -29,6 +37,176 @@ type SDKConfig struct {
 	// NonStreamKeepAliveInterval controls how often blank lines are emitted for non-streaming responses.
 	// <= 0 disables keep-alives. Value is in seconds.
 	NonStreamKeepAliveInterval int `yaml:"nonstream-keepalive-interval,omitempty" json:"nonstream-keepalive-interval,omitempty"`
+
+	// Moderation configures the optional inbound prompt moderation pre-check.
+	Moderation ModerationConfig `yaml:"moderation,omitempty" json:"moderation,omitempty"`
+
+	// StructuredOutput configures validation of structured output responses
+	// against the requested json_schema, with automatic repair and retry.
+	StructuredOutput StructuredOutputConfig `yaml:"structured-output,omitempty" json:"structured-output,omitempty"`
+
+	// ToolValidation configures optional validation of tool-call arguments
+	// against the declared tool JSON schemas, with per-model rules.
+	ToolValidation ToolValidationConfig `yaml:"tool-validation,omitempty" json:"tool-validation,omitempty"`
+
+	// VirtualModels defines cost-aware virtual model aliases that route each
+	// request to the cheapest capable concrete model and walk up the cost
+	// ladder when a candidate fails.
+	VirtualModels []VirtualModelRule `yaml:"virtual-models,omitempty" json:"virtual-models,omitempty"`
+
+	// FallbackChains defines ordered cross-provider fallback sequences tried
+	// when the requested model fails with a rate limit, server error, or an
+	// empty response.
+	FallbackChains []FallbackChain `yaml:"fallback-chains,omitempty" json:"fallback-chains,omitempty"`
+}
+
+// ModerationConfig controls the optional moderation pre-check applied to inbound prompts
+// before they are forwarded upstream. When enabled, prompt text is submitted to an
+// OpenAI-compatible moderations endpoint and flagged requests are rejected with a
+// policy error in the client's format.
+type ModerationConfig struct {
+	// Enabled toggles the moderation pre-check. Default is false.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// BaseURL is the base URL of the OpenAI-compatible moderations provider
+	// (e.g. "https://api.openai.com/v1"). Required when enabled.
+	BaseURL string `yaml:"base-url,omitempty" json:"base-url,omitempty"`
+
+	// APIKey authenticates requests to the moderation provider.
+	APIKey string `yaml:"api-key,omitempty" json:"api-key,omitempty"`
+
+	// Model optionally selects the moderation model (e.g. "omni-moderation-latest").
+	Model string `yaml:"model,omitempty" json:"model,omitempty"`
+
+	// Categories restricts blocking to the listed category names. When empty,
+	// any flagged category blocks the request.
+	Categories []string `yaml:"categories,omitempty" json:"categories,omitempty"`
+
+	// Threshold overrides the provider's flagged verdict with a category score
+	// comparison when > 0. Scores greater than or equal to the threshold block the request.
+	Threshold float64 `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+
+	// BlockOnError rejects requests when the moderation provider is unreachable.
+	// Default is false (fail open).
+	BlockOnError bool `yaml:"block-on-error,omitempty" json:"block-on-error,omitempty"`
+}
+
+// StructuredOutputConfig controls enforcement of response_format.json_schema
+// on chat completion responses. When enabled, the final response content is
+// validated against the requested schema; invalid content is repaired when
+// possible and the upstream call is retried up to max-retries times before an
+// error is returned.
+type StructuredOutputConfig struct {
+	// Enabled toggles structured output validation. Default is false.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// MaxRetries is the number of automatic upstream retries after a response
+	// fails schema validation. 0 validates and repairs without retrying.
+	MaxRetries int `yaml:"max-retries,omitempty" json:"max-retries,omitempty"`
+}
+
+// ToolValidationConfig controls validation of tool-call arguments emitted by
+// models. When a response carries tool calls and a rule matches the model, the
+// call arguments are checked against the JSON schema declared for the tool in
+// the request and handled according to the rule's mode.
+type ToolValidationConfig struct {
+	// Rules lists model-targeted validation rules; the first matching rule wins.
+	Rules []ToolValidationRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+}
+
+// ToolValidationRule ties model patterns to a tool-call validation mode.
+type ToolValidationRule struct {
+	// Models lists model entries with name pattern and protocol constraint,
+	// using the same matching semantics as payload rules.
+	Models []PayloadModelRule `yaml:"models" json:"models"`
+
+	// Mode selects what happens when arguments fail schema validation:
+	// "repair" fixes common JSON syntax errors and falls back to annotate,
+	// "reject" fails the request, and "annotate" forwards the call with a
+	// validation note attached. Default is "annotate".
+	Mode string `yaml:"mode" json:"mode"`
+}
+
+// VirtualModelRule defines one virtual model alias. Requests for the alias
+// are routed to the candidate with the lowest pricing-table rate that
+// satisfies the request's capability needs (tools, vision, context length);
+// failures fall back to the next-cheapest candidate.
+type VirtualModelRule struct {
+	// Alias is the client-visible model name.
+	Alias string `yaml:"alias" json:"alias"`
+
+	// Candidates lists the concrete models the alias may route to. Listing
+	// order does not matter; candidates are tried cheapest-first.
+	Candidates []VirtualModelCandidate `yaml:"candidates" json:"candidates"`
+}
+
+// VirtualModelCandidate is one concrete model a virtual alias can use.
+type VirtualModelCandidate struct {
+	// Model is the registered model name.
+	Model string `yaml:"model" json:"model"`
+
+	// Capabilities optionally declares what the model supports ("tools",
+	// "vision"). Requests needing a capability skip candidates that declare
+	// a capability list without it; an empty list declares nothing and
+	// never causes a skip.
+	Capabilities []string `yaml:"capabilities,omitempty" json:"capabilities,omitempty"`
+}
+
+// FallbackChain defines an ordered fallback sequence for one model. Requests
+// for the model are served by it when healthy; a 429, 5xx, or empty response
+// moves the request to the next model in the chain, in listed order. The
+// fallbacks may live on different providers — responses are translated to the
+// client's format regardless of which provider served them.
+type FallbackChain struct {
+	// Model is the client-visible model name that heads the chain.
+	Model string `yaml:"model" json:"model"`
+
+	// Fallbacks lists the models to try next, in order. Entries may be
+	// concrete models on any provider or virtual model aliases.
+	Fallbacks []string `yaml:"fallbacks" json:"fallbacks"`
+}
+
+// RequestLogRedactionConfig limits what recorded request logs persist so debug
+// logs do not become a secrets or PII liability. Request and response headers
+// are always masked; these rules additionally apply to logged bodies.
+type RequestLogRedactionConfig struct {
+	// StripBase64 replaces long base64 runs (inline images, audio, documents)
+	// in logged bodies with a size placeholder.
+	StripBase64 bool `yaml:"strip-base64,omitempty" json:"strip-base64,omitempty"`
+
+	// MaskCredentials masks Authorization, api_key, and token values embedded
+	// in logged body JSON.
+	MaskCredentials bool `yaml:"mask-credentials,omitempty" json:"mask-credentials,omitempty"`
+
+	// MaxBodyBytes caps how many body bytes are persisted per request or
+	// response attempt; 0 keeps full bodies.
+	MaxBodyBytes int `yaml:"max-body-bytes,omitempty" json:"max-body-bytes,omitempty"`
+}
+
+// RequestLogRetentionConfig bounds the disk footprint of the per-request log
+// directory. All limits are optional; zero values disable the corresponding
+// rule.
+type RequestLogRetentionConfig struct {
+	// MaxFileSizeMB truncates individual request log files that exceed this
+	// size; 0 keeps files at their full size.
+	MaxFileSizeMB int `yaml:"max-file-size-mb,omitempty" json:"max-file-size-mb,omitempty"`
+
+	// MaxAgeDays deletes request log files older than this many days; 0 keeps
+	// files indefinitely.
+	MaxAgeDays int `yaml:"max-age-days,omitempty" json:"max-age-days,omitempty"`
+
+	// MaxTotalSizeMB deletes the oldest request log files once the directory
+	// exceeds this total size; 0 disables the cap.
+	MaxTotalSizeMB int `yaml:"max-total-size-mb,omitempty" json:"max-total-size-mb,omitempty"`
+
+	// Compress gzips request log files once they have been idle for a few
+	// minutes, trading CPU for disk space.
+	Compress bool `yaml:"compress,omitempty" json:"compress,omitempty"`
+}
+
+// Active reports whether any retention rule is enabled.
+func (c RequestLogRetentionConfig) Active() bool {
+	return c.MaxFileSizeMB > 0 || c.MaxAgeDays > 0 || c.MaxTotalSizeMB > 0 || c.Compress
 }
 
 // StreamingConfig holds server streaming behavior configuration.
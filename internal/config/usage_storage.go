@@ -0,0 +1,13 @@
+package config
+
+// UsageStorageConfig selects a database backend for persisting usage records.
+// When Backend is empty, usage accounting stays in-memory only and is lost on
+// restart.
+type UsageStorageConfig struct {
+	// Backend names the store implementation: "sqlite" or "postgres".
+	Backend string `yaml:"backend,omitempty" json:"backend,omitempty"`
+
+	// DSN is the connection string for the backend: a file path for sqlite,
+	// a standard connection URL for postgres.
+	DSN string `yaml:"dsn,omitempty" json:"dsn,omitempty"`
+}
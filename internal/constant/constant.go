@@ -27,4 +27,10 @@ const (
 
 	// Kiro represents the AWS CodeWhisperer (Kiro) provider identifier.
 	Kiro = "kiro"
+
+	// Cohere represents the Cohere Chat API provider identifier.
+	Cohere = "cohere"
+
+	// Ollama represents the Ollama-compatible front-end format identifier.
+	Ollama = "ollama"
 )
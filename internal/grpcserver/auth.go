@@ -0,0 +1,141 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	sdkaccess "github.com/shariqriazz/modelgate/sdk/access"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// managementMethods lists the RPCs that mirror the HTTP management API and
+// therefore require the management secret instead of a client API key.
+var managementMethods = map[string]bool{
+	"/" + serviceName + "/ListAuths":       true,
+	"/" + serviceName + "/SetAuthDisabled": true,
+}
+
+// unaryAuthInterceptor authorizes every unary RPC before it reaches a handler.
+func (s *Server) unaryAuthInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := s.authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamAuthInterceptor authorizes every streaming RPC before it reaches a
+// handler.
+func (s *Server) streamAuthInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := s.authorize(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// authorize enforces the same gating as the HTTP API: inference RPCs require
+// a valid client API key and management RPCs require the management secret.
+func (s *Server) authorize(ctx context.Context, fullMethod string) error {
+	if managementMethods[fullMethod] {
+		return s.authorizeManagement(ctx)
+	}
+	return s.authorizeInference(ctx)
+}
+
+// authorizeInference validates the caller against the configured access
+// providers, mirroring the HTTP AuthMiddleware. When no providers are
+// configured all requests are allowed (legacy behaviour).
+func (s *Server) authorizeInference(ctx context.Context) error {
+	if s.access == nil {
+		return nil
+	}
+	_, err := s.access.Authenticate(ctx, httpRequestFromMetadata(ctx))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, sdkaccess.ErrNoCredentials):
+		return status.Error(codes.Unauthenticated, "missing API key")
+	case errors.Is(err, sdkaccess.ErrInvalidCredential):
+		return status.Error(codes.Unauthenticated, "invalid API key")
+	default:
+		return status.Error(codes.Internal, "authentication service error")
+	}
+}
+
+// authorizeManagement validates the caller's management secret the same way
+// the HTTP management middleware does: the MANAGEMENT_PASSWORD environment
+// value is compared in constant time and the configured secret is a bcrypt
+// hash.
+func (s *Server) authorizeManagement(ctx context.Context) error {
+	var secretHash string
+	if s.cfg != nil {
+		secretHash = s.cfg.RemoteManagement.SecretKey
+	}
+	if secretHash == "" && s.envSecret == "" {
+		return status.Error(codes.PermissionDenied, "remote management key not set")
+	}
+	provided := bearerToken(ctx)
+	if provided == "" {
+		provided = metadataValue(ctx, "x-management-key")
+	}
+	if provided == "" {
+		return status.Error(codes.Unauthenticated, "missing management key")
+	}
+	if s.envSecret != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(s.envSecret)) == 1 {
+		return nil
+	}
+	if secretHash == "" || bcrypt.CompareHashAndPassword([]byte(secretHash), []byte(provided)) != nil {
+		return status.Error(codes.Unauthenticated, "invalid management key")
+	}
+	return nil
+}
+
+// httpRequestFromMetadata lifts the incoming gRPC metadata into an HTTP
+// request header so the shared access providers can authenticate gRPC callers
+// without a parallel credential path.
+func httpRequestFromMetadata(ctx context.Context) *http.Request {
+	req := &http.Request{Header: make(http.Header)}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return req
+	}
+	for key, values := range md {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	return req
+}
+
+// bearerToken returns the token from the authorization metadata entry,
+// accepting both "Bearer <key>" and a bare key.
+func bearerToken(ctx context.Context) string {
+	raw := metadataValue(ctx, "authorization")
+	if raw == "" {
+		return ""
+	}
+	parts := strings.SplitN(raw, " ", 2)
+	if len(parts) == 2 && strings.EqualFold(parts[0], "bearer") {
+		return strings.TrimSpace(parts[1])
+	}
+	return raw
+}
+
+// metadataValue returns the first value for the metadata key, trimmed.
+func metadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
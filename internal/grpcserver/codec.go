@@ -0,0 +1,29 @@
+// Package grpcserver exposes an optional gRPC interface mirroring the HTTP
+// API: chat completion (unary and server-streaming) plus auth management RPCs
+// for internal services that want typed clients instead of raw SSE parsing.
+//
+// Messages travel as JSON rather than protobuf binary so the service shares
+// the exact request and response schemas of the HTTP endpoints; clients dial
+// with grpc.CallContentSubtype("json") (see modelgate.proto for the contract).
+package grpcserver
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// codecName is the gRPC content-subtype served by the ModelGate service.
+const codecName = "json"
+
+// jsonCodec marshals gRPC messages as plain JSON so the wire payloads match
+// the HTTP API schemas byte for byte.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return codecName }
+
+func init() { encoding.RegisterCodec(jsonCodec{}) }
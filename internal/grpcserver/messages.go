@@ -0,0 +1,51 @@
+package grpcserver
+
+import "encoding/json"
+
+// ChatCompletionRequest carries an OpenAI-compatible chat completion payload.
+// The payload is the same JSON body accepted by POST /v1/chat/completions.
+type ChatCompletionRequest struct {
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ChatCompletionResponse carries the full upstream completion response.
+type ChatCompletionResponse struct {
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ChatCompletionChunk carries a single streaming completion event. Chunks are
+// the raw JSON objects an SSE client would receive after "data: ", without
+// framing or a terminal [DONE] marker; end of stream is the gRPC stream end.
+type ChatCompletionChunk struct {
+	Payload json.RawMessage `json:"payload"`
+}
+
+// ListAuthsRequest is the empty request for the ListAuths RPC.
+type ListAuthsRequest struct{}
+
+// AuthInfo summarizes a managed credential for management clients.
+type AuthInfo struct {
+	ID          string `json:"id"`
+	Provider    string `json:"provider"`
+	Label       string `json:"label,omitempty"`
+	Status      string `json:"status"`
+	Disabled    bool   `json:"disabled"`
+	Unavailable bool   `json:"unavailable"`
+}
+
+// ListAuthsResponse lists all auths known to the core manager.
+type ListAuthsResponse struct {
+	Auths []AuthInfo `json:"auths"`
+}
+
+// SetAuthDisabledRequest enables or disables the auth identified by ID.
+type SetAuthDisabledRequest struct {
+	ID       string `json:"id"`
+	Disabled bool   `json:"disabled"`
+}
+
+// SetAuthDisabledResponse reports the resulting auth state.
+type SetAuthDisabledResponse struct {
+	ID       string `json:"id"`
+	Disabled bool   `json:"disabled"`
+}
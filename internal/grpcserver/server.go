@@ -0,0 +1,232 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	sdkaccess "github.com/shariqriazz/modelgate/sdk/access"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server hosts the optional gRPC interface on its own listener. Requests are
+// executed through the same core auth manager as the HTTP API, so key pooling,
+// retries, and usage accounting behave identically on both surfaces, and every
+// RPC is gated the same way as its HTTP counterpart: inference requires a
+// client API key and auth management requires the management secret.
+type Server struct {
+	manager   *coreauth.Manager
+	cfg       *config.Config
+	access    *sdkaccess.Manager
+	envSecret string
+	grpcSrv   *grpc.Server
+}
+
+// New constructs a gRPC server backed by the given core auth manager, using
+// the config and access providers to authorize callers.
+func New(manager *coreauth.Manager, cfg *config.Config, access *sdkaccess.Manager) *Server {
+	return &Server{
+		manager:   manager,
+		cfg:       cfg,
+		access:    access,
+		envSecret: strings.TrimSpace(os.Getenv("MANAGEMENT_PASSWORD")),
+	}
+}
+
+// Serve listens on addr and blocks serving gRPC until Stop is called or the
+// listener fails.
+func (s *Server) Serve(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.grpcSrv = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryAuthInterceptor),
+		grpc.StreamInterceptor(s.streamAuthInterceptor),
+	)
+	s.grpcSrv.RegisterService(&serviceDesc, s)
+	log.Infof("gRPC server started on %s", addr)
+	return s.grpcSrv.Serve(listener)
+}
+
+// Stop drains in-flight RPCs and shuts the server down, forcing closure if
+// draining outlives the context.
+func (s *Server) Stop(ctx context.Context) {
+	if s == nil || s.grpcSrv == nil {
+		return
+	}
+	done := make(chan struct{})
+	go func() {
+		s.grpcSrv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.grpcSrv.Stop()
+	}
+}
+
+// ChatCompletion executes a non-streaming chat completion through the core
+// auth manager and returns the full upstream response.
+func (s *Server) ChatCompletion(ctx context.Context, in *ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	providers, model, err := s.requestDetails(in)
+	if err != nil {
+		return nil, err
+	}
+	req, opts := buildExecution(model, in.Payload, false)
+	resp, execErr := s.manager.Execute(ctx, providers, req, opts)
+	if execErr != nil {
+		return nil, grpcStatusError(execErr)
+	}
+	return &ChatCompletionResponse{Payload: bytes.Clone(resp.Payload)}, nil
+}
+
+// StreamChatCompletion executes a streaming chat completion, sending one
+// message per upstream chunk until the provider stream ends.
+func (s *Server) StreamChatCompletion(in *ChatCompletionRequest, stream chatCompletionSender) error {
+	providers, model, err := s.requestDetails(in)
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	req, opts := buildExecution(model, in.Payload, true)
+	chunks, execErr := s.manager.ExecuteStream(ctx, providers, req, opts)
+	if execErr != nil {
+		return grpcStatusError(execErr)
+	}
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			return grpcStatusError(chunk.Err)
+		}
+		if len(chunk.Payload) == 0 {
+			continue
+		}
+		if errSend := stream.Send(&ChatCompletionChunk{Payload: bytes.Clone(chunk.Payload)}); errSend != nil {
+			return errSend
+		}
+	}
+	return nil
+}
+
+// ListAuths returns a management summary of every auth known to the manager.
+func (s *Server) ListAuths(_ context.Context, _ *ListAuthsRequest) (*ListAuthsResponse, error) {
+	auths := s.manager.List()
+	out := make([]AuthInfo, 0, len(auths))
+	for _, auth := range auths {
+		if auth == nil {
+			continue
+		}
+		out = append(out, AuthInfo{
+			ID:          auth.ID,
+			Provider:    auth.Provider,
+			Label:       auth.Label,
+			Status:      string(auth.Status),
+			Disabled:    auth.Disabled,
+			Unavailable: auth.Unavailable,
+		})
+	}
+	return &ListAuthsResponse{Auths: out}, nil
+}
+
+// SetAuthDisabled enables or disables the auth identified by ID, mirroring
+// the management HTTP endpoint.
+func (s *Server) SetAuthDisabled(ctx context.Context, in *SetAuthDisabledRequest) (*SetAuthDisabledResponse, error) {
+	id := strings.TrimSpace(in.ID)
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	target, ok := s.manager.GetByID(id)
+	if !ok || target == nil {
+		return nil, status.Errorf(codes.NotFound, "auth not found: %s", id)
+	}
+	target.Disabled = in.Disabled
+	if in.Disabled {
+		target.Status = coreauth.StatusDisabled
+		target.StatusMessage = "disabled via gRPC API"
+	} else {
+		target.Status = coreauth.StatusActive
+		target.StatusMessage = ""
+	}
+	target.UpdatedAt = time.Now()
+	if _, err := s.manager.Update(ctx, target); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &SetAuthDisabledResponse{ID: target.ID, Disabled: target.Disabled}, nil
+}
+
+// requestDetails validates the inbound payload and resolves the providers
+// registered for the requested model.
+func (s *Server) requestDetails(in *ChatCompletionRequest) ([]string, string, error) {
+	if in == nil || len(in.Payload) == 0 {
+		return nil, "", status.Error(codes.InvalidArgument, "payload is required")
+	}
+	model := gjson.GetBytes(in.Payload, "model").String()
+	if model == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "model is required")
+	}
+	providers := registry.GetGlobalRegistry().GetModelProviders(model)
+	if len(providers) == 0 {
+		return nil, "", status.Errorf(codes.NotFound, "model not found: %s", model)
+	}
+	return providers, model, nil
+}
+
+// buildExecution assembles the executor request and options for a chat
+// completion payload in the OpenAI schema.
+func buildExecution(model string, payload []byte, streaming bool) (cliproxyexecutor.Request, cliproxyexecutor.Options) {
+	req := cliproxyexecutor.Request{
+		Model:   model,
+		Payload: bytes.Clone(payload),
+	}
+	opts := cliproxyexecutor.Options{
+		Stream:          streaming,
+		OriginalRequest: bytes.Clone(payload),
+		SourceFormat:    sdktranslator.FromString("openai"),
+		Metadata:        map[string]any{cliproxyexecutor.RequestedModelMetadataKey: model},
+	}
+	return req, opts
+}
+
+// grpcStatusError maps an execution error onto a gRPC status, honoring the
+// HTTP-like status code when the error carries one.
+func grpcStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	code := codes.Internal
+	if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
+		switch httpStatus := se.StatusCode(); {
+		case httpStatus == http.StatusBadRequest:
+			code = codes.InvalidArgument
+		case httpStatus == http.StatusUnauthorized:
+			code = codes.Unauthenticated
+		case httpStatus == http.StatusForbidden:
+			code = codes.PermissionDenied
+		case httpStatus == http.StatusNotFound:
+			code = codes.NotFound
+		case httpStatus == http.StatusRequestTimeout || httpStatus == http.StatusGatewayTimeout:
+			code = codes.DeadlineExceeded
+		case httpStatus == http.StatusTooManyRequests:
+			code = codes.ResourceExhausted
+		case httpStatus == http.StatusNotImplemented:
+			code = codes.Unimplemented
+		case httpStatus >= http.StatusInternalServerError:
+			code = codes.Unavailable
+		}
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,103 @@
+package grpcserver
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+const serviceName = "modelgate.v1.ModelGate"
+
+// serviceDesc is the hand-rolled gRPC service descriptor for the ModelGate
+// service. The handler shims follow the protoc-generated shape so standard
+// unary and stream interceptors keep working.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*modelGateServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ChatCompletion", Handler: chatCompletionHandler},
+		{MethodName: "ListAuths", Handler: listAuthsHandler},
+		{MethodName: "SetAuthDisabled", Handler: setAuthDisabledHandler},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "StreamChatCompletion", Handler: streamChatCompletionHandler, ServerStreams: true},
+	},
+	Metadata: "modelgate.proto",
+}
+
+// modelGateServer is the server contract registered under serviceDesc.
+type modelGateServer interface {
+	ChatCompletion(ctx context.Context, req *ChatCompletionRequest) (*ChatCompletionResponse, error)
+	StreamChatCompletion(req *ChatCompletionRequest, stream chatCompletionSender) error
+	ListAuths(ctx context.Context, req *ListAuthsRequest) (*ListAuthsResponse, error)
+	SetAuthDisabled(ctx context.Context, req *SetAuthDisabledRequest) (*SetAuthDisabledResponse, error)
+}
+
+// chatCompletionSender is the send side of the StreamChatCompletion RPC.
+type chatCompletionSender interface {
+	Send(chunk *ChatCompletionChunk) error
+	Context() context.Context
+}
+
+func chatCompletionHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(ChatCompletionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(modelGateServer).ChatCompletion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/ChatCompletion"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(modelGateServer).ChatCompletion(ctx, req.(*ChatCompletionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func listAuthsHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(ListAuthsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(modelGateServer).ListAuths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/ListAuths"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(modelGateServer).ListAuths(ctx, req.(*ListAuthsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func setAuthDisabledHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(SetAuthDisabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(modelGateServer).SetAuthDisabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + serviceName + "/SetAuthDisabled"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(modelGateServer).SetAuthDisabled(ctx, req.(*SetAuthDisabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func streamChatCompletionHandler(srv any, stream grpc.ServerStream) error {
+	in := new(ChatCompletionRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+	return srv.(modelGateServer).StreamChatCompletion(in, &chatCompletionServerStream{stream})
+}
+
+// chatCompletionServerStream adapts the generic grpc.ServerStream into the
+// typed sender used by StreamChatCompletion.
+type chatCompletionServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *chatCompletionServerStream) Send(chunk *ChatCompletionChunk) error {
+	return s.ServerStream.SendMsg(chunk)
+}
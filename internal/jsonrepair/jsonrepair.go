@@ -0,0 +1,310 @@
+// Package jsonrepair rebuilds approximately-JSON text produced by models into
+// valid JSON. Unlike pattern-based fixups, it tokenizes the input and re-emits
+// a well-formed document, which lets it recover from unquoted keys, single or
+// unterminated strings, trailing or missing commas, missing closing braces and
+// brackets, Python-style literals, and concatenated top-level objects.
+package jsonrepair
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Repair attempts to convert approximately-JSON text into valid JSON.
+// It returns the repaired document and true on success, or empty string and
+// false when no JSON value can be recovered. Input that already parses is
+// returned unchanged.
+func Repair(raw string) (string, bool) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", false
+	}
+	if json.Valid([]byte(trimmed)) {
+		return trimmed, true
+	}
+
+	p := &parser{src: trimmed}
+	var values []string
+	for {
+		p.skipFiller()
+		if p.done() {
+			break
+		}
+		value, ok := p.parseValue()
+		if !ok {
+			break
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return "", false
+	}
+
+	out := values[0]
+	if len(values) > 1 {
+		out = mergeConcatenated(values)
+	}
+	if !json.Valid([]byte(out)) {
+		return "", false
+	}
+	return out, true
+}
+
+// mergeConcatenated combines multiple top-level values: adjacent objects are
+// merged into one (models often re-emit the same call twice), anything else is
+// wrapped in an array.
+func mergeConcatenated(values []string) string {
+	allObjects := true
+	for _, value := range values {
+		if !strings.HasPrefix(value, "{") {
+			allObjects = false
+			break
+		}
+	}
+	if !allObjects {
+		return "[" + strings.Join(values, ",") + "]"
+	}
+	var members []string
+	for _, value := range values {
+		inner := strings.TrimSpace(value[1 : len(value)-1])
+		if inner != "" {
+			members = append(members, inner)
+		}
+	}
+	return "{" + strings.Join(members, ",") + "}"
+}
+
+// parser walks the input once, emitting valid JSON for each recognized value.
+type parser struct {
+	src string
+	pos int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+// skipFiller advances past whitespace and separators that may legitimately
+// appear between top-level values or members.
+func (p *parser) skipFiller() {
+	for !p.done() {
+		switch p.src[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+// parseValue parses one JSON value starting at the current position.
+func (p *parser) parseValue() (string, bool) {
+	p.skipFiller()
+	if p.done() {
+		return "", false
+	}
+	switch ch := p.peek(); {
+	case ch == '{':
+		return p.parseObject()
+	case ch == '[':
+		return p.parseArray()
+	case ch == '"' || ch == '\'':
+		return p.parseString(ch)
+	case ch == '-' || ch == '+' || (ch >= '0' && ch <= '9'):
+		return p.parseNumber()
+	case isWordChar(ch):
+		return p.parseWord()
+	default:
+		// Unrecognized punctuation: skip it and try the next character.
+		p.pos++
+		return p.parseValue()
+	}
+}
+
+// parseObject parses an object, tolerating unquoted keys, missing commas,
+// trailing commas, and a missing closing brace at end of input.
+func (p *parser) parseObject() (string, bool) {
+	p.pos++ // consume '{'
+	var members []string
+	for {
+		p.skipFiller()
+		if p.done() {
+			break // unterminated object: close it here
+		}
+		if p.peek() == '}' {
+			p.pos++
+			break
+		}
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		key, ok := p.parseKey()
+		if !ok {
+			break
+		}
+		p.skipFiller()
+		if !p.done() && p.peek() == ':' {
+			p.pos++
+		}
+		value, ok := p.parseValue()
+		if !ok {
+			value = "null"
+		}
+		members = append(members, key+":"+value)
+	}
+	return "{" + strings.Join(members, ",") + "}", true
+}
+
+// parseKey parses an object key, quoting bare identifiers.
+func (p *parser) parseKey() (string, bool) {
+	p.skipFiller()
+	if p.done() {
+		return "", false
+	}
+	if ch := p.peek(); ch == '"' || ch == '\'' {
+		return p.parseString(ch)
+	}
+	start := p.pos
+	for !p.done() && isWordChar(p.peek()) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", false
+	}
+	return encodeString(p.src[start:p.pos]), true
+}
+
+// parseArray parses an array, tolerating missing commas, trailing commas, and
+// a missing closing bracket at end of input.
+func (p *parser) parseArray() (string, bool) {
+	p.pos++ // consume '['
+	var elements []string
+	for {
+		p.skipFiller()
+		if p.done() {
+			break // unterminated array: close it here
+		}
+		if p.peek() == ']' {
+			p.pos++
+			break
+		}
+		if p.peek() == ',' {
+			p.pos++
+			continue
+		}
+		value, ok := p.parseValue()
+		if !ok {
+			break
+		}
+		elements = append(elements, value)
+	}
+	return "[" + strings.Join(elements, ",") + "]", true
+}
+
+// parseString parses a string delimited by the given quote character,
+// interpreting standard escapes and closing the string at end of input when
+// the terminator is missing.
+func (p *parser) parseString(quote byte) (string, bool) {
+	p.pos++ // consume opening quote
+	var builder strings.Builder
+	for !p.done() {
+		ch := p.src[p.pos]
+		if ch == quote {
+			p.pos++
+			return encodeString(builder.String()), true
+		}
+		if ch == '\\' && p.pos+1 < len(p.src) {
+			p.pos++
+			switch esc := p.src[p.pos]; esc {
+			case 'n':
+				builder.WriteByte('\n')
+			case 't':
+				builder.WriteByte('\t')
+			case 'r':
+				builder.WriteByte('\r')
+			case 'b':
+				builder.WriteByte('\b')
+			case 'f':
+				builder.WriteByte('\f')
+			case 'u':
+				if p.pos+4 < len(p.src) {
+					if code, err := strconv.ParseUint(p.src[p.pos+1:p.pos+5], 16, 32); err == nil {
+						builder.WriteRune(rune(code))
+						p.pos += 4
+						break
+					}
+				}
+				builder.WriteByte(esc)
+			default:
+				builder.WriteByte(esc)
+			}
+			p.pos++
+			continue
+		}
+		builder.WriteByte(ch)
+		p.pos++
+	}
+	// Unterminated string: close it at end of input.
+	return encodeString(builder.String()), true
+}
+
+// parseNumber parses a numeric literal, falling back to a quoted string when
+// the token is not a valid JSON number.
+func (p *parser) parseNumber() (string, bool) {
+	start := p.pos
+	for !p.done() {
+		ch := p.peek()
+		if (ch >= '0' && ch <= '9') || ch == '-' || ch == '+' || ch == '.' || ch == 'e' || ch == 'E' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	token := p.src[start:p.pos]
+	if json.Valid([]byte(token)) {
+		return token, true
+	}
+	return encodeString(token), true
+}
+
+// parseWord parses a bare word, mapping JSON and Python literals and quoting
+// anything else as a string.
+func (p *parser) parseWord() (string, bool) {
+	start := p.pos
+	for !p.done() && isWordChar(p.peek()) {
+		p.pos++
+	}
+	switch word := p.src[start:p.pos]; word {
+	case "true", "True", "TRUE":
+		return "true", true
+	case "false", "False", "FALSE":
+		return "false", true
+	case "null", "None", "NULL", "nil":
+		return "null", true
+	default:
+		return encodeString(word), true
+	}
+}
+
+// encodeString renders a Go string as a valid JSON string literal.
+func encodeString(value string) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return `""`
+	}
+	return string(encoded)
+}
+
+// isWordChar reports whether ch can appear in a bare identifier.
+func isWordChar(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == '_' || ch == '$'
+}
@@ -0,0 +1,35 @@
+package jsonrepair
+
+import "testing"
+
+func TestRepair(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{"already valid", `{"a":1}`, `{"a":1}`, true},
+		{"single quotes", `{'a': 'b'}`, `{"a":"b"}`, true},
+		{"unquoted keys", `{a: 1, b_c: 2}`, `{"a":1,"b_c":2}`, true},
+		{"trailing comma", `{"a": 1,}`, `{"a":1}`, true},
+		{"missing brace", `{"a": 1`, `{"a":1}`, true},
+		{"missing value", `{"a": `, `{"a":null}`, true},
+		{"unterminated string", `{"a": "hello`, `{"a":"hello"}`, true},
+		{"nested missing brackets", `{"a": [1, 2, {"b": 3`, `{"a":[1,2,{"b":3}]}`, true},
+		{"missing comma", `{"a": 1 "b": 2}`, `{"a":1,"b":2}`, true},
+		{"concatenated objects", `{"a": 1}{"b": 2}`, `{"a":1,"b":2}`, true},
+		{"concatenated mixed", `{"a": 1} [2]`, `[{"a":1},[2]]`, true},
+		{"python literals", `{"a": True, "b": None}`, `{"a":true,"b":null}`, true},
+		{"bare word value", `{"a": yes}`, `{"a":"yes"}`, true},
+		{"escapes preserved", `{"a": 'line\nbreak'}`, `{"a":"line\nbreak"}`, true},
+		{"empty", "", "", false},
+		{"whitespace only", "  \n ", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := Repair(tc.in)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("%s: Repair = (%q, %v), want (%q, %v)", tc.name, got, ok, tc.want, tc.ok)
+		}
+	}
+}
@@ -2,12 +2,14 @@ package logging
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/shariqriazz/modelgate/internal/config"
@@ -31,6 +33,9 @@ type LogFormatter struct{}
 
 // Format renders a single log entry with custom formatting.
 func (m *LogFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if !shouldEmitLogEntry(entry) {
+		return nil, nil
+	}
 	var buffer *bytes.Buffer
 	if entry.Buffer != nil {
 		buffer = entry.Buffer
@@ -63,6 +68,53 @@ func (m *LogFormatter) Format(entry *log.Entry) ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+// JSONLogFormatter renders each log entry as one JSON object per line with
+// stable field names (time, level, msg, request_id, source plus any structured
+// fields such as provider, auth_id, model, latency_ms) so output can be
+// ingested by aggregators like Loki or ELK without custom parsing rules.
+type JSONLogFormatter struct{}
+
+// Format renders a single log entry as a JSON line.
+func (m *JSONLogFormatter) Format(entry *log.Entry) ([]byte, error) {
+	if !shouldEmitLogEntry(entry) {
+		return nil, nil
+	}
+	data := make(map[string]any, len(entry.Data)+5)
+	for key, value := range entry.Data {
+		if err, ok := value.(error); ok {
+			data[key] = err.Error()
+		} else {
+			data[key] = value
+		}
+	}
+	if _, ok := data["request_id"]; !ok {
+		data["request_id"] = ""
+	}
+	data["time"] = entry.Time.Format(time.RFC3339Nano)
+	level := entry.Level.String()
+	if level == "warning" {
+		level = "warn"
+	}
+	data["level"] = level
+	data["msg"] = strings.TrimRight(entry.Message, "\r\n")
+	if entry.Caller != nil {
+		data["source"] = fmt.Sprintf("%s:%d", filepath.Base(entry.Caller.File), entry.Caller.Line)
+	}
+
+	var buffer *bytes.Buffer
+	if entry.Buffer != nil {
+		buffer = entry.Buffer
+	} else {
+		buffer = &bytes.Buffer{}
+	}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return nil, fmt.Errorf("logging: failed to encode JSON log entry: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
 // SetupBaseLogger configures the shared logrus instance and Gin writers.
 // It is safe to call multiple times; initialization happens only once.
 func SetupBaseLogger() {
@@ -114,6 +166,12 @@ func ConfigureLogOutput(cfg *config.Config) error {
 	writerMu.Lock()
 	defer writerMu.Unlock()
 
+	if strings.EqualFold(strings.TrimSpace(cfg.LoggingFormat), "json") {
+		log.SetFormatter(&JSONLogFormatter{})
+	} else {
+		log.SetFormatter(&LogFormatter{})
+	}
+
 	logDir := "logs"
 	if base := util.WritablePath(); base != "" {
 		logDir = filepath.Join(base, "logs")
@@ -147,6 +205,7 @@ func ConfigureLogOutput(cfg *config.Config) error {
 	}
 
 	configureLogDirCleanerLocked(logDir, cfg.LogsMaxTotalSizeMB, protectedPath)
+	SetLogSampling(cfg.LogSampling)
 	return nil
 }
 
@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func TestJSONLogFormatterStableFields(t *testing.T) {
+	entry := &log.Entry{
+		Logger:  log.StandardLogger(),
+		Time:    time.Unix(10, 0).UTC(),
+		Level:   log.WarnLevel,
+		Message: "upstream retry\n",
+		Data: log.Fields{
+			"request_id": "a1b2c3d4",
+			"provider":   "gemini",
+			"latency_ms": int64(120),
+		},
+	}
+
+	output, err := (&JSONLogFormatter{}).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err = json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if decoded["level"] != "warn" {
+		t.Fatalf("expected level warn, got %v", decoded["level"])
+	}
+	if decoded["msg"] != "upstream retry" {
+		t.Fatalf("expected trimmed message, got %v", decoded["msg"])
+	}
+	if decoded["request_id"] != "a1b2c3d4" {
+		t.Fatalf("expected request_id field, got %v", decoded["request_id"])
+	}
+	if decoded["provider"] != "gemini" {
+		t.Fatalf("expected provider field, got %v", decoded["provider"])
+	}
+}
+
+func TestJSONLogFormatterDefaultsRequestID(t *testing.T) {
+	entry := &log.Entry{
+		Logger:  log.StandardLogger(),
+		Time:    time.Unix(10, 0).UTC(),
+		Level:   log.InfoLevel,
+		Message: "startup",
+	}
+
+	output, err := (&JSONLogFormatter{}).Format(entry)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded map[string]any
+	if err = json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if value, ok := decoded["request_id"]; !ok || value != "" {
+		t.Fatalf("expected empty request_id field, got %v (present=%t)", value, ok)
+	}
+}
@@ -0,0 +1,140 @@
+// This file implements adaptive log sampling. When inbound request volume
+// exceeds a configured per-minute threshold, debug-level log entries and
+// per-request log files are kept at a 1-in-N rate so logging overhead stays
+// bounded under load. Warnings and errors are always emitted.
+package logging
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+const logSamplingWindow = time.Minute
+
+// logSampler tracks request volume over a rolling one-minute window and
+// decides, per sampling key, whether a log entry should be kept.
+type logSampler struct {
+	mu            sync.Mutex
+	cfg           config.LogSamplingConfig
+	windowStart   time.Time
+	windowCount   int
+	overThreshold bool
+	counters      map[string]uint64
+}
+
+var defaultLogSampler = &logSampler{counters: make(map[string]uint64)}
+
+// SetLogSampling applies the sampling configuration. Passing an unchanged
+// configuration is a no-op so hot reloads do not reset sampling counters.
+func SetLogSampling(cfg config.LogSamplingConfig) {
+	defaultLogSampler.mu.Lock()
+	defer defaultLogSampler.mu.Unlock()
+	if reflect.DeepEqual(defaultLogSampler.cfg, cfg) {
+		return
+	}
+	defaultLogSampler.cfg = cfg
+	defaultLogSampler.windowStart = time.Time{}
+	defaultLogSampler.windowCount = 0
+	defaultLogSampler.overThreshold = false
+	defaultLogSampler.counters = make(map[string]uint64)
+}
+
+// ObserveRequestVolume counts an inbound request toward the sampling window.
+func ObserveRequestVolume() {
+	defaultLogSampler.observe(time.Now())
+}
+
+func (s *logSampler) observe(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.cfg.Active() {
+		return
+	}
+	if s.windowStart.IsZero() || now.Sub(s.windowStart) >= logSamplingWindow {
+		// Carry the verdict of the finished window forward so sampling does
+		// not flap at every window boundary.
+		s.overThreshold = s.windowCount >= s.cfg.ThresholdPerMinute
+		s.windowStart = now
+		s.windowCount = 0
+	}
+	s.windowCount++
+	if s.windowCount >= s.cfg.ThresholdPerMinute {
+		s.overThreshold = true
+	}
+}
+
+// keep reports whether the entry identified by key should be emitted at the
+// given keep-1-in-N rate. It always returns true while sampling is inactive.
+func (s *logSampler) keep(key string, rate int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.cfg.Active() || !s.overThreshold || rate <= 1 {
+		return true
+	}
+	n := s.counters[key]
+	s.counters[key] = n + 1
+	return n%uint64(rate) == 0
+}
+
+// rateFor resolves the sampling rate for a log level and optional provider.
+// A rate of 0 means the entry is never sampled.
+func (s *logSampler) rateFor(level string, provider string) int {
+	s.mu.Lock()
+	cfg := s.cfg
+	s.mu.Unlock()
+
+	if provider != "" {
+		if rate, ok := cfg.Providers[provider]; ok {
+			return rate
+		}
+	}
+	if rate, ok := cfg.Levels[level]; ok {
+		return rate
+	}
+	switch level {
+	case "debug", "trace":
+		return cfg.Rate
+	default:
+		// Info and above are only sampled via an explicit per-level override.
+		return 0
+	}
+}
+
+// shouldEmitLogEntry decides whether a logrus entry is written. Entries at
+// warn level and above are always written.
+func shouldEmitLogEntry(entry *log.Entry) bool {
+	if entry == nil || entry.Level <= log.WarnLevel {
+		return true
+	}
+	level := entry.Level.String()
+	provider, _ := entry.Data["provider"].(string)
+	rate := defaultLogSampler.rateFor(level, strings.TrimSpace(provider))
+	if rate <= 1 {
+		return true
+	}
+	key := level
+	if provider != "" {
+		key += "|" + provider
+	}
+	return defaultLogSampler.keep(key, rate)
+}
+
+// shouldEmitRequestLog decides whether a per-request log file is written.
+func shouldEmitRequestLog() bool {
+	rate := defaultLogSampler.rateFor("request", "")
+	if rate == 0 {
+		defaultLogSampler.mu.Lock()
+		rate = defaultLogSampler.cfg.Rate
+		defaultLogSampler.mu.Unlock()
+	}
+	if rate <= 1 {
+		return true
+	}
+	return defaultLogSampler.keep("request", rate)
+}
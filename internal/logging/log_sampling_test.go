@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+func TestLogSamplerInactiveBelowThreshold(t *testing.T) {
+	sampler := &logSampler{counters: make(map[string]uint64)}
+	sampler.cfg = config.LogSamplingConfig{ThresholdPerMinute: 100, Rate: 10}
+
+	now := time.Now()
+	for i := 0; i < 50; i++ {
+		sampler.observe(now)
+	}
+	for i := 0; i < 20; i++ {
+		if !sampler.keep("debug", 10) {
+			t.Fatalf("expected every entry to be kept below the threshold")
+		}
+	}
+}
+
+func TestLogSamplerKeepsOneInN(t *testing.T) {
+	sampler := &logSampler{counters: make(map[string]uint64)}
+	sampler.cfg = config.LogSamplingConfig{ThresholdPerMinute: 10, Rate: 5}
+
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		sampler.observe(now)
+	}
+
+	kept := 0
+	for i := 0; i < 50; i++ {
+		if sampler.keep("debug", 5) {
+			kept++
+		}
+	}
+	if kept != 10 {
+		t.Fatalf("expected 10 of 50 entries kept at rate 5, got %d", kept)
+	}
+}
+
+func TestLogSamplerRateResolution(t *testing.T) {
+	sampler := &logSampler{counters: make(map[string]uint64)}
+	sampler.cfg = config.LogSamplingConfig{
+		ThresholdPerMinute: 10,
+		Rate:               10,
+		Levels:             map[string]int{"debug": 20, "info": 5},
+		Providers:          map[string]int{"gemini": 50},
+	}
+
+	if got := sampler.rateFor("debug", ""); got != 20 {
+		t.Fatalf("expected level override 20 for debug, got %d", got)
+	}
+	if got := sampler.rateFor("debug", "gemini"); got != 50 {
+		t.Fatalf("expected provider override 50, got %d", got)
+	}
+	if got := sampler.rateFor("info", ""); got != 5 {
+		t.Fatalf("expected info override 5, got %d", got)
+	}
+	if got := sampler.rateFor("warn", ""); got != 0 {
+		t.Fatalf("expected warn to never be sampled, got %d", got)
+	}
+	if got := sampler.rateFor("trace", ""); got != 10 {
+		t.Fatalf("expected trace to fall back to default rate 10, got %d", got)
+	}
+}
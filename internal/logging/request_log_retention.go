@@ -0,0 +1,231 @@
+// This file implements rotation and retention for the per-request log
+// directory written by FileRequestLogger. A background sweeper compresses
+// idle log files, truncates oversized ones, and deletes files by age and by
+// total directory size so long-running gateways do not fill the disk.
+package logging
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+const (
+	// requestLogRetentionInterval is how often the retention sweeper runs.
+	requestLogRetentionInterval = time.Minute
+
+	// requestLogCompressAfter is how long a request log file must be idle
+	// before it is compressed. Streaming logs are assembled in place, so a
+	// short grace period avoids compressing files that are still being written.
+	requestLogCompressAfter = 5 * time.Minute
+)
+
+// SetRetention applies retention rules to the request log directory and
+// (re)starts the background sweeper. Passing a zero-value config stops the
+// sweeper and disables retention.
+func (l *FileRequestLogger) SetRetention(retention config.RequestLogRetentionConfig) {
+	l.retentionMu.Lock()
+	defer l.retentionMu.Unlock()
+
+	if l.retentionCancel != nil {
+		l.retentionCancel()
+		l.retentionCancel = nil
+	}
+	l.retention = retention
+
+	if !retention.Active() {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.retentionCancel = cancel
+	go runRequestLogRetention(ctx, l.logsDir, retention)
+}
+
+func runRequestLogRetention(ctx context.Context, logsDir string, retention config.RequestLogRetentionConfig) {
+	ticker := time.NewTicker(requestLogRetentionInterval)
+	defer ticker.Stop()
+
+	sweepOnce := func() {
+		if err := sweepRequestLogs(logsDir, retention, time.Now()); err != nil {
+			log.WithError(err).Warn("logging: request log retention sweep failed")
+		}
+	}
+
+	sweepOnce()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sweepOnce()
+		}
+	}
+}
+
+// sweepRequestLogs enforces the retention rules against logsDir once.
+// Temp files used by in-flight streaming logs are never touched.
+func sweepRequestLogs(logsDir string, retention config.RequestLogRetentionConfig, now time.Time) error {
+	dir := strings.TrimSpace(logsDir)
+	if dir == "" {
+		return nil
+	}
+	dir = filepath.Clean(dir)
+
+	entries, errRead := os.ReadDir(dir)
+	if errRead != nil {
+		if os.IsNotExist(errRead) {
+			return nil
+		}
+		return errRead
+	}
+
+	type logFile struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var (
+		files []logFile
+		total int64
+	)
+	maxFileBytes := int64(retention.MaxFileSizeMB) * 1024 * 1024
+	maxAge := time.Duration(retention.MaxAgeDays) * 24 * time.Hour
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		if !isLogFileName(name) {
+			continue
+		}
+		info, errInfo := entry.Info()
+		if errInfo != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		size := info.Size()
+		modTime := info.ModTime()
+
+		if maxAge > 0 && now.Sub(modTime) > maxAge {
+			if errRemove := os.Remove(path); errRemove != nil {
+				log.WithError(errRemove).Warnf("logging: failed to remove expired request log: %s", name)
+			}
+			continue
+		}
+
+		if maxFileBytes > 0 && size > maxFileBytes && strings.HasSuffix(strings.ToLower(name), ".log") {
+			if errTruncate := truncateRequestLog(path, maxFileBytes); errTruncate != nil {
+				log.WithError(errTruncate).Warnf("logging: failed to truncate oversized request log: %s", name)
+			} else {
+				size = maxFileBytes
+			}
+		}
+
+		if retention.Compress && strings.HasSuffix(strings.ToLower(name), ".log") && now.Sub(modTime) > requestLogCompressAfter {
+			if compressedSize, errCompress := compressRequestLog(path); errCompress != nil {
+				log.WithError(errCompress).Warnf("logging: failed to compress request log: %s", name)
+			} else {
+				path += ".gz"
+				size = compressedSize
+			}
+		}
+
+		files = append(files, logFile{path: path, size: size, modTime: modTime})
+		total += size
+	}
+
+	maxTotalBytes := int64(retention.MaxTotalSizeMB) * 1024 * 1024
+	if maxTotalBytes <= 0 || total <= maxTotalBytes {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files {
+		if total <= maxTotalBytes {
+			break
+		}
+		if errRemove := os.Remove(file.path); errRemove != nil {
+			log.WithError(errRemove).Warnf("logging: failed to remove old request log: %s", filepath.Base(file.path))
+			continue
+		}
+		total -= file.size
+	}
+	return nil
+}
+
+// truncateRequestLog caps a plain-text request log at maxBytes and appends a
+// marker so readers know the tail was dropped by retention.
+func truncateRequestLog(path string, maxBytes int64) error {
+	file, errOpen := os.OpenFile(path, os.O_WRONLY, 0644)
+	if errOpen != nil {
+		return errOpen
+	}
+	defer func() {
+		if errClose := file.Close(); errClose != nil {
+			log.WithError(errClose).Warn("logging: failed to close truncated request log")
+		}
+	}()
+	if errTruncate := file.Truncate(maxBytes); errTruncate != nil {
+		return errTruncate
+	}
+	if _, errSeek := file.Seek(0, io.SeekEnd); errSeek != nil {
+		return errSeek
+	}
+	_, errWrite := io.WriteString(file, "\n[truncated: request-log-retention.max-file-size-mb]\n")
+	return errWrite
+}
+
+// compressRequestLog gzips a request log in place, producing path+".gz" and
+// removing the original. It returns the compressed size.
+func compressRequestLog(path string) (int64, error) {
+	source, errOpen := os.Open(path)
+	if errOpen != nil {
+		return 0, errOpen
+	}
+
+	gzPath := path + ".gz"
+	target, errCreate := os.OpenFile(gzPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if errCreate != nil {
+		_ = source.Close()
+		return 0, errCreate
+	}
+
+	gzWriter := gzip.NewWriter(target)
+	_, errCopy := io.Copy(gzWriter, source)
+	if errClose := gzWriter.Close(); errCopy == nil {
+		errCopy = errClose
+	}
+	if errClose := source.Close(); errCopy == nil {
+		errCopy = errClose
+	}
+	if errClose := target.Close(); errCopy == nil {
+		errCopy = errClose
+	}
+	if errCopy != nil {
+		_ = os.Remove(gzPath)
+		return 0, errCopy
+	}
+
+	info, errStat := os.Stat(gzPath)
+	if errStat != nil {
+		return 0, errStat
+	}
+	if errRemove := os.Remove(path); errRemove != nil {
+		log.WithError(errRemove).Warnf("logging: failed to remove request log after compression: %s", filepath.Base(path))
+	}
+	return info.Size(), nil
+}
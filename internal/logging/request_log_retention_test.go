@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+func TestSweepRequestLogsDeletesExpired(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	writeLogFile(t, filepath.Join(dir, "expired.log"), 10, now.Add(-48*time.Hour))
+	writeLogFile(t, filepath.Join(dir, "fresh.log"), 10, now.Add(-time.Hour))
+
+	retention := config.RequestLogRetentionConfig{MaxAgeDays: 1}
+	if err := sweepRequestLogs(dir, retention, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "expired.log")); !os.IsNotExist(err) {
+		t.Fatalf("expected expired.log to be removed, stat error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "fresh.log")); err != nil {
+		t.Fatalf("expected fresh.log to remain, stat error: %v", err)
+	}
+}
+
+func TestSweepRequestLogsCompressesIdle(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	writeLogFile(t, filepath.Join(dir, "idle.log"), 100, now.Add(-10*time.Minute))
+	writeLogFile(t, filepath.Join(dir, "active.log"), 100, now.Add(-time.Minute))
+
+	retention := config.RequestLogRetentionConfig{Compress: true}
+	if err := sweepRequestLogs(dir, retention, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "idle.log.gz")); err != nil {
+		t.Fatalf("expected idle.log.gz to exist, stat error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "idle.log")); !os.IsNotExist(err) {
+		t.Fatalf("expected idle.log to be removed after compression, stat error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "active.log")); err != nil {
+		t.Fatalf("expected active.log to remain uncompressed, stat error: %v", err)
+	}
+}
+
+func TestSweepRequestLogsTruncatesOversized(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	oversized := filepath.Join(dir, "big.log")
+	writeLogFile(t, oversized, 2*1024*1024, now.Add(-time.Minute))
+
+	retention := config.RequestLogRetentionConfig{MaxFileSizeMB: 1}
+	if err := sweepRequestLogs(dir, retention, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(oversized)
+	if err != nil {
+		t.Fatalf("expected big.log to remain, stat error: %v", err)
+	}
+	if info.Size() >= 2*1024*1024 {
+		t.Fatalf("expected big.log to be truncated, size %d", info.Size())
+	}
+}
+
+func TestSweepRequestLogsEnforcesTotalSize(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	writeLogFile(t, filepath.Join(dir, "oldest.log"), 700*1024, now.Add(-3*time.Hour))
+	writeLogFile(t, filepath.Join(dir, "newest.log"), 700*1024, now.Add(-time.Hour))
+
+	retention := config.RequestLogRetentionConfig{MaxTotalSizeMB: 1}
+	if err := sweepRequestLogs(dir, retention, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "oldest.log")); !os.IsNotExist(err) {
+		t.Fatalf("expected oldest.log to be removed, stat error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "newest.log")); err != nil {
+		t.Fatalf("expected newest.log to remain, stat error: %v", err)
+	}
+}
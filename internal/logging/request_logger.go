@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"compress/flate"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -22,6 +24,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/shariqriazz/modelgate/internal/buildinfo"
+	"github.com/shariqriazz/modelgate/internal/config"
 	"github.com/shariqriazz/modelgate/internal/interfaces"
 	"github.com/shariqriazz/modelgate/internal/util"
 )
@@ -124,6 +127,15 @@ type FileRequestLogger struct {
 
 	// logsDir is the directory where log files are stored.
 	logsDir string
+
+	// retentionMu guards retention and retentionCancel.
+	retentionMu sync.Mutex
+
+	// retention holds the rotation/retention rules applied to logsDir.
+	retention config.RequestLogRetentionConfig
+
+	// retentionCancel stops the background retention sweeper when set.
+	retentionCancel context.CancelFunc
 }
 
 // NewFileRequestLogger creates a new file-based request logger.
@@ -197,6 +209,9 @@ func (l *FileRequestLogger) logRequest(url, method string, requestHeaders map[st
 	if !l.enabled && !force {
 		return nil
 	}
+	if !force && !shouldEmitRequestLog() {
+		return nil
+	}
 
 	// Ensure logs directory exists
 	if errEnsure := l.ensureLogsDir(); errEnsure != nil {
@@ -283,6 +298,9 @@ func (l *FileRequestLogger) LogStreamingRequest(url, method string, headers map[
 	if !l.enabled {
 		return &NoOpStreamingLogWriter{}, nil
 	}
+	if !shouldEmitRequestLog() {
+		return &NoOpStreamingLogWriter{}, nil
+	}
 
 	// Ensure logs directory exists
 	if err := l.ensureLogsDir(); err != nil {
@@ -0,0 +1,246 @@
+// Package moderation implements the optional inbound prompt moderation pre-check.
+// It extracts user-visible text from inbound payloads across the supported request
+// schemas, submits it to an OpenAI-compatible moderations endpoint, and reports
+// which configured categories exceeded their thresholds.
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/util"
+	"github.com/shariqriazz/modelgate/sdk/config"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	defaultModerationTimeout = 15 * time.Second
+	// maxInputChars bounds the text submitted per request to keep moderation calls cheap.
+	maxInputChars = 32_000
+)
+
+// Result describes the outcome of a moderation check.
+type Result struct {
+	// Flagged reports whether the request should be blocked.
+	Flagged bool
+	// Categories lists the category names that triggered the block.
+	Categories []string
+}
+
+// Check runs the moderation pre-check for the given inbound payload.
+// The format identifies the inbound schema (e.g. "openai", "claude", "gemini").
+// It returns a nil result when moderation is disabled or no text was found.
+func Check(ctx context.Context, cfg *config.SDKConfig, format string, payload []byte) (*Result, error) {
+	if cfg == nil || !cfg.Moderation.Enabled {
+		return nil, nil
+	}
+	texts := ExtractTexts(format, payload)
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	input := strings.Join(texts, "\n")
+	if len(input) > maxInputChars {
+		input = input[:maxInputChars]
+	}
+	return moderate(ctx, cfg, input)
+}
+
+// ExtractTexts collects prompt text from an inbound payload based on its schema.
+// Unknown formats fall back to scanning all known message locations.
+func ExtractTexts(format string, payload []byte) []string {
+	if len(payload) == 0 {
+		return nil
+	}
+	var texts []string
+	appendText := func(value string) {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			texts = append(texts, trimmed)
+		}
+	}
+
+	collectContent := func(content gjson.Result) {
+		switch {
+		case content.Type == gjson.String:
+			appendText(content.String())
+		case content.IsArray():
+			content.ForEach(func(_, part gjson.Result) bool {
+				if text := part.Get("text"); text.Exists() {
+					appendText(text.String())
+				}
+				return true
+			})
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "gemini", "gemini-cli":
+		gjson.GetBytes(payload, "contents").ForEach(func(_, content gjson.Result) bool {
+			content.Get("parts").ForEach(func(_, part gjson.Result) bool {
+				appendText(part.Get("text").String())
+				return true
+			})
+			return true
+		})
+	case "openai-response", "codex":
+		if input := gjson.GetBytes(payload, "input"); input.Exists() {
+			if input.Type == gjson.String {
+				appendText(input.String())
+			} else if input.IsArray() {
+				input.ForEach(func(_, item gjson.Result) bool {
+					collectContent(item.Get("content"))
+					return true
+				})
+			}
+		}
+		appendText(gjson.GetBytes(payload, "instructions").String())
+	default:
+		gjson.GetBytes(payload, "messages").ForEach(func(_, message gjson.Result) bool {
+			collectContent(message.Get("content"))
+			return true
+		})
+		if system := gjson.GetBytes(payload, "system"); system.Exists() {
+			collectContent(system)
+		}
+	}
+	return texts
+}
+
+type moderationRequest struct {
+	Input string `json:"input"`
+	Model string `json:"model,omitempty"`
+}
+
+// Forward proxies a raw moderations request body to the configured provider and
+// returns the upstream status code and response payload unchanged. It backs the
+// /v1/moderations endpoint so clients can keep the gateway as their single base URL.
+func Forward(ctx context.Context, cfg *config.SDKConfig, payload []byte) (int, []byte, error) {
+	if cfg == nil {
+		return 0, nil, fmt.Errorf("moderation: configuration is nil")
+	}
+	baseURL := strings.TrimSpace(cfg.Moderation.BaseURL)
+	if baseURL == "" {
+		return 0, nil, fmt.Errorf("moderation: base-url is not configured")
+	}
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/moderations"
+
+	// Apply the configured default model when the client omits one.
+	if model := strings.TrimSpace(cfg.Moderation.Model); model != "" && !gjson.GetBytes(payload, "model").Exists() {
+		if updated, err := sjson.SetBytes(payload, "model", model); err == nil {
+			payload = updated
+		}
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if apiKey := strings.TrimSpace(cfg.Moderation.APIKey); apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	httpClient := util.SetProxy(cfg, &http.Client{Timeout: defaultModerationTimeout})
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return httpResp.StatusCode, data, nil
+}
+
+// moderate submits the input to the configured moderations endpoint and evaluates the verdict.
+func moderate(ctx context.Context, cfg *config.SDKConfig, input string) (*Result, error) {
+	baseURL := strings.TrimSpace(cfg.Moderation.BaseURL)
+	if baseURL == "" {
+		return nil, fmt.Errorf("moderation: base-url is not configured")
+	}
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/moderations"
+
+	body, err := json.Marshal(moderationRequest{Input: input, Model: strings.TrimSpace(cfg.Moderation.Model)})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if apiKey := strings.TrimSpace(cfg.Moderation.APIKey); apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	httpClient := util.SetProxy(cfg, &http.Client{Timeout: defaultModerationTimeout})
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, fmt.Errorf("moderation: provider returned status %d", httpResp.StatusCode)
+	}
+	return evaluate(cfg, data), nil
+}
+
+// evaluate converts a moderations response into a block decision based on the
+// configured category allowlist and score threshold.
+func evaluate(cfg *config.SDKConfig, data []byte) *Result {
+	allowed := make(map[string]struct{}, len(cfg.Moderation.Categories))
+	for _, category := range cfg.Moderation.Categories {
+		if trimmed := strings.ToLower(strings.TrimSpace(category)); trimmed != "" {
+			allowed[trimmed] = struct{}{}
+		}
+	}
+	threshold := cfg.Moderation.Threshold
+
+	flagged := make(map[string]struct{})
+	gjson.GetBytes(data, "results").ForEach(func(_, result gjson.Result) bool {
+		if threshold > 0 {
+			result.Get("category_scores").ForEach(func(key, score gjson.Result) bool {
+				if score.Float() >= threshold {
+					flagged[strings.ToLower(key.String())] = struct{}{}
+				}
+				return true
+			})
+			return true
+		}
+		if !result.Get("flagged").Bool() {
+			return true
+		}
+		result.Get("categories").ForEach(func(key, value gjson.Result) bool {
+			if value.Bool() {
+				flagged[strings.ToLower(key.String())] = struct{}{}
+			}
+			return true
+		})
+		return true
+	})
+
+	out := &Result{}
+	for category := range flagged {
+		if len(allowed) > 0 {
+			if _, ok := allowed[category]; !ok {
+				continue
+			}
+		}
+		out.Categories = append(out.Categories, category)
+	}
+	sort.Strings(out.Categories)
+	out.Flagged = len(out.Categories) > 0
+	return out
+}
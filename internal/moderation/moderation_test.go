@@ -0,0 +1,84 @@
+package moderation
+
+import (
+	"testing"
+
+	"github.com/shariqriazz/modelgate/sdk/config"
+)
+
+func TestExtractTexts(t *testing.T) {
+	cases := []struct {
+		name    string
+		format  string
+		payload string
+		want    int
+	}{
+		{
+			name:    "openai string content",
+			format:  "openai",
+			payload: `{"messages":[{"role":"user","content":"hello"},{"role":"assistant","content":"hi"}]}`,
+			want:    2,
+		},
+		{
+			name:    "claude block content with system",
+			format:  "claude",
+			payload: `{"system":"be nice","messages":[{"role":"user","content":[{"type":"text","text":"hello"}]}]}`,
+			want:    2,
+		},
+		{
+			name:    "gemini contents",
+			format:  "gemini",
+			payload: `{"contents":[{"parts":[{"text":"hello"},{"inlineData":{}}]}]}`,
+			want:    1,
+		},
+		{
+			name:    "responses string input",
+			format:  "openai-response",
+			payload: `{"input":"hello","instructions":"be brief"}`,
+			want:    2,
+		},
+		{
+			name:    "empty payload",
+			format:  "openai",
+			payload: `{}`,
+			want:    0,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ExtractTexts(tc.format, []byte(tc.payload))
+			if len(got) != tc.want {
+				t.Fatalf("ExtractTexts(%s) returned %d texts, want %d: %v", tc.format, len(got), tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	response := []byte(`{"results":[{"flagged":true,"categories":{"hate":true,"violence":false},"category_scores":{"hate":0.91,"violence":0.12}}]}`)
+
+	cfg := &config.SDKConfig{}
+	result := evaluate(cfg, response)
+	if !result.Flagged || len(result.Categories) != 1 || result.Categories[0] != "hate" {
+		t.Fatalf("expected flagged hate category, got %+v", result)
+	}
+
+	cfg.Moderation.Categories = []string{"violence"}
+	result = evaluate(cfg, response)
+	if result.Flagged {
+		t.Fatalf("expected no block when flagged category is not configured, got %+v", result)
+	}
+
+	cfg.Moderation.Categories = nil
+	cfg.Moderation.Threshold = 0.5
+	result = evaluate(cfg, response)
+	if !result.Flagged || len(result.Categories) != 1 || result.Categories[0] != "hate" {
+		t.Fatalf("expected threshold block on hate, got %+v", result)
+	}
+
+	cfg.Moderation.Threshold = 0.99
+	result = evaluate(cfg, response)
+	if result.Flagged {
+		t.Fatalf("expected no block above threshold, got %+v", result)
+	}
+}
@@ -0,0 +1,156 @@
+// Package recording captures the exact payloads flowing through the
+// translator pipeline to disk so they can be replayed after upgrades with the
+// `-replay` command for translation regression testing.
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	log "github.com/sirupsen/logrus"
+)
+
+// defaultDir is where recordings land when no directory is configured.
+const defaultDir = "recordings"
+
+// Entry is one recorded translator exchange. Request entries hold the inbound
+// payload and the translated upstream payload; response entries additionally
+// hold the request pair the upstream response belongs to.
+type Entry struct {
+	// Kind is "request" or "response".
+	Kind       string    `json:"kind"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Model      string    `json:"model,omitempty"`
+	Stream     bool      `json:"stream,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// Original is the untranslated inbound request (response entries only).
+	Original string `json:"original,omitempty"`
+	// Request is the translated upstream request (response entries only).
+	Request string `json:"request,omitempty"`
+	// Input is the payload that was fed to the translator.
+	Input string `json:"input"`
+	// Output is the translator result.
+	Output string `json:"output"`
+}
+
+// File pairs a recorded entry with the file it was loaded from.
+type File struct {
+	Name  string
+	Entry Entry
+}
+
+var (
+	mu      sync.Mutex
+	enabled bool
+	dir     = defaultDir
+	seq     atomic.Int64
+)
+
+// Configure enables or disables recording and installs (or removes) the
+// translator observers. An empty directory falls back to "recordings".
+func Configure(enable bool, directory string) {
+	directory = strings.TrimSpace(directory)
+	if directory == "" {
+		directory = defaultDir
+	}
+	mu.Lock()
+	enabled = enable
+	dir = directory
+	mu.Unlock()
+	if enable {
+		sdktranslator.SetRequestRecorder(recordRequest)
+		sdktranslator.SetResponseRecorder(recordResponse)
+		return
+	}
+	sdktranslator.SetRequestRecorder(nil)
+	sdktranslator.SetResponseRecorder(nil)
+}
+
+func recordRequest(from, to sdktranslator.Format, model string, stream bool, rawJSON, translated []byte) {
+	save(Entry{
+		Kind:       "request",
+		From:       from.String(),
+		To:         to.String(),
+		Model:      model,
+		Stream:     stream,
+		RecordedAt: time.Now(),
+		Input:      string(rawJSON),
+		Output:     string(translated),
+	})
+}
+
+func recordResponse(from, to sdktranslator.Format, model string, originalRequestRawJSON, requestRawJSON, rawJSON []byte, translated string) {
+	save(Entry{
+		Kind:       "response",
+		From:       from.String(),
+		To:         to.String(),
+		Model:      model,
+		RecordedAt: time.Now(),
+		Original:   string(originalRequestRawJSON),
+		Request:    string(requestRawJSON),
+		Input:      string(rawJSON),
+		Output:     translated,
+	})
+}
+
+// save writes the entry to its own JSON file off the request path.
+func save(entry Entry) {
+	mu.Lock()
+	if !enabled {
+		mu.Unlock()
+		return
+	}
+	target := dir
+	mu.Unlock()
+	name := fmt.Sprintf("%d-%04d-%s.json", entry.RecordedAt.UnixNano(), seq.Add(1), entry.Kind)
+	go func() {
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			log.WithError(err).Warnf("recording: failed to create directory %s", target)
+			return
+		}
+		data, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			log.WithError(err).Warn("recording: failed to encode entry")
+			return
+		}
+		if errWrite := os.WriteFile(filepath.Join(target, name), data, 0o644); errWrite != nil {
+			log.WithError(errWrite).Warnf("recording: failed to write %s", name)
+		}
+	}()
+}
+
+// Load reads every recorded entry from directory, sorted by file name so
+// replay follows the original recording order.
+func Load(directory string) ([]File, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]File, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, errRead := os.ReadFile(filepath.Join(directory, entry.Name()))
+		if errRead != nil {
+			return nil, fmt.Errorf("recording: failed to read %s: %w", entry.Name(), errRead)
+		}
+		var rec Entry
+		if errJSON := json.Unmarshal(data, &rec); errJSON != nil {
+			return nil, fmt.Errorf("recording: %s is not a valid recording: %w", entry.Name(), errJSON)
+		}
+		out = append(out, File{Name: entry.Name(), Entry: rec})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const catalogWebhookTimeout = 10 * time.Second
+
+// catalogWebhookURL receives model catalog diff notifications when set.
+var catalogWebhookURL atomic.Value
+
+// SetCatalogWebhook configures the URL notified when periodic model refresh
+// detects added or removed upstream models. An empty URL disables webhooks;
+// the structured diff log is always emitted.
+func SetCatalogWebhook(url string) {
+	catalogWebhookURL.Store(strings.TrimSpace(url))
+}
+
+// CatalogDiff describes a detected change in a client's upstream model list.
+type CatalogDiff struct {
+	Provider  string    `json:"provider"`
+	ClientID  string    `json:"client_id"`
+	Added     []string  `json:"added,omitempty"`
+	Removed   []string  `json:"removed,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// notifyCatalogDiff logs a structured diff and fires the configured webhook
+// when an upstream model refresh added or removed models for a client.
+func notifyCatalogDiff(provider, clientID string, added, removed []string) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+	diff := CatalogDiff{
+		Provider:  provider,
+		ClientID:  clientID,
+		Added:     append([]string(nil), added...),
+		Removed:   append([]string(nil), removed...),
+		Timestamp: time.Now(),
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+
+	log.WithFields(log.Fields{
+		"provider": provider,
+		"added":    strings.Join(diff.Added, ","),
+		"removed":  strings.Join(diff.Removed, ","),
+	}).Infof("model catalog changed for client %s: %d added, %d removed", clientID, len(diff.Added), len(diff.Removed))
+
+	url, _ := catalogWebhookURL.Load().(string)
+	if url == "" {
+		return
+	}
+	go func() {
+		if err := postCatalogDiff(url, diff); err != nil {
+			log.WithError(err).Warnf("model catalog webhook delivery failed for client %s", clientID)
+		}
+	}()
+}
+
+func postCatalogDiff(url string, diff CatalogDiff) error {
+	payload, errMarshal := json.Marshal(diff)
+	if errMarshal != nil {
+		return errMarshal
+	}
+	client := &http.Client{Timeout: catalogWebhookTimeout}
+	resp, errPost := client.Post(url, "application/json", bytes.NewReader(payload))
+	if errPost != nil {
+		return errPost
+	}
+	return resp.Body.Close()
+}
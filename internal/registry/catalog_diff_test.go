@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPostCatalogDiff(t *testing.T) {
+	received := make(chan CatalogDiff, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var diff CatalogDiff
+		if err := json.Unmarshal(body, &diff); err != nil {
+			t.Errorf("webhook payload is not valid JSON: %v", err)
+		}
+		received <- diff
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	want := CatalogDiff{
+		Provider:  "antigravity",
+		ClientID:  "auth-1",
+		Added:     []string{"gemini-3-pro-preview"},
+		Removed:   []string{"gemini-2.5-flash"},
+		Timestamp: time.Now(),
+	}
+	if err := postCatalogDiff(server.URL, want); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.Provider != want.Provider || got.ClientID != want.ClientID {
+			t.Fatalf("unexpected payload: %+v", got)
+		}
+		if len(got.Added) != 1 || got.Added[0] != want.Added[0] {
+			t.Fatalf("unexpected added list: %v", got.Added)
+		}
+		if len(got.Removed) != 1 || got.Removed[0] != want.Removed[0] {
+			t.Fatalf("unexpected removed list: %v", got.Removed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("webhook was not called")
+	}
+}
+
+func TestNotifyCatalogDiffNoChanges(t *testing.T) {
+	SetCatalogWebhook("http://127.0.0.1:1/unreachable")
+	defer SetCatalogWebhook("")
+	// No added or removed models must neither log a diff nor fire the webhook;
+	// an unreachable URL would surface as a test-time warning otherwise.
+	notifyCatalogDiff("gemini", "auth-1", nil, nil)
+}
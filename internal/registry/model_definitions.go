@@ -940,6 +940,30 @@ func GetQwenModels() []*ModelInfo {
 	}
 }
 
+// qwenPortalOnlyModels lists models served only by the Qwen OAuth portal and
+// not by the DashScope regional endpoints.
+var qwenPortalOnlyModels = map[string]struct{}{
+	"vision-model": {},
+}
+
+// GetQwenModelsForRegion returns the Qwen model definitions available for an
+// endpoint region ("cn" or "international"). An empty region means the OAuth
+// portal, which serves the full set.
+func GetQwenModelsForRegion(region string) []*ModelInfo {
+	models := GetQwenModels()
+	if strings.TrimSpace(region) == "" {
+		return models
+	}
+	filtered := make([]*ModelInfo, 0, len(models))
+	for _, m := range models {
+		if _, portalOnly := qwenPortalOnlyModels[m.ID]; portalOnly {
+			continue
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered
+}
+
 // iFlowThinkingSupport is a shared ThinkingSupport configuration for iFlow models
 // that support thinking mode via chat_template_kwargs.enable_thinking (boolean toggle).
 // Uses level-based configuration so standard normalization flows apply before conversion.
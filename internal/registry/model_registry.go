@@ -286,6 +286,8 @@ func (r *ModelRegistry) RegisterClient(clientID, clientProvider string, models [
 		}
 	}
 
+	notifyCatalogDiff(provider, clientID, added, removed)
+
 	// Handle provider change for overlapping models before modifications.
 	if providerChanged && oldProvider != "" {
 		for id, newCount := range newCounts {
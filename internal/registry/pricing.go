@@ -0,0 +1,177 @@
+// Package registry pricing support. This file defines per-model pricing
+// metadata used to estimate request cost from token usage. Prices are
+// expressed in USD per million tokens and can be overridden from config.
+package registry
+
+import (
+	"strings"
+	"sync"
+)
+
+// ModelPricing holds a model's token prices in USD per million tokens.
+type ModelPricing struct {
+	// InputUSDPerMillion is the price for non-cached prompt tokens.
+	InputUSDPerMillion float64 `json:"input_usd_per_million,omitempty"`
+	// OutputUSDPerMillion is the price for completion tokens, including
+	// reasoning tokens when the provider bills them as output.
+	OutputUSDPerMillion float64 `json:"output_usd_per_million,omitempty"`
+	// CachedUSDPerMillion is the price for cached prompt tokens. Zero means
+	// cached tokens are billed at the input rate.
+	CachedUSDPerMillion float64 `json:"cached_usd_per_million,omitempty"`
+}
+
+// ModelPricingOverride binds a model name pattern ('*' wildcards allowed) to
+// pricing that takes precedence over the built-in table.
+type ModelPricingOverride struct {
+	Pattern string
+	Pricing ModelPricing
+}
+
+// modelPricingEntry is one row of the built-in pricing table.
+type modelPricingEntry struct {
+	pattern string
+	pricing ModelPricing
+}
+
+// defaultModelPricing lists published list prices for common model families.
+// More specific patterns must precede broader ones; the first match wins.
+var defaultModelPricing = []modelPricingEntry{
+	{"gpt-4o-mini*", ModelPricing{0.15, 0.60, 0.075}},
+	{"gpt-4o*", ModelPricing{2.50, 10.00, 1.25}},
+	{"gpt-4.1-nano*", ModelPricing{0.10, 0.40, 0.025}},
+	{"gpt-4.1-mini*", ModelPricing{0.40, 1.60, 0.10}},
+	{"gpt-4.1*", ModelPricing{2.00, 8.00, 0.50}},
+	{"gpt-5-nano*", ModelPricing{0.05, 0.40, 0.005}},
+	{"gpt-5-mini*", ModelPricing{0.25, 2.00, 0.025}},
+	{"gpt-5*", ModelPricing{1.25, 10.00, 0.125}},
+	{"o3-mini*", ModelPricing{1.10, 4.40, 0.55}},
+	{"o3*", ModelPricing{2.00, 8.00, 0.50}},
+	{"o4-mini*", ModelPricing{1.10, 4.40, 0.275}},
+	{"claude-opus-4*", ModelPricing{15.00, 75.00, 1.50}},
+	{"claude-sonnet-4*", ModelPricing{3.00, 15.00, 0.30}},
+	{"claude-haiku-4*", ModelPricing{1.00, 5.00, 0.10}},
+	{"claude-3-5-haiku*", ModelPricing{0.80, 4.00, 0.08}},
+	{"claude-3-7-sonnet*", ModelPricing{3.00, 15.00, 0.30}},
+	{"gemini-2.5-flash-lite*", ModelPricing{0.10, 0.40, 0.025}},
+	{"gemini-2.5-flash*", ModelPricing{0.30, 2.50, 0.075}},
+	{"gemini-2.5-pro*", ModelPricing{1.25, 10.00, 0.31}},
+	{"gemini-2.0-flash-lite*", ModelPricing{0.075, 0.30, 0.019}},
+	{"gemini-2.0-flash*", ModelPricing{0.10, 0.40, 0.025}},
+	{"deepseek-chat*", ModelPricing{0.27, 1.10, 0.07}},
+	{"deepseek-reasoner*", ModelPricing{0.55, 2.19, 0.14}},
+}
+
+var (
+	pricingOverridesMu sync.RWMutex
+	pricingOverrides   []ModelPricingOverride
+)
+
+// SetModelPricingOverrides replaces the config-supplied pricing overrides.
+// It is wired to the pricing config key and safe to call on reload.
+func SetModelPricingOverrides(overrides []ModelPricingOverride) {
+	cloned := make([]ModelPricingOverride, 0, len(overrides))
+	for _, override := range overrides {
+		if strings.TrimSpace(override.Pattern) == "" {
+			continue
+		}
+		cloned = append(cloned, override)
+	}
+	pricingOverridesMu.Lock()
+	pricingOverrides = cloned
+	pricingOverridesMu.Unlock()
+}
+
+// LookupModelPricing resolves pricing for a model name, consulting the
+// config overrides before the built-in table. The first matching pattern
+// wins; ok is false when the model has no known pricing.
+func LookupModelPricing(model string) (ModelPricing, bool) {
+	model = strings.TrimSpace(model)
+	if model == "" {
+		return ModelPricing{}, false
+	}
+	pricingOverridesMu.RLock()
+	overrides := pricingOverrides
+	pricingOverridesMu.RUnlock()
+	for _, override := range overrides {
+		if matchPricingPattern(override.Pattern, model) {
+			return override.Pricing, true
+		}
+	}
+	for _, entry := range defaultModelPricing {
+		if matchPricingPattern(entry.pattern, model) {
+			return entry.pricing, true
+		}
+	}
+	return ModelPricing{}, false
+}
+
+// EstimateCostUSD computes the estimated cost of a request from its token
+// counts. Everything beyond the prompt is billed at the output rate, derived
+// from the provider total when available so reasoning tokens are counted once
+// regardless of whether the provider folds them into completion tokens.
+// Cached tokens are billed at the cached rate when one is known and at the
+// input rate otherwise. ok is false when the model has no known pricing.
+func EstimateCostUSD(model string, inputTokens, outputTokens, reasoningTokens, cachedTokens, totalTokens int64) (float64, bool) {
+	pricing, ok := LookupModelPricing(model)
+	if !ok {
+		return 0, false
+	}
+	billableOutput := totalTokens - inputTokens
+	if totalTokens <= 0 || billableOutput < 0 {
+		billableOutput = outputTokens + reasoningTokens
+	}
+	billableInput := inputTokens
+	cachedRate := pricing.CachedUSDPerMillion
+	if cachedRate > 0 && cachedTokens > 0 {
+		billableInput -= cachedTokens
+		if billableInput < 0 {
+			billableInput = 0
+		}
+	} else {
+		cachedTokens = 0
+	}
+	cost := float64(billableInput)*pricing.InputUSDPerMillion +
+		float64(billableOutput)*pricing.OutputUSDPerMillion +
+		float64(cachedTokens)*cachedRate
+	return cost / 1e6, true
+}
+
+// matchPricingPattern matches a model name against a pattern where '*'
+// matches zero or more characters.
+func matchPricingPattern(pattern, model string) bool {
+	pattern = strings.TrimSpace(pattern)
+	model = strings.TrimSpace(model)
+	if pattern == "" {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	pi, si := 0, 0
+	starIdx := -1
+	matchIdx := 0
+	for si < len(model) {
+		if pi < len(pattern) && (pattern[pi] == model[si]) {
+			pi++
+			si++
+			continue
+		}
+		if pi < len(pattern) && pattern[pi] == '*' {
+			starIdx = pi
+			matchIdx = si
+			pi++
+			continue
+		}
+		if starIdx != -1 {
+			pi = starIdx + 1
+			matchIdx++
+			si = matchIdx
+			continue
+		}
+		return false
+	}
+	for pi < len(pattern) && pattern[pi] == '*' {
+		pi++
+	}
+	return pi == len(pattern)
+}
@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLookupModelPricing(t *testing.T) {
+	SetModelPricingOverrides(nil)
+	if _, ok := LookupModelPricing("completely-unknown-model"); ok {
+		t.Fatalf("expected no pricing for unknown model")
+	}
+	pricing, ok := LookupModelPricing("gpt-4o-mini-2024-07-18")
+	if !ok {
+		t.Fatalf("expected built-in pricing for gpt-4o-mini")
+	}
+	if pricing.InputUSDPerMillion != 0.15 {
+		t.Fatalf("unexpected input price: %v", pricing.InputUSDPerMillion)
+	}
+
+	SetModelPricingOverrides([]ModelPricingOverride{
+		{Pattern: "gpt-4o-mini*", Pricing: ModelPricing{InputUSDPerMillion: 1, OutputUSDPerMillion: 2}},
+	})
+	defer SetModelPricingOverrides(nil)
+	pricing, ok = LookupModelPricing("gpt-4o-mini-2024-07-18")
+	if !ok || pricing.InputUSDPerMillion != 1 {
+		t.Fatalf("expected override pricing, got (%v, %v)", pricing, ok)
+	}
+}
+
+func TestEstimateCostUSD(t *testing.T) {
+	SetModelPricingOverrides([]ModelPricingOverride{
+		{Pattern: "test-model", Pricing: ModelPricing{InputUSDPerMillion: 1, OutputUSDPerMillion: 10, CachedUSDPerMillion: 0.5}},
+	})
+	defer SetModelPricingOverrides(nil)
+
+	if _, ok := EstimateCostUSD("unknown-model", 1000, 1000, 0, 0, 2000); ok {
+		t.Fatalf("expected no cost estimate for unknown model")
+	}
+
+	cases := []struct {
+		name                                    string
+		input, output, reasoning, cached, total int64
+		want                                    float64
+	}{
+		{"plain", 1_000_000, 100_000, 0, 0, 1_100_000, 1 + 1},
+		{"cached discount", 1_000_000, 100_000, 0, 500_000, 1_100_000, 0.5 + 0.25 + 1},
+		{"reasoning without total", 1_000_000, 100_000, 100_000, 0, 0, 1 + 2},
+		{"reasoning folded into total", 1_000_000, 100_000, 100_000, 0, 1_200_000, 1 + 2},
+	}
+	for _, tc := range cases {
+		got, ok := EstimateCostUSD("test-model", tc.input, tc.output, tc.reasoning, tc.cached, tc.total)
+		if !ok || math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("%s: EstimateCostUSD = (%v, %v), want (%v, true)", tc.name, got, ok, tc.want)
+		}
+	}
+}
@@ -4,7 +4,6 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"crypto/sha256"
@@ -23,13 +22,13 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/jsonrepair"
 	"github.com/shariqriazz/modelgate/internal/registry"
 	"github.com/shariqriazz/modelgate/internal/util"
 	sdkAuth "github.com/shariqriazz/modelgate/sdk/auth"
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -121,7 +120,7 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 		auth = updatedAuth
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -140,8 +139,8 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 	requestedModel := payloadRequestedModel(opts, req.Model)
 	translated = applyPayloadConfigWithRoot(e.cfg, req.Model, "antigravity", "request", translated, originalTranslated, requestedModel)
 
-	baseURLs := antigravityBaseURLFallbackOrder(auth)
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	baseURLs := antigravityBaseURLFallbackOrder(e.cfg, auth)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 
 	var lastStatus int
 	var lastBody []byte
@@ -154,6 +153,7 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 			return resp, err
 		}
 
+		attemptStart := time.Now()
 		httpResp, errDo := httpClient.Do(httpReq)
 		if errDo != nil {
 			recordAPIResponseError(ctx, e.cfg, errDo)
@@ -164,7 +164,7 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 			lastBody = nil
 			lastErr = errDo
 			if idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
 			err = errDo
@@ -174,7 +174,7 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 		recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
 		bodyBytes, errRead := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("antigravity executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 		}
 		if errRead != nil {
 			recordAPIResponseError(ctx, e.cfg, errRead)
@@ -184,12 +184,12 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 		appendAPIResponseChunk(ctx, e.cfg, bodyBytes)
 
 		if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
-			log.Debugf("antigravity executor: upstream error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+			logEntry(ctx).Debugf("antigravity executor: upstream error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
 			lastStatus = httpResp.StatusCode
 			lastBody = append([]byte(nil), bodyBytes...)
 			lastErr = nil
 			if httpResp.StatusCode == http.StatusTooManyRequests && idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
 			sErr := statusErr{code: httpResp.StatusCode, msg: string(bodyBytes)}
@@ -202,6 +202,7 @@ func (e *AntigravityExecutor) Execute(ctx context.Context, auth *modelgateauth.A
 			return resp, err
 		}
 
+		antigravityEndpointLatencies.record(auth.ID, baseURL, time.Since(attemptStart), time.Now())
 		reporter.publish(ctx, parseAntigravityUsage(bodyBytes))
 		var param any
 		converted := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), translated, bodyBytes, &param)
@@ -237,7 +238,7 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 		auth = updatedAuth
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -256,8 +257,8 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 	requestedModel := payloadRequestedModel(opts, req.Model)
 	translated = applyPayloadConfigWithRoot(e.cfg, req.Model, "antigravity", "request", translated, originalTranslated, requestedModel)
 
-	baseURLs := antigravityBaseURLFallbackOrder(auth)
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	baseURLs := antigravityBaseURLFallbackOrder(e.cfg, auth)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 
 	var lastStatus int
 	var lastBody []byte
@@ -270,6 +271,7 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 			return resp, err
 		}
 
+		attemptStart := time.Now()
 		httpResp, errDo := httpClient.Do(httpReq)
 		if errDo != nil {
 			recordAPIResponseError(ctx, e.cfg, errDo)
@@ -280,7 +282,7 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 			lastBody = nil
 			lastErr = errDo
 			if idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
 			err = errDo
@@ -290,7 +292,7 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 		if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
 			bodyBytes, errRead := io.ReadAll(httpResp.Body)
 			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Errorf("antigravity executor: close response body error: %v", errClose)
+				logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 			}
 			if errRead != nil {
 				recordAPIResponseError(ctx, e.cfg, errRead)
@@ -306,7 +308,7 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 				lastBody = nil
 				lastErr = errRead
 				if idx+1 < len(baseURLs) {
-					log.Debugf("antigravity executor: read error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+					logEntry(ctx).Debugf("antigravity executor: read error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 					continue
 				}
 				err = errRead
@@ -317,7 +319,7 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 			lastBody = append([]byte(nil), bodyBytes...)
 			lastErr = nil
 			if httpResp.StatusCode == http.StatusTooManyRequests && idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
 			sErr := statusErr{code: httpResp.StatusCode, msg: string(bodyBytes)}
@@ -330,16 +332,16 @@ func (e *AntigravityExecutor) executeClaudeNonStream(ctx context.Context, auth *
 			return resp, err
 		}
 
+		antigravityEndpointLatencies.record(auth.ID, baseURL, time.Since(attemptStart), time.Now())
 		out := make(chan modelgateexecutor.StreamChunk)
 		go func(resp *http.Response) {
 			defer close(out)
 			defer func() {
 				if errClose := resp.Body.Close(); errClose != nil {
-					log.Errorf("antigravity executor: close response body error: %v", errClose)
+					logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 				}
 			}()
-			scanner := bufio.NewScanner(resp.Body)
-			scanner.Buffer(nil, streamScannerBuffer)
+			scanner := newSSEScanner(e.Identifier(), resp.Body)
 			for scanner.Scan() {
 				line := scanner.Bytes()
 				appendAPIResponseChunk(ctx, e.cfg, line)
@@ -591,7 +593,7 @@ func (e *AntigravityExecutor) convertStreamToNonStream(stream []byte) []byte {
 // streamValidationResult holds the result of validating a stream's first chunks
 type streamValidationResult struct {
 	bufferedChunks []modelgateexecutor.StreamChunk
-	scanner        *bufio.Scanner
+	scanner        *sseScanner
 	resp           *http.Response
 	needsRetry     bool
 	retryReason    string
@@ -616,8 +618,7 @@ func (e *AntigravityExecutor) validateStreamStart(
 		bufferedChunks: make([]modelgateexecutor.StreamChunk, 0, 8),
 	}
 
-	scanner := bufio.NewScanner(resp.Body)
-	scanner.Buffer(nil, streamScannerBuffer)
+	scanner := newSSEScanner(e.Identifier(), resp.Body)
 	result.scanner = scanner
 
 	contentChunkCount := 0
@@ -636,12 +637,12 @@ func (e *AntigravityExecutor) validateStreamStart(
 
 		// Check for MALFORMED_FUNCTION_CALL - this is retryable with auto-fix
 		if malformedMsg := checkForMalformedFunctionCall(payload); malformedMsg != "" {
-			log.Warnf("antigravity executor: MALFORMED_FUNCTION_CALL detected, attempting auto-fix")
+			logEntry(ctx).Warnf("antigravity executor: MALFORMED_FUNCTION_CALL detected, attempting auto-fix")
 			result.malformedJSON = malformedMsg
 
 			// Try to auto-fix the malformed JSON
-			if fixed, ok := attemptJSONRepair(malformedMsg); ok {
-				log.Infof("antigravity executor: successfully repaired malformed JSON")
+			if fixed, ok := jsonrepair.Repair(malformedMsg); ok {
+				logEntry(ctx).Infof("antigravity executor: successfully repaired malformed JSON")
 				// Create a synthetic valid tool call response
 				syntheticChunk := createRepairedToolCallChunk([]byte(fixed), model)
 				if syntheticChunk != nil {
@@ -661,7 +662,7 @@ func (e *AntigravityExecutor) validateStreamStart(
 			reporter.publish(ctx, detail)
 		}
 
-		chunks := sdktranslator.TranslateStream(ctx, to, from, model, bytes.Clone(originalRequest), bytes.Clone(translated), bytes.Clone(payload), param)
+		chunks := sdktranslator.TranslateStream(ctx, to, from, model, originalRequest, translated, bytes.Clone(payload), param)
 		for _, chunk := range chunks {
 			result.bufferedChunks = append(result.bufferedChunks, modelgateexecutor.StreamChunk{Payload: []byte(chunk)})
 			contentChunkCount++
@@ -746,7 +747,7 @@ func (e *AntigravityExecutor) ExecuteStream(ctx context.Context, auth *modelgate
 		auth = updatedAuth
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	isClaude := strings.Contains(strings.ToLower(req.Model), "claude")
@@ -767,8 +768,8 @@ func (e *AntigravityExecutor) ExecuteStream(ctx context.Context, auth *modelgate
 	requestedModel := payloadRequestedModel(opts, req.Model)
 	translated = applyPayloadConfigWithRoot(e.cfg, req.Model, "antigravity", "request", translated, originalTranslated, requestedModel)
 
-	baseURLs := antigravityBaseURLFallbackOrder(auth)
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	baseURLs := antigravityBaseURLFallbackOrder(e.cfg, auth)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 
 	attempts := antigravityRetryAttempts(auth, e.cfg)
 
@@ -786,6 +787,7 @@ attemptLoop:
 				return nil, err
 			}
 
+			attemptStart := time.Now()
 			httpResp, errDo := httpClient.Do(httpReq)
 			if errDo != nil {
 				recordAPIResponseError(ctx, e.cfg, errDo)
@@ -796,7 +798,7 @@ attemptLoop:
 				lastBody = nil
 				lastErr = errDo
 				if idx+1 < len(baseURLs) {
-					log.Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+					logEntry(ctx).Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 					continue
 				}
 				err = errDo
@@ -808,7 +810,7 @@ attemptLoop:
 			if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
 				bodyBytes, errRead := io.ReadAll(httpResp.Body)
 				if errClose := httpResp.Body.Close(); errClose != nil {
-					log.Errorf("antigravity executor: close response body error: %v", errClose)
+					logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 				}
 				if errRead != nil {
 					recordAPIResponseError(ctx, e.cfg, errRead)
@@ -824,7 +826,7 @@ attemptLoop:
 					lastBody = nil
 					lastErr = errRead
 					if idx+1 < len(baseURLs) {
-						log.Debugf("antigravity executor: read error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+						logEntry(ctx).Debugf("antigravity executor: read error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 						continue
 					}
 					err = errRead
@@ -841,7 +843,7 @@ attemptLoop:
 					if isBare429(httpResp.StatusCode, bodyBytes) {
 						if attempt < attempts-1 {
 							delay := antigravityNoCapacityRetryDelay(attempt)
-							log.Warnf("antigravity executor: bare 429 from %s, attempt %d/%d, retrying in %v...",
+							logEntry(ctx).Warnf("antigravity executor: bare 429 from %s, attempt %d/%d, retrying in %v...",
 								req.Model, attempt+1, attempts, delay)
 							if errWait := antigravityWait(ctx, delay); errWait != nil {
 								return nil, errWait
@@ -852,12 +854,12 @@ attemptLoop:
 					// Check for "no capacity" message (specific error)
 					if antigravityShouldRetryNoCapacity(httpResp.StatusCode, bodyBytes) {
 						if idx+1 < len(baseURLs) {
-							log.Debugf("antigravity executor: no capacity on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+							logEntry(ctx).Debugf("antigravity executor: no capacity on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 							continue
 						}
 						if attempt < attempts-1 {
 							delay := antigravityNoCapacityRetryDelay(attempt)
-							log.Debugf("antigravity executor: no capacity for model %s, retrying in %s (attempt %d/%d)", req.Model, delay, attempt+1, attempts)
+							logEntry(ctx).Debugf("antigravity executor: no capacity for model %s, retrying in %s (attempt %d/%d)", req.Model, delay, attempt+1, attempts)
 							if errWait := antigravityWait(ctx, delay); errWait != nil {
 								return nil, errWait
 							}
@@ -865,7 +867,7 @@ attemptLoop:
 						}
 					}
 					if idx+1 < len(baseURLs) {
-						log.Debugf("antigravity executor: rate limited on base url %s, retrying with fallback", baseURL)
+						logEntry(ctx).Debugf("antigravity executor: rate limited on base url %s, retrying with fallback", baseURL)
 						continue
 					}
 				}
@@ -880,18 +882,21 @@ attemptLoop:
 				return nil, err
 			}
 
-			// Success - validate stream start before returning channel
+			// Success - validate stream start before returning channel. The
+			// original request view is immutable and shared by every chunk.
+			antigravityEndpointLatencies.record(auth.ID, baseURL, time.Since(attemptStart), time.Now())
 			var param any
+			originalRequestView := bytes.Clone(opts.OriginalRequest)
 			validation := e.validateStreamStart(ctx, httpResp, to, from, req.Model,
-				bytes.Clone(opts.OriginalRequest), translated, &param, reporter)
+				originalRequestView, translated, &param, reporter)
 
 			// Check if we need to retry (empty response or malformed call)
 			if validation.needsRetry && attempt < attempts-1 {
 				delay := antigravityNoCapacityRetryDelay(attempt)
-				log.Warnf("antigravity executor: %s detected from %s, attempt %d/%d, retrying in %v...",
+				logEntry(ctx).Warnf("antigravity executor: %s detected from %s, attempt %d/%d, retrying in %v...",
 					validation.retryReason, req.Model, attempt+1, attempts, delay)
 				if errClose := httpResp.Body.Close(); errClose != nil {
-					log.Debugf("antigravity executor: close body on retry: %v", errClose)
+					logEntry(ctx).Debugf("antigravity executor: close body on retry: %v", errClose)
 				}
 				if errWait := antigravityWait(ctx, delay); errWait != nil {
 					return nil, errWait
@@ -907,7 +912,7 @@ attemptLoop:
 				defer close(out)
 				defer func() {
 					if errClose := v.resp.Body.Close(); errClose != nil {
-						log.Errorf("antigravity executor: close response body error: %v", errClose)
+						logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 					}
 				}()
 
@@ -932,9 +937,9 @@ attemptLoop:
 
 					// Check for MALFORMED_FUNCTION_CALL in remaining stream
 					if malformedMsg := checkForMalformedFunctionCall(payload); malformedMsg != "" {
-						log.Warnf("antigravity executor: MALFORMED_FUNCTION_CALL in stream: %s", malformedMsg[:min(100, len(malformedMsg))])
-						if fixed, ok := attemptJSONRepair(malformedMsg); ok {
-							log.Infof("antigravity executor: repaired malformed JSON in-stream")
+						logEntry(ctx).Warnf("antigravity executor: MALFORMED_FUNCTION_CALL in stream: %s", malformedMsg[:min(100, len(malformedMsg))])
+						if fixed, ok := jsonrepair.Repair(malformedMsg); ok {
+							logEntry(ctx).Infof("antigravity executor: repaired malformed JSON in-stream")
 							if syntheticChunk := createRepairedToolCallChunk([]byte(fixed), req.Model); syntheticChunk != nil {
 								out <- modelgateexecutor.StreamChunk{Payload: syntheticChunk}
 								chunkCount++
@@ -947,14 +952,14 @@ attemptLoop:
 						reporter.publish(ctx, detail)
 					}
 
-					chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), translated, bytes.Clone(payload), &param)
+					chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, translated, bytes.Clone(payload), &param)
 					for i := range chunks {
 						chunkCount++
 						out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
 					}
 				}
 
-				tail := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), translated, []byte("[DONE]"), &param)
+				tail := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, translated, []byte("[DONE]"), &param)
 				for i := range tail {
 					chunkCount++
 					out <- modelgateexecutor.StreamChunk{Payload: []byte(tail[i])}
@@ -966,7 +971,7 @@ attemptLoop:
 					out <- modelgateexecutor.StreamChunk{Err: errScan}
 				} else {
 					if chunkCount == 0 {
-						log.Warnf("antigravity executor: stream completed with zero content chunks (attempt %d)", attemptNum+1)
+						logEntry(ctx).Warnf("antigravity executor: stream completed with zero content chunks (attempt %d)", attemptNum+1)
 					}
 					reporter.ensurePublished(ctx)
 				}
@@ -1033,8 +1038,8 @@ func (e *AntigravityExecutor) CountTokens(ctx context.Context, auth *modelgateau
 
 	isClaude := strings.Contains(strings.ToLower(req.Model), "claude")
 
-	baseURLs := antigravityBaseURLFallbackOrder(auth)
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	baseURLs := antigravityBaseURLFallbackOrder(e.cfg, auth)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 
 	var authID, authLabel, authType, authValue string
 	if auth != nil {
@@ -1058,7 +1063,7 @@ func (e *AntigravityExecutor) CountTokens(ctx context.Context, auth *modelgateau
 
 		base := strings.TrimSuffix(baseURL, "/")
 		if base == "" {
-			base = buildBaseURL(auth)
+			base = buildBaseURL(e.cfg, auth)
 		}
 
 		var requestURL strings.Builder
@@ -1081,10 +1086,12 @@ func (e *AntigravityExecutor) CountTokens(ctx context.Context, auth *modelgateau
 			httpReq.Host = host
 		}
 
+		applyRequestOptions(httpReq, opts)
 		recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 			URL:       requestURL.String(),
 			Method:    http.MethodPost,
 			Headers:   httpReq.Header.Clone(),
+			Tags:      opts.Tags,
 			Body:      payload,
 			Provider:  e.Identifier(),
 			AuthID:    authID,
@@ -1103,7 +1110,7 @@ func (e *AntigravityExecutor) CountTokens(ctx context.Context, auth *modelgateau
 			lastBody = nil
 			lastErr = errDo
 			if idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
 			return modelgateexecutor.Response{}, errDo
@@ -1112,7 +1119,7 @@ func (e *AntigravityExecutor) CountTokens(ctx context.Context, auth *modelgateau
 		recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
 		bodyBytes, errRead := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("antigravity executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 		}
 		if errRead != nil {
 			recordAPIResponseError(ctx, e.cfg, errRead)
@@ -1130,7 +1137,7 @@ func (e *AntigravityExecutor) CountTokens(ctx context.Context, auth *modelgateau
 		lastBody = append([]byte(nil), bodyBytes...)
 		lastErr = nil
 		if httpResp.StatusCode == http.StatusTooManyRequests && idx+1 < len(baseURLs) {
-			log.Debugf("antigravity executor: rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+			logEntry(ctx).Debugf("antigravity executor: rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 			continue
 		}
 		sErr := statusErr{code: httpResp.StatusCode, msg: string(bodyBytes)}
@@ -1163,25 +1170,25 @@ func FetchAntigravityModels(ctx context.Context, auth *modelgateauth.Auth, cfg *
 	exec := &AntigravityExecutor{cfg: cfg}
 	token, updatedAuth, errToken := exec.ensureAccessToken(ctx, auth)
 	if errToken != nil {
-		log.Warnf("antigravity executor: fetch models failed for %s: token error: %v", auth.ID, errToken)
+		logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: token error: %v", auth.ID, errToken)
 		return nil
 	}
 	if token == "" {
-		log.Warnf("antigravity executor: fetch models failed for %s: got empty token", auth.ID)
+		logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: got empty token", auth.ID)
 		return nil
 	}
 	if updatedAuth != nil {
 		auth = updatedAuth
 	}
 
-	baseURLs := antigravityBaseURLFallbackOrder(auth)
+	baseURLs := antigravityBaseURLFallbackOrder(cfg, auth)
 	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
 
 	for idx, baseURL := range baseURLs {
 		modelsURL := baseURL + antigravityModelsPath
 		httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodPost, modelsURL, bytes.NewReader([]byte(`{}`)))
 		if errReq != nil {
-			log.Warnf("antigravity executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+			logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
 			return nil
 		}
 		httpReq.Header.Set("Content-Type", "application/json")
@@ -1194,41 +1201,41 @@ func FetchAntigravityModels(ctx context.Context, auth *modelgateauth.Auth, cfg *
 		httpResp, errDo := httpClient.Do(httpReq)
 		if errDo != nil {
 			if errors.Is(errDo, context.Canceled) || errors.Is(errDo, context.DeadlineExceeded) {
-				log.Warnf("antigravity executor: fetch models failed for %s: context canceled: %v", auth.ID, errDo)
+				logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: context canceled: %v", auth.ID, errDo)
 				return nil
 			}
 			if idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: models request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: models request error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
-			log.Warnf("antigravity executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+			logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
 			return nil
 		}
 
 		bodyBytes, errRead := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("antigravity executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 		}
 		if errRead != nil {
 			if idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: models read error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: models read error on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
-			log.Warnf("antigravity executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+			logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
 			return nil
 		}
 		if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
 			if httpResp.StatusCode == http.StatusTooManyRequests && idx+1 < len(baseURLs) {
-				log.Debugf("antigravity executor: models request rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
+				logEntry(ctx).Debugf("antigravity executor: models request rate limited on base url %s, retrying with fallback base url: %s", baseURL, baseURLs[idx+1])
 				continue
 			}
-			log.Warnf("antigravity executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, string(bodyBytes))
+			logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, string(bodyBytes))
 			return nil
 		}
 
 		result := gjson.GetBytes(bodyBytes, "models")
 		if !result.Exists() {
-			log.Warnf("antigravity executor: fetch models failed for %s: no models field in response, body: %s", auth.ID, string(bodyBytes))
+			logEntry(ctx).Warnf("antigravity executor: fetch models failed for %s: no models field in response, body: %s", auth.ID, string(bodyBytes))
 			return nil
 		}
 
@@ -1323,7 +1330,7 @@ func (e *AntigravityExecutor) refreshToken(ctx context.Context, auth *modelgatea
 	}
 	defer func() {
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("antigravity executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("antigravity executor: close response body error: %v", errClose)
 		}
 	}()
 
@@ -1365,11 +1372,24 @@ func (e *AntigravityExecutor) refreshToken(ctx context.Context, auth *modelgatea
 	auth.Metadata["expired"] = now.Add(time.Duration(tokenResp.ExpiresIn) * time.Second).Format(time.RFC3339)
 	auth.Metadata["type"] = antigravityAuthType
 	if errProject := e.ensureAntigravityProjectID(ctx, auth, tokenResp.AccessToken); errProject != nil {
-		log.Warnf("antigravity executor: ensure project id failed: %v", errProject)
+		logEntry(ctx).Warnf("antigravity executor: ensure project id failed: %v", errProject)
 	}
 	return auth, nil
 }
 
+// Warmup resolves the Antigravity project ID ahead of the first request. The
+// lookup normally happens during Refresh; warming it at boot spares the first
+// user call the extra round-trip for auths whose token is still fresh.
+func (e *AntigravityExecutor) Warmup(ctx context.Context, auth *modelgateauth.Auth) (bool, error) {
+	if auth == nil || auth.Metadata == nil || auth.Metadata["project_id"] != nil {
+		return false, nil
+	}
+	if err := e.ensureAntigravityProjectID(ctx, auth, ""); err != nil {
+		return false, err
+	}
+	return auth.Metadata["project_id"] != nil, nil
+}
+
 func (e *AntigravityExecutor) ensureAntigravityProjectID(ctx context.Context, auth *modelgateauth.Auth, accessToken string) error {
 	if auth == nil {
 		return nil
@@ -1410,7 +1430,7 @@ func (e *AntigravityExecutor) buildRequest(ctx context.Context, auth *modelgatea
 
 	base := strings.TrimSuffix(baseURL, "/")
 	if base == "" {
-		base = buildBaseURL(auth)
+		base = buildBaseURL(e.cfg, auth)
 	}
 	path := antigravityGeneratePath
 	if stream {
@@ -1564,8 +1584,8 @@ func int64Value(value any) (int64, bool) {
 	return 0, false
 }
 
-func buildBaseURL(auth *modelgateauth.Auth) string {
-	if baseURLs := antigravityBaseURLFallbackOrder(auth); len(baseURLs) > 0 {
+func buildBaseURL(cfg *config.Config, auth *modelgateauth.Auth) string {
+	if baseURLs := antigravityBaseURLFallbackOrder(cfg, auth); len(baseURLs) > 0 {
 		return baseURLs[0]
 	}
 	return antigravityBaseURLDaily
@@ -1654,15 +1674,22 @@ func antigravityWait(ctx context.Context, wait time.Duration) error {
 	}
 }
 
-func antigravityBaseURLFallbackOrder(auth *modelgateauth.Auth) []string {
+func antigravityBaseURLFallbackOrder(cfg *config.Config, auth *modelgateauth.Auth) []string {
 	if base := resolveCustomAntigravityBaseURL(auth); base != "" {
 		return []string{base}
 	}
-	return []string{
+	urls := []string{
 		antigravitySandboxBaseURLDaily,
 		antigravityBaseURLDaily,
 		antigravityBaseURLProd,
 	}
+	if auth != nil {
+		// Re-probe RTTs when stale and try the fastest region first instead of
+		// always walking the static order.
+		antigravityBaseURLRTTs.maybeProbe(cfg, auth, urls)
+		urls = antigravityBaseURLRTTs.order(auth.ID, urls, time.Now())
+	}
+	return urls
 }
 
 func resolveCustomAntigravityBaseURL(auth *modelgateauth.Auth) string {
@@ -1700,7 +1727,11 @@ func geminiToAntigravity(modelName string, payload []byte, projectID string) []b
 	template, _ = sjson.Set(template, "request.sessionId", generateStableSessionID(payload))
 
 	template, _ = sjson.Delete(template, "request.safetySettings")
-	template, _ = sjson.Set(template, "request.toolConfig.functionCallingConfig.mode", "VALIDATED")
+	// Default to VALIDATED function calling but respect a mode the client (or
+	// the strict-tool mapping in the translators) already chose.
+	if !gjson.Get(template, "request.toolConfig.functionCallingConfig.mode").Exists() {
+		template, _ = sjson.Set(template, "request.toolConfig.functionCallingConfig.mode", "VALIDATED")
+	}
 
 	if !strings.HasPrefix(modelName, "gemini-3-") && !strings.HasPrefix(modelName, "gemini-3.1-") {
 		if thinkingLevel := gjson.Get(template, "request.generationConfig.thinkingConfig.thinkingLevel"); thinkingLevel.Exists() {
@@ -1884,189 +1915,6 @@ func isBare429(statusCode int, body []byte) bool {
 	return err != nil || retryDelay == nil
 }
 
-// attemptJSONRepair tries to fix common JSON syntax errors in malformed function call arguments.
-// Returns the fixed JSON string and true if successful, or empty string and false if repair failed.
-// Handles: unquoted keys, single quotes, trailing commas.
-func attemptJSONRepair(raw string) (string, bool) {
-	raw = strings.TrimSpace(raw)
-	if raw == "" {
-		return "", false
-	}
-
-	// First, try to parse as-is
-	if json.Valid([]byte(raw)) {
-		return raw, true
-	}
-
-	fixed := raw
-
-	// Fix 1: Replace single quotes with double quotes (but be careful about escaped quotes)
-	// This is a simple approach that handles most cases
-	if strings.Contains(fixed, "'") {
-		// Simple replacement - may not handle all edge cases but covers common scenarios
-		inString := false
-		var result strings.Builder
-		for i := 0; i < len(fixed); i++ {
-			ch := fixed[i]
-			if ch == '"' && (i == 0 || fixed[i-1] != '\\') {
-				inString = !inString
-				result.WriteByte(ch)
-			} else if ch == '\'' && !inString {
-				result.WriteByte('"')
-			} else {
-				result.WriteByte(ch)
-			}
-		}
-		fixed = result.String()
-	}
-
-	// Fix 2: Add quotes around unquoted keys
-	// Pattern: {key: or ,key: where key is alphanumeric/underscore
-	// This is a simplified regex-like approach
-	fixed = fixUnquotedKeys(fixed)
-
-	// Fix 3: Remove trailing commas before } or ]
-	fixed = removeTrailingCommas(fixed)
-
-	// Check if fixed JSON is now valid
-	if json.Valid([]byte(fixed)) {
-		return fixed, true
-	}
-
-	return "", false
-}
-
-// fixUnquotedKeys adds double quotes around unquoted JSON keys.
-func fixUnquotedKeys(s string) string {
-	var result strings.Builder
-	i := 0
-	for i < len(s) {
-		ch := s[i]
-
-		// Skip strings
-		if ch == '"' {
-			result.WriteByte(ch)
-			i++
-			for i < len(s) && s[i] != '"' {
-				if s[i] == '\\' && i+1 < len(s) {
-					result.WriteByte(s[i])
-					i++
-				}
-				if i < len(s) {
-					result.WriteByte(s[i])
-					i++
-				}
-			}
-			if i < len(s) {
-				result.WriteByte(s[i])
-				i++
-			}
-			continue
-		}
-
-		// Look for unquoted keys after { or ,
-		if ch == '{' || ch == ',' {
-			result.WriteByte(ch)
-			i++
-
-			// Skip whitespace
-			for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
-				result.WriteByte(s[i])
-				i++
-			}
-
-			// Check if next is an unquoted identifier followed by :
-			if i < len(s) && isIdentifierStart(s[i]) {
-				keyStart := i
-				for i < len(s) && isIdentifierChar(s[i]) {
-					i++
-				}
-				key := s[keyStart:i]
-
-				// Skip whitespace before :
-				wsStart := i
-				for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
-					i++
-				}
-				ws := s[wsStart:i]
-
-				if i < len(s) && s[i] == ':' {
-					// This is an unquoted key - add quotes
-					result.WriteByte('"')
-					result.WriteString(key)
-					result.WriteByte('"')
-					result.WriteString(ws)
-				} else {
-					// Not a key, write as-is
-					result.WriteString(key)
-					result.WriteString(ws)
-				}
-			}
-			continue
-		}
-
-		result.WriteByte(ch)
-		i++
-	}
-	return result.String()
-}
-
-// removeTrailingCommas removes trailing commas before } or ].
-func removeTrailingCommas(s string) string {
-	var result strings.Builder
-	i := 0
-	for i < len(s) {
-		ch := s[i]
-
-		// Skip strings
-		if ch == '"' {
-			result.WriteByte(ch)
-			i++
-			for i < len(s) && s[i] != '"' {
-				if s[i] == '\\' && i+1 < len(s) {
-					result.WriteByte(s[i])
-					i++
-				}
-				if i < len(s) {
-					result.WriteByte(s[i])
-					i++
-				}
-			}
-			if i < len(s) {
-				result.WriteByte(s[i])
-				i++
-			}
-			continue
-		}
-
-		// Look for trailing commas
-		if ch == ',' {
-			// Look ahead to see if next non-whitespace is } or ]
-			j := i + 1
-			for j < len(s) && (s[j] == ' ' || s[j] == '\t' || s[j] == '\n' || s[j] == '\r') {
-				j++
-			}
-			if j < len(s) && (s[j] == '}' || s[j] == ']') {
-				// Skip this comma (trailing comma)
-				i++
-				continue
-			}
-		}
-
-		result.WriteByte(ch)
-		i++
-	}
-	return result.String()
-}
-
-func isIdentifierStart(ch byte) bool {
-	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '_' || ch == '$'
-}
-
-func isIdentifierChar(ch byte) bool {
-	return isIdentifierStart(ch) || (ch >= '0' && ch <= '9')
-}
-
 // checkForMalformedFunctionCall checks if a streaming response contains a MALFORMED_FUNCTION_CALL error.
 // Returns the error message if found, empty string otherwise.
 func checkForMalformedFunctionCall(chunk []byte) string {
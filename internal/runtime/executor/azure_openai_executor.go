@@ -0,0 +1,591 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	"github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const azureOpenAIDefaultAPIVersion = "2024-06-01"
+
+// AzureOpenAIExecutor executes OpenAI-compatible chat completions against Azure
+// OpenAI resources. Azure routes by deployment rather than model name, so the
+// executor rewrites paths to /openai/deployments/{name}/chat/completions using
+// the deployment mapping configured for the resource.
+type AzureOpenAIExecutor struct {
+	cfg *config.Config
+}
+
+// NewAzureOpenAIExecutor constructs a new executor instance.
+func NewAzureOpenAIExecutor(cfg *config.Config) *AzureOpenAIExecutor {
+	return &AzureOpenAIExecutor{cfg: cfg}
+}
+
+// Identifier returns the provider key.
+func (e *AzureOpenAIExecutor) Identifier() string { return "azure-openai" }
+
+// PrepareRequest injects Azure OpenAI credentials into the outgoing HTTP request.
+func (e *AzureOpenAIExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, _, _ := azureOpenAICreds(auth)
+	if strings.TrimSpace(apiKey) != "" {
+		req.Header.Set("Api-Key", apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects Azure OpenAI credentials into the request and executes it.
+func (e *AzureOpenAIExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("azure openai executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming chat completion request.
+func (e *AzureOpenAIExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, endpoint, apiVersion := azureOpenAICreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("azure openai executor: missing api key")
+		return resp, err
+	}
+	if endpoint == "" {
+		err = fmt.Errorf("azure openai executor: missing endpoint")
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, false)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	deployment := e.deploymentFor(auth, req.Model)
+	requestURL := azureOpenAIChatURL(endpoint, deployment, apiVersion)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyAzureOpenAIHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       requestURL,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("azure openai executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("azure openai request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = azureOpenAIStatusErr(httpResp.StatusCode, string(b), httpResp.Header)
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming chat completion request.
+func (e *AzureOpenAIExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, endpoint, apiVersion := azureOpenAICreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("azure openai executor: missing api key")
+		return nil, err
+	}
+	if endpoint == "" {
+		err = fmt.Errorf("azure openai executor: missing endpoint")
+		return nil, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, true)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	deployment := e.deploymentFor(auth, req.Model)
+	requestURL := azureOpenAIChatURL(endpoint, deployment, apiVersion)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyAzureOpenAIHeaders(httpReq, apiKey, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       requestURL,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("azure openai executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("azure openai streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = azureOpenAIStatusErr(httpResp.StatusCode, string(data), httpResp.Header)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("azure openai executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			if detail, ok := parseOpenAIStreamUsage(line); ok {
+				reporter.publish(ctx, detail)
+			}
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *AzureOpenAIExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("azure openai executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("azure openai executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// ExecuteImages performs an image generation request. Azure's images API is
+// OpenAI-shaped apart from the deployment path, so the payload is forwarded
+// as-is to the deployment serving the requested model.
+func (e *AzureOpenAIExecutor) ExecuteImages(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, endpoint, apiVersion := azureOpenAICreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("azure openai executor: missing api key")
+		return resp, err
+	}
+	if endpoint == "" {
+		err = fmt.Errorf("azure openai executor: missing endpoint")
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	body := bytes.Clone(req.Payload)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+
+	deployment := e.deploymentFor(auth, req.Model)
+	requestURL := azureOpenAIImagesURL(endpoint, deployment, apiVersion)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyAzureOpenAIHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       requestURL,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("azure openai executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("azure openai images error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = azureOpenAIStatusErr(httpResp.StatusCode, string(b), httpResp.Header)
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	// Images responses carry no token usage metadata; record the request itself.
+	reporter.ensurePublished(ctx)
+
+	resp = modelgateexecutor.Response{Payload: data}
+	return resp, nil
+}
+
+// ExecuteSpeech performs a text-to-speech request against the deployment
+// serving the requested model and streams the raw audio bytes back. Usage is
+// reported by input character count since audio responses carry no token
+// metadata.
+func (e *AzureOpenAIExecutor) ExecuteSpeech(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, endpoint, apiVersion := azureOpenAICreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("azure openai executor: missing api key")
+		return nil, err
+	}
+	if endpoint == "" {
+		err = fmt.Errorf("azure openai executor: missing endpoint")
+		return nil, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	body := bytes.Clone(req.Payload)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	inputChars := int64(len(gjson.GetBytes(body, "input").String()))
+
+	deployment := e.deploymentFor(auth, req.Model)
+	requestURL := azureOpenAISpeechURL(endpoint, deployment, apiVersion)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Api-Key", apiKey)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       requestURL,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("azure openai executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("azure openai speech error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = azureOpenAIStatusErr(httpResp.StatusCode, string(data), httpResp.Header)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("azure openai executor: close response body error: %v", errClose)
+			}
+		}()
+
+		buf := make([]byte, 32*1024)
+		for {
+			n, errRead := httpResp.Body.Read(buf)
+			if n > 0 {
+				out <- modelgateexecutor.StreamChunk{Payload: bytes.Clone(buf[:n])}
+			}
+			if errRead != nil {
+				if errRead != io.EOF {
+					recordAPIResponseError(ctx, e.cfg, errRead)
+					reporter.publishFailure(ctx)
+					out <- modelgateexecutor.StreamChunk{Err: errRead}
+					return
+				}
+				break
+			}
+		}
+		// Audio responses carry no token usage; report the input character count.
+		reporter.publish(ctx, usage.Detail{InputTokens: inputChars, TotalTokens: inputChars})
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+// Refresh is a no-op for API-key based Azure OpenAI credentials.
+func (e *AzureOpenAIExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+// deploymentFor resolves the deployment name serving the requested model. When
+// the model is not mapped the model name itself is used, which matches Azure
+// setups that name deployments after the models they serve.
+func (e *AzureOpenAIExecutor) deploymentFor(auth *modelgateauth.Auth, model string) string {
+	entry := azureOpenAIConfigEntry(e.cfg, auth)
+	if entry != nil {
+		for i := range entry.Deployments {
+			dep := entry.Deployments[i]
+			if strings.EqualFold(strings.TrimSpace(dep.Model), strings.TrimSpace(model)) && strings.TrimSpace(dep.Name) != "" {
+				return strings.TrimSpace(dep.Name)
+			}
+		}
+	}
+	return strings.TrimSpace(model)
+}
+
+// azureOpenAIConfigEntry matches the auth back to its config entry so the
+// executor can consult the deployment mapping.
+func azureOpenAIConfigEntry(cfg *config.Config, auth *modelgateauth.Auth) *config.AzureOpenAIKey {
+	if cfg == nil || auth == nil {
+		return nil
+	}
+	var attrKey, attrEndpoint string
+	if auth.Attributes != nil {
+		attrKey = strings.TrimSpace(auth.Attributes["api_key"])
+		attrEndpoint = strings.TrimSpace(auth.Attributes["base_url"])
+	}
+	for i := range cfg.AzureOpenAIKey {
+		entry := &cfg.AzureOpenAIKey[i]
+		if attrKey != "" && strings.EqualFold(strings.TrimSpace(entry.APIKey), attrKey) &&
+			strings.EqualFold(strings.TrimSpace(entry.Endpoint), attrEndpoint) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func azureOpenAIChatURL(endpoint, deployment, apiVersion string) string {
+	if apiVersion == "" {
+		apiVersion = azureOpenAIDefaultAPIVersion
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/openai/deployments/" + url.PathEscape(deployment) +
+		"/chat/completions?api-version=" + url.QueryEscape(apiVersion)
+}
+
+func azureOpenAIImagesURL(endpoint, deployment, apiVersion string) string {
+	if apiVersion == "" {
+		apiVersion = azureOpenAIDefaultAPIVersion
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/openai/deployments/" + url.PathEscape(deployment) +
+		"/images/generations?api-version=" + url.QueryEscape(apiVersion)
+}
+
+func azureOpenAISpeechURL(endpoint, deployment, apiVersion string) string {
+	if apiVersion == "" {
+		apiVersion = azureOpenAIDefaultAPIVersion
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/openai/deployments/" + url.PathEscape(deployment) +
+		"/audio/speech?api-version=" + url.QueryEscape(apiVersion)
+}
+
+func applyAzureOpenAIHeaders(r *http.Request, apiKey string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Api-Key", apiKey)
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+}
+
+func azureOpenAICreds(a *modelgateauth.Auth) (apiKey, endpoint, apiVersion string) {
+	if a == nil {
+		return "", "", ""
+	}
+	if a.Attributes != nil {
+		apiKey = strings.TrimSpace(a.Attributes["api_key"])
+		endpoint = strings.TrimSpace(a.Attributes["base_url"])
+		apiVersion = strings.TrimSpace(a.Attributes["api_version"])
+	}
+	if apiKey == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["api_key"].(string); ok {
+			apiKey = strings.TrimSpace(v)
+		}
+	}
+	if endpoint == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["endpoint"].(string); ok {
+			endpoint = strings.TrimSpace(v)
+		}
+	}
+	return apiKey, endpoint, apiVersion
+}
+
+// azureOpenAIStatusErr builds a statusErr for an upstream failure, honoring the
+// Retry-After header Azure sends with 429 responses.
+func azureOpenAIStatusErr(code int, body string, headers http.Header) statusErr {
+	sErr := statusErr{code: code, msg: body}
+	if code != http.StatusTooManyRequests || headers == nil {
+		return sErr
+	}
+	if raw := strings.TrimSpace(headers.Get("Retry-After")); raw != "" {
+		if seconds, err := strconv.ParseFloat(raw, 64); err == nil && seconds > 0 {
+			d := time.Duration(seconds * float64(time.Second))
+			sErr.retryAfter = &d
+		}
+	}
+	return sErr
+}
@@ -0,0 +1,141 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+const (
+	// baseURLProbeInterval is the minimum time between probe cycles per auth.
+	baseURLProbeInterval = 5 * time.Minute
+	// baseURLProbeTimeout bounds each individual probe request.
+	baseURLProbeTimeout = 3 * time.Second
+	// baseURLProbeMaxAge is how long a probe result stays usable for ordering;
+	// older results fall back to the static position.
+	baseURLProbeMaxAge = 3 * baseURLProbeInterval
+)
+
+// baseURLProbe is the measured round-trip time for one base URL.
+type baseURLProbe struct {
+	rtt        time.Duration
+	reachable  bool
+	measuredAt time.Time
+}
+
+// baseURLRTTTracker measures round-trip times to regional base URLs and orders
+// fallback lists fastest-first. RTTs are tracked per auth because per-auth
+// proxies make the network path auth specific. Probing is traffic driven: a
+// stale auth is re-probed asynchronously the next time its fallback list is
+// requested, so idle auths cost nothing.
+type baseURLRTTTracker struct {
+	mu       sync.Mutex
+	probes   map[string]map[string]baseURLProbe
+	inflight map[string]bool
+	lastRun  map[string]time.Time
+}
+
+var antigravityBaseURLRTTs = newBaseURLRTTTracker()
+
+func newBaseURLRTTTracker() *baseURLRTTTracker {
+	return &baseURLRTTTracker{
+		probes:   make(map[string]map[string]baseURLProbe),
+		inflight: make(map[string]bool),
+		lastRun:  make(map[string]time.Time),
+	}
+}
+
+// maybeProbe kicks off an asynchronous probe cycle for the auth when the last
+// one is older than the probe interval. At most one cycle runs per auth.
+func (t *baseURLRTTTracker) maybeProbe(cfg *config.Config, auth *modelgateauth.Auth, urls []string) {
+	if t == nil || auth == nil || auth.ID == "" || len(urls) < 2 {
+		return
+	}
+	now := time.Now()
+	t.mu.Lock()
+	if t.inflight[auth.ID] || now.Sub(t.lastRun[auth.ID]) < baseURLProbeInterval {
+		t.mu.Unlock()
+		return
+	}
+	t.inflight[auth.ID] = true
+	t.lastRun[auth.ID] = now
+	t.mu.Unlock()
+	go t.probeCycle(cfg, auth.Clone(), append([]string(nil), urls...))
+}
+
+func (t *baseURLRTTTracker) probeCycle(cfg *config.Config, auth *modelgateauth.Auth, urls []string) {
+	client := newProxyAwareHTTPClient(context.Background(), cfg, auth, baseURLProbeTimeout)
+	results := make(map[string]baseURLProbe, len(urls))
+	for _, base := range urls {
+		rtt, reachable := probeBaseURL(client, base)
+		results[base] = baseURLProbe{rtt: rtt, reachable: reachable, measuredAt: time.Now()}
+	}
+	t.mu.Lock()
+	t.probes[auth.ID] = results
+	delete(t.inflight, auth.ID)
+	t.mu.Unlock()
+}
+
+// probeBaseURL measures the round trip to the endpoint. Any HTTP status counts
+// as reachable; only transport failures and timeouts do not.
+func probeBaseURL(client *http.Client, base string) (time.Duration, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), baseURLProbeTimeout)
+	defer cancel()
+	probeReq, err := http.NewRequestWithContext(ctx, http.MethodHead, base+"/", nil)
+	if err != nil {
+		return 0, false
+	}
+	start := time.Now()
+	resp, err := client.Do(probeReq)
+	if err != nil {
+		return 0, false
+	}
+	_ = resp.Body.Close()
+	return time.Since(start), true
+}
+
+// order returns the URLs sorted fastest-first. Endpoints with enough fresh
+// observed request latency come first in ascending p50 order, then reachable
+// probed endpoints in ascending RTT order, then unprobed (or stale) ones in
+// their static position, with unreachable ones last. Observed latency ranks
+// above probe RTTs because it reflects real traffic; probes keep ordering the
+// endpoints that currently receive none.
+func (t *baseURLRTTTracker) order(authID string, urls []string, now time.Time) []string {
+	if t == nil || authID == "" || len(urls) < 2 {
+		return urls
+	}
+	t.mu.Lock()
+	probes := t.probes[authID]
+	t.mu.Unlock()
+	if len(probes) == 0 {
+		return urls
+	}
+	rank := func(base string) (int, time.Duration) {
+		if p50, _, n := antigravityEndpointLatencies.percentiles(authID, base, now); n >= endpointLatencyMinSamples {
+			return 0, p50
+		}
+		probe, ok := probes[base]
+		if !ok || now.Sub(probe.measuredAt) > baseURLProbeMaxAge {
+			return 2, 0
+		}
+		if !probe.reachable {
+			return 3, 0
+		}
+		return 1, probe.rtt
+	}
+	ordered := append([]string(nil), urls...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		rankI, rttI := rank(ordered[i])
+		rankJ, rttJ := rank(ordered[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return rankI <= 1 && rttI < rttJ
+	})
+	return ordered
+}
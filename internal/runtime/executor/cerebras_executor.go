@@ -0,0 +1,375 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	cerebrasDefaultBaseURL  = "https://api.cerebras.ai/v1"
+	cerebrasDefaultEndpoint = "/chat/completions"
+)
+
+// CerebrasExecutor executes OpenAI-compatible chat completions against the Cerebras Cloud API using config-provided API keys.
+type CerebrasExecutor struct {
+	cfg *config.Config
+}
+
+// NewCerebrasExecutor constructs a new executor instance.
+func NewCerebrasExecutor(cfg *config.Config) *CerebrasExecutor { return &CerebrasExecutor{cfg: cfg} }
+
+// Identifier returns the provider key.
+func (e *CerebrasExecutor) Identifier() string { return "cerebras" }
+
+// PrepareRequest injects Cerebras credentials into the outgoing HTTP request.
+func (e *CerebrasExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, _ := cerebrasCreds(auth)
+	if strings.TrimSpace(apiKey) != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects Cerebras credentials into the request and executes it.
+func (e *CerebrasExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("cerebras executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming chat completion request.
+func (e *CerebrasExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := cerebrasCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("cerebras executor: missing api key")
+		return resp, err
+	}
+	if baseURL == "" {
+		baseURL = cerebrasDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, false)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + cerebrasDefaultEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyCerebrasHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cerebras executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("cerebras request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = cerebrasStatusErr(httpResp.StatusCode, string(b), httpResp.Header)
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming chat completion request.
+func (e *CerebrasExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, baseURL := cerebrasCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("cerebras executor: missing api key")
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = cerebrasDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, true)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + cerebrasDefaultEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyCerebrasHeaders(httpReq, apiKey, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cerebras executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("cerebras streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = cerebrasStatusErr(httpResp.StatusCode, string(data), httpResp.Header)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("cerebras executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			if detail, ok := parseOpenAIStreamUsage(line); ok {
+				reporter.publish(ctx, detail)
+			}
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *CerebrasExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("cerebras executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("cerebras executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// Refresh is a no-op for API-key based Cerebras credentials.
+func (e *CerebrasExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+func applyCerebrasHeaders(r *http.Request, apiKey string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+apiKey)
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+}
+
+func cerebrasCreds(a *modelgateauth.Auth) (apiKey, baseURL string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.Attributes != nil {
+		if v := strings.TrimSpace(a.Attributes["api_key"]); v != "" {
+			apiKey = v
+		}
+		if v := strings.TrimSpace(a.Attributes["base_url"]); v != "" {
+			baseURL = v
+		}
+	}
+	if apiKey == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["api_key"].(string); ok {
+			apiKey = strings.TrimSpace(v)
+		}
+	}
+	if baseURL == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["base_url"].(string); ok {
+			baseURL = strings.TrimSpace(v)
+		}
+	}
+	return apiKey, baseURL
+}
+
+// cerebrasStatusErr builds a statusErr for an upstream failure, feeding the
+// Cerebras rate-limit headers into the retry hint so the scheduler can cool
+// the key until the reported window resets.
+func cerebrasStatusErr(code int, body string, headers http.Header) statusErr {
+	sErr := statusErr{code: code, msg: body}
+	if code != http.StatusTooManyRequests {
+		return sErr
+	}
+	if retryAfter := cerebrasRetryAfter(headers); retryAfter != nil {
+		sErr.retryAfter = retryAfter
+	}
+	return sErr
+}
+
+// cerebrasRetryAfter derives a retry delay from Cerebras rate-limit headers.
+// The per-minute token window resets quickly, so prefer it when the minute
+// budget is exhausted; otherwise fall back to the daily request window or a
+// plain Retry-After header.
+func cerebrasRetryAfter(headers http.Header) *time.Duration {
+	if headers == nil {
+		return nil
+	}
+	if remaining, ok := cerebrasHeaderFloat(headers, "X-Ratelimit-Remaining-Tokens-Minute"); ok && remaining <= 0 {
+		if reset, okReset := cerebrasHeaderFloat(headers, "X-Ratelimit-Reset-Tokens-Minute"); okReset && reset > 0 {
+			d := time.Duration(reset * float64(time.Second))
+			return &d
+		}
+	}
+	if remaining, ok := cerebrasHeaderFloat(headers, "X-Ratelimit-Remaining-Requests-Day"); ok && remaining <= 0 {
+		if reset, okReset := cerebrasHeaderFloat(headers, "X-Ratelimit-Reset-Requests-Day"); okReset && reset > 0 {
+			d := time.Duration(reset * float64(time.Second))
+			return &d
+		}
+	}
+	if reset, ok := cerebrasHeaderFloat(headers, "Retry-After"); ok && reset > 0 {
+		d := time.Duration(reset * float64(time.Second))
+		return &d
+	}
+	return nil
+}
+
+func cerebrasHeaderFloat(headers http.Header, key string) (float64, bool) {
+	raw := strings.TrimSpace(headers.Get(key))
+	if raw == "" {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,381 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	claudeauth "github.com/shariqriazz/modelgate/internal/auth/claude"
+	"github.com/shariqriazz/modelgate/internal/config"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	claudeMessagesEndpoint = "/v1/messages"
+	claudeAPIVersion       = "2023-06-01"
+)
+
+// ClaudeExecutor executes Anthropic Messages API requests using either config
+// API keys or claude.ai consumer OAuth tokens (Pro/Max subscriptions).
+type ClaudeExecutor struct {
+	cfg *config.Config
+}
+
+// NewClaudeExecutor constructs a new executor instance.
+func NewClaudeExecutor(cfg *config.Config) *ClaudeExecutor { return &ClaudeExecutor{cfg: cfg} }
+
+// Identifier returns the provider key.
+func (e *ClaudeExecutor) Identifier() string { return "claude" }
+
+// PrepareRequest injects Anthropic credentials into the outgoing HTTP request.
+func (e *ClaudeExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, accessToken, _ := claudeCreds(auth)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		req.Header.Set("anthropic-beta", claudeauth.OAuthBetaHeader)
+	} else if apiKey != "" {
+		req.Header.Set("x-api-key", apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects Anthropic credentials into the request and executes it.
+func (e *ClaudeExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("claude executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming messages request.
+func (e *ClaudeExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, accessToken, baseURL := claudeCreds(auth)
+	if apiKey == "" && accessToken == "" {
+		err = fmt.Errorf("claude executor: missing credentials")
+		return resp, err
+	}
+	if baseURL == "" {
+		baseURL = claudeauth.DefaultAPIBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("claude")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, false)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + claudeMessagesEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyClaudeHeaders(httpReq, apiKey, accessToken, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("claude executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	applyClaudeRateLimitHeaders(ctx, auth, opts, httpResp.Header)
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("claude request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseClaudeUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming messages request.
+func (e *ClaudeExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, accessToken, baseURL := claudeCreds(auth)
+	if apiKey == "" && accessToken == "" {
+		err = fmt.Errorf("claude executor: missing credentials")
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = claudeauth.DefaultAPIBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("claude")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, true)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	body, _ = sjson.SetBytes(body, "stream", true)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + claudeMessagesEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyClaudeHeaders(httpReq, apiKey, accessToken, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	applyClaudeRateLimitHeaders(ctx, auth, opts, httpResp.Header)
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("claude executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("claude streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("claude executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		// When no response translator is registered (claude→claude passthrough),
+		// raw scanner lines are returned without newlines and SSE framing must be
+		// restored before handing chunks to the handler.
+		ssePassthrough := !sdktranslator.HasResponseTransformer(from, to)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			if detail, ok := parseClaudeStreamUsage(line); ok {
+				reporter.publish(ctx, detail)
+			}
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
+			if ssePassthrough {
+				for i := range chunks {
+					chunks[i] = chunks[i] + "\n"
+				}
+			}
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+// CountTokens estimates token usage locally using the Claude-adjusted tokenizer.
+func (e *ClaudeExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("claude executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("claude executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// Refresh renews the consumer OAuth access token when a refresh token is stored.
+// API-key based auths pass through unchanged.
+func (e *ClaudeExecutor) Refresh(ctx context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	logEntry(ctx).Debugf("claude executor: refresh called")
+	if auth == nil {
+		return nil, fmt.Errorf("claude executor: auth is nil")
+	}
+
+	refreshToken := metaStringValue(auth.Metadata, "refresh_token")
+	if refreshToken == "" {
+		return auth, nil
+	}
+
+	svc := claudeauth.NewClaudeAuth(e.cfg)
+	tokenData, err := svc.RefreshTokens(ctx, refreshToken)
+	if err != nil {
+		logEntry(ctx).Errorf("claude executor: token refresh failed: %v", err)
+		return nil, err
+	}
+
+	if auth.Metadata == nil {
+		auth.Metadata = make(map[string]any)
+	}
+	auth.Metadata["access_token"] = tokenData.AccessToken
+	if tokenData.RefreshToken != "" {
+		auth.Metadata["refresh_token"] = tokenData.RefreshToken
+	}
+	auth.Metadata["expired"] = tokenData.Expire
+	auth.Metadata["type"] = "claude"
+	auth.Metadata["last_refresh"] = time.Now().Format(time.RFC3339)
+
+	return auth, nil
+}
+
+func applyClaudeHeaders(r *http.Request, apiKey, accessToken string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("anthropic-version", claudeAPIVersion)
+	if accessToken != "" {
+		r.Header.Set("Authorization", "Bearer "+accessToken)
+		r.Header.Set("anthropic-beta", claudeauth.OAuthBetaHeader)
+	} else {
+		r.Header.Set("x-api-key", apiKey)
+	}
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+}
+
+// claudeCreds resolves credentials for an auth entry. Config API keys live in
+// Attributes; OAuth tokens minted by the login flow live in Metadata.
+func claudeCreds(a *modelgateauth.Auth) (apiKey, accessToken, baseURL string) {
+	if a == nil {
+		return "", "", ""
+	}
+	if a.Attributes != nil {
+		if v := strings.TrimSpace(a.Attributes["api_key"]); v != "" {
+			apiKey = v
+		}
+		if v := strings.TrimSpace(a.Attributes["base_url"]); v != "" {
+			baseURL = v
+		}
+	}
+	if a.Metadata != nil {
+		if v, ok := a.Metadata["access_token"].(string); ok {
+			accessToken = strings.TrimSpace(v)
+		}
+		if apiKey == "" {
+			if v, ok := a.Metadata["api_key"].(string); ok {
+				apiKey = strings.TrimSpace(v)
+			}
+		}
+		if baseURL == "" {
+			if v, ok := a.Metadata["base_url"].(string); ok {
+				baseURL = strings.TrimSpace(v)
+			}
+		}
+	}
+	return apiKey, accessToken, baseURL
+}
@@ -0,0 +1,558 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	cloudflareDefaultBaseURL = "https://api.cloudflare.com"
+	cloudflareRunPath        = "/ai/run/"
+	cloudflareModelsPath     = "/ai/models/search"
+)
+
+// CloudflareExecutor executes inference against Cloudflare Workers AI using
+// the account-scoped /client/v4/accounts/{id}/ai/run endpoint. Requests are
+// translated through the OpenAI chat format and converted to the Workers AI
+// request/response shapes, so the inexpensive edge-hosted models can serve as
+// fallbacks in routing chains alongside the cloud providers.
+type CloudflareExecutor struct {
+	cfg *config.Config
+}
+
+// NewCloudflareExecutor constructs a new executor instance.
+func NewCloudflareExecutor(cfg *config.Config) *CloudflareExecutor {
+	return &CloudflareExecutor{cfg: cfg}
+}
+
+// Identifier returns the provider key.
+func (e *CloudflareExecutor) Identifier() string { return "cloudflare" }
+
+// PrepareRequest injects the Cloudflare API token into the outgoing HTTP request.
+func (e *CloudflareExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiToken, _, _ := cloudflareCreds(auth)
+	if apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+apiToken)
+	}
+	return nil
+}
+
+// HttpRequest injects Cloudflare credentials into the request and executes it.
+func (e *CloudflareExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("cloudflare executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming inference request.
+func (e *CloudflareExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiToken, accountID, baseURL := cloudflareCreds(auth)
+	if apiToken == "" || accountID == "" {
+		err = fmt.Errorf("cloudflare executor: missing api token or account id")
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	openAIBody := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	openAIBody, _ = sjson.SetBytes(openAIBody, "model", req.Model)
+	body := cloudflareRunRequestFromOpenAI(openAIBody, false)
+
+	endpoint := cloudflareRunEndpoint(baseURL, accountID, req.Model)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyCloudflareHeaders(httpReq, apiToken, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cloudflare executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("cloudflare request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
+		return resp, err
+	}
+
+	raw, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, raw)
+	data := cloudflareResponseToOpenAI(raw, req.Model)
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), openAIBody, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming inference request. Workers AI streams SSE
+// events carrying incremental response text; each one is converted to an
+// OpenAI chunk before translation.
+func (e *CloudflareExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiToken, accountID, baseURL := cloudflareCreds(auth)
+	if apiToken == "" || accountID == "" {
+		err = fmt.Errorf("cloudflare executor: missing api token or account id")
+		return nil, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	openAIBody := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	openAIBody, _ = sjson.SetBytes(openAIBody, "model", req.Model)
+	body := cloudflareRunRequestFromOpenAI(openAIBody, true)
+
+	endpoint := cloudflareRunEndpoint(baseURL, accountID, req.Model)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyCloudflareHeaders(httpReq, apiToken, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cloudflare executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("cloudflare streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("cloudflare executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		emit := func(line []byte) {
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, openAIBody, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			payload := jsonPayload(line)
+			if len(payload) == 0 {
+				continue
+			}
+			if bytes.Equal(bytes.TrimSpace(payload), []byte("[DONE]")) {
+				// Workers AI has no terminal finish_reason event, so one is
+				// synthesized before the stream is closed out.
+				emit(append([]byte("data: "), cloudflareFinishChunk(req.Model)...))
+				emit([]byte("data: [DONE]"))
+				break
+			}
+			converted := cloudflareChunkToOpenAI(payload, req.Model)
+			if len(converted) == 0 {
+				continue
+			}
+			if detail, ok := parseOpenAIStreamUsage(append([]byte("data: "), converted...)); ok {
+				reporter.publish(ctx, detail)
+			}
+			emit(append([]byte("data: "), converted...))
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *CloudflareExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("cloudflare executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("cloudflare executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// Refresh is a no-op for token based Cloudflare credentials.
+func (e *CloudflareExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+// FetchCloudflareModels lists the Workers AI text generation catalog for the
+// account via /ai/models/search so the models can be registered for routing.
+func FetchCloudflareModels(ctx context.Context, auth *modelgateauth.Auth, cfg *config.Config) []*registry.ModelInfo {
+	apiToken, accountID, baseURL := cloudflareCreds(auth)
+	if apiToken == "" || accountID == "" {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models failed for %s: missing credentials", auth.ID)
+		return nil
+	}
+
+	modelsURL := cloudflareAccountBase(baseURL, accountID) + cloudflareModelsPath + "?per_page=100"
+	httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if errReq != nil {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+		return nil
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+apiToken)
+	httpReq.Header.Set("Accept", "application/json")
+
+	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
+	httpResp, errDo := httpClient.Do(httpReq)
+	if errDo != nil {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+		return nil
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cloudflare executor: close response body error: %v", errClose)
+		}
+	}()
+	bodyBytes, errRead := io.ReadAll(httpResp.Body)
+	if errRead != nil {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+		return nil
+	}
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+		return nil
+	}
+
+	list := gjson.GetBytes(bodyBytes, "result")
+	if !list.Exists() || !list.IsArray() {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models failed for %s: no result field in response", auth.ID)
+		return nil
+	}
+
+	now := time.Now().Unix()
+	models := make([]*registry.ModelInfo, 0, len(list.Array()))
+	for _, entry := range list.Array() {
+		// The search endpoint returns every task type; only chat-capable
+		// text generation models are routable through this executor.
+		if entry.Get("task.name").String() != "Text Generation" {
+			continue
+		}
+		id := strings.TrimSpace(entry.Get("name").String())
+		if id == "" {
+			continue
+		}
+		description := strings.TrimSpace(entry.Get("description").String())
+		if description == "" {
+			description = id
+		}
+		models = append(models, &registry.ModelInfo{
+			ID:          id,
+			Object:      "model",
+			Created:     now,
+			OwnedBy:     "cloudflare",
+			Type:        "cloudflare",
+			DisplayName: id,
+			Description: description,
+		})
+	}
+	if len(models) == 0 {
+		logEntry(ctx).Warnf("cloudflare executor: fetch models returned no models for %s", auth.ID)
+		return nil
+	}
+	return models
+}
+
+func applyCloudflareHeaders(r *http.Request, apiToken string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+apiToken)
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+}
+
+func cloudflareCreds(a *modelgateauth.Auth) (apiToken, accountID, baseURL string) {
+	if a == nil {
+		return "", "", ""
+	}
+	if a.Attributes != nil {
+		apiToken = strings.TrimSpace(a.Attributes["api_key"])
+		accountID = strings.TrimSpace(a.Attributes["account_id"])
+		baseURL = strings.TrimSpace(a.Attributes["base_url"])
+	}
+	return apiToken, accountID, baseURL
+}
+
+// cloudflareAccountBase builds the account-scoped Workers AI base URL.
+func cloudflareAccountBase(baseURL, accountID string) string {
+	if baseURL == "" {
+		baseURL = cloudflareDefaultBaseURL
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/client/v4/accounts/" + url.PathEscape(accountID)
+}
+
+// cloudflareRunEndpoint builds the /ai/run URL for a model. Workers AI model
+// names contain slashes (e.g. "@cf/meta/llama-3.1-8b-instruct") which must
+// stay literal path segments.
+func cloudflareRunEndpoint(baseURL, accountID, model string) string {
+	return cloudflareAccountBase(baseURL, accountID) + cloudflareRunPath + model
+}
+
+// cloudflareRunRequestFromOpenAI converts an OpenAI chat completion request
+// into the Workers AI /ai/run shape. Messages keep their roles but multi-part
+// text content is flattened, since Workers AI expects plain strings.
+func cloudflareRunRequestFromOpenAI(openAIBody []byte, stream bool) []byte {
+	root := gjson.ParseBytes(openAIBody)
+	out := []byte(`{}`)
+	out, _ = sjson.SetBytes(out, "stream", stream)
+
+	messages := make([]map[string]any, 0)
+	for _, msg := range root.Get("messages").Array() {
+		entry := map[string]any{"role": msg.Get("role").String()}
+		content := msg.Get("content")
+		if content.IsArray() {
+			var sb strings.Builder
+			for _, part := range content.Array() {
+				if part.Get("type").String() == "text" {
+					sb.WriteString(part.Get("text").String())
+				}
+			}
+			entry["content"] = sb.String()
+		} else {
+			entry["content"] = content.String()
+		}
+		messages = append(messages, entry)
+	}
+	out, _ = sjson.SetBytes(out, "messages", messages)
+
+	if tools := root.Get("tools"); tools.IsArray() {
+		out, _ = sjson.SetRawBytes(out, "tools", []byte(tools.Raw))
+	}
+	if v := root.Get("temperature"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "temperature", v.Float())
+	}
+	if v := root.Get("top_p"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "top_p", v.Float())
+	}
+	if v := root.Get("max_tokens"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "max_tokens", v.Int())
+	}
+	return out
+}
+
+// cloudflareResponseToOpenAI converts a non-streaming /ai/run response
+// envelope into an OpenAI chat completion object.
+func cloudflareResponseToOpenAI(raw []byte, model string) []byte {
+	result := gjson.GetBytes(raw, "result")
+	now := time.Now().Unix()
+	out := []byte(`{"object":"chat.completion"}`)
+	out, _ = sjson.SetBytes(out, "id", fmt.Sprintf("chatcmpl-%d", now))
+	out, _ = sjson.SetBytes(out, "created", now)
+	out, _ = sjson.SetBytes(out, "model", model)
+
+	message := map[string]any{
+		"role":    "assistant",
+		"content": result.Get("response").String(),
+	}
+	finishReason := "stop"
+	if calls := result.Get("tool_calls"); calls.IsArray() && len(calls.Array()) > 0 {
+		message["tool_calls"] = cloudflareToolCallsToOpenAI(calls)
+		finishReason = "tool_calls"
+	}
+	out, _ = sjson.SetBytes(out, "choices.0.index", 0)
+	out, _ = sjson.SetBytes(out, "choices.0.message", message)
+	out, _ = sjson.SetBytes(out, "choices.0.finish_reason", finishReason)
+
+	if usageNode := result.Get("usage"); usageNode.Exists() {
+		promptTokens := usageNode.Get("prompt_tokens").Int()
+		completionTokens := usageNode.Get("completion_tokens").Int()
+		out, _ = sjson.SetBytes(out, "usage.prompt_tokens", promptTokens)
+		out, _ = sjson.SetBytes(out, "usage.completion_tokens", completionTokens)
+		out, _ = sjson.SetBytes(out, "usage.total_tokens", promptTokens+completionTokens)
+	}
+	return out
+}
+
+// cloudflareChunkToOpenAI converts one Workers AI SSE payload into an OpenAI
+// chat completion chunk.
+func cloudflareChunkToOpenAI(payload []byte, model string) []byte {
+	root := gjson.ParseBytes(payload)
+	if !root.IsObject() {
+		return nil
+	}
+	now := time.Now().Unix()
+	out := []byte(`{"object":"chat.completion.chunk"}`)
+	out, _ = sjson.SetBytes(out, "id", fmt.Sprintf("chatcmpl-%d", now))
+	out, _ = sjson.SetBytes(out, "created", now)
+	out, _ = sjson.SetBytes(out, "model", model)
+	out, _ = sjson.SetBytes(out, "choices.0.index", 0)
+
+	delta := map[string]any{}
+	if content := root.Get("response").String(); content != "" {
+		delta["role"] = "assistant"
+		delta["content"] = content
+	}
+	if calls := root.Get("tool_calls"); calls.IsArray() && len(calls.Array()) > 0 {
+		delta["role"] = "assistant"
+		delta["tool_calls"] = cloudflareToolCallsToOpenAI(calls)
+	}
+	out, _ = sjson.SetBytes(out, "choices.0.delta", delta)
+	out, _ = sjson.SetRawBytes(out, "choices.0.finish_reason", []byte("null"))
+
+	if usageNode := root.Get("usage"); usageNode.Exists() {
+		promptTokens := usageNode.Get("prompt_tokens").Int()
+		completionTokens := usageNode.Get("completion_tokens").Int()
+		out, _ = sjson.SetBytes(out, "usage.prompt_tokens", promptTokens)
+		out, _ = sjson.SetBytes(out, "usage.completion_tokens", completionTokens)
+		out, _ = sjson.SetBytes(out, "usage.total_tokens", promptTokens+completionTokens)
+	}
+	return out
+}
+
+// cloudflareFinishChunk builds the terminal chunk carrying the finish reason.
+func cloudflareFinishChunk(model string) []byte {
+	now := time.Now().Unix()
+	out := []byte(`{"object":"chat.completion.chunk"}`)
+	out, _ = sjson.SetBytes(out, "id", fmt.Sprintf("chatcmpl-%d", now))
+	out, _ = sjson.SetBytes(out, "created", now)
+	out, _ = sjson.SetBytes(out, "model", model)
+	out, _ = sjson.SetBytes(out, "choices.0.index", 0)
+	out, _ = sjson.SetBytes(out, "choices.0.delta", map[string]any{})
+	out, _ = sjson.SetBytes(out, "choices.0.finish_reason", "stop")
+	return out
+}
+
+// cloudflareToolCallsToOpenAI maps Workers AI tool calls (object arguments) to
+// OpenAI tool calls (stringified arguments).
+func cloudflareToolCallsToOpenAI(calls gjson.Result) []map[string]any {
+	out := make([]map[string]any, 0, len(calls.Array()))
+	for i, call := range calls.Array() {
+		args := call.Get("arguments").Raw
+		if args == "" {
+			args = "{}"
+		}
+		out = append(out, map[string]any{
+			"index": i,
+			"id":    fmt.Sprintf("call_%d_%d", time.Now().UnixNano(), i),
+			"type":  "function",
+			"function": map[string]any{
+				"name":      call.Get("name").String(),
+				"arguments": args,
+			},
+		})
+	}
+	return out
+}
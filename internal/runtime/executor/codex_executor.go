@@ -1,7 +1,6 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -18,7 +17,6 @@ import (
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 	"github.com/tiktoken-go/tokenizer"
@@ -87,7 +85,7 @@ func (e *CodexExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 	if baseURL == "" {
 		baseURL = "https://chatgpt.com/backend-api/codex"
 	}
-	reporter := newUsageReporter(ctx, e.Identifier(), baseModel, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), baseModel, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -124,10 +122,12 @@ func (e *CodexExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -135,7 +135,7 @@ func (e *CodexExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 		AuthType:  authType,
 		AuthValue: authValue,
 	})
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -143,14 +143,14 @@ func (e *CodexExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 	}
 	defer func() {
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("codex executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("codex executor: close response body error: %v", errClose)
 		}
 	}()
 	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		b, _ := io.ReadAll(httpResp.Body)
 		appendAPIResponseChunk(ctx, e.cfg, b)
-		log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
 		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
 		return resp, err
 	}
@@ -193,7 +193,7 @@ func (e *CodexExecutor) executeCompact(ctx context.Context, auth *modelgateauth.
 		baseURL = "https://chatgpt.com/backend-api/codex"
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), baseModel, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), baseModel, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -227,10 +227,12 @@ func (e *CodexExecutor) executeCompact(ctx context.Context, auth *modelgateauth.
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -238,7 +240,7 @@ func (e *CodexExecutor) executeCompact(ctx context.Context, auth *modelgateauth.
 		AuthType:  authType,
 		AuthValue: authValue,
 	})
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -246,14 +248,14 @@ func (e *CodexExecutor) executeCompact(ctx context.Context, auth *modelgateauth.
 	}
 	defer func() {
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("codex executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("codex executor: close response body error: %v", errClose)
 		}
 	}()
 	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		b, _ := io.ReadAll(httpResp.Body)
 		appendAPIResponseChunk(ctx, e.cfg, b)
-		log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
 		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
 		return resp, err
 	}
@@ -281,7 +283,7 @@ func (e *CodexExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 	if baseURL == "" {
 		baseURL = "https://chatgpt.com/backend-api/codex"
 	}
-	reporter := newUsageReporter(ctx, e.Identifier(), baseModel, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), baseModel, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -317,10 +319,12 @@ func (e *CodexExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -329,7 +333,7 @@ func (e *CodexExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -339,14 +343,14 @@ func (e *CodexExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		data, readErr := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("codex executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("codex executor: close response body error: %v", errClose)
 		}
 		if readErr != nil {
 			recordAPIResponseError(ctx, e.cfg, readErr)
 			return nil, readErr
 		}
 		appendAPIResponseChunk(ctx, e.cfg, data)
-		log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
 		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
 		return nil, err
 	}
@@ -356,11 +360,10 @@ func (e *CodexExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		defer close(out)
 		defer func() {
 			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Errorf("codex executor: close response body error: %v", errClose)
+				logEntry(ctx).Errorf("codex executor: close response body error: %v", errClose)
 			}
 		}()
-		scanner := bufio.NewScanner(httpResp.Body)
-		scanner.Buffer(nil, 52_428_800) // 50MB
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
 		var param any
 		for scanner.Scan() {
 			line := scanner.Bytes()
@@ -375,7 +378,7 @@ func (e *CodexExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 				}
 			}
 
-			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(originalPayload), body, bytes.Clone(line), &param)
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalPayload, body, bytes.Clone(line), &param)
 			for i := range chunks {
 				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
 			}
@@ -545,7 +548,7 @@ func countCodexInputTokens(enc tokenizer.Codec, body []byte) (int64, error) {
 }
 
 func (e *CodexExecutor) Refresh(ctx context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
-	log.Debugf("codex executor: refresh called")
+	logEntry(ctx).Debugf("codex executor: refresh called")
 	if auth == nil {
 		return nil, statusErr{code: 500, msg: "codex executor: auth is nil"}
 	}
@@ -0,0 +1,398 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	cohereDefaultBaseURL  = "https://api.cohere.com"
+	cohereChatEndpoint    = "/v1/chat"
+	cohereModelsEndpoint  = "/v1/models"
+	cohereStreamChunkSize = 1024 * 1024
+)
+
+// CohereExecutor executes chat completions against Cohere's Chat API using
+// API keys declared in the config file. Requests are translated through the
+// OpenAI chat format and then into Cohere's message/chat_history shape via
+// the registered cohere translator pair, so OpenAI, Claude, and Gemini front
+// ends all reach the same upstream conversion.
+type CohereExecutor struct {
+	cfg *config.Config
+}
+
+// NewCohereExecutor constructs a new executor instance.
+func NewCohereExecutor(cfg *config.Config) *CohereExecutor { return &CohereExecutor{cfg: cfg} }
+
+// Identifier returns the provider key.
+func (e *CohereExecutor) Identifier() string { return "cohere" }
+
+// PrepareRequest injects the Cohere bearer token into the outgoing HTTP request.
+func (e *CohereExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, _ := cohereCreds(auth)
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects Cohere credentials into the request and executes it.
+func (e *CohereExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("cohere executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming chat request.
+func (e *CohereExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := cohereCreds(auth)
+	if baseURL == "" {
+		baseURL = cohereDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	openaiFormat := sdktranslator.FromString("openai")
+	cohereFormat := sdktranslator.FromString("cohere")
+	openAIBody := sdktranslator.TranslateRequest(from, openaiFormat, req.Model, bytes.Clone(req.Payload), false)
+	openAIBody, _ = sjson.SetBytes(openAIBody, "model", req.Model)
+	body := sdktranslator.TranslateRequest(openaiFormat, cohereFormat, req.Model, openAIBody, false)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + cohereChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyCohereHeaders(httpReq, apiKey)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cohere executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("cohere request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = cohereStatusErr(httpResp, b)
+		return resp, err
+	}
+
+	raw, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, raw)
+
+	var hop1 any
+	openAIData := sdktranslator.TranslateNonStream(ctx, cohereFormat, openaiFormat, req.Model, openAIBody, body, raw, &hop1)
+	reporter.publish(ctx, parseOpenAIUsage([]byte(openAIData)))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var hop2 any
+	out := sdktranslator.TranslateNonStream(ctx, openaiFormat, from, req.Model, bytes.Clone(opts.OriginalRequest), openAIBody, []byte(openAIData), &hop2)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming chat request. Cohere streams NDJSON
+// events; each one is converted to an OpenAI SSE chunk before translation.
+func (e *CohereExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, baseURL := cohereCreds(auth)
+	if baseURL == "" {
+		baseURL = cohereDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	openaiFormat := sdktranslator.FromString("openai")
+	cohereFormat := sdktranslator.FromString("cohere")
+	openAIBody := sdktranslator.TranslateRequest(from, openaiFormat, req.Model, bytes.Clone(req.Payload), true)
+	openAIBody, _ = sjson.SetBytes(openAIBody, "model", req.Model)
+	body := sdktranslator.TranslateRequest(openaiFormat, cohereFormat, req.Model, openAIBody, true)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + cohereChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyCohereHeaders(httpReq, apiKey)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cohere executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("cohere streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = cohereStatusErr(httpResp, data)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("cohere executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := bufio.NewScanner(httpResp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), cohereStreamChunkSize)
+		var hop1 any
+		var hop2 any
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		emit := func(line []byte) {
+			chunks := sdktranslator.TranslateStream(ctx, openaiFormat, from, req.Model, originalRequestView, openAIBody, bytes.Clone(line), &hop2)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			converted := sdktranslator.TranslateStream(ctx, cohereFormat, openaiFormat, req.Model, openAIBody, body, bytes.Clone(line), &hop1)
+			done := gjson.GetBytes(line, "event_type").String() == "stream-end"
+			for i := range converted {
+				chunk := []byte("data: " + converted[i])
+				if detail, ok := parseOpenAIStreamUsage(chunk); ok {
+					reporter.publish(ctx, detail)
+				}
+				emit(chunk)
+			}
+			if done {
+				emit([]byte("data: [DONE]"))
+				break
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *CohereExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("cohere executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("cohere executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// Refresh is a no-op for API key based Cohere auths.
+func (e *CohereExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+// FetchCohereModels lists the models available to the API key via /v1/models
+// so they can be registered alongside the other provider catalogs.
+func FetchCohereModels(ctx context.Context, auth *modelgateauth.Auth, cfg *config.Config) []*registry.ModelInfo {
+	apiKey, baseURL := cohereCreds(auth)
+	if baseURL == "" {
+		baseURL = cohereDefaultBaseURL
+	}
+
+	modelsURL := strings.TrimSuffix(baseURL, "/") + cohereModelsEndpoint
+	httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if errReq != nil {
+		logEntry(ctx).Warnf("cohere executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+		return nil
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if apiKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
+	httpResp, errDo := httpClient.Do(httpReq)
+	if errDo != nil {
+		logEntry(ctx).Warnf("cohere executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+		return nil
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("cohere executor: close response body error: %v", errClose)
+		}
+	}()
+	bodyBytes, errRead := io.ReadAll(httpResp.Body)
+	if errRead != nil {
+		logEntry(ctx).Warnf("cohere executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+		return nil
+	}
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		logEntry(ctx).Warnf("cohere executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+		return nil
+	}
+
+	list := gjson.GetBytes(bodyBytes, "models")
+	if !list.Exists() || !list.IsArray() {
+		logEntry(ctx).Warnf("cohere executor: fetch models failed for %s: no models field in response", auth.ID)
+		return nil
+	}
+
+	now := time.Now().Unix()
+	models := make([]*registry.ModelInfo, 0, len(list.Array()))
+	for _, entry := range list.Array() {
+		id := strings.TrimSpace(entry.Get("name").String())
+		if id == "" {
+			continue
+		}
+		models = append(models, &registry.ModelInfo{
+			ID:          id,
+			Object:      "model",
+			Created:     now,
+			OwnedBy:     "cohere",
+			Type:        "cohere",
+			DisplayName: id,
+			Description: id,
+		})
+	}
+	if len(models) == 0 {
+		logEntry(ctx).Warnf("cohere executor: fetch models returned no models for %s", auth.ID)
+		return nil
+	}
+	return models
+}
+
+// cohereStatusErr wraps an upstream error status, honoring Retry-After on 429s.
+func cohereStatusErr(httpResp *http.Response, body []byte) statusErr {
+	sErr := statusErr{code: httpResp.StatusCode, msg: string(body)}
+	if httpResp.StatusCode == http.StatusTooManyRequests {
+		if seconds, errParse := strconv.Atoi(strings.TrimSpace(httpResp.Header.Get("Retry-After"))); errParse == nil && seconds > 0 {
+			retryAfter := time.Duration(seconds) * time.Second
+			sErr.retryAfter = &retryAfter
+		}
+	}
+	return sErr
+}
+
+func applyCohereHeaders(r *http.Request, apiKey string) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json")
+	if apiKey != "" {
+		r.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
+
+func cohereCreds(a *modelgateauth.Auth) (apiKey, baseURL string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.Attributes != nil {
+		apiKey = strings.TrimSpace(a.Attributes["api_key"])
+		baseURL = strings.TrimSpace(a.Attributes["base_url"])
+	}
+	return apiKey, baseURL
+}
@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// apiDebugMarkerKey marks a request whose caller asked for per-request
+	// debug output via the X-ModelGate-Debug header and is allowed to see it.
+	apiDebugMarkerKey = "API_DEBUG"
+
+	// apiDebugTraceKey accumulates one compact line per upstream attempt,
+	// status, and error so the middleware can surface the retry trace.
+	apiDebugTraceKey = "API_DEBUG_TRACE"
+
+	// apiDebugPayloadKey holds the translated upstream request payload of the
+	// most recent attempt.
+	apiDebugPayloadKey = "API_DEBUG_PAYLOAD"
+)
+
+// debugTraceEnabled reports whether the request opted into debug tracing.
+func debugTraceEnabled(ginCtx *gin.Context) bool {
+	return ginCtx != nil && ginCtx.GetBool(apiDebugMarkerKey)
+}
+
+// recordDebugAttempt appends a trace line describing an upstream attempt and
+// stores its translated payload for the debug envelope.
+func recordDebugAttempt(ginCtx *gin.Context, info upstreamRequestLog) {
+	if !debugTraceEnabled(ginCtx) {
+		return
+	}
+	lines := debugTraceLines(ginCtx)
+	attempt := 0
+	for _, line := range lines {
+		if bytes.HasPrefix([]byte(line), []byte("attempt=")) {
+			attempt++
+		}
+	}
+	line := fmt.Sprintf("attempt=%d provider=%s auth=%s url=%s", attempt+1, info.Provider, info.AuthID, info.URL)
+	if info.AuthLabel != "" {
+		line += fmt.Sprintf(" label=%s", info.AuthLabel)
+	}
+	ginCtx.Set(apiDebugTraceKey, append(lines, line))
+	if len(info.Body) > 0 {
+		ginCtx.Set(apiDebugPayloadKey, bytes.Clone(info.Body))
+	}
+}
+
+// recordDebugStatus appends the upstream HTTP status of the latest attempt.
+func recordDebugStatus(ginCtx *gin.Context, status int) {
+	if !debugTraceEnabled(ginCtx) || status <= 0 {
+		return
+	}
+	ginCtx.Set(apiDebugTraceKey, append(debugTraceLines(ginCtx), fmt.Sprintf("status=%d", status)))
+}
+
+// recordDebugError appends the upstream error of the latest attempt.
+func recordDebugError(ginCtx *gin.Context, err error) {
+	if !debugTraceEnabled(ginCtx) || err == nil {
+		return
+	}
+	ginCtx.Set(apiDebugTraceKey, append(debugTraceLines(ginCtx), fmt.Sprintf("error=%s", err.Error())))
+}
+
+func debugTraceLines(ginCtx *gin.Context) []string {
+	if value, exists := ginCtx.Get(apiDebugTraceKey); exists {
+		if lines, ok := value.([]string); ok {
+			return lines
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// openAIEmbeddingsInputs extracts the input texts from an OpenAI embeddings
+// request body. The input field may be a single string or an array of strings;
+// token-array inputs are not supported because upstream providers expect text.
+func openAIEmbeddingsInputs(body []byte) ([]string, error) {
+	input := gjson.GetBytes(body, "input")
+	if !input.Exists() {
+		return nil, fmt.Errorf("embeddings request missing input")
+	}
+	if input.Type == gjson.String {
+		return []string{input.String()}, nil
+	}
+	if !input.IsArray() {
+		return nil, fmt.Errorf("embeddings input must be a string or array of strings")
+	}
+	items := input.Array()
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.Type != gjson.String {
+			return nil, fmt.Errorf("embeddings input must be a string or array of strings")
+		}
+		texts = append(texts, item.String())
+	}
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("embeddings request missing input")
+	}
+	return texts, nil
+}
+
+// buildOpenAIEmbeddingsResponse assembles an OpenAI embeddings list response
+// from per-input vectors for providers whose native API is not OpenAI-shaped.
+func buildOpenAIEmbeddingsResponse(model string, vectors [][]float64, promptTokens int64) []byte {
+	type embeddingItem struct {
+		Object    string    `json:"object"`
+		Index     int       `json:"index"`
+		Embedding []float64 `json:"embedding"`
+	}
+	type embeddingUsage struct {
+		PromptTokens int64 `json:"prompt_tokens"`
+		TotalTokens  int64 `json:"total_tokens"`
+	}
+	type embeddingList struct {
+		Object string          `json:"object"`
+		Data   []embeddingItem `json:"data"`
+		Model  string          `json:"model"`
+		Usage  embeddingUsage  `json:"usage"`
+	}
+	out := embeddingList{
+		Object: "list",
+		Data:   make([]embeddingItem, 0, len(vectors)),
+		Model:  model,
+		Usage:  embeddingUsage{PromptTokens: promptTokens, TotalTokens: promptTokens},
+	}
+	for i, vec := range vectors {
+		if vec == nil {
+			vec = []float64{}
+		}
+		out.Data = append(out.Data, embeddingItem{Object: "embedding", Index: i, Embedding: vec})
+	}
+	payload, _ := json.Marshal(out)
+	return payload
+}
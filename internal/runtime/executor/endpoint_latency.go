@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// endpointLatencyWindow bounds how far back observed samples count toward
+	// an endpoint's percentiles.
+	endpointLatencyWindow = 10 * time.Minute
+	// endpointLatencyMaxSamples caps the per-endpoint ring so hot endpoints
+	// stay cheap to summarize.
+	endpointLatencyMaxSamples = 64
+	// endpointLatencyMinSamples is the evidence required before observed
+	// latency outranks probe RTTs in endpoint ordering.
+	endpointLatencyMinSamples = 4
+)
+
+// endpointLatencySample is one observed upstream request latency.
+type endpointLatencySample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// endpointLatencyTracker keeps rolling windows of observed request latencies
+// per auth and base URL and summarizes them as p50/p95. Unlike the RTT
+// prober, which measures an empty round trip, these samples reflect real
+// traffic, so they capture capacity differences between endpoints (e.g.
+// antigravity sandbox vs prod) that a bare HEAD cannot.
+type endpointLatencyTracker struct {
+	mu      sync.Mutex
+	samples map[string]map[string][]endpointLatencySample
+}
+
+var antigravityEndpointLatencies = newEndpointLatencyTracker()
+
+func newEndpointLatencyTracker() *endpointLatencyTracker {
+	return &endpointLatencyTracker{samples: make(map[string]map[string][]endpointLatencySample)}
+}
+
+// record appends an observed latency for the auth/base pair. Only successful
+// requests should be recorded so endpoints that fail fast do not look fast.
+func (t *endpointLatencyTracker) record(authID, base string, latency time.Duration, now time.Time) {
+	if t == nil || authID == "" || base == "" || latency <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	byBase := t.samples[authID]
+	if byBase == nil {
+		byBase = make(map[string][]endpointLatencySample)
+		t.samples[authID] = byBase
+	}
+	samples := pruneEndpointSamples(byBase[base], now)
+	samples = append(samples, endpointLatencySample{at: now, latency: latency})
+	if len(samples) > endpointLatencyMaxSamples {
+		samples = samples[len(samples)-endpointLatencyMaxSamples:]
+	}
+	byBase[base] = samples
+}
+
+// percentiles returns the p50/p95 of the fresh samples for the auth/base pair
+// together with how many samples back them.
+func (t *endpointLatencyTracker) percentiles(authID, base string, now time.Time) (p50, p95 time.Duration, n int) {
+	if t == nil || authID == "" || base == "" {
+		return 0, 0, 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	byBase := t.samples[authID]
+	if byBase == nil {
+		return 0, 0, 0
+	}
+	samples := pruneEndpointSamples(byBase[base], now)
+	byBase[base] = samples
+	if len(samples) == 0 {
+		return 0, 0, 0
+	}
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, sample := range samples {
+		latencies = append(latencies, sample.latency)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return latencyPercentile(latencies, 50), latencyPercentile(latencies, 95), len(latencies)
+}
+
+// pruneEndpointSamples drops samples older than the window; samples are
+// appended in time order so the first surviving index bounds the slice.
+func pruneEndpointSamples(samples []endpointLatencySample, now time.Time) []endpointLatencySample {
+	cutoff := now.Add(-endpointLatencyWindow)
+	idx := 0
+	for idx < len(samples) && !samples[idx].at.After(cutoff) {
+		idx++
+	}
+	if idx == 0 {
+		return samples
+	}
+	return append([]endpointLatencySample(nil), samples[idx:]...)
+}
+
+// latencyPercentile returns the pct-th percentile of sorted latencies.
+func latencyPercentile(sorted []time.Duration, pct int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*pct + 99) / 100
+	if idx > 0 {
+		idx--
+	}
+	return sorted[idx]
+}
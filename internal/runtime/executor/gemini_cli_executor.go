@@ -4,7 +4,6 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -24,7 +23,6 @@ import (
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 	"golang.org/x/oauth2"
@@ -36,9 +34,6 @@ const (
 	codeAssistVersion       = "v1internal"
 	geminiOAuthClientID     = "681255809395-oo8ft2oprdrnp9e3aqf6av3hmdib135j.apps.googleusercontent.com"
 	geminiOAuthClientSecret = "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"
-
-	// streamScannerBuffer is the buffer size for SSE stream scanning.
-	streamScannerBuffer = 52_428_800
 )
 
 var geminiOAuthScopes = []string{
@@ -109,7 +104,7 @@ func (e *GeminiCLIExecutor) Execute(ctx context.Context, auth *modelgateauth.Aut
 	if err != nil {
 		return resp, err
 	}
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -184,10 +179,12 @@ func (e *GeminiCLIExecutor) Execute(ctx context.Context, auth *modelgateauth.Aut
 		reqHTTP.Header.Set("Authorization", "Bearer "+tok.AccessToken)
 		applyGeminiCLIHeaders(reqHTTP)
 		reqHTTP.Header.Set("Accept", "application/json")
+		applyRequestOptions(reqHTTP, opts)
 		recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 			URL:       url,
 			Method:    http.MethodPost,
 			Headers:   reqHTTP.Header.Clone(),
+			Tags:      opts.Tags,
 			Body:      payload,
 			Provider:  e.Identifier(),
 			AuthID:    authID,
@@ -205,7 +202,7 @@ func (e *GeminiCLIExecutor) Execute(ctx context.Context, auth *modelgateauth.Aut
 
 		data, errRead := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("gemini cli executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("gemini cli executor: close response body error: %v", errClose)
 		}
 		recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
 		if errRead != nil {
@@ -224,12 +221,12 @@ func (e *GeminiCLIExecutor) Execute(ctx context.Context, auth *modelgateauth.Aut
 
 		lastStatus = httpResp.StatusCode
 		lastBody = append([]byte(nil), data...)
-		log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
 		if httpResp.StatusCode == 429 {
 			if idx+1 < len(models) {
-				log.Debugf("gemini cli executor: rate limited, retrying with next model: %s", models[idx+1])
+				logEntry(ctx).Debugf("gemini cli executor: rate limited, retrying with next model: %s", models[idx+1])
 			} else {
-				log.Debug("gemini cli executor: rate limited, no additional fallback model")
+				logEntry(ctx).Debug("gemini cli executor: rate limited, no additional fallback model")
 			}
 			continue
 		}
@@ -254,7 +251,7 @@ func (e *GeminiCLIExecutor) ExecuteStream(ctx context.Context, auth *modelgateau
 	if err != nil {
 		return nil, err
 	}
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -320,10 +317,12 @@ func (e *GeminiCLIExecutor) ExecuteStream(ctx context.Context, auth *modelgateau
 		reqHTTP.Header.Set("Authorization", "Bearer "+tok.AccessToken)
 		applyGeminiCLIHeaders(reqHTTP)
 		reqHTTP.Header.Set("Accept", "text/event-stream")
+		applyRequestOptions(reqHTTP, opts)
 		recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 			URL:       url,
 			Method:    http.MethodPost,
 			Headers:   reqHTTP.Header.Clone(),
+			Tags:      opts.Tags,
 			Body:      payload,
 			Provider:  e.Identifier(),
 			AuthID:    authID,
@@ -342,7 +341,7 @@ func (e *GeminiCLIExecutor) ExecuteStream(ctx context.Context, auth *modelgateau
 		if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 			data, errRead := io.ReadAll(httpResp.Body)
 			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Errorf("gemini cli executor: close response body error: %v", errClose)
+				logEntry(ctx).Errorf("gemini cli executor: close response body error: %v", errClose)
 			}
 			if errRead != nil {
 				recordAPIResponseError(ctx, e.cfg, errRead)
@@ -352,12 +351,12 @@ func (e *GeminiCLIExecutor) ExecuteStream(ctx context.Context, auth *modelgateau
 			appendAPIResponseChunk(ctx, e.cfg, data)
 			lastStatus = httpResp.StatusCode
 			lastBody = append([]byte(nil), data...)
-			log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+			logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
 			if httpResp.StatusCode == 429 {
 				if idx+1 < len(models) {
-					log.Debugf("gemini cli executor: rate limited, retrying with next model: %s", models[idx+1])
+					logEntry(ctx).Debugf("gemini cli executor: rate limited, retrying with next model: %s", models[idx+1])
 				} else {
-					log.Debug("gemini cli executor: rate limited, no additional fallback model")
+					logEntry(ctx).Debug("gemini cli executor: rate limited, no additional fallback model")
 				}
 				continue
 			}
@@ -371,13 +370,15 @@ func (e *GeminiCLIExecutor) ExecuteStream(ctx context.Context, auth *modelgateau
 			defer close(out)
 			defer func() {
 				if errClose := resp.Body.Close(); errClose != nil {
-					log.Errorf("gemini cli executor: close response body error: %v", errClose)
+					logEntry(ctx).Errorf("gemini cli executor: close response body error: %v", errClose)
 				}
 			}()
 			if opts.Alt == "" {
-				scanner := bufio.NewScanner(resp.Body)
-				scanner.Buffer(nil, streamScannerBuffer)
+				scanner := newSSEScanner(e.Identifier(), resp.Body)
 				var param any
+				// One immutable copy of the original request is shared by every chunk;
+				// stream translators only read it, so per-line clones are wasted work.
+				originalRequestView := bytes.Clone(opts.OriginalRequest)
 				for scanner.Scan() {
 					line := scanner.Bytes()
 					appendAPIResponseChunk(ctx, e.cfg, line)
@@ -385,14 +386,14 @@ func (e *GeminiCLIExecutor) ExecuteStream(ctx context.Context, auth *modelgateau
 						reporter.publish(ctx, detail)
 					}
 					if bytes.HasPrefix(line, dataTag) {
-						segments := sdktranslator.TranslateStream(respCtx, to, from, attemptModel, bytes.Clone(opts.OriginalRequest), reqBody, bytes.Clone(line), &param)
+						segments := sdktranslator.TranslateStream(respCtx, to, from, attemptModel, originalRequestView, reqBody, bytes.Clone(line), &param)
 						for i := range segments {
 							out <- modelgateexecutor.StreamChunk{Payload: []byte(segments[i])}
 						}
 					}
 				}
 
-				segments := sdktranslator.TranslateStream(respCtx, to, from, attemptModel, bytes.Clone(opts.OriginalRequest), reqBody, bytes.Clone([]byte("[DONE]")), &param)
+				segments := sdktranslator.TranslateStream(respCtx, to, from, attemptModel, originalRequestView, reqBody, bytes.Clone([]byte("[DONE]")), &param)
 				for i := range segments {
 					out <- modelgateexecutor.StreamChunk{Payload: []byte(segments[i])}
 				}
@@ -497,10 +498,12 @@ func (e *GeminiCLIExecutor) CountTokens(ctx context.Context, auth *modelgateauth
 		reqHTTP.Header.Set("Authorization", "Bearer "+tok.AccessToken)
 		applyGeminiCLIHeaders(reqHTTP)
 		reqHTTP.Header.Set("Accept", "application/json")
+		applyRequestOptions(reqHTTP, opts)
 		recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 			URL:       url,
 			Method:    http.MethodPost,
 			Headers:   reqHTTP.Header.Clone(),
+			Tags:      opts.Tags,
 			Body:      payload,
 			Provider:  e.Identifier(),
 			AuthID:    authID,
@@ -530,7 +533,7 @@ func (e *GeminiCLIExecutor) CountTokens(ctx context.Context, auth *modelgateauth
 		lastStatus = resp.StatusCode
 		lastBody = append([]byte(nil), data...)
 		if resp.StatusCode == 429 {
-			log.Debugf("gemini cli executor: rate limited, retrying with next model")
+			logEntry(ctx).Debugf("gemini cli executor: rate limited, retrying with next model")
 			continue
 		}
 		break
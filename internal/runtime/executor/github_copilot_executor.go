@@ -1,7 +1,6 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -9,6 +8,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,22 +20,20 @@ import (
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
 
 const (
-	githubCopilotBaseURL       = "https://api.githubcopilot.com"
-	githubCopilotChatPath      = "/chat/completions"
-	githubCopilotResponsesPath = "/responses"
-	githubCopilotMessagesPath  = "/v1/messages"
-	githubCopilotAuthType      = "github-copilot"
-	githubCopilotTokenCacheTTL = 25 * time.Minute
+	githubCopilotBaseURL        = "https://api.githubcopilot.com"
+	githubCopilotChatPath       = "/chat/completions"
+	githubCopilotResponsesPath  = "/responses"
+	githubCopilotEmbeddingsPath = "/embeddings"
+	githubCopilotMessagesPath   = "/v1/messages"
+	githubCopilotAuthType       = "github-copilot"
+	githubCopilotTokenCacheTTL  = 25 * time.Minute
 	// tokenExpiryBuffer is the time before expiry when we should refresh the token.
 	tokenExpiryBuffer = 5 * time.Minute
-	// maxScannerBufferSize is the maximum buffer size for SSE scanning (20MB).
-	maxScannerBufferSize = 20_971_520
 
 	// Copilot API header values.
 	copilotUserAgent     = "GitHubCopilotChat/0.35.0"
@@ -49,9 +47,7 @@ const (
 
 // GitHubCopilotExecutor handles requests to the GitHub Copilot API.
 type GitHubCopilotExecutor struct {
-	cfg   *config.Config
-	mu    sync.RWMutex
-	cache map[string]*cachedAPIToken
+	cfg *config.Config
 }
 
 // cachedAPIToken stores a cached Copilot API token with its expiry.
@@ -60,12 +56,86 @@ type cachedAPIToken struct {
 	expiresAt time.Time
 }
 
+// copilotAPITokenCache shares minted Copilot API tokens across executor
+// instances (executors are rebuilt on config reload) and feeds the background
+// refresher that renews tokens before they expire.
+type copilotAPITokenCache struct {
+	mu    sync.RWMutex
+	cache map[string]*cachedAPIToken
+	cfg   atomic.Pointer[config.Config]
+	once  sync.Once
+}
+
+var copilotTokenCache = &copilotAPITokenCache{cache: make(map[string]*cachedAPIToken)}
+
 // NewGitHubCopilotExecutor constructs a new executor instance.
 func NewGitHubCopilotExecutor(cfg *config.Config) *GitHubCopilotExecutor {
-	return &GitHubCopilotExecutor{
-		cfg:   cfg,
-		cache: make(map[string]*cachedAPIToken),
+	copilotTokenCache.cfg.Store(cfg)
+	copilotTokenCache.once.Do(func() { go copilotTokenCache.refreshLoop() })
+	return &GitHubCopilotExecutor{cfg: cfg}
+}
+
+// refreshLoop renews cached API tokens shortly before they expire so requests
+// never pay the token exchange round-trip mid-burst.
+func (c *copilotAPITokenCache) refreshLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.refreshExpiring()
+	}
+}
+
+// refreshExpiring renews every cached token inside the expiry buffer. Entries
+// that are already expired and fail to renew are dropped so the lazy path in
+// ensureAPIToken surfaces the error on the next request.
+func (c *copilotAPITokenCache) refreshExpiring() {
+	cfg := c.cfg.Load()
+	now := time.Now()
+	c.mu.RLock()
+	due := make([]string, 0, len(c.cache))
+	for accessToken, cached := range c.cache {
+		if !cached.expiresAt.After(now.Add(tokenExpiryBuffer)) {
+			due = append(due, accessToken)
+		}
+	}
+	c.mu.RUnlock()
+	for _, accessToken := range due {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		apiToken, err := copilotauth.NewCopilotAuth(cfg).GetCopilotAPIToken(ctx, accessToken)
+		cancel()
+		if err != nil {
+			logEntry(ctx).Debugf("github-copilot executor: background token refresh failed: %v", err)
+			c.mu.Lock()
+			if cached, ok := c.cache[accessToken]; ok && cached.expiresAt.Before(now) {
+				delete(c.cache, accessToken)
+			}
+			c.mu.Unlock()
+			continue
+		}
+		c.store(accessToken, apiToken.Token, apiToken.ExpiresAt)
+	}
+}
+
+// lookup returns a cached token that is still comfortably within its lifetime.
+func (c *copilotAPITokenCache) lookup(accessToken string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if cached, ok := c.cache[accessToken]; ok && cached.expiresAt.After(time.Now().Add(tokenExpiryBuffer)) {
+		return cached.token, true
+	}
+	return "", false
+}
+
+// store caches a minted token, deriving the expiry from the upstream response
+// when present.
+func (c *copilotAPITokenCache) store(accessToken, token string, expiresAtUnix int64) {
+	expiresAt := time.Now().Add(githubCopilotTokenCacheTTL)
+	if expiresAtUnix > 0 {
+		expiresAt = time.Unix(expiresAtUnix, 0)
 	}
+	c.mu.Lock()
+	c.cache[accessToken] = &cachedAPIToken{token: token, expiresAt: expiresAt}
+	c.mu.Unlock()
 }
 
 // Identifier implements ProviderExecutor.
@@ -111,7 +181,7 @@ func (e *GitHubCopilotExecutor) Execute(ctx context.Context, auth *modelgateauth
 		return resp, errToken
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -172,10 +242,12 @@ func (e *GitHubCopilotExecutor) Execute(ctx context.Context, auth *modelgateauth
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -184,7 +256,7 @@ func (e *GitHubCopilotExecutor) Execute(ctx context.Context, auth *modelgateauth
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -192,7 +264,7 @@ func (e *GitHubCopilotExecutor) Execute(ctx context.Context, auth *modelgateauth
 	}
 	defer func() {
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("github-copilot executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("github-copilot executor: close response body error: %v", errClose)
 		}
 	}()
 
@@ -201,7 +273,7 @@ func (e *GitHubCopilotExecutor) Execute(ctx context.Context, auth *modelgateauth
 	if !isHTTPSuccess(httpResp.StatusCode) {
 		data, _ := io.ReadAll(httpResp.Body)
 		appendAPIResponseChunk(ctx, e.cfg, data)
-		log.Debugf("github-copilot executor: upstream error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		logEntry(ctx).Debugf("github-copilot executor: upstream error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
 		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
 		return resp, err
 	}
@@ -235,7 +307,7 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 		return nil, errToken
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -302,10 +374,12 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -314,7 +388,7 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -326,14 +400,14 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 	if !isHTTPSuccess(httpResp.StatusCode) {
 		data, readErr := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("github-copilot executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("github-copilot executor: close response body error: %v", errClose)
 		}
 		if readErr != nil {
 			recordAPIResponseError(ctx, e.cfg, readErr)
 			return nil, readErr
 		}
 		appendAPIResponseChunk(ctx, e.cfg, data)
-		log.Debugf("github-copilot executor: upstream error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		logEntry(ctx).Debugf("github-copilot executor: upstream error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
 		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
 		return nil, err
 	}
@@ -345,13 +419,15 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 		defer close(out)
 		defer func() {
 			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Errorf("github-copilot executor: close response body error: %v", errClose)
+				logEntry(ctx).Errorf("github-copilot executor: close response body error: %v", errClose)
 			}
 		}()
 
-		scanner := bufio.NewScanner(httpResp.Body)
-		scanner.Buffer(nil, maxScannerBufferSize)
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
 		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
 		// When no response translator is registered, the stream is passed through
 		// verbatim. The scanner strips newlines, so we need to re-add them.
 		ssePassthrough := !sdktranslator.HasResponseTransformer(from, to)
@@ -381,7 +457,7 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 				}
 			}
 
-			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, bytes.Clone(line), &param)
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
 			// When no response translator is registered (e.g. claude→claude passthrough),
 			// raw scanner lines are returned without newlines. Append \n to each line to
 			// preserve SSE framing for the handler.
@@ -407,6 +483,84 @@ func (e *GitHubCopilotExecutor) ExecuteStream(ctx context.Context, auth *modelga
 	return stream, nil
 }
 
+// ExecuteEmbeddings performs an embeddings request against the Copilot API.
+// Copilot's embeddings endpoint is OpenAI-shaped, so the payload is forwarded
+// as-is with the normalized model name set.
+func (e *GitHubCopilotExecutor) ExecuteEmbeddings(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiToken, errToken := e.ensureAPIToken(ctx, auth)
+	if errToken != nil {
+		return resp, errToken
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	body := bytes.Clone(req.Payload)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	body = e.normalizeModel(req.Model, body)
+
+	url := githubCopilotBaseURL + githubCopilotEmbeddingsPath
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	e.applyHeaders(httpReq, apiToken, sdktranslator.FromString("openai"), body)
+
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       url,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("github-copilot executor: close response body error: %v", errClose)
+		}
+	}()
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if !isHTTPSuccess(httpResp.StatusCode) {
+		data, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("github-copilot executor: embeddings error status: %d, body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	reporter.ensurePublished(ctx)
+
+	resp = modelgateexecutor.Response{Payload: data}
+	return resp, nil
+}
+
 // CountTokens is not supported for GitHub Copilot.
 func (e *GitHubCopilotExecutor) CountTokens(_ context.Context, _ *modelgateauth.Auth, _ modelgateexecutor.Request, _ modelgateexecutor.Options) (modelgateexecutor.Response, error) {
 	return modelgateexecutor.Response{}, statusErr{code: http.StatusNotImplemented, msg: "count tokens not supported for github-copilot"}
@@ -435,6 +589,16 @@ func (e *GitHubCopilotExecutor) Refresh(ctx context.Context, auth *modelgateauth
 	return auth, nil
 }
 
+// Warmup mints and caches the Copilot API token ahead of the first request so
+// the initial user call does not pay the token exchange round-trip.
+func (e *GitHubCopilotExecutor) Warmup(ctx context.Context, auth *modelgateauth.Auth) (bool, error) {
+	if auth == nil || metaStringValue(auth.Metadata, "access_token") == "" {
+		return false, nil
+	}
+	_, err := e.ensureAPIToken(ctx, auth)
+	return false, err
+}
+
 // ensureAPIToken gets or refreshes the Copilot API token.
 func (e *GitHubCopilotExecutor) ensureAPIToken(ctx context.Context, auth *modelgateauth.Auth) (string, error) {
 	if auth == nil {
@@ -447,13 +611,10 @@ func (e *GitHubCopilotExecutor) ensureAPIToken(ctx context.Context, auth *modelg
 		return "", statusErr{code: http.StatusUnauthorized, msg: "missing github access token"}
 	}
 
-	// Check for cached API token using thread-safe access
-	e.mu.RLock()
-	if cached, ok := e.cache[accessToken]; ok && cached.expiresAt.After(time.Now().Add(tokenExpiryBuffer)) {
-		e.mu.RUnlock()
-		return cached.token, nil
+	// Check the shared cache first; the background refresher keeps entries warm.
+	if token, ok := copilotTokenCache.lookup(accessToken); ok {
+		return token, nil
 	}
-	e.mu.RUnlock()
 
 	// Get a new Copilot API token
 	copilotAuth := copilotauth.NewCopilotAuth(e.cfg)
@@ -462,17 +623,7 @@ func (e *GitHubCopilotExecutor) ensureAPIToken(ctx context.Context, auth *modelg
 		return "", statusErr{code: http.StatusUnauthorized, msg: fmt.Sprintf("failed to get copilot api token: %v", err)}
 	}
 
-	// Cache the token with thread-safe access
-	expiresAt := time.Now().Add(githubCopilotTokenCacheTTL)
-	if apiToken.ExpiresAt > 0 {
-		expiresAt = time.Unix(apiToken.ExpiresAt, 0)
-	}
-	e.mu.Lock()
-	e.cache[accessToken] = &cachedAPIToken{
-		token:     apiToken.Token,
-		expiresAt: expiresAt,
-	}
-	e.mu.Unlock()
+	copilotTokenCache.store(accessToken, apiToken.Token, apiToken.ExpiresAt)
 
 	return apiToken.Token, nil
 }
@@ -0,0 +1,654 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	"github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	groqDefaultBaseURL = "https://api.groq.com/openai/v1"
+	groqChatEndpoint   = "/chat/completions"
+)
+
+// GroqExecutor executes OpenAI-compatible chat completions against the Groq
+// Cloud API using config-provided API keys. Groq reports server-side timing
+// (queue time, prompt time, completion time) alongside token usage; those
+// timings are attached to the usage record as tags so provider throughput can
+// be compared from the usage data alone.
+type GroqExecutor struct {
+	cfg *config.Config
+}
+
+// NewGroqExecutor constructs a new executor instance.
+func NewGroqExecutor(cfg *config.Config) *GroqExecutor { return &GroqExecutor{cfg: cfg} }
+
+// Identifier returns the provider key.
+func (e *GroqExecutor) Identifier() string { return "groq" }
+
+// PrepareRequest injects Groq credentials into the outgoing HTTP request.
+func (e *GroqExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, _ := groqCreds(auth)
+	if strings.TrimSpace(apiKey) != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects Groq credentials into the request and executes it.
+func (e *GroqExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("groq executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming chat completion request.
+func (e *GroqExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := groqCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("groq executor: missing api key")
+		return resp, err
+	}
+	if baseURL == "" {
+		baseURL = groqDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, false)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + groqChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyGroqHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("groq request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = groqStatusErr(httpResp.StatusCode, string(b), httpResp.Header)
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	applyGroqTimingTags(reporter, gjson.GetBytes(data, "usage"))
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming chat completion request.
+func (e *GroqExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, baseURL := groqCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("groq executor: missing api key")
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = groqDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, true)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + groqChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyGroqHeaders(httpReq, apiKey, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("groq streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = groqStatusErr(httpResp.StatusCode, string(data), httpResp.Header)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			if detail, timing, ok := parseGroqStreamUsage(line); ok {
+				applyGroqTimingTags(reporter, timing)
+				reporter.publish(ctx, detail)
+			}
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *GroqExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("groq executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("groq executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// ExecuteTranscriptions performs an audio transcription request against Groq's
+// Whisper-compatible endpoint. The internal envelope is repacked into the
+// multipart form the upstream expects.
+func (e *GroqExecutor) ExecuteTranscriptions(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := groqCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("groq executor: missing api key")
+		return resp, err
+	}
+	if baseURL == "" {
+		baseURL = groqDefaultBaseURL
+	}
+
+	envelope, err := parseTranscriptionEnvelope(req.Payload)
+	if err != nil {
+		err = statusErr{code: http.StatusBadRequest, msg: err.Error()}
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	body, contentType, err := whisperMultipartBody(envelope, req.Model)
+	if err != nil {
+		return resp, err
+	}
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/audio/transcriptions"
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Accept", "application/json")
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("groq transcription error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = groqStatusErr(httpResp.StatusCode, string(b), httpResp.Header)
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	// Transcription responses carry no token usage metadata; record the request itself.
+	reporter.ensurePublished(ctx)
+
+	resp = modelgateexecutor.Response{Payload: data}
+	return resp, nil
+}
+
+// ExecuteSpeech performs a text-to-speech request against Groq's
+// OpenAI-compatible endpoint and streams the raw audio bytes back. Usage is
+// reported by input character count since audio responses carry no token
+// metadata.
+func (e *GroqExecutor) ExecuteSpeech(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, baseURL := groqCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("groq executor: missing api key")
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = groqDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	body := bytes.Clone(req.Payload)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	inputChars := int64(len(gjson.GetBytes(body, "input").String()))
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + "/audio/speech"
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("groq speech error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = groqStatusErr(httpResp.StatusCode, string(data), httpResp.Header)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+			}
+		}()
+
+		buf := make([]byte, 32*1024)
+		for {
+			n, errRead := httpResp.Body.Read(buf)
+			if n > 0 {
+				out <- modelgateexecutor.StreamChunk{Payload: bytes.Clone(buf[:n])}
+			}
+			if errRead != nil {
+				if errRead != io.EOF {
+					recordAPIResponseError(ctx, e.cfg, errRead)
+					reporter.publishFailure(ctx)
+					out <- modelgateexecutor.StreamChunk{Err: errRead}
+					return
+				}
+				break
+			}
+		}
+		// Audio responses carry no token usage; report the input character count.
+		reporter.publish(ctx, usage.Detail{InputTokens: inputChars, TotalTokens: inputChars})
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+// Refresh is a no-op for API-key based Groq credentials.
+func (e *GroqExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+// FetchGroqModels lists the Groq model catalog so the routed models stay in
+// sync with upstream availability.
+func FetchGroqModels(ctx context.Context, auth *modelgateauth.Auth, cfg *config.Config) []*registry.ModelInfo {
+	apiKey, baseURL := groqCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		logEntry(ctx).Warnf("groq executor: fetch models failed for %s: missing credentials", auth.ID)
+		return nil
+	}
+	if baseURL == "" {
+		baseURL = groqDefaultBaseURL
+	}
+
+	modelsURL := strings.TrimSuffix(baseURL, "/") + "/models"
+	httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if errReq != nil {
+		logEntry(ctx).Warnf("groq executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+		return nil
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Accept", "application/json")
+
+	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
+	httpResp, errDo := httpClient.Do(httpReq)
+	if errDo != nil {
+		logEntry(ctx).Warnf("groq executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+		return nil
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("groq executor: close response body error: %v", errClose)
+		}
+	}()
+	bodyBytes, errRead := io.ReadAll(httpResp.Body)
+	if errRead != nil {
+		logEntry(ctx).Warnf("groq executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+		return nil
+	}
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		logEntry(ctx).Warnf("groq executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+		return nil
+	}
+
+	data := gjson.GetBytes(bodyBytes, "data")
+	if !data.Exists() || !data.IsArray() {
+		logEntry(ctx).Warnf("groq executor: fetch models failed for %s: no data field in response", auth.ID)
+		return nil
+	}
+
+	now := time.Now().Unix()
+	models := make([]*registry.ModelInfo, 0, len(data.Array()))
+	for _, entry := range data.Array() {
+		id := strings.TrimSpace(entry.Get("id").String())
+		if id == "" {
+			continue
+		}
+		created := entry.Get("created").Int()
+		if created == 0 {
+			created = now
+		}
+		ownedBy := strings.TrimSpace(entry.Get("owned_by").String())
+		if ownedBy == "" {
+			ownedBy = "groq"
+		}
+		models = append(models, &registry.ModelInfo{
+			ID:          id,
+			Object:      "model",
+			Created:     created,
+			OwnedBy:     ownedBy,
+			Type:        "groq",
+			DisplayName: id,
+			Description: id,
+		})
+	}
+	if len(models) == 0 {
+		logEntry(ctx).Warnf("groq executor: fetch models returned no models for %s", auth.ID)
+		return nil
+	}
+	return models
+}
+
+func applyGroqHeaders(r *http.Request, apiKey string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+apiKey)
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+}
+
+func groqCreds(a *modelgateauth.Auth) (apiKey, baseURL string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.Attributes != nil {
+		apiKey = strings.TrimSpace(a.Attributes["api_key"])
+		baseURL = strings.TrimSpace(a.Attributes["base_url"])
+	}
+	return apiKey, baseURL
+}
+
+// parseGroqStreamUsage extracts token usage from a Groq stream line. Groq
+// delivers usage on the final chunk under x_groq.usage rather than the
+// top-level usage field OpenAI uses, so both locations are checked. The usage
+// node is returned alongside the detail so timing fields can be read from it.
+func parseGroqStreamUsage(line []byte) (usage.Detail, gjson.Result, bool) {
+	payload := jsonPayload(line)
+	if len(payload) == 0 || !gjson.ValidBytes(payload) {
+		return usage.Detail{}, gjson.Result{}, false
+	}
+	usageNode := gjson.GetBytes(payload, "x_groq.usage")
+	if !usageNode.Exists() {
+		usageNode = gjson.GetBytes(payload, "usage")
+	}
+	if !usageNode.Exists() {
+		return usage.Detail{}, gjson.Result{}, false
+	}
+	detail := usage.Detail{
+		InputTokens:  usageNode.Get("prompt_tokens").Int(),
+		OutputTokens: usageNode.Get("completion_tokens").Int(),
+		TotalTokens:  usageNode.Get("total_tokens").Int(),
+	}
+	if cached := usageNode.Get("prompt_tokens_details.cached_tokens"); cached.Exists() {
+		detail.CachedTokens = cached.Int()
+	}
+	return detail, usageNode, true
+}
+
+// applyGroqTimingTags records Groq's server-side timing fields (seconds) as
+// usage tags, plus a derived completion tokens-per-second throughput figure.
+func applyGroqTimingTags(r *usageReporter, usageNode gjson.Result) {
+	if r == nil || !usageNode.Exists() {
+		return
+	}
+	for _, field := range []struct{ key, path string }{
+		{"groq_queue_time", "queue_time"},
+		{"groq_prompt_time", "prompt_time"},
+		{"groq_completion_time", "completion_time"},
+		{"groq_total_time", "total_time"},
+	} {
+		if v := usageNode.Get(field.path); v.Exists() {
+			r.addTag(field.key, strconv.FormatFloat(v.Float(), 'f', -1, 64))
+		}
+	}
+	if completionTime := usageNode.Get("completion_time").Float(); completionTime > 0 {
+		if tokens := usageNode.Get("completion_tokens").Float(); tokens > 0 {
+			r.addTag("groq_tokens_per_second", strconv.FormatFloat(tokens/completionTime, 'f', 2, 64))
+		}
+	}
+}
+
+// groqStatusErr builds a statusErr for an upstream failure, honoring the
+// Retry-After header Groq sends with 429 responses.
+func groqStatusErr(code int, body string, headers http.Header) statusErr {
+	sErr := statusErr{code: code, msg: body}
+	if code != http.StatusTooManyRequests || headers == nil {
+		return sErr
+	}
+	if raw := strings.TrimSpace(headers.Get("Retry-After")); raw != "" {
+		if seconds, err := strconv.ParseFloat(raw, 64); err == nil && seconds > 0 {
+			d := time.Duration(seconds * float64(time.Second))
+			sErr.retryAfter = &d
+		}
+	}
+	return sErr
+}
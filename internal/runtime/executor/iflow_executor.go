@@ -1,7 +1,6 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -12,6 +11,7 @@ import (
 
 	iflowauth "github.com/shariqriazz/modelgate/internal/auth/iflow"
 	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
 	"github.com/shariqriazz/modelgate/internal/util"
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
@@ -76,7 +76,7 @@ func (e *IFlowExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 		baseURL = iflowauth.DefaultAPIBaseURL
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -111,10 +111,12 @@ func (e *IFlowExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       endpoint,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -123,7 +125,7 @@ func (e *IFlowExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -131,7 +133,7 @@ func (e *IFlowExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 	}
 	defer func() {
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("iflow executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("iflow executor: close response body error: %v", errClose)
 		}
 	}()
 	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
@@ -139,7 +141,7 @@ func (e *IFlowExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, r
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		b, _ := io.ReadAll(httpResp.Body)
 		appendAPIResponseChunk(ctx, e.cfg, b)
-		log.Debugf("iflow request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		logEntry(ctx).Debugf("iflow request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
 		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
 		return resp, err
 	}
@@ -171,7 +173,7 @@ func (e *IFlowExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		baseURL = iflowauth.DefaultAPIBaseURL
 	}
 
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -212,10 +214,12 @@ func (e *IFlowExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       endpoint,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -224,7 +228,7 @@ func (e *IFlowExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -235,10 +239,10 @@ func (e *IFlowExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		data, _ := io.ReadAll(httpResp.Body)
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("iflow executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("iflow executor: close response body error: %v", errClose)
 		}
 		appendAPIResponseChunk(ctx, e.cfg, data)
-		log.Debugf("iflow streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		logEntry(ctx).Debugf("iflow streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
 		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
 		return nil, err
 	}
@@ -249,20 +253,22 @@ func (e *IFlowExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.A
 		defer close(out)
 		defer func() {
 			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Errorf("iflow executor: close response body error: %v", errClose)
+				logEntry(ctx).Errorf("iflow executor: close response body error: %v", errClose)
 			}
 		}()
 
-		scanner := bufio.NewScanner(httpResp.Body)
-		scanner.Buffer(nil, 52_428_800) // 50MB
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
 		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
 		for scanner.Scan() {
 			line := scanner.Bytes()
 			appendAPIResponseChunk(ctx, e.cfg, line)
 			if detail, ok := parseOpenAIStreamUsage(line); ok {
 				reporter.publish(ctx, detail)
 			}
-			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, bytes.Clone(line), &param)
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
 			for i := range chunks {
 				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
 			}
@@ -301,7 +307,7 @@ func (e *IFlowExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Aut
 
 // Refresh refreshes OAuth tokens or cookie-based API keys and updates the stored API key.
 func (e *IFlowExecutor) Refresh(ctx context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
-	log.Debugf("iflow executor: refresh called")
+	logEntry(ctx).Debugf("iflow executor: refresh called")
 	if auth == nil {
 		return nil, fmt.Errorf("iflow executor: auth is nil")
 	}
@@ -329,7 +335,7 @@ func (e *IFlowExecutor) Refresh(ctx context.Context, auth *modelgateauth.Auth) (
 
 // refreshCookieBased refreshes API key using browser cookie
 func (e *IFlowExecutor) refreshCookieBased(ctx context.Context, auth *modelgateauth.Auth, cookie, email string) (*modelgateauth.Auth, error) {
-	log.Debugf("iflow executor: checking refresh need for cookie-based API key for user: %s", email)
+	logEntry(ctx).Debugf("iflow executor: checking refresh need for cookie-based API key for user: %s", email)
 
 	// Get current expiry time from metadata
 	var currentExpire string
@@ -342,19 +348,19 @@ func (e *IFlowExecutor) refreshCookieBased(ctx context.Context, auth *modelgatea
 	// Check if refresh is needed
 	needsRefresh, _, err := iflowauth.ShouldRefreshAPIKey(currentExpire)
 	if err != nil {
-		log.Warnf("iflow executor: failed to check refresh need: %v", err)
+		logEntry(ctx).Warnf("iflow executor: failed to check refresh need: %v", err)
 		// If we can't check, continue with refresh anyway as a safety measure
 	} else if !needsRefresh {
-		log.Debugf("iflow executor: no refresh needed for user: %s", email)
+		logEntry(ctx).Debugf("iflow executor: no refresh needed for user: %s", email)
 		return auth, nil
 	}
 
-	log.Infof("iflow executor: refreshing cookie-based API key for user: %s", email)
+	logEntry(ctx).Infof("iflow executor: refreshing cookie-based API key for user: %s", email)
 
 	svc := iflowauth.NewIFlowAuth(e.cfg)
 	keyData, err := svc.RefreshAPIKey(ctx, cookie, email)
 	if err != nil {
-		log.Errorf("iflow executor: cookie-based API key refresh failed: %v", err)
+		logEntry(ctx).Errorf("iflow executor: cookie-based API key refresh failed: %v", err)
 		return nil, err
 	}
 
@@ -368,7 +374,7 @@ func (e *IFlowExecutor) refreshCookieBased(ctx context.Context, auth *modelgatea
 	auth.Metadata["cookie"] = cookie
 	auth.Metadata["email"] = email
 
-	log.Infof("iflow executor: cookie-based API key refreshed successfully, new expiry: %s", keyData.ExpireTime)
+	logEntry(ctx).Infof("iflow executor: cookie-based API key refreshed successfully, new expiry: %s", keyData.ExpireTime)
 
 	if auth.Attributes == nil {
 		auth.Attributes = make(map[string]string)
@@ -396,13 +402,13 @@ func (e *IFlowExecutor) refreshOAuthBased(ctx context.Context, auth *modelgateau
 
 	// Log the old access token (masked) before refresh
 	if oldAccessToken != "" {
-		log.Debugf("iflow executor: refreshing access token, old: %s", util.HideAPIKey(oldAccessToken))
+		logEntry(ctx).Debugf("iflow executor: refreshing access token, old: %s", util.HideAPIKey(oldAccessToken))
 	}
 
 	svc := iflowauth.NewIFlowAuth(e.cfg)
 	tokenData, err := svc.RefreshTokens(ctx, refreshToken)
 	if err != nil {
-		log.Errorf("iflow executor: token refresh failed: %v", err)
+		logEntry(ctx).Errorf("iflow executor: token refresh failed: %v", err)
 		return nil, err
 	}
 
@@ -421,7 +427,7 @@ func (e *IFlowExecutor) refreshOAuthBased(ctx context.Context, auth *modelgateau
 	auth.Metadata["last_refresh"] = time.Now().Format(time.RFC3339)
 
 	// Log the new access token (masked) after successful refresh
-	log.Debugf("iflow executor: token refresh successful, new: %s", util.HideAPIKey(tokenData.AccessToken))
+	logEntry(ctx).Debugf("iflow executor: token refresh successful, new: %s", util.HideAPIKey(tokenData.AccessToken))
 
 	if auth.Attributes == nil {
 		auth.Attributes = make(map[string]string)
@@ -560,3 +566,91 @@ func applyIFlowThinkingConfig(body []byte) []byte {
 
 	return body
 }
+
+// FetchIFlowModels retrieves the available model list using the supplied auth.
+// Models also present in the static catalog keep their definitions (thinking
+// support, display names); newly launched models get minimal entries so they
+// become routable without a code change.
+func FetchIFlowModels(ctx context.Context, auth *modelgateauth.Auth, cfg *config.Config) []*registry.ModelInfo {
+	apiKey, baseURL := iflowCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		logEntry(ctx).Warnf("iflow executor: fetch models failed for %s: missing credentials", auth.ID)
+		return nil
+	}
+	if baseURL == "" {
+		baseURL = iflowauth.DefaultAPIBaseURL
+	}
+
+	modelsURL := strings.TrimSuffix(baseURL, "/") + "/models"
+	httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if errReq != nil {
+		logEntry(ctx).Warnf("iflow executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+		return nil
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Accept", "application/json")
+	httpReq.Header.Set("User-Agent", iflowUserAgent)
+
+	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
+	httpResp, errDo := httpClient.Do(httpReq)
+	if errDo != nil {
+		logEntry(ctx).Warnf("iflow executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+		return nil
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("iflow executor: close response body error: %v", errClose)
+		}
+	}()
+	bodyBytes, errRead := io.ReadAll(httpResp.Body)
+	if errRead != nil {
+		logEntry(ctx).Warnf("iflow executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+		return nil
+	}
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		logEntry(ctx).Warnf("iflow executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+		return nil
+	}
+
+	data := gjson.GetBytes(bodyBytes, "data")
+	if !data.Exists() || !data.IsArray() {
+		logEntry(ctx).Warnf("iflow executor: fetch models failed for %s: no data field in response", auth.ID)
+		return nil
+	}
+
+	static := make(map[string]*registry.ModelInfo)
+	for _, m := range registry.GetIFlowModels() {
+		static[m.ID] = m
+	}
+
+	now := time.Now().Unix()
+	models := make([]*registry.ModelInfo, 0, len(data.Array()))
+	for _, entry := range data.Array() {
+		id := strings.TrimSpace(entry.Get("id").String())
+		if id == "" {
+			continue
+		}
+		if known, ok := static[id]; ok {
+			models = append(models, known)
+			continue
+		}
+		created := entry.Get("created").Int()
+		if created == 0 {
+			created = now
+		}
+		models = append(models, &registry.ModelInfo{
+			ID:          id,
+			Object:      "model",
+			Created:     created,
+			OwnedBy:     "iflow",
+			Type:        "iflow",
+			DisplayName: id,
+			Description: id,
+		})
+	}
+	if len(models) == 0 {
+		logEntry(ctx).Warnf("iflow executor: fetch models returned no models for %s", auth.ID)
+		return nil
+	}
+	return models
+}
@@ -0,0 +1,71 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// imagenPredictRequestFromOpenAI converts an OpenAI images generation request
+// into an Imagen :predict payload. The prompt becomes the single instance and
+// n/size map onto sampleCount/aspectRatio parameters.
+func imagenPredictRequestFromOpenAI(body []byte) ([]byte, error) {
+	root := gjson.ParseBytes(body)
+	prompt := root.Get("prompt").String()
+	if prompt == "" {
+		return nil, fmt.Errorf("images request missing prompt")
+	}
+
+	parameters := map[string]any{"sampleCount": 1}
+	if n := root.Get("n").Int(); n > 0 {
+		parameters["sampleCount"] = n
+	}
+	if ratio := imagenAspectRatio(root.Get("size").String()); ratio != "" {
+		parameters["aspectRatio"] = ratio
+	}
+
+	return json.Marshal(map[string]any{
+		"instances":  []map[string]any{{"prompt": prompt}},
+		"parameters": parameters,
+	})
+}
+
+// imagenAspectRatio maps an OpenAI "{width}x{height}" size onto the closest
+// aspect ratio Imagen accepts. Unknown or square sizes return "" so the
+// upstream default applies.
+func imagenAspectRatio(size string) string {
+	switch size {
+	case "", "256x256", "512x512", "1024x1024":
+		return ""
+	case "1792x1024", "1536x1024":
+		return "16:9"
+	case "1024x1792", "1024x1536":
+		return "9:16"
+	}
+	return ""
+}
+
+// imagenPredictResponseToOpenAI converts an Imagen :predict response into an
+// OpenAI images list. Imagen always returns inline base64 payloads, so each
+// prediction becomes a b64_json entry.
+func imagenPredictResponseToOpenAI(data []byte) []byte {
+	type imageItem struct {
+		B64JSON string `json:"b64_json"`
+	}
+	type imageList struct {
+		Created int64       `json:"created"`
+		Data    []imageItem `json:"data"`
+	}
+	out := imageList{Created: time.Now().Unix(), Data: []imageItem{}}
+	for _, prediction := range gjson.GetBytes(data, "predictions").Array() {
+		encoded := prediction.Get("bytesBase64Encoded").String()
+		if encoded == "" {
+			continue
+		}
+		out.Data = append(out.Data, imageItem{B64JSON: encoded})
+	}
+	payload, _ := json.Marshal(out)
+	return payload
+}
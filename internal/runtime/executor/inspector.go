@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"sync"
+	"time"
+)
+
+// InspectorEvent is one sanitized request/response summary streamed to live
+// inspector subscribers. Bodies and credentials are never included.
+type InspectorEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Type is "request" (upstream attempt dispatched), "status" (upstream
+	// HTTP status received), or "response" (usage accounted).
+	Type      string `json:"type"`
+	Provider  string `json:"provider,omitempty"`
+	Model     string `json:"model,omitempty"`
+	AuthID    string `json:"auth_id,omitempty"`
+	AuthLabel string `json:"auth_label,omitempty"`
+	Method    string `json:"method,omitempty"`
+	URL       string `json:"url,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	LatencyMS int64  `json:"latency_ms,omitempty"`
+	Failed    bool   `json:"failed,omitempty"`
+
+	InputTokens  int64 `json:"input_tokens,omitempty"`
+	OutputTokens int64 `json:"output_tokens,omitempty"`
+	TotalTokens  int64 `json:"total_tokens,omitempty"`
+}
+
+// inspectorHub fans InspectorEvents out to live subscribers. Slow subscribers
+// drop events rather than block the request path.
+type inspectorHub struct {
+	mu          sync.Mutex
+	nextID      int
+	subscribers map[int]chan InspectorEvent
+}
+
+var defaultInspectorHub = &inspectorHub{subscribers: make(map[int]chan InspectorEvent)}
+
+// SubscribeInspector registers a live inspector subscriber. The returned
+// cancel function must be called to release the subscription.
+func SubscribeInspector() (<-chan InspectorEvent, func()) {
+	return defaultInspectorHub.subscribe()
+}
+
+func (h *inspectorHub) subscribe() (<-chan InspectorEvent, func()) {
+	ch := make(chan InspectorEvent, 64)
+	h.mu.Lock()
+	id := h.nextID
+	h.nextID++
+	h.subscribers[id] = ch
+	h.mu.Unlock()
+	cancel := func() {
+		h.mu.Lock()
+		if existing, ok := h.subscribers[id]; ok {
+			delete(h.subscribers, id)
+			close(existing)
+		}
+		h.mu.Unlock()
+	}
+	return ch, cancel
+}
+
+// broadcast delivers the event to every subscriber without blocking; events
+// to subscribers with full buffers are dropped.
+func (h *inspectorHub) broadcast(event InspectorEvent) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	h.mu.Lock()
+	for _, ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	h.mu.Unlock()
+}
+
+// hasSubscribers reports whether any inspector client is connected so event
+// assembly can be skipped entirely otherwise.
+func (h *inspectorHub) hasSubscribers() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers) > 0
+}
+
+// publishInspectorEvent broadcasts an event when at least one live inspector
+// is connected.
+func publishInspectorEvent(event InspectorEvent) {
+	if !defaultInspectorHub.hasSubscribers() {
+		return
+	}
+	defaultInspectorHub.broadcast(event)
+}
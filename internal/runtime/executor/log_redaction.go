@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+var (
+	// base64RunPattern matches long base64 runs such as inline image, audio,
+	// or document payloads. Ordinary JSON text never produces runs this long.
+	base64RunPattern = regexp.MustCompile(`[A-Za-z0-9+/_-]{512,}={0,2}`)
+
+	// credentialFieldPattern matches credential-bearing JSON string fields in
+	// logged bodies (e.g. "api_key":"...", "authorization":"Bearer ...").
+	credentialFieldPattern = regexp.MustCompile(`(?i)("(?:api[_-]?key|authorization|access[_-]?token|refresh[_-]?token|id[_-]?token|token|secret|password)"\s*:\s*")([^"]+)(")`)
+)
+
+// redactLoggedChunk applies the strip-base64 and mask-credentials redaction
+// rules to a body fragment before it is persisted to the request log.
+func redactLoggedChunk(cfg *config.Config, data []byte) []byte {
+	if cfg == nil || len(data) == 0 {
+		return data
+	}
+	rules := cfg.RequestLogRedaction
+	if rules.StripBase64 {
+		data = base64RunPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+			return []byte(fmt.Sprintf("[base64 omitted: %d bytes]", len(match)))
+		})
+	}
+	if rules.MaskCredentials {
+		data = credentialFieldPattern.ReplaceAll(data, []byte(`${1}***${3}`))
+	}
+	return data
+}
+
+// redactLoggedBody applies all redaction rules, including the per-body size
+// cap, to a complete request or response body.
+func redactLoggedBody(cfg *config.Config, body []byte) []byte {
+	if cfg == nil || len(body) == 0 {
+		return body
+	}
+	out := redactLoggedChunk(cfg, body)
+	if limit := cfg.RequestLogRedaction.MaxBodyBytes; limit > 0 && len(out) > limit {
+		capped := bytes.Clone(out[:limit])
+		capped = append(capped, []byte(fmt.Sprintf("\n[truncated %d bytes: request-log-redaction.max-body-bytes]", len(out)-limit))...)
+		out = capped
+	}
+	return out
+}
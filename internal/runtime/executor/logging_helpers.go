@@ -12,9 +12,21 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/logging"
 	"github.com/shariqriazz/modelgate/internal/util"
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	log "github.com/sirupsen/logrus"
 )
 
+// logEntry returns a logrus entry carrying the request_id field when the
+// context holds a request ID, so executor logs correlate with handler logs.
+func logEntry(ctx context.Context) *log.Entry {
+	if reqID := logging.GetRequestID(ctx); reqID != "" {
+		return log.WithField("request_id", reqID)
+	}
+	return log.NewEntry(log.StandardLogger())
+}
+
 const (
 	apiAttemptsKey = "API_UPSTREAM_ATTEMPTS"
 	apiRequestKey  = "API_REQUEST"
@@ -32,6 +44,7 @@ type upstreamRequestLog struct {
 	AuthLabel string
 	AuthType  string
 	AuthValue string
+	Tags      map[string]string
 }
 
 type upstreamAttempt struct {
@@ -43,18 +56,29 @@ type upstreamAttempt struct {
 	headersWritten       bool
 	bodyStarted          bool
 	bodyHasContent       bool
+	bodyBytes            int
+	bodyTruncated        bool
 	errorWritten         bool
 }
 
 // recordAPIRequest stores the upstream request metadata in Gin context for request logging.
 func recordAPIRequest(ctx context.Context, cfg *config.Config, info upstreamRequestLog) {
-	if cfg == nil || !cfg.RequestLog {
-		return
-	}
 	ginCtx := ginContextFrom(ctx)
 	if ginCtx == nil {
 		return
 	}
+	recordDebugAttempt(ginCtx, info)
+	publishInspectorEvent(InspectorEvent{
+		Type:      "request",
+		Provider:  info.Provider,
+		AuthID:    info.AuthID,
+		AuthLabel: info.AuthLabel,
+		Method:    info.Method,
+		URL:       info.URL,
+	})
+	if cfg == nil || !cfg.RequestLog {
+		return
+	}
 
 	attempts := getAttempts(ginCtx)
 	index := len(attempts) + 1
@@ -73,11 +97,23 @@ func recordAPIRequest(ctx context.Context, cfg *config.Config, info upstreamRequ
 	if auth := formatAuthInfo(info); auth != "" {
 		builder.WriteString(fmt.Sprintf("Auth: %s\n", auth))
 	}
+	if len(info.Tags) > 0 {
+		keys := make([]string, 0, len(info.Tags))
+		for key := range info.Tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		builder.WriteString("Tags:")
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf(" %s=%s", key, info.Tags[key]))
+		}
+		builder.WriteString("\n")
+	}
 	builder.WriteString("\nHeaders:\n")
 	writeHeaders(builder, info.Headers)
 	builder.WriteString("\nBody:\n")
 	if len(info.Body) > 0 {
-		builder.WriteString(string(bytes.Clone(info.Body)))
+		builder.WriteString(string(redactLoggedBody(cfg, bytes.Clone(info.Body))))
 	} else {
 		builder.WriteString("<empty>")
 	}
@@ -95,13 +131,15 @@ func recordAPIRequest(ctx context.Context, cfg *config.Config, info upstreamRequ
 
 // recordAPIResponseMetadata captures upstream response status/header information for the latest attempt.
 func recordAPIResponseMetadata(ctx context.Context, cfg *config.Config, status int, headers http.Header) {
-	if cfg == nil || !cfg.RequestLog {
-		return
-	}
 	ginCtx := ginContextFrom(ctx)
 	if ginCtx == nil {
 		return
 	}
+	recordDebugStatus(ginCtx, status)
+	publishInspectorEvent(InspectorEvent{Type: "status", Status: status})
+	if cfg == nil || !cfg.RequestLog {
+		return
+	}
 	attempts, attempt := ensureAttempt(ginCtx)
 	ensureResponseIntro(attempt)
 
@@ -121,13 +159,14 @@ func recordAPIResponseMetadata(ctx context.Context, cfg *config.Config, status i
 
 // recordAPIResponseError adds an error entry for the latest attempt when no HTTP response is available.
 func recordAPIResponseError(ctx context.Context, cfg *config.Config, err error) {
-	if cfg == nil || !cfg.RequestLog || err == nil {
-		return
-	}
 	ginCtx := ginContextFrom(ctx)
 	if ginCtx == nil {
 		return
 	}
+	recordDebugError(ginCtx, err)
+	if cfg == nil || !cfg.RequestLog || err == nil {
+		return
+	}
 	attempts, attempt := ensureAttempt(ginCtx)
 	ensureResponseIntro(attempt)
 
@@ -153,6 +192,7 @@ func appendAPIResponseChunk(ctx context.Context, cfg *config.Config, chunk []byt
 	if len(data) == 0 {
 		return
 	}
+	data = redactLoggedChunk(cfg, data)
 	ginCtx := ginContextFrom(ctx)
 	if ginCtx == nil {
 		return
@@ -170,10 +210,28 @@ func appendAPIResponseChunk(ctx context.Context, cfg *config.Config, chunk []byt
 		attempt.response.WriteString("Body:\n")
 		attempt.bodyStarted = true
 	}
+	if limit := cfg.RequestLogRedaction.MaxBodyBytes; limit > 0 {
+		if attempt.bodyTruncated {
+			return
+		}
+		if attempt.bodyBytes+len(data) > limit {
+			remaining := limit - attempt.bodyBytes
+			if remaining > 0 {
+				data = data[:remaining]
+			} else {
+				data = nil
+			}
+			attempt.bodyTruncated = true
+		}
+	}
 	if attempt.bodyHasContent {
 		attempt.response.WriteString("\n\n")
 	}
 	attempt.response.WriteString(string(data))
+	attempt.bodyBytes += len(data)
+	if attempt.bodyTruncated {
+		attempt.response.WriteString("\n[body truncated: request-log-redaction.max-body-bytes reached]")
+	}
 	attempt.bodyHasContent = true
 
 	updateAggregatedResponse(ginCtx, attempts)
@@ -374,3 +432,9 @@ func (e statusErr) Error() string {
 }
 func (e statusErr) StatusCode() int            { return e.code }
 func (e statusErr) RetryAfter() *time.Duration { return e.retryAfter }
+
+// Is maps the status code onto the exported executor error taxonomy so callers
+// can branch with errors.Is(err, cliproxyexecutor.ErrRateLimited) and friends.
+func (e statusErr) Is(target error) bool {
+	return cliproxyexecutor.StatusMatches(target, e.code, e.msg)
+}
@@ -0,0 +1,543 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	ollamaDefaultBaseURL  = "http://127.0.0.1:11434"
+	ollamaChatEndpoint    = "/api/chat"
+	ollamaTagsEndpoint    = "/api/tags"
+	ollamaStreamChunkSize = 1024 * 1024
+)
+
+// OllamaExecutor executes chat completions against a local or remote Ollama
+// server using its native /api/chat endpoint. Requests are translated through
+// the OpenAI chat format and converted to Ollama's request/response shapes,
+// so locally hosted models participate in routing like any cloud provider.
+type OllamaExecutor struct {
+	cfg *config.Config
+}
+
+// NewOllamaExecutor constructs a new executor instance.
+func NewOllamaExecutor(cfg *config.Config) *OllamaExecutor { return &OllamaExecutor{cfg: cfg} }
+
+// Identifier returns the provider key.
+func (e *OllamaExecutor) Identifier() string { return "ollama" }
+
+// PrepareRequest injects the optional Ollama bearer token into the outgoing HTTP request.
+func (e *OllamaExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	_, apiKey := ollamaCreds(auth)
+	if strings.TrimSpace(apiKey) != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects Ollama credentials into the request and executes it.
+func (e *OllamaExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("ollama executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming chat request.
+func (e *OllamaExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	baseURL, apiKey := ollamaCreds(auth)
+	if baseURL == "" {
+		baseURL = ollamaDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	openAIBody := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	openAIBody, _ = sjson.SetBytes(openAIBody, "model", req.Model)
+	body := ollamaChatRequestFromOpenAI(openAIBody, false)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + ollamaChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyOllamaHeaders(httpReq, apiKey)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("ollama executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("ollama request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
+		return resp, err
+	}
+
+	raw, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, raw)
+	data := ollamaResponseToOpenAI(raw, req.Model)
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), openAIBody, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming chat request. Ollama streams NDJSON
+// objects; each one is converted to an OpenAI SSE chunk before translation.
+func (e *OllamaExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	baseURL, apiKey := ollamaCreds(auth)
+	if baseURL == "" {
+		baseURL = ollamaDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	openAIBody := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	openAIBody, _ = sjson.SetBytes(openAIBody, "model", req.Model)
+	body := ollamaChatRequestFromOpenAI(openAIBody, true)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + ollamaChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyOllamaHeaders(httpReq, apiKey)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("ollama executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("ollama streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("ollama executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := bufio.NewScanner(httpResp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), ollamaStreamChunkSize)
+		var param any
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		emit := func(line []byte) {
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, openAIBody, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		for scanner.Scan() {
+			line := bytes.TrimSpace(scanner.Bytes())
+			if len(line) == 0 {
+				continue
+			}
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			converted, done := ollamaChunkToOpenAI(line, req.Model)
+			if len(converted) == 0 {
+				continue
+			}
+			if detail, ok := parseOpenAIStreamUsage(append([]byte("data: "), converted...)); ok {
+				reporter.publish(ctx, detail)
+			}
+			emit(append([]byte("data: "), converted...))
+			if done {
+				emit([]byte("data: [DONE]"))
+				break
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *OllamaExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("ollama executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("ollama executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// Refresh is a no-op: local Ollama servers have no credentials to renew.
+func (e *OllamaExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+// FetchOllamaModels lists the models installed on the Ollama server via
+// /api/tags so they can be registered alongside cloud provider catalogs.
+func FetchOllamaModels(ctx context.Context, auth *modelgateauth.Auth, cfg *config.Config) []*registry.ModelInfo {
+	baseURL, apiKey := ollamaCreds(auth)
+	if baseURL == "" {
+		baseURL = ollamaDefaultBaseURL
+	}
+
+	tagsURL := strings.TrimSuffix(baseURL, "/") + ollamaTagsEndpoint
+	httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodGet, tagsURL, nil)
+	if errReq != nil {
+		logEntry(ctx).Warnf("ollama executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+		return nil
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if strings.TrimSpace(apiKey) != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
+	httpResp, errDo := httpClient.Do(httpReq)
+	if errDo != nil {
+		logEntry(ctx).Warnf("ollama executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+		return nil
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("ollama executor: close response body error: %v", errClose)
+		}
+	}()
+	bodyBytes, errRead := io.ReadAll(httpResp.Body)
+	if errRead != nil {
+		logEntry(ctx).Warnf("ollama executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+		return nil
+	}
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		logEntry(ctx).Warnf("ollama executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+		return nil
+	}
+
+	list := gjson.GetBytes(bodyBytes, "models")
+	if !list.Exists() || !list.IsArray() {
+		logEntry(ctx).Warnf("ollama executor: fetch models failed for %s: no models field in response", auth.ID)
+		return nil
+	}
+
+	now := time.Now().Unix()
+	models := make([]*registry.ModelInfo, 0, len(list.Array()))
+	for _, entry := range list.Array() {
+		id := strings.TrimSpace(entry.Get("name").String())
+		if id == "" {
+			continue
+		}
+		created := now
+		if modified, errParse := time.Parse(time.RFC3339Nano, entry.Get("modified_at").String()); errParse == nil {
+			created = modified.Unix()
+		}
+		models = append(models, &registry.ModelInfo{
+			ID:          id,
+			Object:      "model",
+			Created:     created,
+			OwnedBy:     "ollama",
+			Type:        "ollama",
+			DisplayName: id,
+			Description: id,
+		})
+	}
+	if len(models) == 0 {
+		logEntry(ctx).Warnf("ollama executor: fetch models returned no models for %s", auth.ID)
+		return nil
+	}
+	return models
+}
+
+func applyOllamaHeaders(r *http.Request, apiKey string) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Accept", "application/json")
+	if strings.TrimSpace(apiKey) != "" {
+		r.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+}
+
+func ollamaCreds(a *modelgateauth.Auth) (baseURL, apiKey string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.Attributes != nil {
+		baseURL = strings.TrimSpace(a.Attributes["base_url"])
+		apiKey = strings.TrimSpace(a.Attributes["api_key"])
+	}
+	return baseURL, apiKey
+}
+
+// ollamaChatRequestFromOpenAI converts an OpenAI chat completion request into
+// Ollama's /api/chat shape. Sampling parameters move under "options" and
+// multi-part text content is flattened, since Ollama expects plain strings.
+func ollamaChatRequestFromOpenAI(openAIBody []byte, stream bool) []byte {
+	root := gjson.ParseBytes(openAIBody)
+	out := []byte(`{}`)
+	out, _ = sjson.SetBytes(out, "model", root.Get("model").String())
+	out, _ = sjson.SetBytes(out, "stream", stream)
+
+	messages := make([]map[string]any, 0)
+	for _, msg := range root.Get("messages").Array() {
+		entry := map[string]any{"role": msg.Get("role").String()}
+		content := msg.Get("content")
+		if content.IsArray() {
+			var sb strings.Builder
+			for _, part := range content.Array() {
+				if part.Get("type").String() == "text" {
+					sb.WriteString(part.Get("text").String())
+				}
+			}
+			entry["content"] = sb.String()
+		} else {
+			entry["content"] = content.String()
+		}
+		if calls := msg.Get("tool_calls"); calls.IsArray() {
+			toolCalls := make([]map[string]any, 0, len(calls.Array()))
+			for _, call := range calls.Array() {
+				var args any
+				if raw := call.Get("function.arguments").String(); raw != "" {
+					if errUnmarshal := json.Unmarshal([]byte(raw), &args); errUnmarshal != nil {
+						args = map[string]any{}
+					}
+				}
+				toolCalls = append(toolCalls, map[string]any{
+					"function": map[string]any{
+						"name":      call.Get("function.name").String(),
+						"arguments": args,
+					},
+				})
+			}
+			entry["tool_calls"] = toolCalls
+		}
+		messages = append(messages, entry)
+	}
+	out, _ = sjson.SetBytes(out, "messages", messages)
+
+	if tools := root.Get("tools"); tools.IsArray() {
+		out, _ = sjson.SetRawBytes(out, "tools", []byte(tools.Raw))
+	}
+	if v := root.Get("temperature"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "options.temperature", v.Float())
+	}
+	if v := root.Get("top_p"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "options.top_p", v.Float())
+	}
+	if v := root.Get("max_tokens"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "options.num_predict", v.Int())
+	}
+	if v := root.Get("stop"); v.Exists() {
+		out, _ = sjson.SetRawBytes(out, "options.stop", []byte(v.Raw))
+	}
+	return out
+}
+
+// ollamaResponseToOpenAI converts a non-streaming /api/chat response into an
+// OpenAI chat completion object so downstream translators can consume it.
+func ollamaResponseToOpenAI(raw []byte, model string) []byte {
+	root := gjson.ParseBytes(raw)
+	now := time.Now().Unix()
+	out := []byte(`{"object":"chat.completion"}`)
+	out, _ = sjson.SetBytes(out, "id", fmt.Sprintf("chatcmpl-%d", now))
+	out, _ = sjson.SetBytes(out, "created", now)
+	out, _ = sjson.SetBytes(out, "model", model)
+
+	message := map[string]any{
+		"role":    "assistant",
+		"content": root.Get("message.content").String(),
+	}
+	finishReason := "stop"
+	if calls := root.Get("message.tool_calls"); calls.IsArray() && len(calls.Array()) > 0 {
+		message["tool_calls"] = ollamaToolCallsToOpenAI(calls)
+		finishReason = "tool_calls"
+	}
+	out, _ = sjson.SetBytes(out, "choices.0.index", 0)
+	out, _ = sjson.SetBytes(out, "choices.0.message", message)
+	out, _ = sjson.SetBytes(out, "choices.0.finish_reason", finishReason)
+
+	promptTokens := root.Get("prompt_eval_count").Int()
+	completionTokens := root.Get("eval_count").Int()
+	out, _ = sjson.SetBytes(out, "usage.prompt_tokens", promptTokens)
+	out, _ = sjson.SetBytes(out, "usage.completion_tokens", completionTokens)
+	out, _ = sjson.SetBytes(out, "usage.total_tokens", promptTokens+completionTokens)
+	return out
+}
+
+// ollamaChunkToOpenAI converts one NDJSON stream object into an OpenAI chat
+// completion chunk. The second return reports whether this was the final chunk.
+func ollamaChunkToOpenAI(line []byte, model string) ([]byte, bool) {
+	root := gjson.ParseBytes(line)
+	if !root.IsObject() {
+		return nil, false
+	}
+	done := root.Get("done").Bool()
+	now := time.Now().Unix()
+	out := []byte(`{"object":"chat.completion.chunk"}`)
+	out, _ = sjson.SetBytes(out, "id", fmt.Sprintf("chatcmpl-%d", now))
+	out, _ = sjson.SetBytes(out, "created", now)
+	out, _ = sjson.SetBytes(out, "model", model)
+	out, _ = sjson.SetBytes(out, "choices.0.index", 0)
+
+	delta := map[string]any{}
+	if content := root.Get("message.content").String(); content != "" {
+		delta["role"] = "assistant"
+		delta["content"] = content
+	}
+	if calls := root.Get("message.tool_calls"); calls.IsArray() && len(calls.Array()) > 0 {
+		delta["role"] = "assistant"
+		delta["tool_calls"] = ollamaToolCallsToOpenAI(calls)
+	}
+	out, _ = sjson.SetBytes(out, "choices.0.delta", delta)
+
+	if done {
+		finishReason := "stop"
+		if _, hasCalls := delta["tool_calls"]; hasCalls {
+			finishReason = "tool_calls"
+		}
+		out, _ = sjson.SetBytes(out, "choices.0.finish_reason", finishReason)
+		promptTokens := root.Get("prompt_eval_count").Int()
+		completionTokens := root.Get("eval_count").Int()
+		out, _ = sjson.SetBytes(out, "usage.prompt_tokens", promptTokens)
+		out, _ = sjson.SetBytes(out, "usage.completion_tokens", completionTokens)
+		out, _ = sjson.SetBytes(out, "usage.total_tokens", promptTokens+completionTokens)
+	} else {
+		out, _ = sjson.SetRawBytes(out, "choices.0.finish_reason", []byte("null"))
+	}
+	return out, done
+}
+
+// ollamaToolCallsToOpenAI maps Ollama tool calls (object arguments) to OpenAI
+// tool calls (stringified arguments).
+func ollamaToolCallsToOpenAI(calls gjson.Result) []map[string]any {
+	out := make([]map[string]any, 0, len(calls.Array()))
+	for i, call := range calls.Array() {
+		args := call.Get("function.arguments").Raw
+		if args == "" {
+			args = "{}"
+		}
+		out = append(out, map[string]any{
+			"index": i,
+			"id":    fmt.Sprintf("call_%d_%d", time.Now().UnixNano(), i),
+			"type":  "function",
+			"function": map[string]any{
+				"name":      call.Get("function.name").String(),
+				"arguments": args,
+			},
+		})
+	}
+	return out
+}
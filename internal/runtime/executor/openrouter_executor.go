@@ -0,0 +1,474 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	"github.com/shariqriazz/modelgate/internal/util"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	openRouterDefaultBaseURL  = "https://openrouter.ai/api/v1"
+	openRouterChatEndpoint    = "/chat/completions"
+	openRouterDefaultReferer  = "https://github.com/shariqriazz/modelgate"
+	openRouterDefaultAppTitle = "ModelGate"
+)
+
+// OpenRouterExecutor executes OpenAI-compatible chat completions against the
+// OpenRouter API using config-provided API keys. OpenRouter requires the
+// HTTP-Referer and X-Title attribution headers, and reports remaining credits
+// through rate-limit headers that feed the retry hint on 429 responses.
+type OpenRouterExecutor struct {
+	cfg *config.Config
+}
+
+// NewOpenRouterExecutor constructs a new executor instance.
+func NewOpenRouterExecutor(cfg *config.Config) *OpenRouterExecutor {
+	return &OpenRouterExecutor{cfg: cfg}
+}
+
+// Identifier returns the provider key.
+func (e *OpenRouterExecutor) Identifier() string { return "openrouter" }
+
+// PrepareRequest injects OpenRouter credentials into the outgoing HTTP request.
+func (e *OpenRouterExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, _ := openRouterCreds(auth)
+	if strings.TrimSpace(apiKey) != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects OpenRouter credentials into the request and executes it.
+func (e *OpenRouterExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("openrouter executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// Execute performs a non-streaming chat completion request.
+func (e *OpenRouterExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := openRouterCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("openrouter executor: missing api key")
+		return resp, err
+	}
+	if baseURL == "" {
+		baseURL = openRouterDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, false)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + openRouterChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyOpenRouterHeaders(httpReq, auth, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("openrouter executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	logOpenRouterCredits(auth, httpResp.Header)
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("openrouter request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = openRouterStatusErr(httpResp.StatusCode, string(b), httpResp.Header)
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseOpenAIUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming chat completion request.
+func (e *OpenRouterExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, baseURL := openRouterCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("openrouter executor: missing api key")
+		return nil, err
+	}
+	if baseURL == "" {
+		baseURL = openRouterDefaultBaseURL
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, true)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	body, _ = sjson.SetBytes(body, "model", req.Model)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := strings.TrimSuffix(baseURL, "/") + openRouterChatEndpoint
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyOpenRouterHeaders(httpReq, auth, apiKey, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	logOpenRouterCredits(auth, httpResp.Header)
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("openrouter executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("openrouter streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = openRouterStatusErr(httpResp.StatusCode, string(data), httpResp.Header)
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("openrouter executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			if detail, ok := parseOpenAIStreamUsage(line); ok {
+				reporter.publish(ctx, detail)
+			}
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+func (e *OpenRouterExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("openai")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	enc, err := tokenizerForModel(req.Model)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("openrouter executor: tokenizer init failed: %w", err)
+	}
+
+	count, err := countOpenAIChatTokens(enc, body)
+	if err != nil {
+		return modelgateexecutor.Response{}, fmt.Errorf("openrouter executor: token counting failed: %w", err)
+	}
+
+	usageJSON := buildOpenAIUsageJSON(count)
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, usageJSON)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// Refresh is a no-op for API-key based OpenRouter credentials.
+func (e *OpenRouterExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+// FetchOpenRouterModels lists the OpenRouter model catalog so the routed
+// models stay in sync with upstream availability.
+func FetchOpenRouterModels(ctx context.Context, auth *modelgateauth.Auth, cfg *config.Config) []*registry.ModelInfo {
+	apiKey, baseURL := openRouterCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		logEntry(ctx).Warnf("openrouter executor: fetch models failed for %s: missing credentials", auth.ID)
+		return nil
+	}
+	if baseURL == "" {
+		baseURL = openRouterDefaultBaseURL
+	}
+
+	modelsURL := strings.TrimSuffix(baseURL, "/") + "/models"
+	httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodGet, modelsURL, nil)
+	if errReq != nil {
+		logEntry(ctx).Warnf("openrouter executor: fetch models failed for %s: create request error: %v", auth.ID, errReq)
+		return nil
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+	httpReq.Header.Set("Accept", "application/json")
+
+	httpClient := newProxyAwareHTTPClient(ctx, cfg, auth, 0)
+	httpResp, errDo := httpClient.Do(httpReq)
+	if errDo != nil {
+		logEntry(ctx).Warnf("openrouter executor: fetch models failed for %s: request error: %v", auth.ID, errDo)
+		return nil
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("openrouter executor: close response body error: %v", errClose)
+		}
+	}()
+	bodyBytes, errRead := io.ReadAll(httpResp.Body)
+	if errRead != nil {
+		logEntry(ctx).Warnf("openrouter executor: fetch models failed for %s: read body error: %v", auth.ID, errRead)
+		return nil
+	}
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		logEntry(ctx).Warnf("openrouter executor: fetch models failed for %s: unexpected status %d, body: %s", auth.ID, httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), bodyBytes))
+		return nil
+	}
+
+	data := gjson.GetBytes(bodyBytes, "data")
+	if !data.Exists() || !data.IsArray() {
+		logEntry(ctx).Warnf("openrouter executor: fetch models failed for %s: no data field in response", auth.ID)
+		return nil
+	}
+
+	now := time.Now().Unix()
+	models := make([]*registry.ModelInfo, 0, len(data.Array()))
+	for _, entry := range data.Array() {
+		id := strings.TrimSpace(entry.Get("id").String())
+		if id == "" {
+			continue
+		}
+		created := entry.Get("created").Int()
+		if created == 0 {
+			created = now
+		}
+		display := strings.TrimSpace(entry.Get("name").String())
+		if display == "" {
+			display = id
+		}
+		models = append(models, &registry.ModelInfo{
+			ID:          id,
+			Object:      "model",
+			Created:     created,
+			OwnedBy:     "openrouter",
+			Type:        "openrouter",
+			DisplayName: display,
+			Description: display,
+		})
+	}
+	if len(models) == 0 {
+		logEntry(ctx).Warnf("openrouter executor: fetch models returned no models for %s", auth.ID)
+		return nil
+	}
+	return models
+}
+
+// applyOpenRouterHeaders sets auth and the attribution headers OpenRouter
+// requires. Config-provided headers are applied last so they can override the
+// default referer and title.
+func applyOpenRouterHeaders(r *http.Request, auth *modelgateauth.Auth, apiKey string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+apiKey)
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+	referer := openRouterDefaultReferer
+	title := openRouterDefaultAppTitle
+	if auth != nil && auth.Attributes != nil {
+		if v := strings.TrimSpace(auth.Attributes["site_url"]); v != "" {
+			referer = v
+		}
+		if v := strings.TrimSpace(auth.Attributes["site_name"]); v != "" {
+			title = v
+		}
+	}
+	r.Header.Set("HTTP-Referer", referer)
+	r.Header.Set("X-Title", title)
+	if auth != nil {
+		util.ApplyCustomHeadersFromAttrs(r, auth.Attributes)
+	}
+}
+
+func openRouterCreds(a *modelgateauth.Auth) (apiKey, baseURL string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.Attributes != nil {
+		apiKey = strings.TrimSpace(a.Attributes["api_key"])
+		baseURL = strings.TrimSpace(a.Attributes["base_url"])
+	}
+	return apiKey, baseURL
+}
+
+// openRouterStatusErr builds a statusErr for an upstream failure, deriving a
+// retry hint from OpenRouter's rate-limit headers so the scheduler cools the
+// key until the reported window resets.
+func openRouterStatusErr(code int, body string, headers http.Header) statusErr {
+	sErr := statusErr{code: code, msg: body}
+	if code != http.StatusTooManyRequests && code != http.StatusPaymentRequired {
+		return sErr
+	}
+	if retryAfter := openRouterRetryAfter(headers); retryAfter != nil {
+		sErr.retryAfter = retryAfter
+	}
+	return sErr
+}
+
+// openRouterRetryAfter derives a retry delay from OpenRouter rate-limit
+// headers. X-RateLimit-Reset carries a unix-millisecond timestamp; a plain
+// Retry-After header (seconds) is the fallback.
+func openRouterRetryAfter(headers http.Header) *time.Duration {
+	if headers == nil {
+		return nil
+	}
+	if raw := strings.TrimSpace(headers.Get("X-RateLimit-Reset")); raw != "" {
+		if resetMillis, err := strconv.ParseInt(raw, 10, 64); err == nil && resetMillis > 0 {
+			if d := time.Until(time.UnixMilli(resetMillis)); d > 0 {
+				return &d
+			}
+		}
+	}
+	if raw := strings.TrimSpace(headers.Get("Retry-After")); raw != "" {
+		if seconds, err := strconv.ParseFloat(raw, 64); err == nil && seconds > 0 {
+			d := time.Duration(seconds * float64(time.Second))
+			return &d
+		}
+	}
+	return nil
+}
+
+// logOpenRouterCredits surfaces the remaining-credit headers at debug level so
+// exhausted keys can be diagnosed without extra API calls.
+func logOpenRouterCredits(auth *modelgateauth.Auth, headers http.Header) {
+	if headers == nil || !log.IsLevelEnabled(log.DebugLevel) {
+		return
+	}
+	remaining := strings.TrimSpace(headers.Get("X-RateLimit-Remaining"))
+	limit := strings.TrimSpace(headers.Get("X-RateLimit-Limit"))
+	if remaining == "" && limit == "" {
+		return
+	}
+	authID := ""
+	if auth != nil {
+		authID = auth.ID
+	}
+	log.Debugf("openrouter credits for %s: remaining=%s limit=%s", authID, remaining, limit)
+}
@@ -0,0 +1,35 @@
+package executor
+
+import (
+	"net/http"
+	"time"
+
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+// applyRequestOptions merges caller-supplied extra headers from executor options
+// into the outbound upstream request. Option headers override provider defaults
+// on key collisions. It is a no-op for nil requests or empty options.
+func applyRequestOptions(r *http.Request, opts modelgateexecutor.Options) {
+	if r == nil || len(opts.Headers) == 0 {
+		return
+	}
+	for key, values := range opts.Headers {
+		if len(values) == 0 {
+			continue
+		}
+		r.Header.Del(key)
+		for _, value := range values {
+			r.Header.Add(key, value)
+		}
+	}
+}
+
+// optionTimeout returns the caller-supplied per-request timeout, or zero to keep
+// the provider default.
+func optionTimeout(opts modelgateexecutor.Options) time.Duration {
+	if opts.Timeout > 0 {
+		return opts.Timeout
+	}
+	return 0
+}
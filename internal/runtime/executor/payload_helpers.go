@@ -10,6 +10,7 @@ import (
 	"github.com/shariqriazz/modelgate/internal/thinking"
 	"github.com/shariqriazz/modelgate/internal/util"
 	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -116,6 +117,10 @@ func applyPayloadConfigWithRoot(cfg *config.Config, model, protocol, root string
 	if cfg == nil || len(payload) == 0 {
 		return payload
 	}
+	// Raw passthrough models go upstream verbatim; payload rules do not apply.
+	if sdktranslator.IsPassthroughModel(model) || sdktranslator.IsPassthroughModel(requestedModel) {
+		return payload
+	}
 	rules := cfg.Payload
 	if len(rules.Default) == 0 && len(rules.DefaultRaw) == 0 && len(rules.Override) == 0 && len(rules.OverrideRaw) == 0 && len(rules.Filter) == 0 {
 		return payload
@@ -1,7 +1,6 @@
 package executor
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"fmt"
@@ -15,7 +14,6 @@ import (
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
-	log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -24,8 +22,24 @@ const (
 	qwenUserAgent           = "google-api-nodejs-client/9.15.1"
 	qwenXGoogAPIClient      = "gl-node/22.17.0"
 	qwenClientMetadataValue = "ideType=IDE_UNSPECIFIED,platform=PLATFORM_UNSPECIFIED,pluginType=GEMINI"
+
+	qwenDefaultBaseURL       = "https://portal.qwen.ai/v1"
+	qwenDashScopeCNBaseURL   = "https://dashscope.aliyuncs.com/compatible-mode/v1"
+	qwenDashScopeIntlBaseURL = "https://dashscope-intl.aliyuncs.com/compatible-mode/v1"
 )
 
+// qwenRegionBaseURL maps a region attribute to the matching DashScope
+// endpoint. Unknown regions return "" so callers fall back to the portal.
+func qwenRegionBaseURL(region string) string {
+	switch strings.ToLower(strings.TrimSpace(region)) {
+	case "cn", "china":
+		return qwenDashScopeCNBaseURL
+	case "intl", "international":
+		return qwenDashScopeIntlBaseURL
+	}
+	return ""
+}
+
 // QwenExecutor is a stateless executor for Qwen Code using OpenAI-compatible chat completions.
 // If access token is unavailable, it falls back to legacy via ClientAdapter.
 type QwenExecutor struct {
@@ -68,9 +82,9 @@ func (e *QwenExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, re
 	token, baseURL := qwenCreds(auth)
 
 	if baseURL == "" {
-		baseURL = "https://portal.qwen.ai/v1"
+		baseURL = qwenDefaultBaseURL
 	}
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -102,10 +116,12 @@ func (e *QwenExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, re
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -114,7 +130,7 @@ func (e *QwenExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, re
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -122,14 +138,14 @@ func (e *QwenExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, re
 	}
 	defer func() {
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("qwen executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("qwen executor: close response body error: %v", errClose)
 		}
 	}()
 	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		b, _ := io.ReadAll(httpResp.Body)
 		appendAPIResponseChunk(ctx, e.cfg, b)
-		log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
 		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
 		return resp, err
 	}
@@ -150,9 +166,9 @@ func (e *QwenExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Au
 	token, baseURL := qwenCreds(auth)
 
 	if baseURL == "" {
-		baseURL = "https://portal.qwen.ai/v1"
+		baseURL = qwenDefaultBaseURL
 	}
-	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth)
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
 	defer reporter.trackFailure(ctx, &err)
 
 	from := opts.SourceFormat
@@ -192,10 +208,12 @@ func (e *QwenExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Au
 		authLabel = auth.Label
 		authType, authValue = auth.AccountInfo()
 	}
+	applyRequestOptions(httpReq, opts)
 	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
 		URL:       url,
 		Method:    http.MethodPost,
 		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
 		Body:      body,
 		Provider:  e.Identifier(),
 		AuthID:    authID,
@@ -204,7 +222,7 @@ func (e *QwenExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Au
 		AuthValue: authValue,
 	})
 
-	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
 	httpResp, err := httpClient.Do(httpReq)
 	if err != nil {
 		recordAPIResponseError(ctx, e.cfg, err)
@@ -214,9 +232,9 @@ func (e *QwenExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Au
 	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
 		b, _ := io.ReadAll(httpResp.Body)
 		appendAPIResponseChunk(ctx, e.cfg, b)
-		log.Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		logEntry(ctx).Debugf("request error, error status: %d, error body: %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
 		if errClose := httpResp.Body.Close(); errClose != nil {
-			log.Errorf("qwen executor: close response body error: %v", errClose)
+			logEntry(ctx).Errorf("qwen executor: close response body error: %v", errClose)
 		}
 		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
 		return nil, err
@@ -227,24 +245,26 @@ func (e *QwenExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Au
 		defer close(out)
 		defer func() {
 			if errClose := httpResp.Body.Close(); errClose != nil {
-				log.Errorf("qwen executor: close response body error: %v", errClose)
+				logEntry(ctx).Errorf("qwen executor: close response body error: %v", errClose)
 			}
 		}()
-		scanner := bufio.NewScanner(httpResp.Body)
-		scanner.Buffer(nil, 52_428_800) // 50MB
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
 		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
 		for scanner.Scan() {
 			line := scanner.Bytes()
 			appendAPIResponseChunk(ctx, e.cfg, line)
 			if detail, ok := parseOpenAIStreamUsage(line); ok {
 				reporter.publish(ctx, detail)
 			}
-			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, bytes.Clone(line), &param)
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
 			for i := range chunks {
 				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
 			}
 		}
-		doneChunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, bytes.Clone([]byte("[DONE]")), &param)
+		doneChunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone([]byte("[DONE]")), &param)
 		for i := range doneChunks {
 			out <- modelgateexecutor.StreamChunk{Payload: []byte(doneChunks[i])}
 		}
@@ -283,7 +303,7 @@ func (e *QwenExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth
 }
 
 func (e *QwenExecutor) Refresh(ctx context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
-	log.Debugf("qwen executor: refresh called")
+	logEntry(ctx).Debugf("qwen executor: refresh called")
 	if auth == nil {
 		return nil, fmt.Errorf("qwen executor: auth is nil")
 	}
@@ -346,6 +366,9 @@ func qwenCreds(a *modelgateauth.Auth) (token, baseURL string) {
 		if v := a.Attributes["base_url"]; v != "" {
 			baseURL = v
 		}
+		if baseURL == "" {
+			baseURL = qwenRegionBaseURL(a.Attributes["region"])
+		}
 	}
 	if token == "" && a.Metadata != nil {
 		if v, ok := a.Metadata["access_token"].(string); ok {
@@ -355,5 +378,10 @@ func qwenCreds(a *modelgateauth.Auth) (token, baseURL string) {
 			baseURL = fmt.Sprintf("https://%s/v1", v)
 		}
 	}
+	if baseURL == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["region"].(string); ok {
+			baseURL = qwenRegionBaseURL(v)
+		}
+	}
 	return
 }
@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+const anthropicRateLimitPrefix = "anthropic-ratelimit-"
+
+// applyClaudeRateLimitHeaders emits anthropic-ratelimit-* headers on
+// Claude-format responses so pacing clients (notably Claude Code) keep
+// working through the proxy. Gateway key budgets (rpm_limit/tpm_limit
+// attributes) supply the limits; upstream anthropic-ratelimit headers, when
+// present, are forwarded and cap the remaining headroom at whichever side
+// reports less.
+func applyClaudeRateLimitHeaders(ctx context.Context, auth *modelgateauth.Auth, opts modelgateexecutor.Options, upstream http.Header) {
+	if !strings.EqualFold(string(opts.SourceFormat), "claude") {
+		return
+	}
+	ginCtx := ginContextFrom(ctx)
+	if ginCtx == nil || ginCtx.Writer.Written() {
+		return
+	}
+	header := ginCtx.Writer.Header()
+	if snapshot, ok := modelgateauth.DefaultKeyBudgetTracker().Snapshot(auth, time.Now()); ok {
+		if snapshot.RPMLimit > 0 {
+			header.Set(anthropicRateLimitPrefix+"requests-limit", strconv.Itoa(snapshot.RPMLimit))
+			header.Set(anthropicRateLimitPrefix+"requests-remaining", strconv.Itoa(snapshot.RPMRemaining))
+			header.Set(anthropicRateLimitPrefix+"requests-reset", snapshot.RPMResetAt.UTC().Format(time.RFC3339))
+		}
+		if snapshot.TPMLimit > 0 {
+			header.Set(anthropicRateLimitPrefix+"tokens-limit", strconv.FormatInt(snapshot.TPMLimit, 10))
+			header.Set(anthropicRateLimitPrefix+"tokens-remaining", strconv.FormatInt(snapshot.TPMRemaining, 10))
+			header.Set(anthropicRateLimitPrefix+"tokens-reset", snapshot.TPMResetAt.UTC().Format(time.RFC3339))
+		}
+	}
+	for key, values := range upstream {
+		lower := strings.ToLower(key)
+		if !strings.HasPrefix(lower, anthropicRateLimitPrefix) || len(values) == 0 {
+			continue
+		}
+		value := strings.TrimSpace(values[0])
+		if value == "" {
+			continue
+		}
+		if strings.HasSuffix(lower, "-remaining") {
+			if existing := header.Get(lower); existing != "" && !remainingIsLower(value, existing) {
+				// The gateway budget is the tighter constraint; keep it.
+				continue
+			}
+		} else if header.Get(lower) != "" {
+			// Limits and reset times from the gateway budget stay authoritative
+			// once set; only fill gaps from upstream.
+			continue
+		}
+		header.Set(lower, value)
+	}
+}
+
+// remainingIsLower reports whether candidate is a numeric headroom strictly
+// below current. Non-numeric values never win.
+func remainingIsLower(candidate, current string) bool {
+	candidateN, errCandidate := strconv.ParseInt(candidate, 10, 64)
+	currentN, errCurrent := strconv.ParseInt(current, 10, 64)
+	if errCandidate != nil || errCurrent != nil {
+		return false
+	}
+	return candidateN < currentN
+}
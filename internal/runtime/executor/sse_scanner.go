@@ -0,0 +1,149 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// sseScanner splits a Server-Sent Events stream into lines with the same
+// Scan/Bytes/Err surface as bufio.Scanner, but parses the stream as SSE
+// events instead of raw lines:
+//
+//   - there is no maximum token size, so oversized events never fail with
+//     "token too long";
+//   - CRLF line endings are normalized to bare LF;
+//   - comment lines (leading ':') such as keep-alive pings are dropped;
+//   - consecutive data fields of one event are merged into a single
+//     "data: ..." line, so downstream translators always see one complete
+//     payload per data line.
+//
+// Non-data field lines (event:, id:, retry:) and the blank line terminating
+// each event are passed through unchanged so passthrough streams keep their
+// framing.
+type sseScanner struct {
+	r *bufio.Reader
+	// provider tags errors and resolves the configured event size cap.
+	provider string
+	// limit caps the byte size of a single event; zero means unbounded.
+	limit int64
+	// queue holds the lines of the most recently parsed event.
+	queue [][]byte
+	cur   []byte
+	err   error
+	done  bool
+}
+
+// newSSEScanner wraps an upstream response body in an SSE-aware line scanner
+// for the given provider, applying that provider's configured event size cap.
+func newSSEScanner(provider string, r io.Reader) *sseScanner {
+	return &sseScanner{
+		r:        bufio.NewReader(r),
+		provider: provider,
+		limit:    streamScannerLimitFor(provider),
+	}
+}
+
+// Scan advances to the next line, returning false at end of stream or on error.
+func (s *sseScanner) Scan() bool {
+	for len(s.queue) == 0 {
+		if s.done {
+			return false
+		}
+		s.readEvent()
+	}
+	s.cur = s.queue[0]
+	s.queue = s.queue[1:]
+	return true
+}
+
+// Bytes returns the current line. The slice is owned by the scanner's queue
+// entry and remains valid until the stream is garbage collected, matching the
+// copy discipline callers already apply per line.
+func (s *sseScanner) Bytes() []byte {
+	return s.cur
+}
+
+// Err returns the first non-EOF error encountered while reading the stream.
+func (s *sseScanner) Err() error {
+	return s.err
+}
+
+// readEvent consumes one SSE event (through its terminating blank line or
+// EOF) and appends the normalized lines to the queue.
+func (s *sseScanner) readEvent() {
+	var fieldLines [][]byte
+	var dataParts [][]byte
+	sawData := false
+
+	flush := func(terminated bool) {
+		s.queue = append(s.queue, fieldLines...)
+		if sawData {
+			data := append([]byte("data: "), bytes.Join(dataParts, []byte("\n"))...)
+			s.queue = append(s.queue, data)
+		}
+		if terminated {
+			// Preserve the event-terminating blank line for framing.
+			s.queue = append(s.queue, []byte{})
+		}
+	}
+
+	var eventBytes int64
+	for {
+		line, errRead := s.readLine(&eventBytes)
+		line = bytes.TrimSuffix(line, []byte("\n"))
+		line = bytes.TrimSuffix(line, []byte("\r"))
+
+		if len(line) > 0 {
+			switch {
+			case line[0] == ':':
+				// Comment/keep-alive; drop it.
+			case bytes.HasPrefix(line, []byte("data:")):
+				sawData = true
+				dataParts = append(dataParts, bytes.TrimPrefix(line[5:], []byte(" ")))
+			case bytes.Equal(line, []byte("data")):
+				sawData = true
+				dataParts = append(dataParts, nil)
+			default:
+				fieldLines = append(fieldLines, line)
+			}
+		}
+
+		if errRead != nil {
+			s.done = true
+			if errRead != io.EOF {
+				s.err = errRead
+			}
+			if s.err != nil {
+				// Don't hand a truncated event downstream.
+				return
+			}
+			flush(false)
+			return
+		}
+		if len(line) == 0 {
+			flush(true)
+			return
+		}
+	}
+}
+
+// readLine reads one line of unbounded length, accounting the consumed bytes
+// against the current event's size budget. When the configured cap is
+// exceeded the stream fails with a descriptive error instead of buffering the
+// rest of the event.
+func (s *sseScanner) readLine(eventBytes *int64) ([]byte, error) {
+	var line []byte
+	for {
+		frag, err := s.r.ReadSlice('\n')
+		line = append(line, frag...)
+		*eventBytes += int64(len(frag))
+		if s.limit > 0 && *eventBytes > s.limit {
+			return line, sseEventTooLargeError(s.provider, s.limit)
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		return line, err
+	}
+}
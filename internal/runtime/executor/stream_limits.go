@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// streamScannerLimits holds per-provider SSE event size caps in bytes, built
+// from the stream-scanner-limit-mb config map.
+var streamScannerLimits atomic.Value // map[string]int64
+
+// SetStreamScannerLimits replaces the per-provider SSE event size caps. Keys
+// are provider identifiers (e.g. "codex", "gemini-cli") or "default" for
+// providers without an explicit entry; values are megabytes. Zero or negative
+// values clear the cap, leaving the stream unbounded. It is wired to the
+// stream-scanner-limit-mb config key.
+func SetStreamScannerLimits(limits map[string]int) {
+	resolved := make(map[string]int64, len(limits))
+	for provider, mb := range limits {
+		provider = strings.ToLower(strings.TrimSpace(provider))
+		if provider == "" || mb <= 0 {
+			continue
+		}
+		resolved[provider] = int64(mb) * 1024 * 1024
+	}
+	streamScannerLimits.Store(resolved)
+}
+
+// streamScannerLimitFor resolves the SSE event size cap in bytes for a
+// provider, falling back to the "default" entry. Zero means unbounded.
+func streamScannerLimitFor(provider string) int64 {
+	limits, _ := streamScannerLimits.Load().(map[string]int64)
+	if limits == nil {
+		return 0
+	}
+	if limit, ok := limits[strings.ToLower(provider)]; ok {
+		return limit
+	}
+	return limits["default"]
+}
+
+// sseEventTooLargeError is returned as the scanner error when a single SSE
+// event outgrows the configured cap, so clients see a descriptive error event
+// instead of a raw scanner failure.
+func sseEventTooLargeError(provider string, limit int64) error {
+	return fmt.Errorf("sse stream: event from provider %s exceeded the configured %d MB scanner limit; raise stream-scanner-limit-mb to allow larger events", provider, limit/(1024*1024))
+}
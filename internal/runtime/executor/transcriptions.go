@@ -0,0 +1,83 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"mime/multipart"
+
+	"github.com/tidwall/gjson"
+)
+
+// transcriptionEnvelope mirrors the internal JSON contract the front end builds
+// from the multipart upload on /v1/audio/transcriptions.
+type transcriptionEnvelope struct {
+	Model          string
+	FileName       string
+	MimeType       string
+	Audio          []byte
+	Language       string
+	Prompt         string
+	ResponseFormat string
+	Temperature    string
+}
+
+// parseTranscriptionEnvelope decodes the internal transcription payload.
+func parseTranscriptionEnvelope(payload []byte) (*transcriptionEnvelope, error) {
+	root := gjson.ParseBytes(payload)
+	encoded := root.Get("audio_b64").String()
+	if encoded == "" {
+		return nil, fmt.Errorf("transcription request missing audio data")
+	}
+	audio, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("transcription request has invalid audio data: %w", err)
+	}
+	envelope := &transcriptionEnvelope{
+		Model:          root.Get("model").String(),
+		FileName:       root.Get("file_name").String(),
+		MimeType:       root.Get("mime_type").String(),
+		Audio:          audio,
+		Language:       root.Get("language").String(),
+		Prompt:         root.Get("prompt").String(),
+		ResponseFormat: root.Get("response_format").String(),
+		Temperature:    root.Get("temperature").String(),
+	}
+	if envelope.FileName == "" {
+		envelope.FileName = "audio"
+	}
+	return envelope, nil
+}
+
+// whisperMultipartBody rebuilds the Whisper-compatible multipart form from the
+// internal envelope for upstreams that take OpenAI-style audio uploads.
+func whisperMultipartBody(envelope *transcriptionEnvelope, model string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", envelope.FileName)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err = part.Write(envelope.Audio); err != nil {
+		return nil, "", err
+	}
+	fields := map[string]string{
+		"model":           model,
+		"language":        envelope.Language,
+		"prompt":          envelope.Prompt,
+		"response_format": envelope.ResponseFormat,
+		"temperature":     envelope.Temperature,
+	}
+	for name, value := range fields {
+		if value == "" {
+			continue
+		}
+		if err = writer.WriteField(name, value); err != nil {
+			return nil, "", err
+		}
+	}
+	if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), writer.FormDataContentType(), nil
+}
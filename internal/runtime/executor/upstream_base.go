@@ -0,0 +1,100 @@
+package executor
+
+import (
+	"strings"
+
+	claudeauth "github.com/shariqriazz/modelgate/internal/auth/claude"
+	iflowauth "github.com/shariqriazz/modelgate/internal/auth/iflow"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+// UpstreamBaseURL resolves the upstream API base for a provider/auth pair so
+// raw proxy routes can forward arbitrary paths. It honors a per-auth base_url
+// override before falling back to the provider default, matching what the
+// executors use for modeled endpoints. Providers whose upstream spans multiple
+// bases (gemini-cli, antigravity) return "" and are not proxyable.
+func UpstreamBaseURL(provider string, auth *modelgateauth.Auth) string {
+	switch strings.ToLower(strings.TrimSpace(provider)) {
+	case "claude":
+		_, _, baseURL := claudeCreds(auth)
+		if baseURL == "" {
+			baseURL = claudeauth.DefaultAPIBaseURL
+		}
+		return baseURL
+	case "codex":
+		_, baseURL := codexCreds(auth)
+		if baseURL == "" {
+			baseURL = "https://chatgpt.com/backend-api/codex"
+		}
+		return baseURL
+	case "cerebras":
+		_, baseURL := cerebrasCreds(auth)
+		if baseURL == "" {
+			baseURL = cerebrasDefaultBaseURL
+		}
+		return baseURL
+	case "azure-openai":
+		_, endpoint, _ := azureOpenAICreds(auth)
+		return endpoint
+	case "ollama":
+		baseURL, _ := ollamaCreds(auth)
+		if baseURL == "" {
+			baseURL = ollamaDefaultBaseURL
+		}
+		return baseURL
+	case "openrouter":
+		_, baseURL := openRouterCreds(auth)
+		if baseURL == "" {
+			baseURL = openRouterDefaultBaseURL
+		}
+		return baseURL
+	case "mistral":
+		_, baseURL := mistralCreds(auth)
+		if baseURL == "" {
+			baseURL = mistralDefaultBaseURL
+		}
+		return baseURL
+	case "groq":
+		_, baseURL := groqCreds(auth)
+		if baseURL == "" {
+			baseURL = groqDefaultBaseURL
+		}
+		return baseURL
+	case "deepseek":
+		_, baseURL := deepseekCreds(auth)
+		if baseURL == "" {
+			baseURL = deepseekDefaultBaseURL
+		}
+		return baseURL
+	case "cohere":
+		_, baseURL := cohereCreds(auth)
+		if baseURL == "" {
+			baseURL = cohereDefaultBaseURL
+		}
+		return baseURL
+	case "cloudflare":
+		_, accountID, baseURL := cloudflareCreds(auth)
+		return cloudflareAccountBase(baseURL, accountID)
+	case "iflow":
+		_, baseURL := iflowCreds(auth)
+		if baseURL == "" {
+			baseURL = iflowauth.DefaultAPIBaseURL
+		}
+		return baseURL
+	case "qwen":
+		_, baseURL := qwenCreds(auth)
+		if baseURL == "" {
+			baseURL = qwenDefaultBaseURL
+		}
+		return baseURL
+	case "vertex-express":
+		_, baseURL := vertexExpressCreds(auth)
+		if baseURL == "" {
+			baseURL = vertexExpressDefaultBaseURL
+		}
+		return baseURL
+	case githubCopilotAuthType:
+		return githubCopilotBaseURL
+	}
+	return ""
+}
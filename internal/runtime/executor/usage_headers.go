@@ -0,0 +1,75 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+)
+
+const (
+	usageHeaderInputTokens  = "X-ModelGate-Usage-Input-Tokens"
+	usageHeaderOutputTokens = "X-ModelGate-Usage-Output-Tokens"
+	usageHeaderCost         = "X-ModelGate-Usage-Cost"
+	usageHeaderAuthLabel    = "X-ModelGate-Usage-Auth-Label"
+
+	// apiUsageKey stores the rendered usage announcement on the gin context
+	// for streams whose headers are already committed; the stream forwarder
+	// picks it up and emits it as the final SSE event.
+	apiUsageKey = "API_USAGE"
+)
+
+var usageHeadersEnabled atomic.Bool
+
+// SetUsageHeadersEnabled toggles usage response headers globally. It is wired
+// to the usage-headers config key and safe to flip on reload.
+func SetUsageHeadersEnabled(enabled bool) {
+	usageHeadersEnabled.Store(enabled)
+}
+
+// usageAnnouncement mirrors the response header names so SSE consumers can
+// reuse the same keys they would read from a non-streaming reply.
+type usageAnnouncement struct {
+	InputTokens  int64   `json:"x-modelgate-usage-input-tokens"`
+	OutputTokens int64   `json:"x-modelgate-usage-output-tokens"`
+	Cost         float64 `json:"x-modelgate-usage-cost,omitempty"`
+	AuthLabel    string  `json:"x-modelgate-usage-auth-label,omitempty"`
+}
+
+// announceUsage surfaces the parsed usage detail to the client. When the
+// response has not been written yet (non-streaming) the values go out as
+// headers; otherwise they are stashed for the stream forwarder to append as a
+// terminal usage event.
+func announceUsage(ctx context.Context, detail usage.Detail, cost float64, authLabel string) {
+	if !usageHeadersEnabled.Load() {
+		return
+	}
+	ginCtx := ginContextFrom(ctx)
+	if ginCtx == nil {
+		return
+	}
+	if !ginCtx.Writer.Written() {
+		header := ginCtx.Writer.Header()
+		header.Set(usageHeaderInputTokens, strconv.FormatInt(detail.InputTokens, 10))
+		header.Set(usageHeaderOutputTokens, strconv.FormatInt(detail.OutputTokens, 10))
+		if cost > 0 {
+			header.Set(usageHeaderCost, strconv.FormatFloat(cost, 'f', -1, 64))
+		}
+		if authLabel != "" {
+			header.Set(usageHeaderAuthLabel, authLabel)
+		}
+		return
+	}
+	payload, err := json.Marshal(usageAnnouncement{
+		InputTokens:  detail.InputTokens,
+		OutputTokens: detail.OutputTokens,
+		Cost:         cost,
+		AuthLabel:    authLabel,
+	})
+	if err != nil {
+		return
+	}
+	ginCtx.Set(apiUsageKey, payload)
+}
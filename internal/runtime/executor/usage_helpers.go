@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shariqriazz/modelgate/internal/registry"
 	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	"github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
@@ -20,13 +22,15 @@ type usageReporter struct {
 	model       string
 	authID      string
 	authIndex   string
+	authLabel   string
 	apiKey      string
 	source      string
+	tags        map[string]string
 	requestedAt time.Time
 	once        sync.Once
 }
 
-func newUsageReporter(ctx context.Context, provider, model string, auth *modelgateauth.Auth) *usageReporter {
+func newUsageReporter(ctx context.Context, provider, model string, auth *modelgateauth.Auth, opts modelgateexecutor.Options) *usageReporter {
 	apiKey := apiKeyFromContext(ctx)
 	reporter := &usageReporter{
 		provider:    provider,
@@ -34,14 +38,30 @@ func newUsageReporter(ctx context.Context, provider, model string, auth *modelga
 		requestedAt: time.Now(),
 		apiKey:      apiKey,
 		source:      resolveUsageSource(auth, apiKey),
+		tags:        opts.Tags,
 	}
 	if auth != nil {
 		reporter.authID = auth.ID
 		reporter.authIndex = auth.EnsureIndex()
+		reporter.authLabel = strings.TrimSpace(auth.Label)
 	}
 	return reporter
 }
 
+// addTag attaches a provider-specific label to the usage record. The options
+// map is shared with the caller, so the first added tag copies it.
+func (r *usageReporter) addTag(key, value string) {
+	if r == nil || strings.TrimSpace(key) == "" || strings.TrimSpace(value) == "" {
+		return
+	}
+	tags := make(map[string]string, len(r.tags)+1)
+	for k, v := range r.tags {
+		tags[k] = v
+	}
+	tags[key] = value
+	r.tags = tags
+}
+
 func (r *usageReporter) publish(ctx context.Context, detail usage.Detail) {
 	r.publishWithOutcome(ctx, detail, false)
 }
@@ -73,6 +93,22 @@ func (r *usageReporter) publishWithOutcome(ctx context.Context, detail usage.Det
 		return
 	}
 	r.once.Do(func() {
+		cost, _ := registry.EstimateCostUSD(r.model, detail.InputTokens, detail.OutputTokens, detail.ReasoningTokens, detail.CachedTokens, detail.TotalTokens)
+		publishInspectorEvent(InspectorEvent{
+			Type:         "response",
+			Provider:     r.provider,
+			Model:        r.model,
+			AuthID:       r.authID,
+			AuthLabel:    r.authLabel,
+			LatencyMS:    time.Since(r.requestedAt).Milliseconds(),
+			Failed:       failed,
+			InputTokens:  detail.InputTokens,
+			OutputTokens: detail.OutputTokens,
+			TotalTokens:  detail.TotalTokens,
+		})
+		if !failed {
+			announceUsage(ctx, detail, cost, r.authLabel)
+		}
 		usage.PublishRecord(ctx, usage.Record{
 			Provider:    r.provider,
 			Model:       r.model,
@@ -83,6 +119,8 @@ func (r *usageReporter) publishWithOutcome(ctx context.Context, detail usage.Det
 			RequestedAt: r.requestedAt,
 			Failed:      failed,
 			Detail:      detail,
+			CostUSD:     cost,
+			Tags:        r.tags,
 		})
 	})
 }
@@ -106,6 +144,7 @@ func (r *usageReporter) ensurePublished(ctx context.Context) {
 			RequestedAt: r.requestedAt,
 			Failed:      false,
 			Detail:      usage.Detail{},
+			Tags:        r.tags,
 		})
 	})
 }
@@ -0,0 +1,632 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	modelgateauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	modelgateexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+)
+
+const vertexExpressDefaultBaseURL = "https://aiplatform.googleapis.com"
+
+// VertexExpressExecutor executes Gemini requests against Vertex AI in express
+// mode, where a plain API key replaces service-account authentication and the
+// global publisher endpoint is used instead of a regional project path.
+type VertexExpressExecutor struct {
+	cfg *config.Config
+}
+
+// NewVertexExpressExecutor constructs a new executor instance.
+func NewVertexExpressExecutor(cfg *config.Config) *VertexExpressExecutor {
+	return &VertexExpressExecutor{cfg: cfg}
+}
+
+// Identifier returns the provider key.
+func (e *VertexExpressExecutor) Identifier() string { return "vertex-express" }
+
+// PrepareRequest injects the express-mode API key into the outgoing HTTP request.
+func (e *VertexExpressExecutor) PrepareRequest(req *http.Request, auth *modelgateauth.Auth) error {
+	if req == nil {
+		return nil
+	}
+	apiKey, _ := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) != "" {
+		req.Header.Set("x-goog-api-key", apiKey)
+	}
+	return nil
+}
+
+// HttpRequest injects express-mode credentials into the request and executes it.
+func (e *VertexExpressExecutor) HttpRequest(ctx context.Context, auth *modelgateauth.Auth, req *http.Request) (*http.Response, error) {
+	if req == nil {
+		return nil, fmt.Errorf("vertex express executor: request is nil")
+	}
+	if ctx == nil {
+		ctx = req.Context()
+	}
+	httpReq := req.WithContext(ctx)
+	if err := e.PrepareRequest(httpReq, auth); err != nil {
+		return nil, err
+	}
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, 0)
+	return httpClient.Do(httpReq)
+}
+
+// vertexExpressEndpoint builds the publisher model URL for the given action.
+func vertexExpressEndpoint(baseURL, model, action string) string {
+	if baseURL == "" {
+		baseURL = vertexExpressDefaultBaseURL
+	}
+	return fmt.Sprintf("%s/v1/publishers/google/models/%s:%s", strings.TrimSuffix(baseURL, "/"), model, action)
+}
+
+// Execute performs a non-streaming generate content request.
+func (e *VertexExpressExecutor) Execute(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("vertex express executor: missing api key")
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("gemini")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, false)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := vertexExpressEndpoint(baseURL, req.Model, "generateContent")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyVertexExpressHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("vertex express request error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseGeminiUsage(data))
+	// Ensure usage is recorded even if upstream omits usage metadata.
+	reporter.ensurePublished(ctx)
+
+	var param any
+	out := sdktranslator.TranslateNonStream(ctx, to, from, req.Model, bytes.Clone(opts.OriginalRequest), body, data, &param)
+	resp = modelgateexecutor.Response{Payload: []byte(out)}
+	return resp, nil
+}
+
+// ExecuteStream performs a streaming generate content request.
+func (e *VertexExpressExecutor) ExecuteStream(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (stream <-chan modelgateexecutor.StreamChunk, err error) {
+	apiKey, baseURL := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("vertex express executor: missing api key")
+		return nil, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("gemini")
+	originalPayload := bytes.Clone(req.Payload)
+	if len(opts.OriginalRequest) > 0 {
+		originalPayload = bytes.Clone(opts.OriginalRequest)
+	}
+	originalTranslated := sdktranslator.TranslateRequest(from, to, req.Model, originalPayload, true)
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), true)
+	requestedModel := payloadRequestedModel(opts, req.Model)
+	body = applyPayloadConfigWithRoot(e.cfg, req.Model, to.String(), "", body, originalTranslated, requestedModel)
+
+	endpoint := vertexExpressEndpoint(baseURL, req.Model, "streamGenerateContent") + "?alt=sse"
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	applyVertexExpressHeaders(httpReq, apiKey, true)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return nil, err
+	}
+
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		data, _ := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		logEntry(ctx).Debugf("vertex express streaming error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		err = statusErr{code: httpResp.StatusCode, msg: string(data)}
+		return nil, err
+	}
+
+	out := make(chan modelgateexecutor.StreamChunk)
+	stream = out
+	go func() {
+		defer close(out)
+		defer func() {
+			if errClose := httpResp.Body.Close(); errClose != nil {
+				logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+			}
+		}()
+
+		scanner := newSSEScanner(e.Identifier(), httpResp.Body)
+		var param any
+		// One immutable copy of the original request is shared by every chunk;
+		// stream translators only read it, so per-line clones are wasted work.
+		originalRequestView := bytes.Clone(opts.OriginalRequest)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			appendAPIResponseChunk(ctx, e.cfg, line)
+			if detail, ok := parseGeminiStreamUsage(line); ok {
+				reporter.publish(ctx, detail)
+			}
+			chunks := sdktranslator.TranslateStream(ctx, to, from, req.Model, originalRequestView, body, bytes.Clone(line), &param)
+			for i := range chunks {
+				out <- modelgateexecutor.StreamChunk{Payload: []byte(chunks[i])}
+			}
+		}
+		if errScan := scanner.Err(); errScan != nil {
+			recordAPIResponseError(ctx, e.cfg, errScan)
+			reporter.publishFailure(ctx)
+			out <- modelgateexecutor.StreamChunk{Err: errScan}
+		}
+		// Guarantee a usage record exists even if the stream never emitted usage data.
+		reporter.ensurePublished(ctx)
+	}()
+
+	return stream, nil
+}
+
+// CountTokens counts tokens for the given request using the upstream countTokens action.
+func (e *VertexExpressExecutor) CountTokens(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (modelgateexecutor.Response, error) {
+	apiKey, baseURL := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		return modelgateexecutor.Response{}, fmt.Errorf("vertex express executor: missing api key")
+	}
+
+	from := opts.SourceFormat
+	to := sdktranslator.FromString("gemini")
+	body := sdktranslator.TranslateRequest(from, to, req.Model, bytes.Clone(req.Payload), false)
+
+	endpoint := vertexExpressEndpoint(baseURL, req.Model, "countTokens")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return modelgateexecutor.Response{}, err
+	}
+	applyVertexExpressHeaders(httpReq, apiKey, false)
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:      endpoint,
+		Method:   http.MethodPost,
+		Headers:  httpReq.Header.Clone(),
+		Tags:     opts.Tags,
+		Body:     body,
+		Provider: e.Identifier(),
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return modelgateexecutor.Response{}, err
+	}
+	data, errRead := io.ReadAll(httpResp.Body)
+	if errClose := httpResp.Body.Close(); errClose != nil {
+		logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+	}
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+	if errRead != nil {
+		recordAPIResponseError(ctx, e.cfg, errRead)
+		return modelgateexecutor.Response{}, errRead
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		logEntry(ctx).Debugf("vertex express count tokens error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+		return modelgateexecutor.Response{}, statusErr{code: httpResp.StatusCode, msg: string(data)}
+	}
+
+	count := gjson.GetBytes(data, "totalTokens").Int()
+	translated := sdktranslator.TranslateTokenCount(ctx, to, from, count, data)
+	return modelgateexecutor.Response{Payload: []byte(translated)}, nil
+}
+
+// ExecuteEmbeddings performs an embeddings request using the upstream embedContent
+// action. Publisher models expose no batch action in express mode, so each input
+// is embedded with its own call and the vectors are aggregated into an OpenAI
+// embeddings list response.
+func (e *VertexExpressExecutor) ExecuteEmbeddings(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("vertex express executor: missing api key")
+		return resp, err
+	}
+
+	inputs, err := openAIEmbeddingsInputs(req.Payload)
+	if err != nil {
+		err = statusErr{code: http.StatusBadRequest, msg: err.Error()}
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	endpoint := vertexExpressEndpoint(baseURL, req.Model, "embedContent")
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+
+	vectors := make([][]float64, 0, len(inputs))
+	for _, text := range inputs {
+		body, errBody := json.Marshal(map[string]any{
+			"content": map[string]any{"parts": []map[string]any{{"text": text}}},
+		})
+		if errBody != nil {
+			err = errBody
+			return resp, err
+		}
+
+		httpReq, errReq := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if errReq != nil {
+			err = errReq
+			return resp, err
+		}
+		applyVertexExpressHeaders(httpReq, apiKey, false)
+		applyRequestOptions(httpReq, opts)
+		recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+			URL:      endpoint,
+			Method:   http.MethodPost,
+			Headers:  httpReq.Header.Clone(),
+			Tags:     opts.Tags,
+			Body:     body,
+			Provider: e.Identifier(),
+		})
+
+		httpResp, errDo := httpClient.Do(httpReq)
+		if errDo != nil {
+			recordAPIResponseError(ctx, e.cfg, errDo)
+			err = errDo
+			return resp, err
+		}
+		data, errRead := io.ReadAll(httpResp.Body)
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+		}
+		recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+		if errRead != nil {
+			recordAPIResponseError(ctx, e.cfg, errRead)
+			err = errRead
+			return resp, err
+		}
+		appendAPIResponseChunk(ctx, e.cfg, data)
+		if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+			logEntry(ctx).Debugf("vertex express embeddings error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), data))
+			err = statusErr{code: httpResp.StatusCode, msg: string(data)}
+			return resp, err
+		}
+
+		values := gjson.GetBytes(data, "embedding.values").Array()
+		vec := make([]float64, 0, len(values))
+		for _, v := range values {
+			vec = append(vec, v.Float())
+		}
+		vectors = append(vectors, vec)
+	}
+
+	// embedContent responses carry no usage metadata; record the request itself.
+	reporter.ensurePublished(ctx)
+
+	resp = modelgateexecutor.Response{Payload: buildOpenAIEmbeddingsResponse(req.Model, vectors, 0)}
+	return resp, nil
+}
+
+// ExecuteImages performs an image generation request using the upstream Imagen
+// :predict action. The OpenAI images payload is converted to a predict request
+// and the base64 predictions are normalized into an OpenAI images list.
+func (e *VertexExpressExecutor) ExecuteImages(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("vertex express executor: missing api key")
+		return resp, err
+	}
+
+	body, err := imagenPredictRequestFromOpenAI(req.Payload)
+	if err != nil {
+		err = statusErr{code: http.StatusBadRequest, msg: err.Error()}
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	endpoint := vertexExpressEndpoint(baseURL, req.Model, "predict")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyVertexExpressHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Body:      body,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("vertex express images error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	// Imagen predict responses carry no usage metadata; record the request itself.
+	reporter.ensurePublished(ctx)
+
+	resp = modelgateexecutor.Response{Payload: imagenPredictResponseToOpenAI(data)}
+	return resp, nil
+}
+
+// ExecuteTranscriptions performs an audio transcription request using Gemini
+// audio understanding. The audio travels inline on a generateContent call and
+// the candidate text is normalized into an OpenAI transcription response.
+func (e *VertexExpressExecutor) ExecuteTranscriptions(ctx context.Context, auth *modelgateauth.Auth, req modelgateexecutor.Request, opts modelgateexecutor.Options) (resp modelgateexecutor.Response, err error) {
+	apiKey, baseURL := vertexExpressCreds(auth)
+	if strings.TrimSpace(apiKey) == "" {
+		err = fmt.Errorf("vertex express executor: missing api key")
+		return resp, err
+	}
+
+	envelope, err := parseTranscriptionEnvelope(req.Payload)
+	if err != nil {
+		err = statusErr{code: http.StatusBadRequest, msg: err.Error()}
+		return resp, err
+	}
+
+	reporter := newUsageReporter(ctx, e.Identifier(), req.Model, auth, opts)
+	defer reporter.trackFailure(ctx, &err)
+
+	instruction := "Transcribe this audio verbatim. Respond with only the transcription text."
+	if envelope.Prompt != "" {
+		instruction = envelope.Prompt
+	}
+	mimeType := envelope.MimeType
+	if mimeType == "" {
+		mimeType = "audio/mpeg"
+	}
+	body, err := json.Marshal(map[string]any{
+		"contents": []map[string]any{{
+			"role": "user",
+			"parts": []map[string]any{
+				{"inlineData": map[string]any{"mimeType": mimeType, "data": base64.StdEncoding.EncodeToString(envelope.Audio)}},
+				{"text": instruction},
+			},
+		}},
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	endpoint := vertexExpressEndpoint(baseURL, req.Model, "generateContent")
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	applyVertexExpressHeaders(httpReq, apiKey, false)
+	var authID, authLabel, authType, authValue string
+	if auth != nil {
+		authID = auth.ID
+		authLabel = auth.Label
+		authType, authValue = auth.AccountInfo()
+	}
+	applyRequestOptions(httpReq, opts)
+	recordAPIRequest(ctx, e.cfg, upstreamRequestLog{
+		URL:       endpoint,
+		Method:    http.MethodPost,
+		Headers:   httpReq.Header.Clone(),
+		Tags:      opts.Tags,
+		Provider:  e.Identifier(),
+		AuthID:    authID,
+		AuthLabel: authLabel,
+		AuthType:  authType,
+		AuthValue: authValue,
+	})
+
+	httpClient := newProxyAwareHTTPClient(ctx, e.cfg, auth, optionTimeout(opts))
+	httpResp, err := httpClient.Do(httpReq)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	defer func() {
+		if errClose := httpResp.Body.Close(); errClose != nil {
+			logEntry(ctx).Errorf("vertex express executor: close response body error: %v", errClose)
+		}
+	}()
+	recordAPIResponseMetadata(ctx, e.cfg, httpResp.StatusCode, httpResp.Header.Clone())
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		b, _ := io.ReadAll(httpResp.Body)
+		appendAPIResponseChunk(ctx, e.cfg, b)
+		logEntry(ctx).Debugf("vertex express transcription error: status %d body %s", httpResp.StatusCode, summarizeErrorBody(httpResp.Header.Get("Content-Type"), b))
+		err = statusErr{code: httpResp.StatusCode, msg: string(b)}
+		return resp, err
+	}
+
+	data, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		recordAPIResponseError(ctx, e.cfg, err)
+		return resp, err
+	}
+	appendAPIResponseChunk(ctx, e.cfg, data)
+	reporter.publish(ctx, parseGeminiUsage(data))
+	reporter.ensurePublished(ctx)
+
+	var text strings.Builder
+	for _, part := range gjson.GetBytes(data, "candidates.0.content.parts").Array() {
+		text.WriteString(part.Get("text").String())
+	}
+	out, err := json.Marshal(map[string]any{"text": text.String()})
+	if err != nil {
+		return resp, err
+	}
+	resp = modelgateexecutor.Response{Payload: out}
+	return resp, nil
+}
+
+// Refresh is a no-op for API-key based Vertex express credentials.
+func (e *VertexExpressExecutor) Refresh(_ context.Context, auth *modelgateauth.Auth) (*modelgateauth.Auth, error) {
+	return auth, nil
+}
+
+func applyVertexExpressHeaders(r *http.Request, apiKey string, stream bool) {
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("x-goog-api-key", apiKey)
+	if stream {
+		r.Header.Set("Accept", "text/event-stream")
+	} else {
+		r.Header.Set("Accept", "application/json")
+	}
+}
+
+func vertexExpressCreds(a *modelgateauth.Auth) (apiKey, baseURL string) {
+	if a == nil {
+		return "", ""
+	}
+	if a.Attributes != nil {
+		if v := strings.TrimSpace(a.Attributes["api_key"]); v != "" {
+			apiKey = v
+		}
+		if v := strings.TrimSpace(a.Attributes["base_url"]); v != "" {
+			baseURL = v
+		}
+	}
+	if apiKey == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["api_key"].(string); ok {
+			apiKey = strings.TrimSpace(v)
+		}
+	}
+	if baseURL == "" && a.Metadata != nil {
+		if v, ok := a.Metadata["base_url"].(string); ok {
+			baseURL = strings.TrimSpace(v)
+		}
+	}
+	return apiKey, baseURL
+}
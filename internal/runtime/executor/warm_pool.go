@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+// warmupRequestTimeout bounds each individual warmup request.
+const warmupRequestTimeout = 10 * time.Second
+
+// minWarmupInterval keeps the warmer from spinning when the idle timeout is
+// configured very low.
+const minWarmupInterval = 15 * time.Second
+
+// StartConnectionWarmup launches the background connection warmer when the
+// connection-warmup config section lists hosts. The warmer pre-establishes the
+// configured number of TLS connections per host at startup and re-warms them
+// shortly before the idle pool would close them, so interactive clients do not
+// pay connect plus handshake latency on their first request. The config getter
+// is re-evaluated every cycle so reloads take effect without a restart.
+func StartConnectionWarmup(ctx context.Context, cfgFn func() *config.Config) {
+	if ctx == nil || cfgFn == nil {
+		return
+	}
+	go runConnectionWarmup(ctx, cfgFn)
+}
+
+func runConnectionWarmup(ctx context.Context, cfgFn func() *config.Config) {
+	warmConnections(ctx, cfgFn())
+	for {
+		cfg := cfgFn()
+		_, idleTimeout, _, _ := resolveTransportTuning(cfg)
+		// Re-warm at two thirds of the idle timeout so pooled connections are
+		// refreshed before the transport closes them.
+		interval := idleTimeout * 2 / 3
+		if interval < minWarmupInterval {
+			interval = minWarmupInterval
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		warmConnections(ctx, cfgFn())
+	}
+}
+
+// warmConnections issues the configured number of concurrent requests per
+// host. Each in-flight request pins one pooled connection, so N concurrent
+// workers leave N established connections behind in the idle pool.
+func warmConnections(ctx context.Context, cfg *config.Config) {
+	if cfg == nil || len(cfg.ConnectionWarmup.Hosts) == 0 {
+		return
+	}
+	count := cfg.ConnectionWarmup.ConnectionsPerHost
+	if count <= 0 {
+		count = 1
+	}
+	client := newProxyAwareHTTPClient(ctx, cfg, nil, warmupRequestTimeout)
+	var wg sync.WaitGroup
+	for _, host := range cfg.ConnectionWarmup.Hosts {
+		base := strings.TrimSpace(host)
+		if base == "" {
+			continue
+		}
+		if !strings.Contains(base, "://") {
+			base = "https://" + base
+		}
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func(target string) {
+				defer wg.Done()
+				warmupRequest(ctx, client, target)
+			}(base)
+		}
+	}
+	wg.Wait()
+}
+
+// warmupRequest opens (or reuses) a connection to the host. Any HTTP status
+// counts as a successful warmup; only transport failures are reported.
+func warmupRequest(ctx context.Context, client *http.Client, base string) {
+	reqCtx, cancel := context.WithTimeout(ctx, warmupRequestTimeout)
+	defer cancel()
+	warmReq, err := http.NewRequestWithContext(reqCtx, http.MethodHead, strings.TrimSuffix(base, "/")+"/", nil)
+	if err != nil {
+		return
+	}
+	resp, err := client.Do(warmReq)
+	if err != nil {
+		logEntry(ctx).Debugf("connection warmup: %s unreachable: %v", base, err)
+		return
+	}
+	_ = resp.Body.Close()
+}
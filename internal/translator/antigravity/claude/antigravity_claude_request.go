@@ -384,6 +384,24 @@ func ConvertClaudeRequestToAntigravity(modelName string, inputRawJSON []byte, _
 		out, _ = sjson.SetRaw(out, "request.tools", toolsJSON)
 	}
 
+	// Map Anthropic tool_choice -> Gemini functionCallingConfig; a specific
+	// tool pins allowedFunctionNames.
+	if toolChoice := gjson.GetBytes(rawJSON, "tool_choice"); toolChoice.IsObject() {
+		switch toolChoice.Get("type").String() {
+		case "auto":
+			out, _ = sjson.Set(out, "request.toolConfig.functionCallingConfig.mode", "AUTO")
+		case "none":
+			out, _ = sjson.Set(out, "request.toolConfig.functionCallingConfig.mode", "NONE")
+		case "any":
+			out, _ = sjson.Set(out, "request.toolConfig.functionCallingConfig.mode", "ANY")
+		case "tool":
+			if name := toolChoice.Get("name").String(); name != "" {
+				out, _ = sjson.Set(out, "request.toolConfig.functionCallingConfig.mode", "ANY")
+				out, _ = sjson.Set(out, "request.toolConfig.functionCallingConfig.allowedFunctionNames.0", name)
+			}
+		}
+	}
+
 	// Map Anthropic thinking -> Gemini thinkingBudget/include_thoughts when type==enabled
 	if t := gjson.GetBytes(rawJSON, "thinking"); t.Exists() && t.IsObject() && util.ModelSupportsThinking(modelName) {
 		switch t.Get("type").String() {
@@ -108,6 +108,17 @@ func ConvertOpenAIRequestToAntigravity(modelName string, inputRawJSON []byte, _
 	if tkr := gjson.GetBytes(rawJSON, "top_k"); tkr.Exists() && tkr.Type == gjson.Number {
 		out, _ = sjson.SetBytes(out, "request.generationConfig.topK", tkr.Num)
 	}
+
+	// Map OpenAI response_format -> Gemini JSON mode
+	switch rf := gjson.GetBytes(rawJSON, "response_format"); rf.Get("type").String() {
+	case "json_object":
+		out, _ = sjson.SetBytes(out, "request.generationConfig.responseMimeType", "application/json")
+	case "json_schema":
+		out, _ = sjson.SetBytes(out, "request.generationConfig.responseMimeType", "application/json")
+		if schema := rf.Get("json_schema.schema"); schema.Exists() {
+			out, _ = sjson.SetRawBytes(out, "request.generationConfig.responseJsonSchema", []byte(schema.Raw))
+		}
+	}
 	if maxTok := gjson.GetBytes(rawJSON, "max_tokens"); maxTok.Exists() && maxTok.Type == gjson.Number {
 		out, _ = sjson.SetBytes(out, "request.generationConfig.maxOutputTokens", maxTok.Num)
 	}
@@ -338,6 +349,7 @@ func ConvertOpenAIRequestToAntigravity(modelName string, inputRawJSON []byte, _
 	if tools.IsArray() && len(tools.Array()) > 0 {
 		toolNode := []byte(`{}`)
 		hasTool := false
+		hasStrictTool := false
 		hasFunction := false
 		for _, t := range tools.Array() {
 			if t.Get("type").String() == "function" {
@@ -375,6 +387,9 @@ func ConvertOpenAIRequestToAntigravity(modelName string, inputRawJSON []byte, _
 							continue
 						}
 					}
+					if fn.Get("strict").Bool() {
+						hasStrictTool = true
+					}
 					fnRaw, _ = sjson.Delete(fnRaw, "strict")
 					if !hasFunction {
 						toolNode, _ = sjson.SetRawBytes(toolNode, "functionDeclarations", []byte("[]"))
@@ -421,6 +436,27 @@ func ConvertOpenAIRequestToAntigravity(modelName string, inputRawJSON []byte, _
 			out, _ = sjson.SetRawBytes(out, "request.tools", []byte("[]"))
 			out, _ = sjson.SetRawBytes(out, "request.tools.0", toolNode)
 		}
+		// tool_choice -> functionCallingConfig: none/auto/required map to
+		// NONE/AUTO/ANY and a specific function pins allowedFunctionNames.
+		if toolChoice := gjson.GetBytes(rawJSON, "tool_choice"); toolChoice.Exists() {
+			switch {
+			case toolChoice.Type == gjson.String && toolChoice.String() == "none":
+				out, _ = sjson.SetBytes(out, "request.toolConfig.functionCallingConfig.mode", "NONE")
+			case toolChoice.Type == gjson.String && toolChoice.String() == "auto":
+				out, _ = sjson.SetBytes(out, "request.toolConfig.functionCallingConfig.mode", "AUTO")
+			case toolChoice.Type == gjson.String && toolChoice.String() == "required":
+				out, _ = sjson.SetBytes(out, "request.toolConfig.functionCallingConfig.mode", "ANY")
+			case toolChoice.IsObject() && toolChoice.Get("function.name").String() != "":
+				out, _ = sjson.SetBytes(out, "request.toolConfig.functionCallingConfig.mode", "ANY")
+				out, _ = sjson.SetBytes(out, "request.toolConfig.functionCallingConfig.allowedFunctionNames.0", toolChoice.Get("function.name").String())
+			}
+		}
+		// strict function calling maps to Gemini's VALIDATED mode; an explicit
+		// client toolConfig wins. Schema validation failures surface as the
+		// provider's structured INVALID_ARGUMENT error.
+		if hasStrictTool && !gjson.GetBytes(out, "request.toolConfig.functionCallingConfig.mode").Exists() {
+			out, _ = sjson.SetBytes(out, "request.toolConfig.functionCallingConfig.mode", "VALIDATED")
+		}
 	}
 
 	return common.AttachDefaultSafetySettings(out, "request.safetySettings")
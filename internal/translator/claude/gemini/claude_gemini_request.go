@@ -313,16 +313,32 @@ func ConvertGeminiRequestToClaude(modelName string, inputRawJSON []byte, stream
 		}
 	}
 
-	// Tool config mapping from Gemini format to Claude Code format
-	if toolConfig := root.Get("tool_config"); toolConfig.Exists() {
-		if funcCalling := toolConfig.Get("function_calling_config"); funcCalling.Exists() {
-			if mode := funcCalling.Get("mode"); mode.Exists() {
-				switch mode.String() {
-				case "AUTO":
-					out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"auto"}`)
-				case "NONE":
-					out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"none"}`)
-				case "ANY":
+	// Tool config mapping from Gemini format to Claude Code format. Both the
+	// snake_case and camelCase spellings appear in the wild.
+	toolConfig := root.Get("tool_config")
+	if !toolConfig.Exists() {
+		toolConfig = root.Get("toolConfig")
+	}
+	if toolConfig.Exists() {
+		funcCalling := toolConfig.Get("function_calling_config")
+		if !funcCalling.Exists() {
+			funcCalling = toolConfig.Get("functionCallingConfig")
+		}
+		if mode := funcCalling.Get("mode"); mode.Exists() {
+			allowed := funcCalling.Get("allowed_function_names")
+			if !allowed.Exists() {
+				allowed = funcCalling.Get("allowedFunctionNames")
+			}
+			switch mode.String() {
+			case "AUTO":
+				out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"auto"}`)
+			case "NONE":
+				out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"none"}`)
+			case "ANY", "VALIDATED":
+				if names := allowed.Array(); len(names) == 1 && names[0].String() != "" {
+					toolChoiceJSON, _ := sjson.Set(`{"type":"tool","name":""}`, "name", names[0].String())
+					out, _ = sjson.SetRaw(out, "tool_choice", toolChoiceJSON)
+				} else {
 					out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"any"}`)
 				}
 			}
@@ -275,7 +275,7 @@ func ConvertOpenAIRequestToClaude(modelName string, inputRawJSON []byte, stream
 			choice := toolChoice.String()
 			switch choice {
 			case "none":
-				// Don't set tool_choice, Claude Code will not use tools
+				out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"none"}`)
 			case "auto":
 				out, _ = sjson.SetRaw(out, "tool_choice", `{"type":"auto"}`)
 			case "required":
@@ -293,5 +293,19 @@ func ConvertOpenAIRequestToClaude(modelName string, inputRawJSON []byte, stream
 		}
 	}
 
+	// JSON mode mapping: Claude Code has no native response_format, so force it
+	// through a system instruction. The response translators sanitize the
+	// output (strip fences/prose) so clients still receive bare JSON.
+	if rf := root.Get("response_format"); rf.Exists() {
+		switch rf.Get("type").String() {
+		case "json_object", "json_schema":
+			instruction := "You must respond with a single valid JSON value only. Do not wrap it in markdown code fences and do not add any text before or after the JSON."
+			if schema := rf.Get("json_schema.schema"); schema.Exists() {
+				instruction += " The JSON must conform to this JSON Schema: " + schema.Raw
+			}
+			out, _ = sjson.Set(out, "system", instruction)
+		}
+	}
+
 	return []byte(out)
 }
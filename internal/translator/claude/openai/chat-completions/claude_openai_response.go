@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/shariqriazz/modelgate/internal/util"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -398,6 +399,9 @@ func ConvertClaudeResponseToOpenAINonStream(_ context.Context, _ string, origina
 
 	// Set message content by combining all text parts
 	messageContent := strings.Join(contentParts, "")
+	if messageContent != "" && util.RequestWantsJSONObject(originalRequestRawJSON) {
+		messageContent = util.SanitizeJSONModeText(messageContent)
+	}
 	out, _ = sjson.Set(out, "choices.0.message.content", messageContent)
 
 	// Add reasoning content if available (following OpenAI reasoning format)
@@ -170,6 +170,20 @@ func ConvertClaudeRequestToCodex(modelName string, inputRawJSON []byte, _ bool)
 	if toolsResult.IsArray() {
 		template, _ = sjson.SetRaw(template, "tools", `[]`)
 		template, _ = sjson.Set(template, "tool_choice", `auto`)
+		// Honor an explicit Claude tool_choice instead of always forcing auto.
+		if toolChoice := rootResult.Get("tool_choice"); toolChoice.IsObject() {
+			switch toolChoice.Get("type").String() {
+			case "none":
+				template, _ = sjson.Set(template, "tool_choice", "none")
+			case "any":
+				template, _ = sjson.Set(template, "tool_choice", "required")
+			case "tool":
+				if name := toolChoice.Get("name").String(); name != "" {
+					choiceJSON, _ := sjson.Set(`{"type":"function","name":""}`, "name", name)
+					template, _ = sjson.SetRaw(template, "tool_choice", choiceJSON)
+				}
+			}
+		}
 		toolResults := toolsResult.Array()
 		// Build short name map from declared tools
 		var names []string
@@ -194,6 +194,20 @@ func ConvertGeminiRequestToCodex(modelName string, inputRawJSON []byte, _ bool)
 	if tools.IsArray() {
 		out, _ = sjson.SetRaw(out, "tools", `[]`)
 		out, _ = sjson.Set(out, "tool_choice", "auto")
+		// Honor an explicit functionCallingConfig instead of always forcing auto.
+		if fcc := root.Get("toolConfig.functionCallingConfig"); fcc.Exists() {
+			switch fcc.Get("mode").String() {
+			case "NONE":
+				out, _ = sjson.Set(out, "tool_choice", "none")
+			case "ANY", "VALIDATED":
+				if names := fcc.Get("allowedFunctionNames").Array(); len(names) == 1 && names[0].String() != "" {
+					toolChoice, _ := sjson.Set(`{"type":"function","name":""}`, "name", names[0].String())
+					out, _ = sjson.SetRaw(out, "tool_choice", toolChoice)
+				} else {
+					out, _ = sjson.Set(out, "tool_choice", "required")
+				}
+			}
+		}
 		tarr := tools.Array()
 		for i := 0; i < len(tarr); i++ {
 			td := tarr[i]
@@ -0,0 +1,211 @@
+// Package chat_completions provides request translation functionality for OpenAI to Cohere API compatibility.
+// It converts OpenAI Chat Completions requests into Cohere Chat API compatible JSON using gjson/sjson only,
+// mapping the OpenAI messages array onto Cohere's message/chat_history/tool_results layout.
+package chat_completions
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// ConvertOpenAIRequestToCohere converts an OpenAI Chat Completions request into
+// a Cohere Chat API request. The final user message becomes Cohere's "message"
+// field, preceding turns become "chat_history", system messages become the
+// preamble, and OpenAI function tools are mapped onto Cohere's
+// parameter_definitions schema.
+//
+// Parameters:
+//   - modelName: The name of the model to use for the request
+//   - rawJSON: The raw JSON request data from the OpenAI API
+//   - stream: A boolean indicating if the request is for a streaming response
+//
+// Returns:
+//   - []byte: The transformed request data in Cohere Chat API format
+func ConvertOpenAIRequestToCohere(modelName string, rawJSON []byte, stream bool) []byte {
+	out := []byte(`{}`)
+	out, _ = sjson.SetBytes(out, "model", modelName)
+	if stream {
+		out, _ = sjson.SetBytes(out, "stream", true)
+	}
+
+	root := gjson.ParseBytes(rawJSON)
+
+	// Sampling and length parameters.
+	if v := root.Get("temperature"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "temperature", v.Float())
+	}
+	if v := root.Get("top_p"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "p", v.Float())
+	}
+	if v := root.Get("max_tokens"); v.Exists() {
+		out, _ = sjson.SetBytes(out, "max_tokens", v.Int())
+	}
+	if v := root.Get("stop"); v.Exists() {
+		if v.IsArray() {
+			out, _ = sjson.SetRawBytes(out, "stop_sequences", []byte(v.Raw))
+		} else if v.Type == gjson.String {
+			out, _ = sjson.SetBytes(out, "stop_sequences", []string{v.String()})
+		}
+	}
+
+	// Tools: OpenAI function tools -> Cohere parameter_definitions.
+	if tools := root.Get("tools"); tools.Exists() && tools.IsArray() {
+		toolIndex := 0
+		for _, tool := range tools.Array() {
+			if tool.Get("type").String() != "function" {
+				continue
+			}
+			fn := tool.Get("function")
+			name := strings.TrimSpace(fn.Get("name").String())
+			if name == "" {
+				continue
+			}
+			base := "tools." + strconv.Itoa(toolIndex)
+			out, _ = sjson.SetBytes(out, base+".name", name)
+			if desc := fn.Get("description"); desc.Exists() {
+				out, _ = sjson.SetBytes(out, base+".description", desc.String())
+			}
+			required := map[string]bool{}
+			fn.Get("parameters.required").ForEach(func(_, r gjson.Result) bool {
+				required[r.String()] = true
+				return true
+			})
+			fn.Get("parameters.properties").ForEach(func(pName, prop gjson.Result) bool {
+				pBase := base + ".parameter_definitions." + escapePath(pName.String())
+				if desc := prop.Get("description"); desc.Exists() {
+					out, _ = sjson.SetBytes(out, pBase+".description", desc.String())
+				}
+				out, _ = sjson.SetBytes(out, pBase+".type", cohereParamType(prop.Get("type").String()))
+				out, _ = sjson.SetBytes(out, pBase+".required", required[pName.String()])
+				return true
+			})
+			toolIndex++
+		}
+	}
+
+	// Messages: split into preamble, chat_history, tool_results, and the final
+	// user message. Tool call ids are resolved back to function names so tool
+	// outputs can reference the originating call.
+	messages := root.Get("messages").Array()
+	toolCallNames := map[string]string{}
+	var preambleParts []string
+	historyIndex := 0
+	toolResultIndex := 0
+	lastUserMessage := ""
+	lastUserIndex := -1
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Get("role").String() == "user" {
+			lastUserIndex = i
+			break
+		}
+	}
+	for i, msg := range messages {
+		role := msg.Get("role").String()
+		text := openAIMessageText(msg)
+		switch role {
+		case "system", "developer":
+			if text != "" {
+				preambleParts = append(preambleParts, text)
+			}
+		case "user":
+			if i == lastUserIndex {
+				lastUserMessage = text
+				continue
+			}
+			base := "chat_history." + strconv.Itoa(historyIndex)
+			out, _ = sjson.SetBytes(out, base+".role", "USER")
+			out, _ = sjson.SetBytes(out, base+".message", text)
+			historyIndex++
+		case "assistant":
+			base := "chat_history." + strconv.Itoa(historyIndex)
+			out, _ = sjson.SetBytes(out, base+".role", "CHATBOT")
+			out, _ = sjson.SetBytes(out, base+".message", text)
+			if toolCalls := msg.Get("tool_calls"); toolCalls.Exists() && toolCalls.IsArray() {
+				for j, call := range toolCalls.Array() {
+					name := call.Get("function.name").String()
+					if id := call.Get("id").String(); id != "" {
+						toolCallNames[id] = name
+					}
+					callBase := base + ".tool_calls." + strconv.Itoa(j)
+					out, _ = sjson.SetBytes(out, callBase+".name", name)
+					args := strings.TrimSpace(call.Get("function.arguments").String())
+					if args == "" || !gjson.Valid(args) {
+						args = "{}"
+					}
+					out, _ = sjson.SetRawBytes(out, callBase+".parameters", []byte(args))
+				}
+			}
+			historyIndex++
+		case "tool":
+			name := toolCallNames[msg.Get("tool_call_id").String()]
+			base := "tool_results." + strconv.Itoa(toolResultIndex)
+			out, _ = sjson.SetBytes(out, base+".call.name", name)
+			out, _ = sjson.SetRawBytes(out, base+".call.parameters", []byte(`{}`))
+			if gjson.Valid(text) && gjson.Parse(text).IsObject() {
+				out, _ = sjson.SetRawBytes(out, base+".outputs.0", []byte(text))
+			} else {
+				out, _ = sjson.SetBytes(out, base+".outputs.0.output", text)
+			}
+			toolResultIndex++
+		}
+	}
+	if len(preambleParts) > 0 {
+		out, _ = sjson.SetBytes(out, "preamble", strings.Join(preambleParts, "\n\n"))
+	}
+	out, _ = sjson.SetBytes(out, "message", lastUserMessage)
+
+	return out
+}
+
+// openAIMessageText flattens an OpenAI message content value (string or parts
+// array) into plain text.
+func openAIMessageText(msg gjson.Result) string {
+	content := msg.Get("content")
+	if !content.Exists() {
+		return ""
+	}
+	if content.Type == gjson.String {
+		return content.String()
+	}
+	if content.IsArray() {
+		var parts []string
+		content.ForEach(func(_, part gjson.Result) bool {
+			if part.Get("type").String() == "text" {
+				parts = append(parts, part.Get("text").String())
+			}
+			return true
+		})
+		return strings.Join(parts, "\n")
+	}
+	return ""
+}
+
+// cohereParamType maps a JSON Schema type onto the Python-style type names
+// Cohere's parameter_definitions use.
+func cohereParamType(jsonType string) string {
+	switch strings.ToLower(strings.TrimSpace(jsonType)) {
+	case "string":
+		return "str"
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "array":
+		return "list"
+	case "object":
+		return "dict"
+	default:
+		return "str"
+	}
+}
+
+// escapePath escapes sjson path separators in user-controlled key names.
+func escapePath(key string) string {
+	key = strings.ReplaceAll(key, ".", `\.`)
+	return strings.ReplaceAll(key, "*", `\*`)
+}
@@ -0,0 +1,171 @@
+// Package chat_completions provides response translation functionality for Cohere to OpenAI API compatibility.
+// This package handles the conversion of Cohere Chat API responses into OpenAI Chat Completions compatible
+// JSON, transforming both the NDJSON streaming events and non-streaming responses.
+package chat_completions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// convertCohereResponseToOpenAIParams holds streaming conversion state.
+type convertCohereResponseToOpenAIParams struct {
+	GenerationID  string
+	UnixTimestamp int64
+}
+
+// ConvertCohereResponseToOpenAI converts Cohere streaming events to OpenAI
+// chat completion chunks. Cohere streams NDJSON objects tagged with
+// event_type; text deltas, tool call batches, and the final stream-end event
+// (which carries usage) are mapped onto OpenAI delta chunks.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - modelName: The name of the model
+//   - rawJSON: A raw NDJSON event from the Cohere API
+//   - param: A pointer to a parameter object for maintaining state between calls
+//
+// Returns:
+//   - []string: A slice of strings, each containing an OpenAI-compatible JSON chunk
+func ConvertCohereResponseToOpenAI(_ context.Context, modelName string, _, _, rawJSON []byte, param *any) []string {
+	if *param == nil {
+		*param = &convertCohereResponseToOpenAIParams{UnixTimestamp: time.Now().Unix()}
+	}
+	state := (*param).(*convertCohereResponseToOpenAIParams)
+
+	if bytes.HasPrefix(rawJSON, []byte("data:")) {
+		rawJSON = bytes.TrimSpace(rawJSON[5:])
+	}
+	if len(bytes.TrimSpace(rawJSON)) == 0 || bytes.Equal(bytes.TrimSpace(rawJSON), []byte("[DONE]")) {
+		return []string{}
+	}
+
+	root := gjson.ParseBytes(rawJSON)
+	template := `{"id":"","object":"chat.completion.chunk","created":12345,"model":"model","choices":[{"index":0,"delta":{"role":null,"content":null,"reasoning_content":null,"tool_calls":null},"finish_reason":null,"native_finish_reason":null}]}`
+	template, _ = sjson.Set(template, "model", modelName)
+	template, _ = sjson.Set(template, "created", state.UnixTimestamp)
+	template, _ = sjson.Set(template, "id", state.GenerationID)
+
+	switch root.Get("event_type").String() {
+	case "stream-start":
+		if id := root.Get("generation_id").String(); id != "" {
+			state.GenerationID = id
+			template, _ = sjson.Set(template, "id", id)
+		}
+		template, _ = sjson.Set(template, "choices.0.delta.role", "assistant")
+		return []string{template}
+	case "text-generation":
+		template, _ = sjson.Set(template, "choices.0.delta.content", root.Get("text").String())
+		return []string{template}
+	case "tool-calls-generation":
+		toolCalls := root.Get("tool_calls")
+		if !toolCalls.Exists() || !toolCalls.IsArray() {
+			return []string{}
+		}
+		for i, call := range toolCalls.Array() {
+			base := "choices.0.delta.tool_calls." + strconv.Itoa(i)
+			template, _ = sjson.Set(template, base+".index", i)
+			template, _ = sjson.Set(template, base+".id", cohereToolCallID(state.GenerationID, i))
+			template, _ = sjson.Set(template, base+".type", "function")
+			template, _ = sjson.Set(template, base+".function.name", call.Get("name").String())
+			template, _ = sjson.Set(template, base+".function.arguments", cohereToolCallArguments(call))
+		}
+		return []string{template}
+	case "stream-end":
+		template, _ = sjson.Set(template, "choices.0.finish_reason", cohereFinishReason(root.Get("finish_reason").String(), root.Get("response.tool_calls").Exists()))
+		if tokens := root.Get("response.meta.tokens"); tokens.Exists() {
+			template = setCohereUsage(template, tokens)
+		}
+		return []string{template}
+	}
+	return []string{}
+}
+
+// ConvertCohereResponseToOpenAINonStream converts a non-streaming Cohere Chat
+// response into an OpenAI chat completion response.
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - modelName: The name of the model
+//   - rawJSON: The raw JSON response from the Cohere API
+//   - param: A pointer to a parameter object for the conversion
+//
+// Returns:
+//   - string: An OpenAI-compatible JSON response
+func ConvertCohereResponseToOpenAINonStream(_ context.Context, modelName string, _, _, rawJSON []byte, _ *any) string {
+	root := gjson.ParseBytes(rawJSON)
+	out := `{"id":"","object":"chat.completion","created":123456,"model":"model","choices":[{"index":0,"message":{"role":"assistant","content":null,"reasoning_content":null,"tool_calls":null},"finish_reason":null,"native_finish_reason":null}]}`
+	out, _ = sjson.Set(out, "model", modelName)
+	out, _ = sjson.Set(out, "created", time.Now().Unix())
+	generationID := root.Get("generation_id").String()
+	out, _ = sjson.Set(out, "id", generationID)
+
+	if text := root.Get("text"); text.Exists() && text.String() != "" {
+		out, _ = sjson.Set(out, "choices.0.message.content", text.String())
+	}
+
+	toolCalls := root.Get("tool_calls")
+	hasToolCalls := toolCalls.Exists() && toolCalls.IsArray() && len(toolCalls.Array()) > 0
+	if hasToolCalls {
+		for i, call := range toolCalls.Array() {
+			base := "choices.0.message.tool_calls." + strconv.Itoa(i)
+			out, _ = sjson.Set(out, base+".id", cohereToolCallID(generationID, i))
+			out, _ = sjson.Set(out, base+".type", "function")
+			out, _ = sjson.Set(out, base+".function.name", call.Get("name").String())
+			out, _ = sjson.Set(out, base+".function.arguments", cohereToolCallArguments(call))
+		}
+	}
+
+	out, _ = sjson.Set(out, "choices.0.finish_reason", cohereFinishReason(root.Get("finish_reason").String(), hasToolCalls))
+	if tokens := root.Get("meta.tokens"); tokens.Exists() {
+		out = setCohereUsage(out, tokens)
+	}
+	return out
+}
+
+// cohereFinishReason maps Cohere finish reasons onto OpenAI finish reasons.
+func cohereFinishReason(reason string, hasToolCalls bool) string {
+	if hasToolCalls {
+		return "tool_calls"
+	}
+	switch reason {
+	case "MAX_TOKENS":
+		return "length"
+	default:
+		return "stop"
+	}
+}
+
+// cohereToolCallID builds a deterministic tool call id; Cohere does not assign
+// ids to tool calls, but OpenAI clients require one to correlate tool results.
+func cohereToolCallID(generationID string, index int) string {
+	if generationID == "" {
+		generationID = "cohere"
+	}
+	return fmt.Sprintf("call_%s_%d", generationID, index)
+}
+
+// cohereToolCallArguments serializes a Cohere tool call's parameters object
+// into the JSON string OpenAI uses for function arguments.
+func cohereToolCallArguments(call gjson.Result) string {
+	if params := call.Get("parameters"); params.Exists() {
+		return params.Raw
+	}
+	return "{}"
+}
+
+// setCohereUsage copies Cohere's token accounting into the OpenAI usage block.
+func setCohereUsage(out string, tokens gjson.Result) string {
+	inputTokens := tokens.Get("input_tokens").Int()
+	outputTokens := tokens.Get("output_tokens").Int()
+	out, _ = sjson.Set(out, "usage.prompt_tokens", inputTokens)
+	out, _ = sjson.Set(out, "usage.completion_tokens", outputTokens)
+	out, _ = sjson.Set(out, "usage.total_tokens", inputTokens+outputTokens)
+	return out
+}
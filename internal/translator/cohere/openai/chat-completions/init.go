@@ -0,0 +1,19 @@
+package chat_completions
+
+import (
+	. "github.com/shariqriazz/modelgate/internal/constant"
+	"github.com/shariqriazz/modelgate/internal/interfaces"
+	"github.com/shariqriazz/modelgate/internal/translator/translator"
+)
+
+func init() {
+	translator.Register(
+		OpenAI,
+		Cohere,
+		ConvertOpenAIRequestToCohere,
+		interfaces.TranslateResponse{
+			Stream:    ConvertCohereResponseToOpenAI,
+			NonStream: ConvertCohereResponseToOpenAINonStream,
+		},
+	)
+}
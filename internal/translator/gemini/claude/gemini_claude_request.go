@@ -152,6 +152,24 @@ func ConvertClaudeRequestToGemini(modelName string, inputRawJSON []byte, _ bool)
 		}
 	}
 
+	// Map Anthropic tool_choice -> Gemini functionCallingConfig; a specific
+	// tool pins allowedFunctionNames.
+	if toolChoice := gjson.GetBytes(rawJSON, "tool_choice"); toolChoice.IsObject() {
+		switch toolChoice.Get("type").String() {
+		case "auto":
+			out, _ = sjson.Set(out, "toolConfig.functionCallingConfig.mode", "AUTO")
+		case "none":
+			out, _ = sjson.Set(out, "toolConfig.functionCallingConfig.mode", "NONE")
+		case "any":
+			out, _ = sjson.Set(out, "toolConfig.functionCallingConfig.mode", "ANY")
+		case "tool":
+			if name := toolChoice.Get("name").String(); name != "" {
+				out, _ = sjson.Set(out, "toolConfig.functionCallingConfig.mode", "ANY")
+				out, _ = sjson.Set(out, "toolConfig.functionCallingConfig.allowedFunctionNames.0", name)
+			}
+		}
+	}
+
 	// Map Anthropic thinking -> Gemini thinkingBudget/include_thoughts when enabled
 	// Only apply for models that use numeric budgets, not discrete levels.
 	if t := gjson.GetBytes(rawJSON, "thinking"); t.Exists() && t.IsObject() && util.ModelSupportsThinking(modelName) && !util.ModelUsesThinkingLevels(modelName) {
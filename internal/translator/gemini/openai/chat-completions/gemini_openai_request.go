@@ -98,6 +98,17 @@ func ConvertOpenAIRequestToGemini(modelName string, inputRawJSON []byte, _ bool)
 		out, _ = sjson.SetBytes(out, "generationConfig.topK", tkr.Num)
 	}
 
+	// Map OpenAI response_format -> Gemini JSON mode
+	switch rf := gjson.GetBytes(rawJSON, "response_format"); rf.Get("type").String() {
+	case "json_object":
+		out, _ = sjson.SetBytes(out, "generationConfig.responseMimeType", "application/json")
+	case "json_schema":
+		out, _ = sjson.SetBytes(out, "generationConfig.responseMimeType", "application/json")
+		if schema := rf.Get("json_schema.schema"); schema.Exists() {
+			out, _ = sjson.SetRawBytes(out, "generationConfig.responseJsonSchema", []byte(schema.Raw))
+		}
+	}
+
 	// Map OpenAI modalities -> Gemini generationConfig.responseModalities
 	// e.g. "modalities": ["image", "text"] -> ["IMAGE", "TEXT"]
 	if mods := gjson.GetBytes(rawJSON, "modalities"); mods.Exists() && mods.IsArray() {
@@ -311,6 +322,7 @@ func ConvertOpenAIRequestToGemini(modelName string, inputRawJSON []byte, _ bool)
 	if tools.IsArray() && len(tools.Array()) > 0 {
 		toolNode := []byte(`{}`)
 		hasTool := false
+		hasStrictTool := false
 		hasFunction := false
 		for _, t := range tools.Array() {
 			if t.Get("type").String() == "function" {
@@ -348,6 +360,9 @@ func ConvertOpenAIRequestToGemini(modelName string, inputRawJSON []byte, _ bool)
 							continue
 						}
 					}
+					if fn.Get("strict").Bool() {
+						hasStrictTool = true
+					}
 					fnRaw, _ = sjson.Delete(fnRaw, "strict")
 					if !hasFunction {
 						toolNode, _ = sjson.SetRawBytes(toolNode, "functionDeclarations", []byte("[]"))
@@ -394,6 +409,27 @@ func ConvertOpenAIRequestToGemini(modelName string, inputRawJSON []byte, _ bool)
 			out, _ = sjson.SetRawBytes(out, "tools", []byte("[]"))
 			out, _ = sjson.SetRawBytes(out, "tools.0", toolNode)
 		}
+		// tool_choice -> functionCallingConfig: none/auto/required map to
+		// NONE/AUTO/ANY and a specific function pins allowedFunctionNames.
+		if toolChoice := gjson.GetBytes(rawJSON, "tool_choice"); toolChoice.Exists() {
+			switch {
+			case toolChoice.Type == gjson.String && toolChoice.String() == "none":
+				out, _ = sjson.SetBytes(out, "toolConfig.functionCallingConfig.mode", "NONE")
+			case toolChoice.Type == gjson.String && toolChoice.String() == "auto":
+				out, _ = sjson.SetBytes(out, "toolConfig.functionCallingConfig.mode", "AUTO")
+			case toolChoice.Type == gjson.String && toolChoice.String() == "required":
+				out, _ = sjson.SetBytes(out, "toolConfig.functionCallingConfig.mode", "ANY")
+			case toolChoice.IsObject() && toolChoice.Get("function.name").String() != "":
+				out, _ = sjson.SetBytes(out, "toolConfig.functionCallingConfig.mode", "ANY")
+				out, _ = sjson.SetBytes(out, "toolConfig.functionCallingConfig.allowedFunctionNames.0", toolChoice.Get("function.name").String())
+			}
+		}
+		// strict function calling maps to Gemini's VALIDATED mode; an explicit
+		// client toolConfig wins. Schema validation failures surface as the
+		// provider's structured INVALID_ARGUMENT error.
+		if hasStrictTool && !gjson.GetBytes(out, "toolConfig.functionCallingConfig.mode").Exists() {
+			out, _ = sjson.SetBytes(out, "toolConfig.functionCallingConfig.mode", "VALIDATED")
+		}
 	}
 
 	out = common.AttachDefaultSafetySettings(out, "safetySettings")
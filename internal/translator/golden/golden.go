@@ -0,0 +1,136 @@
+// Package golden provides a golden-test harness for the translator registry.
+// Fixtures are JSON files pairing a sanitized upstream request with the exact
+// output the registered request translator produced when the fixture was
+// generated; the accompanying test re-runs every fixture so cross-format
+// regressions surface as golden diffs. Fixtures are produced with the
+// `-gen-fixtures` command from captured traffic, or refreshed in place with
+// `go test ./internal/translator/golden -update`.
+package golden
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/util"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Fixture is one golden test case: a source request plus the translated
+// request the registry produced for it.
+type Fixture struct {
+	// From and To are translator format identifiers (e.g. "openai", "gemini").
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Model is passed to the request translator as the target model name.
+	Model string `json:"model"`
+	// Stream mirrors the stream flag the translator was invoked with.
+	Stream bool `json:"stream"`
+	// Request is the sanitized upstream request payload.
+	Request json.RawMessage `json:"request"`
+	// Golden is the expected translator output. Empty until generated.
+	Golden json.RawMessage `json:"golden,omitempty"`
+}
+
+// sensitiveKeys are redacted wherever they appear in captured payloads before
+// the payload is stored as a fixture.
+var sensitiveKeys = []string{
+	"api_key",
+	"apiKey",
+	"authorization",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"cookie",
+}
+
+// volatilePaths are removed from translator output before comparison; they
+// change between processes (random session identifiers) and would make golden
+// comparisons flaky.
+var volatilePaths = []string{
+	"metadata.user_id",
+	"request.session_id",
+	"session_id",
+}
+
+// Sanitize redacts credential-bearing fields anywhere in a captured payload.
+func Sanitize(raw []byte) []byte {
+	out := string(raw)
+	root := gjson.ParseBytes(raw)
+	for _, key := range sensitiveKeys {
+		var paths []string
+		util.Walk(root, "", key, &paths)
+		for _, path := range paths {
+			out, _ = sjson.Set(out, path, "REDACTED")
+		}
+	}
+	return []byte(out)
+}
+
+// Normalize renders JSON with sorted keys and volatile fields removed so two
+// translator outputs can be compared byte-for-byte.
+func Normalize(raw []byte) ([]byte, error) {
+	trimmed := string(bytes.TrimSpace(raw))
+	for _, path := range volatilePaths {
+		if gjson.Get(trimmed, path).Exists() {
+			trimmed, _ = sjson.Delete(trimmed, path)
+		}
+	}
+	var value any
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return nil, err
+	}
+	// json.Marshal sorts map keys, which gives us the canonical form.
+	return json.MarshalIndent(value, "", "  ")
+}
+
+// Load reads every *.json fixture in dir, keyed by file name without the
+// extension, in sorted order.
+func Load(dir string) (names []string, fixtures map[string]*Fixture, err error) {
+	entries, errRead := os.ReadDir(dir)
+	if errRead != nil {
+		return nil, nil, errRead
+	}
+	fixtures = make(map[string]*Fixture)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		data, errFile := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if errFile != nil {
+			return nil, nil, errFile
+		}
+		fixture := &Fixture{}
+		if errJSON := json.Unmarshal(data, fixture); errJSON != nil {
+			return nil, nil, fmt.Errorf("fixture %s: %w", entry.Name(), errJSON)
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		names = append(names, name)
+		fixtures[name] = fixture
+	}
+	sort.Strings(names)
+	return names, fixtures, nil
+}
+
+// Save writes a fixture to dir as <name>.json with normalized request and
+// golden payloads.
+func Save(dir, name string, fixture *Fixture) error {
+	if request, errNorm := Normalize(fixture.Request); errNorm == nil {
+		fixture.Request = request
+	}
+	if len(fixture.Golden) > 0 {
+		if golden, errNorm := Normalize(fixture.Golden); errNorm == nil {
+			fixture.Golden = golden
+		}
+	}
+	data, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), append(data, '\n'), 0o644)
+}
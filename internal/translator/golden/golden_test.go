@@ -0,0 +1,59 @@
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	_ "github.com/shariqriazz/modelgate/internal/translator"
+	"github.com/shariqriazz/modelgate/internal/translator/translator"
+)
+
+var update = flag.Bool("update", false, "rewrite golden fixtures with current translator output")
+
+func TestGoldenRequestTranslation(t *testing.T) {
+	names, fixtures, err := Load("testdata")
+	if err != nil {
+		t.Fatalf("load fixtures: %v", err)
+	}
+	if len(names) == 0 {
+		t.Fatal("no golden fixtures found in testdata")
+	}
+	for _, name := range names {
+		fixture := fixtures[name]
+		t.Run(name, func(t *testing.T) {
+			got := translator.Request(fixture.From, fixture.To, fixture.Model, []byte(fixture.Request), fixture.Stream)
+			gotNorm, errNorm := Normalize(got)
+			if errNorm != nil {
+				t.Fatalf("translator output is not valid JSON: %v\n%s", errNorm, got)
+			}
+			if *update {
+				fixture.Golden = gotNorm
+				if errSave := Save("testdata", name, fixture); errSave != nil {
+					t.Fatalf("update fixture: %v", errSave)
+				}
+				return
+			}
+			if len(fixture.Golden) == 0 {
+				t.Fatalf("fixture has no golden output; run with -update to generate it")
+			}
+			wantNorm, errWant := Normalize(fixture.Golden)
+			if errWant != nil {
+				t.Fatalf("golden payload is not valid JSON: %v", errWant)
+			}
+			if !bytes.Equal(gotNorm, wantNorm) {
+				t.Fatalf("translator output changed for %s -> %s\ngot:\n%s\nwant:\n%s", fixture.From, fixture.To, gotNorm, wantNorm)
+			}
+		})
+	}
+}
+
+func TestSanitizeRedactsCredentials(t *testing.T) {
+	raw := []byte(`{"api_key":"sk-secret","nested":{"authorization":"Bearer abc"},"model":"m"}`)
+	got := string(Sanitize(raw))
+	for _, leaked := range []string{"sk-secret", "Bearer abc"} {
+		if bytes.Contains([]byte(got), []byte(leaked)) {
+			t.Fatalf("Sanitize leaked %q: %s", leaked, got)
+		}
+	}
+}
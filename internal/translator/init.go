@@ -26,9 +26,12 @@ import (
 	_ "github.com/shariqriazz/modelgate/internal/translator/openai/claude"
 	_ "github.com/shariqriazz/modelgate/internal/translator/openai/gemini"
 	_ "github.com/shariqriazz/modelgate/internal/translator/openai/gemini-cli"
+	_ "github.com/shariqriazz/modelgate/internal/translator/openai/ollama"
 	_ "github.com/shariqriazz/modelgate/internal/translator/openai/openai/chat-completions"
 	_ "github.com/shariqriazz/modelgate/internal/translator/openai/openai/responses"
 
+	_ "github.com/shariqriazz/modelgate/internal/translator/cohere/openai/chat-completions"
+
 	_ "github.com/shariqriazz/modelgate/internal/translator/antigravity/claude"
 	_ "github.com/shariqriazz/modelgate/internal/translator/antigravity/gemini"
 	_ "github.com/shariqriazz/modelgate/internal/translator/antigravity/openai/chat-completions"
@@ -307,8 +307,14 @@ func ConvertGeminiRequestToOpenAI(modelName string, inputRawJSON []byte, stream
 				out, _ = sjson.Set(out, "tool_choice", "none")
 			case "AUTO":
 				out, _ = sjson.Set(out, "tool_choice", "auto")
-			case "ANY":
-				out, _ = sjson.Set(out, "tool_choice", "required")
+			case "ANY", "VALIDATED":
+				// A single allowed function pins the choice to that function.
+				if names := functionCallingConfig.Get("allowedFunctionNames").Array(); len(names) == 1 && names[0].String() != "" {
+					toolChoiceJSON, _ := sjson.Set(`{"type":"function","function":{"name":""}}`, "function.name", names[0].String())
+					out, _ = sjson.SetRaw(out, "tool_choice", toolChoiceJSON)
+				} else {
+					out, _ = sjson.Set(out, "tool_choice", "required")
+				}
 			}
 		}
 	}
@@ -0,0 +1,19 @@
+package ollama
+
+import (
+	. "github.com/shariqriazz/modelgate/internal/constant"
+	"github.com/shariqriazz/modelgate/internal/interfaces"
+	"github.com/shariqriazz/modelgate/internal/translator/translator"
+)
+
+func init() {
+	translator.Register(
+		Ollama,
+		OpenAI,
+		ConvertOllamaRequestToOpenAI,
+		interfaces.TranslateResponse{
+			Stream:    ConvertOpenAIResponseToOllama,
+			NonStream: ConvertOpenAIResponseToOllamaNonStream,
+		},
+	)
+}
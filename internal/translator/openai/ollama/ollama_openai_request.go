@@ -0,0 +1,153 @@
+// Package ollama provides request and response translation between the Ollama
+// native API (/api/chat and /api/generate) and the OpenAI Chat Completions
+// API. Incoming Ollama payloads are converted into OpenAI chat requests so the
+// existing backend translator matrix applies, and OpenAI responses are
+// converted back into the NDJSON object stream Ollama clients expect.
+package ollama
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// ConvertOllamaRequestToOpenAI converts an Ollama /api/chat or /api/generate
+// request into an OpenAI Chat Completions request. Payloads carrying a
+// "messages" array use the chat shape; payloads carrying "prompt" use the
+// generate shape with optional "system" prefixed as a system message.
+//
+// Parameters:
+//   - modelName: The model name for the request
+//   - rawJSON: The raw Ollama request JSON
+//   - stream: Whether this is a streaming request
+//
+// Returns:
+//   - []byte: The OpenAI-compatible request JSON
+func ConvertOllamaRequestToOpenAI(modelName string, rawJSON []byte, stream bool) []byte {
+	root := gjson.ParseBytes(rawJSON)
+
+	out := []byte(`{}`)
+	out, _ = sjson.SetBytes(out, "model", modelName)
+	out, _ = sjson.SetBytes(out, "stream", stream)
+	if stream {
+		out, _ = sjson.SetBytes(out, "stream_options.include_usage", true)
+	}
+
+	if messages := root.Get("messages"); messages.IsArray() {
+		out, _ = sjson.SetRawBytes(out, "messages", []byte(`[]`))
+		for _, message := range messages.Array() {
+			out, _ = sjson.SetRawBytes(out, "messages.-1", convertOllamaMessage(message))
+		}
+	} else {
+		out, _ = sjson.SetRawBytes(out, "messages", []byte(`[]`))
+		if system := root.Get("system").String(); system != "" {
+			entry, _ := sjson.Set(`{"role":"system"}`, "content", system)
+			out, _ = sjson.SetRawBytes(out, "messages.-1", []byte(entry))
+		}
+		user := []byte(`{"role":"user"}`)
+		if images := root.Get("images"); images.IsArray() {
+			user = appendImageContent(user, root.Get("prompt").String(), images)
+		} else {
+			user, _ = sjson.SetBytes(user, "content", root.Get("prompt").String())
+		}
+		out, _ = sjson.SetRawBytes(out, "messages.-1", user)
+	}
+
+	if tools := root.Get("tools"); tools.IsArray() {
+		out, _ = sjson.SetRawBytes(out, "tools", []byte(tools.Raw))
+	}
+
+	if format := root.Get("format"); format.Exists() {
+		if format.Type == gjson.String {
+			if strings.EqualFold(format.String(), "json") {
+				out, _ = sjson.SetRawBytes(out, "response_format", []byte(`{"type":"json_object"}`))
+			}
+		} else if format.IsObject() {
+			out, _ = sjson.SetRawBytes(out, "response_format", []byte(`{"type":"json_schema","json_schema":{"name":"response"}}`))
+			out, _ = sjson.SetRawBytes(out, "response_format.json_schema.schema", []byte(format.Raw))
+		}
+	}
+
+	options := root.Get("options")
+	if temperature := options.Get("temperature"); temperature.Exists() {
+		out, _ = sjson.SetBytes(out, "temperature", temperature.Float())
+	}
+	if topP := options.Get("top_p"); topP.Exists() {
+		out, _ = sjson.SetBytes(out, "top_p", topP.Float())
+	}
+	if numPredict := options.Get("num_predict"); numPredict.Exists() {
+		out, _ = sjson.SetBytes(out, "max_tokens", numPredict.Int())
+	}
+	if seed := options.Get("seed"); seed.Exists() {
+		out, _ = sjson.SetBytes(out, "seed", seed.Int())
+	}
+	if stop := options.Get("stop"); stop.Exists() {
+		out, _ = sjson.SetRawBytes(out, "stop", []byte(stop.Raw))
+	}
+	if frequencyPenalty := options.Get("frequency_penalty"); frequencyPenalty.Exists() {
+		out, _ = sjson.SetBytes(out, "frequency_penalty", frequencyPenalty.Float())
+	}
+	if presencePenalty := options.Get("presence_penalty"); presencePenalty.Exists() {
+		out, _ = sjson.SetBytes(out, "presence_penalty", presencePenalty.Float())
+	}
+
+	return out
+}
+
+// convertOllamaMessage converts one Ollama chat message into the OpenAI shape,
+// expanding base64 images into image_url content parts and re-encoding tool
+// call arguments from objects to JSON strings.
+func convertOllamaMessage(message gjson.Result) []byte {
+	out := []byte(`{}`)
+	out, _ = sjson.SetBytes(out, "role", message.Get("role").String())
+
+	if images := message.Get("images"); images.IsArray() && len(images.Array()) > 0 {
+		out = appendImageContent(out, message.Get("content").String(), images)
+	} else {
+		out, _ = sjson.SetBytes(out, "content", message.Get("content").String())
+	}
+
+	if toolCalls := message.Get("tool_calls"); toolCalls.IsArray() {
+		out, _ = sjson.SetRawBytes(out, "tool_calls", []byte(`[]`))
+		for index, call := range toolCalls.Array() {
+			entry := []byte(`{"type":"function"}`)
+			entry, _ = sjson.SetBytes(entry, "id", callID(call, index))
+			entry, _ = sjson.SetBytes(entry, "function.name", call.Get("function.name").String())
+			arguments := call.Get("function.arguments")
+			if arguments.IsObject() {
+				entry, _ = sjson.SetBytes(entry, "function.arguments", arguments.Raw)
+			} else {
+				entry, _ = sjson.SetBytes(entry, "function.arguments", arguments.String())
+			}
+			out, _ = sjson.SetRawBytes(out, "tool_calls.-1", entry)
+		}
+	}
+
+	return out
+}
+
+// appendImageContent builds an OpenAI multi-part content array from text plus
+// Ollama base64 image attachments.
+func appendImageContent(out []byte, text string, images gjson.Result) []byte {
+	out, _ = sjson.SetRawBytes(out, "content", []byte(`[]`))
+	if text != "" {
+		part, _ := sjson.Set(`{"type":"text"}`, "text", text)
+		out, _ = sjson.SetRawBytes(out, "content.-1", []byte(part))
+	}
+	for _, image := range images.Array() {
+		part, _ := sjson.Set(`{"type":"image_url"}`, "image_url.url", "data:image/png;base64,"+image.String())
+		out, _ = sjson.SetRawBytes(out, "content.-1", []byte(part))
+	}
+	return out
+}
+
+// callID returns the Ollama-provided tool call ID when present, otherwise a
+// deterministic placeholder so the OpenAI shape stays valid.
+func callID(call gjson.Result, index int) string {
+	if id := call.Get("id").String(); id != "" {
+		return id
+	}
+	return fmt.Sprintf("call_%d", index)
+}
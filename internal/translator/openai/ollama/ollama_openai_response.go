@@ -0,0 +1,210 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+var dataTag = []byte("data:")
+
+// ConvertOpenAIResponseToOllamaParams holds accumulated stream state so the
+// terminal done object can report the finish reason and token counts.
+type ConvertOpenAIResponseToOllamaParams struct {
+	Model            string
+	DoneReason       string
+	PromptTokens     int64
+	CompletionTokens int64
+}
+
+// ConvertOpenAIResponseToOllama converts OpenAI streaming chunks into Ollama
+// NDJSON objects. Content deltas become incremental message (or response)
+// objects with done=false, and the [DONE] marker becomes the terminal object
+// carrying done_reason and eval counts.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - modelName: The name of the model.
+//   - rawJSON: The raw SSE line from the OpenAI-format stream.
+//   - param: A pointer to a parameter object for the conversion.
+//
+// Returns:
+//   - []string: A slice of Ollama NDJSON objects.
+func ConvertOpenAIResponseToOllama(_ context.Context, modelName string, originalRequestRawJSON, _ []byte, rawJSON []byte, param *any) []string {
+	if *param == nil {
+		*param = &ConvertOpenAIResponseToOllamaParams{Model: modelName}
+	}
+	state := (*param).(*ConvertOpenAIResponseToOllamaParams)
+	generate := isGenerateRequest(originalRequestRawJSON)
+
+	if bytes.HasPrefix(rawJSON, dataTag) {
+		rawJSON = bytes.TrimSpace(rawJSON[len(dataTag):])
+	}
+	if strings.TrimSpace(string(rawJSON)) == "[DONE]" {
+		return []string{ollamaDoneObject(state, generate)}
+	}
+
+	root := gjson.ParseBytes(rawJSON)
+	if model := root.Get("model").String(); model != "" {
+		state.Model = model
+	}
+	if usage := root.Get("usage"); usage.Exists() {
+		state.PromptTokens = usage.Get("prompt_tokens").Int()
+		state.CompletionTokens = usage.Get("completion_tokens").Int()
+	}
+	choice := root.Get("choices.0")
+	if finishReason := choice.Get("finish_reason").String(); finishReason != "" {
+		state.DoneReason = ollamaDoneReason(finishReason)
+	}
+
+	var out []string
+	delta := choice.Get("delta")
+	if content := delta.Get("content").String(); content != "" {
+		out = append(out, ollamaChunkObject(state.Model, content, generate))
+	}
+	if toolCalls := delta.Get("tool_calls"); toolCalls.IsArray() && !generate {
+		if chunk := ollamaToolCallObject(state.Model, toolCalls); chunk != "" {
+			out = append(out, chunk)
+		}
+	}
+	if out == nil {
+		return []string{}
+	}
+	return out
+}
+
+// ConvertOpenAIResponseToOllamaNonStream converts a full OpenAI chat
+// completion response into a single Ollama response object.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - modelName: The name of the model.
+//   - rawJSON: The raw JSON response in OpenAI format.
+//   - param: A pointer to a parameter object for the conversion.
+//
+// Returns:
+//   - string: The Ollama-compatible JSON response.
+func ConvertOpenAIResponseToOllamaNonStream(_ context.Context, modelName string, originalRequestRawJSON, _ []byte, rawJSON []byte, _ *any) string {
+	root := gjson.ParseBytes(rawJSON)
+	generate := isGenerateRequest(originalRequestRawJSON)
+
+	model := root.Get("model").String()
+	if model == "" {
+		model = modelName
+	}
+	choice := root.Get("choices.0")
+	content := choice.Get("message.content").String()
+
+	out := ollamaBaseObject(model)
+	if generate {
+		out, _ = sjson.Set(out, "response", content)
+	} else {
+		out, _ = sjson.Set(out, "message.role", "assistant")
+		out, _ = sjson.Set(out, "message.content", content)
+		if toolCalls := choice.Get("message.tool_calls"); toolCalls.IsArray() && !generate {
+			out = setOllamaToolCalls(out, "message.tool_calls", toolCalls)
+		}
+	}
+	out, _ = sjson.Set(out, "done", true)
+	out, _ = sjson.Set(out, "done_reason", ollamaDoneReason(choice.Get("finish_reason").String()))
+	out, _ = sjson.Set(out, "prompt_eval_count", root.Get("usage.prompt_tokens").Int())
+	out, _ = sjson.Set(out, "eval_count", root.Get("usage.completion_tokens").Int())
+	return out
+}
+
+// isGenerateRequest reports whether the original Ollama request used the
+// /api/generate prompt shape rather than the chat messages shape.
+func isGenerateRequest(originalRequestRawJSON []byte) bool {
+	return !gjson.GetBytes(originalRequestRawJSON, "messages").Exists() &&
+		gjson.GetBytes(originalRequestRawJSON, "prompt").Exists()
+}
+
+// ollamaBaseObject starts an Ollama response object with model and timestamp.
+func ollamaBaseObject(model string) string {
+	out, _ := sjson.Set(`{}`, "model", model)
+	out, _ = sjson.Set(out, "created_at", time.Now().UTC().Format(time.RFC3339Nano))
+	return out
+}
+
+// ollamaChunkObject renders one incremental content delta.
+func ollamaChunkObject(model, content string, generate bool) string {
+	out := ollamaBaseObject(model)
+	if generate {
+		out, _ = sjson.Set(out, "response", content)
+	} else {
+		out, _ = sjson.Set(out, "message.role", "assistant")
+		out, _ = sjson.Set(out, "message.content", content)
+	}
+	out, _ = sjson.Set(out, "done", false)
+	return out
+}
+
+// ollamaToolCallObject renders tool call deltas as an Ollama chat message.
+// OpenAI streams arguments as JSON string fragments; complete fragments are
+// re-parsed into objects as Ollama expects, partial ones are skipped.
+func ollamaToolCallObject(model string, toolCalls gjson.Result) string {
+	out := ollamaBaseObject(model)
+	out, _ = sjson.Set(out, "message.role", "assistant")
+	out, _ = sjson.Set(out, "message.content", "")
+	out = setOllamaToolCalls(out, "message.tool_calls", toolCalls)
+	if !gjson.Get(out, "message.tool_calls").Exists() {
+		return ""
+	}
+	out, _ = sjson.Set(out, "done", false)
+	return out
+}
+
+// setOllamaToolCalls writes OpenAI tool calls at path, decoding argument
+// strings back into objects. Calls without a complete argument payload are
+// dropped rather than emitted malformed.
+func setOllamaToolCalls(out, path string, toolCalls gjson.Result) string {
+	for _, call := range toolCalls.Array() {
+		name := call.Get("function.name").String()
+		if name == "" {
+			continue
+		}
+		arguments := call.Get("function.arguments").String()
+		parsed := gjson.Parse(arguments)
+		if arguments != "" && !parsed.IsObject() {
+			continue
+		}
+		entry, _ := sjson.Set(`{}`, "function.name", name)
+		if parsed.IsObject() {
+			entry, _ = sjson.SetRaw(entry, "function.arguments", parsed.Raw)
+		} else {
+			entry, _ = sjson.SetRaw(entry, "function.arguments", `{}`)
+		}
+		out, _ = sjson.SetRaw(out, path+".-1", entry)
+	}
+	return out
+}
+
+// ollamaDoneObject renders the terminal done=true object for a stream.
+func ollamaDoneObject(state *ConvertOpenAIResponseToOllamaParams, generate bool) string {
+	out := ollamaBaseObject(state.Model)
+	if generate {
+		out, _ = sjson.Set(out, "response", "")
+	} else {
+		out, _ = sjson.Set(out, "message.role", "assistant")
+		out, _ = sjson.Set(out, "message.content", "")
+	}
+	out, _ = sjson.Set(out, "done", true)
+	out, _ = sjson.Set(out, "done_reason", state.DoneReason)
+	out, _ = sjson.Set(out, "prompt_eval_count", state.PromptTokens)
+	out, _ = sjson.Set(out, "eval_count", state.CompletionTokens)
+	return out
+}
+
+// ollamaDoneReason maps an OpenAI finish_reason onto Ollama's done_reason.
+func ollamaDoneReason(finishReason string) string {
+	switch finishReason {
+	case "length":
+		return "length"
+	default:
+		return "stop"
+	}
+}
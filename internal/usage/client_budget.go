@@ -0,0 +1,324 @@
+package usage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+	coreusage "github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+	log "github.com/sirupsen/logrus"
+)
+
+func init() {
+	coreusage.RegisterPlugin(clientBudgetUsagePlugin{})
+}
+
+const budgetWebhookTimeout = 10 * time.Second
+
+// Budget dimensions reported in exceeded errors and alerts.
+const (
+	budgetDimensionDailyTokens   = "daily-tokens"
+	budgetDimensionMonthlyTokens = "monthly-tokens"
+	budgetDimensionDailyCost     = "daily-cost-usd"
+	budgetDimensionMonthlyCost   = "monthly-cost-usd"
+)
+
+// clientBudgetEntry accumulates one API key's consumption for the current
+// UTC day and calendar month.
+type clientBudgetEntry struct {
+	dayStart    time.Time
+	monthStart  time.Time
+	dayTokens   int64
+	monthTokens int64
+	dayCost     float64
+	monthCost   float64
+	// alerted tracks fired threshold alerts per window so each threshold
+	// triggers at most once until the window rolls.
+	dayAlerted   map[string]struct{}
+	monthAlerted map[string]struct{}
+}
+
+func (e *clientBudgetEntry) roll(now time.Time) {
+	now = now.UTC()
+	day := now.Truncate(24 * time.Hour)
+	if !day.Equal(e.dayStart) {
+		e.dayStart = day
+		e.dayTokens = 0
+		e.dayCost = 0
+		e.dayAlerted = make(map[string]struct{})
+	}
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if !month.Equal(e.monthStart) {
+		e.monthStart = month
+		e.monthTokens = 0
+		e.monthCost = 0
+		e.monthAlerted = make(map[string]struct{})
+	}
+}
+
+// clientBudgetTracker enforces the client-budgets config: it accumulates
+// consumption per inbound API key from usage records and answers whether a
+// key has exhausted one of its budgets.
+type clientBudgetTracker struct {
+	mu      sync.Mutex
+	rules   []config.ClientBudgetRule
+	entries map[string]*clientBudgetEntry
+}
+
+var defaultClientBudgetTracker = &clientBudgetTracker{entries: make(map[string]*clientBudgetEntry)}
+
+// SetClientBudgets replaces the configured client budget rules. It is wired
+// to the client-budgets config key and safe to call on reload.
+func SetClientBudgets(rules []config.ClientBudgetRule) {
+	cleaned := make([]config.ClientBudgetRule, 0, len(rules))
+	for _, rule := range rules {
+		if strings.TrimSpace(rule.APIKey) == "" {
+			continue
+		}
+		cleaned = append(cleaned, rule)
+	}
+	defaultClientBudgetTracker.mu.Lock()
+	defaultClientBudgetTracker.rules = cleaned
+	defaultClientBudgetTracker.mu.Unlock()
+}
+
+// BudgetExceeded describes an exhausted client budget for error reporting.
+type BudgetExceeded struct {
+	// Dimension names the exhausted budget (e.g. "daily-tokens").
+	Dimension string
+	// ResetAt is when the corresponding window rolls over.
+	ResetAt time.Time
+}
+
+// StatusCode returns 402 for dollar budgets and 429 for token budgets.
+func (b *BudgetExceeded) StatusCode() int {
+	if b.Dimension == budgetDimensionDailyCost || b.Dimension == budgetDimensionMonthlyCost {
+		return http.StatusPaymentRequired
+	}
+	return http.StatusTooManyRequests
+}
+
+func (b *BudgetExceeded) Error() string {
+	return fmt.Sprintf("client budget exceeded (%s); resets at %s", b.Dimension, b.ResetAt.UTC().Format(time.RFC3339))
+}
+
+// CheckClientBudget reports whether the inbound API key has exhausted one of
+// its configured budgets. It returns nil when no budget applies or headroom
+// remains.
+func CheckClientBudget(apiKey string) *BudgetExceeded {
+	return defaultClientBudgetTracker.check(apiKey, time.Now())
+}
+
+func (t *clientBudgetTracker) check(apiKey string, now time.Time) *BudgetExceeded {
+	if t == nil || strings.TrimSpace(apiKey) == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rule := t.ruleFor(apiKey)
+	if rule == nil {
+		return nil
+	}
+	entry, ok := t.entries[apiKey]
+	if !ok {
+		return nil
+	}
+	entry.roll(now)
+	dayReset := entry.dayStart.Add(24 * time.Hour)
+	monthReset := entry.monthStart.AddDate(0, 1, 0)
+	switch {
+	case rule.DailyTokens > 0 && entry.dayTokens >= rule.DailyTokens:
+		return &BudgetExceeded{Dimension: budgetDimensionDailyTokens, ResetAt: dayReset}
+	case rule.MonthlyTokens > 0 && entry.monthTokens >= rule.MonthlyTokens:
+		return &BudgetExceeded{Dimension: budgetDimensionMonthlyTokens, ResetAt: monthReset}
+	case rule.DailyCostUSD > 0 && entry.dayCost >= rule.DailyCostUSD:
+		return &BudgetExceeded{Dimension: budgetDimensionDailyCost, ResetAt: dayReset}
+	case rule.MonthlyCostUSD > 0 && entry.monthCost >= rule.MonthlyCostUSD:
+		return &BudgetExceeded{Dimension: budgetDimensionMonthlyCost, ResetAt: monthReset}
+	}
+	return nil
+}
+
+// ruleFor resolves the first budget rule matching the API key. Callers must
+// hold the tracker mutex.
+func (t *clientBudgetTracker) ruleFor(apiKey string) *config.ClientBudgetRule {
+	for i := range t.rules {
+		if matchBudgetKeyPattern(t.rules[i].APIKey, apiKey) {
+			return &t.rules[i]
+		}
+	}
+	return nil
+}
+
+// record charges one usage record against the key's budgets and fires any
+// newly crossed threshold alerts.
+func (t *clientBudgetTracker) record(record coreusage.Record, now time.Time) {
+	apiKey := strings.TrimSpace(record.APIKey)
+	if t == nil || apiKey == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rule := t.ruleFor(apiKey)
+	if rule == nil {
+		return
+	}
+	entry, ok := t.entries[apiKey]
+	if !ok {
+		entry = &clientBudgetEntry{}
+		t.entries[apiKey] = entry
+	}
+	entry.roll(now)
+	entry.dayTokens += record.Detail.TotalTokens
+	entry.monthTokens += record.Detail.TotalTokens
+	entry.dayCost += record.CostUSD
+	entry.monthCost += record.CostUSD
+
+	t.checkAlerts(rule, apiKey, entry)
+}
+
+// budgetAlert is the JSON payload delivered to the alert webhook.
+type budgetAlert struct {
+	APIKey    string    `json:"api_key"`
+	Dimension string    `json:"dimension"`
+	Threshold int       `json:"threshold_percent"`
+	Used      float64   `json:"used"`
+	Limit     float64   `json:"limit"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// checkAlerts fires log and webhook alerts for thresholds crossed by the
+// current consumption. Callers must hold the tracker mutex.
+func (t *clientBudgetTracker) checkAlerts(rule *config.ClientBudgetRule, apiKey string, entry *clientBudgetEntry) {
+	if len(rule.AlertThresholds) == 0 {
+		return
+	}
+	thresholds := append([]int(nil), rule.AlertThresholds...)
+	sort.Ints(thresholds)
+	dimensions := []struct {
+		name    string
+		used    float64
+		limit   float64
+		alerted map[string]struct{}
+	}{
+		{budgetDimensionDailyTokens, float64(entry.dayTokens), float64(rule.DailyTokens), entry.dayAlerted},
+		{budgetDimensionMonthlyTokens, float64(entry.monthTokens), float64(rule.MonthlyTokens), entry.monthAlerted},
+		{budgetDimensionDailyCost, entry.dayCost, rule.DailyCostUSD, entry.dayAlerted},
+		{budgetDimensionMonthlyCost, entry.monthCost, rule.MonthlyCostUSD, entry.monthAlerted},
+	}
+	for _, dimension := range dimensions {
+		if dimension.limit <= 0 {
+			continue
+		}
+		percent := dimension.used / dimension.limit * 100
+		for _, threshold := range thresholds {
+			if threshold <= 0 || percent < float64(threshold) {
+				continue
+			}
+			key := fmt.Sprintf("%s@%d", dimension.name, threshold)
+			if _, fired := dimension.alerted[key]; fired {
+				continue
+			}
+			dimension.alerted[key] = struct{}{}
+			notifyBudgetAlert(rule.AlertWebhook, budgetAlert{
+				APIKey:    maskClientKey(apiKey),
+				Dimension: dimension.name,
+				Threshold: threshold,
+				Used:      dimension.used,
+				Limit:     dimension.limit,
+				Timestamp: time.Now(),
+			})
+		}
+	}
+}
+
+// notifyBudgetAlert logs the alert and delivers it to the webhook when one is
+// configured.
+func notifyBudgetAlert(webhook string, alert budgetAlert) {
+	log.Warnf("client budget alert: key %s reached %d%% of %s budget (%.2f of %.2f)",
+		alert.APIKey, alert.Threshold, alert.Dimension, alert.Used, alert.Limit)
+	webhook = strings.TrimSpace(webhook)
+	if webhook == "" {
+		return
+	}
+	go func() {
+		if err := postBudgetAlert(webhook, alert); err != nil {
+			log.WithError(err).Warnf("client budget alert webhook delivery failed for key %s", alert.APIKey)
+		}
+	}()
+}
+
+func postBudgetAlert(url string, alert budgetAlert) error {
+	payload, errMarshal := json.Marshal(alert)
+	if errMarshal != nil {
+		return errMarshal
+	}
+	client := &http.Client{Timeout: budgetWebhookTimeout}
+	resp, errPost := client.Post(url, "application/json", bytes.NewReader(payload))
+	if errPost != nil {
+		return errPost
+	}
+	return resp.Body.Close()
+}
+
+// maskClientKey hides all but the last four characters of an API key so
+// alerts never leak full credentials.
+func maskClientKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// matchBudgetKeyPattern matches an API key against a pattern where '*'
+// matches zero or more characters.
+func matchBudgetKeyPattern(pattern, key string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	pi, si := 0, 0
+	starIdx := -1
+	matchIdx := 0
+	for si < len(key) {
+		if pi < len(pattern) && pattern[pi] == key[si] {
+			pi++
+			si++
+			continue
+		}
+		if pi < len(pattern) && pattern[pi] == '*' {
+			starIdx = pi
+			matchIdx = si
+			pi++
+			continue
+		}
+		if starIdx != -1 {
+			pi = starIdx + 1
+			matchIdx++
+			si = matchIdx
+			continue
+		}
+		return false
+	}
+	for pi < len(pattern) && pattern[pi] == '*' {
+		pi++
+	}
+	return pi == len(pattern)
+}
+
+// clientBudgetUsagePlugin feeds usage records into the budget tracker.
+type clientBudgetUsagePlugin struct{}
+
+func (clientBudgetUsagePlugin) HandleUsage(_ context.Context, record coreusage.Record) {
+	defaultClientBudgetTracker.record(record, time.Now())
+}
@@ -64,6 +64,7 @@ type RequestStatistics struct {
 	successCount  int64
 	failureCount  int64
 	totalTokens   int64
+	totalCost     float64
 
 	apis map[string]*apiStats
 
@@ -77,6 +78,7 @@ type RequestStatistics struct {
 type apiStats struct {
 	TotalRequests int64
 	TotalTokens   int64
+	TotalCost     float64
 	Models        map[string]*modelStats
 }
 
@@ -84,6 +86,7 @@ type apiStats struct {
 type modelStats struct {
 	TotalRequests int64
 	TotalTokens   int64
+	TotalCost     float64
 	Details       []RequestDetail
 }
 
@@ -93,6 +96,7 @@ type RequestDetail struct {
 	Source    string     `json:"source"`
 	AuthIndex string     `json:"auth_index"`
 	Tokens    TokenStats `json:"tokens"`
+	CostUSD   float64    `json:"cost_usd,omitempty"`
 	Failed    bool       `json:"failed"`
 }
 
@@ -107,10 +111,11 @@ type TokenStats struct {
 
 // StatisticsSnapshot represents an immutable view of the aggregated metrics.
 type StatisticsSnapshot struct {
-	TotalRequests int64 `json:"total_requests"`
-	SuccessCount  int64 `json:"success_count"`
-	FailureCount  int64 `json:"failure_count"`
-	TotalTokens   int64 `json:"total_tokens"`
+	TotalRequests int64   `json:"total_requests"`
+	SuccessCount  int64   `json:"success_count"`
+	FailureCount  int64   `json:"failure_count"`
+	TotalTokens   int64   `json:"total_tokens"`
+	TotalCostUSD  float64 `json:"total_cost_usd"`
 
 	APIs map[string]APISnapshot `json:"apis"`
 
@@ -124,6 +129,7 @@ type StatisticsSnapshot struct {
 type APISnapshot struct {
 	TotalRequests int64                    `json:"total_requests"`
 	TotalTokens   int64                    `json:"total_tokens"`
+	TotalCostUSD  float64                  `json:"total_cost_usd,omitempty"`
 	Models        map[string]ModelSnapshot `json:"models"`
 }
 
@@ -131,6 +137,7 @@ type APISnapshot struct {
 type ModelSnapshot struct {
 	TotalRequests int64           `json:"total_requests"`
 	TotalTokens   int64           `json:"total_tokens"`
+	TotalCostUSD  float64         `json:"total_cost_usd,omitempty"`
 	Details       []RequestDetail `json:"details"`
 }
 
@@ -190,6 +197,7 @@ func (s *RequestStatistics) Record(ctx context.Context, record coreusage.Record)
 		s.failureCount++
 	}
 	s.totalTokens += totalTokens
+	s.totalCost += record.CostUSD
 
 	stats, ok := s.apis[statsKey]
 	if !ok {
@@ -201,6 +209,7 @@ func (s *RequestStatistics) Record(ctx context.Context, record coreusage.Record)
 		Source:    record.Source,
 		AuthIndex: record.AuthIndex,
 		Tokens:    detail,
+		CostUSD:   record.CostUSD,
 		Failed:    failed,
 	})
 
@@ -213,6 +222,7 @@ func (s *RequestStatistics) Record(ctx context.Context, record coreusage.Record)
 func (s *RequestStatistics) updateAPIStats(stats *apiStats, model string, detail RequestDetail) {
 	stats.TotalRequests++
 	stats.TotalTokens += detail.Tokens.TotalTokens
+	stats.TotalCost += detail.CostUSD
 	modelStatsValue, ok := stats.Models[model]
 	if !ok {
 		modelStatsValue = &modelStats{}
@@ -220,6 +230,7 @@ func (s *RequestStatistics) updateAPIStats(stats *apiStats, model string, detail
 	}
 	modelStatsValue.TotalRequests++
 	modelStatsValue.TotalTokens += detail.Tokens.TotalTokens
+	modelStatsValue.TotalCost += detail.CostUSD
 	modelStatsValue.Details = append(modelStatsValue.Details, detail)
 }
 
@@ -237,12 +248,14 @@ func (s *RequestStatistics) Snapshot() StatisticsSnapshot {
 	result.SuccessCount = s.successCount
 	result.FailureCount = s.failureCount
 	result.TotalTokens = s.totalTokens
+	result.TotalCostUSD = s.totalCost
 
 	result.APIs = make(map[string]APISnapshot, len(s.apis))
 	for apiName, stats := range s.apis {
 		apiSnapshot := APISnapshot{
 			TotalRequests: stats.TotalRequests,
 			TotalTokens:   stats.TotalTokens,
+			TotalCostUSD:  stats.TotalCost,
 			Models:        make(map[string]ModelSnapshot, len(stats.Models)),
 		}
 		for modelName, modelStatsValue := range stats.Models {
@@ -251,6 +264,7 @@ func (s *RequestStatistics) Snapshot() StatisticsSnapshot {
 			apiSnapshot.Models[modelName] = ModelSnapshot{
 				TotalRequests: modelStatsValue.TotalRequests,
 				TotalTokens:   modelStatsValue.TotalTokens,
+				TotalCostUSD:  modelStatsValue.TotalCost,
 				Details:       requestDetails,
 			}
 		}
@@ -363,6 +377,7 @@ func (s *RequestStatistics) recordImported(apiName, modelName string, stats *api
 		s.successCount++
 	}
 	s.totalTokens += totalTokens
+	s.totalCost += detail.CostUSD
 
 	s.updateAPIStats(stats, modelName, detail)
 
@@ -0,0 +1,249 @@
+package usage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	coreusage "github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	usageTable = "usage_records"
+
+	// defaultQueryLimit caps history queries that do not specify a limit.
+	defaultQueryLimit = 1000
+
+	dialectSQLite   = "sqlite"
+	dialectPostgres = "postgres"
+)
+
+// SQLStore implements Store on top of database/sql. The same implementation
+// serves SQLite and Postgres; only the driver, DDL types, and placeholder
+// syntax differ per dialect.
+type SQLStore struct {
+	db      *sql.DB
+	dialect string
+}
+
+// NewSQLiteStore opens (creating if needed) a SQLite-backed usage store at the
+// given file path.
+func NewSQLiteStore(ctx context.Context, path string) (*SQLStore, error) {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return nil, fmt.Errorf("sqlite usage store: path is required")
+	}
+	db, err := sql.Open("sqlite", trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite usage store: open: %w", err)
+	}
+	// SQLite serializes writers; a single connection avoids lock contention.
+	db.SetMaxOpenConns(1)
+	store := &SQLStore{db: db, dialect: dialectSQLite}
+	if err = store.ensureSchema(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// NewPostgresStore connects to Postgres with the given DSN and prepares the
+// usage table.
+func NewPostgresStore(ctx context.Context, dsn string) (*SQLStore, error) {
+	trimmed := strings.TrimSpace(dsn)
+	if trimmed == "" {
+		return nil, fmt.Errorf("postgres usage store: DSN is required")
+	}
+	db, err := sql.Open("pgx", trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("postgres usage store: open: %w", err)
+	}
+	if err = db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("postgres usage store: ping: %w", err)
+	}
+	store := &SQLStore{db: db, dialect: dialectPostgres}
+	if err = store.ensureSchema(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// OpenStore constructs the store for the configured backend name.
+func OpenStore(ctx context.Context, backend, dsn string) (Store, error) {
+	switch strings.ToLower(strings.TrimSpace(backend)) {
+	case dialectSQLite:
+		return NewSQLiteStore(ctx, dsn)
+	case dialectPostgres:
+		return NewPostgresStore(ctx, dsn)
+	default:
+		return nil, fmt.Errorf("usage store: unknown backend %q", backend)
+	}
+}
+
+// ensureSchema creates the usage table and its time index when missing.
+func (s *SQLStore) ensureSchema(ctx context.Context) error {
+	idColumn := "id INTEGER PRIMARY KEY AUTOINCREMENT"
+	if s.dialect == dialectPostgres {
+		idColumn = "id BIGSERIAL PRIMARY KEY"
+	}
+	ddl := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		%s,
+		requested_at TIMESTAMP NOT NULL,
+		provider TEXT NOT NULL DEFAULT '',
+		model TEXT NOT NULL DEFAULT '',
+		api_key TEXT NOT NULL DEFAULT '',
+		auth_id TEXT NOT NULL DEFAULT '',
+		auth_index TEXT NOT NULL DEFAULT '',
+		source TEXT NOT NULL DEFAULT '',
+		failed BOOLEAN NOT NULL DEFAULT FALSE,
+		input_tokens BIGINT NOT NULL DEFAULT 0,
+		output_tokens BIGINT NOT NULL DEFAULT 0,
+		reasoning_tokens BIGINT NOT NULL DEFAULT 0,
+		cached_tokens BIGINT NOT NULL DEFAULT 0,
+		total_tokens BIGINT NOT NULL DEFAULT 0,
+		cost_usd DOUBLE PRECISION NOT NULL DEFAULT 0
+	)`, usageTable, idColumn)
+	if _, err := s.db.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("%s usage store: create table: %w", s.dialect, err)
+	}
+	index := fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_requested_at ON %s (requested_at)", usageTable, usageTable)
+	if _, err := s.db.ExecContext(ctx, index); err != nil {
+		return fmt.Errorf("%s usage store: create index: %w", s.dialect, err)
+	}
+	return nil
+}
+
+// placeholder renders the dialect-specific parameter marker for position n
+// (1-based).
+func (s *SQLStore) placeholder(n int) string {
+	if s.dialect == dialectPostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// SaveRecord implements Store.
+func (s *SQLStore) SaveRecord(ctx context.Context, record coreusage.Record) error {
+	if s == nil || s.db == nil {
+		return fmt.Errorf("usage store is not initialized")
+	}
+	requestedAt := record.RequestedAt
+	if requestedAt.IsZero() {
+		requestedAt = time.Now()
+	}
+	placeholders := make([]string, 14)
+	for i := range placeholders {
+		placeholders[i] = s.placeholder(i + 1)
+	}
+	query := fmt.Sprintf(`INSERT INTO %s (
+		requested_at, provider, model, api_key, auth_id, auth_index, source, failed,
+		input_tokens, output_tokens, reasoning_tokens, cached_tokens, total_tokens, cost_usd
+	) VALUES (%s)`, usageTable, strings.Join(placeholders, ", "))
+	_, err := s.db.ExecContext(ctx, query,
+		requestedAt.UTC(),
+		record.Provider,
+		record.Model,
+		record.APIKey,
+		record.AuthID,
+		record.AuthIndex,
+		record.Source,
+		record.Failed,
+		record.Detail.InputTokens,
+		record.Detail.OutputTokens,
+		record.Detail.ReasoningTokens,
+		record.Detail.CachedTokens,
+		record.Detail.TotalTokens,
+		record.CostUSD,
+	)
+	if err != nil {
+		return fmt.Errorf("%s usage store: insert: %w", s.dialect, err)
+	}
+	return nil
+}
+
+// QueryRecords implements Store.
+func (s *SQLStore) QueryRecords(ctx context.Context, query RecordQuery) ([]StoredRecord, error) {
+	if s == nil || s.db == nil {
+		return nil, fmt.Errorf("usage store is not initialized")
+	}
+	var conditions []string
+	var args []any
+	addCondition := func(clause string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(clause, s.placeholder(len(args))))
+	}
+	if !query.Since.IsZero() {
+		addCondition("requested_at >= %s", query.Since.UTC())
+	}
+	if !query.Until.IsZero() {
+		addCondition("requested_at <= %s", query.Until.UTC())
+	}
+	if provider := strings.TrimSpace(query.Provider); provider != "" {
+		addCondition("provider = %s", provider)
+	}
+	if model := strings.TrimSpace(query.Model); model != "" {
+		addCondition("model = %s", model)
+	}
+	if apiKey := strings.TrimSpace(query.APIKey); apiKey != "" {
+		addCondition("api_key = %s", apiKey)
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	stmt := fmt.Sprintf(`SELECT requested_at, provider, model, api_key, auth_id, auth_index, source, failed,
+		input_tokens, output_tokens, reasoning_tokens, cached_tokens, total_tokens, cost_usd FROM %s`, usageTable)
+	if len(conditions) > 0 {
+		stmt += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+	stmt += fmt.Sprintf(" ORDER BY requested_at DESC LIMIT %s", s.placeholder(len(args)))
+
+	rows, err := s.db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s usage store: query: %w", s.dialect, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []StoredRecord
+	for rows.Next() {
+		var record StoredRecord
+		if err = rows.Scan(
+			&record.RequestedAt,
+			&record.Provider,
+			&record.Model,
+			&record.APIKey,
+			&record.AuthID,
+			&record.AuthIndex,
+			&record.Source,
+			&record.Failed,
+			&record.InputTokens,
+			&record.OutputTokens,
+			&record.ReasoningTokens,
+			&record.CachedTokens,
+			&record.TotalTokens,
+			&record.CostUSD,
+		); err != nil {
+			return nil, fmt.Errorf("%s usage store: scan: %w", s.dialect, err)
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s usage store: rows: %w", s.dialect, err)
+	}
+	return records, nil
+}
+
+// Close implements Store.
+func (s *SQLStore) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
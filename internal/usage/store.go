@@ -0,0 +1,91 @@
+package usage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coreusage "github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+	log "github.com/sirupsen/logrus"
+)
+
+// Store persists usage records so accounting survives restarts and can be
+// queried historically. Implementations must be safe for concurrent use.
+type Store interface {
+	// SaveRecord appends one usage record to the backing store.
+	SaveRecord(ctx context.Context, record coreusage.Record) error
+	// QueryRecords returns stored records matching the query, newest first.
+	QueryRecords(ctx context.Context, query RecordQuery) ([]StoredRecord, error)
+	// Close releases the underlying database resources.
+	Close() error
+}
+
+// RecordQuery filters historical usage records. Zero-value fields are ignored.
+type RecordQuery struct {
+	Since    time.Time
+	Until    time.Time
+	Provider string
+	Model    string
+	APIKey   string
+	// Limit caps the number of returned records; implementations apply a
+	// default when it is zero or negative.
+	Limit int
+}
+
+// StoredRecord is one persisted usage record as returned by a Store.
+type StoredRecord struct {
+	RequestedAt     time.Time `json:"requested_at"`
+	Provider        string    `json:"provider"`
+	Model           string    `json:"model"`
+	APIKey          string    `json:"api_key"`
+	AuthID          string    `json:"auth_id"`
+	AuthIndex       string    `json:"auth_index"`
+	Source          string    `json:"source"`
+	Failed          bool      `json:"failed"`
+	InputTokens     int64     `json:"input_tokens"`
+	OutputTokens    int64     `json:"output_tokens"`
+	ReasoningTokens int64     `json:"reasoning_tokens"`
+	CachedTokens    int64     `json:"cached_tokens"`
+	TotalTokens     int64     `json:"total_tokens"`
+	CostUSD         float64   `json:"cost_usd"`
+}
+
+var (
+	activeStoreMu sync.RWMutex
+	activeStore   Store
+)
+
+// SetActiveStore installs the process-wide usage store. Passing nil clears it.
+func SetActiveStore(store Store) {
+	activeStoreMu.Lock()
+	activeStore = store
+	activeStoreMu.Unlock()
+}
+
+// ActiveStore returns the installed usage store, or nil when persistence is
+// not configured.
+func ActiveStore() Store {
+	activeStoreMu.RLock()
+	defer activeStoreMu.RUnlock()
+	return activeStore
+}
+
+// StorePlugin forwards usage records to a persistent store.
+// It implements coreusage.Plugin and runs on the usage manager's dispatch
+// goroutine, so writes never block the request path.
+type StorePlugin struct {
+	store Store
+}
+
+// NewStorePlugin constructs a plugin that persists records to the given store.
+func NewStorePlugin(store Store) *StorePlugin { return &StorePlugin{store: store} }
+
+// HandleUsage implements coreusage.Plugin.
+func (p *StorePlugin) HandleUsage(ctx context.Context, record coreusage.Record) {
+	if p == nil || p.store == nil {
+		return
+	}
+	if err := p.store.SaveRecord(ctx, record); err != nil {
+		log.Errorf("usage: failed to persist usage record: %v", err)
+	}
+}
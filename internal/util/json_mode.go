@@ -0,0 +1,63 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// RequestWantsJSONObject reports whether an OpenAI Chat Completions request
+// asked for JSON mode via response_format (either "json_object" or
+// "json_schema").
+func RequestWantsJSONObject(rawJSON []byte) bool {
+	switch gjson.GetBytes(rawJSON, "response_format.type").String() {
+	case "json_object", "json_schema":
+		return true
+	}
+	return false
+}
+
+// SanitizeJSONModeText normalizes model output produced under JSON mode.
+// Providers without a native JSON mode tend to wrap the object in markdown
+// fences or surround it with commentary; this strips fences and, when the
+// remainder still fails to parse, extracts the outermost JSON object or array.
+// If no valid JSON can be recovered the input is returned unchanged.
+func SanitizeJSONModeText(text string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	if gjson.Valid(trimmed) {
+		return trimmed
+	}
+
+	// Strip a leading ```json / ``` fence line and a trailing ``` fence.
+	if strings.HasPrefix(trimmed, "```") {
+		body := trimmed
+		if idx := strings.IndexByte(body, '\n'); idx >= 0 {
+			body = body[idx+1:]
+		} else {
+			body = strings.TrimPrefix(body, "```json")
+			body = strings.TrimPrefix(body, "```")
+		}
+		body = strings.TrimSpace(body)
+		body = strings.TrimSuffix(body, "```")
+		body = strings.TrimSpace(body)
+		if gjson.Valid(body) {
+			return body
+		}
+		trimmed = body
+	}
+
+	// Fall back to the outermost object or array embedded in the text.
+	for _, pair := range [][2]byte{{'{', '}'}, {'[', ']'}} {
+		start := strings.IndexByte(trimmed, pair[0])
+		end := strings.LastIndexByte(trimmed, pair[1])
+		if start >= 0 && end > start {
+			if candidate := trimmed[start : end+1]; gjson.Valid(candidate) {
+				return candidate
+			}
+		}
+	}
+	return text
+}
@@ -0,0 +1,43 @@
+package util
+
+import "testing"
+
+func TestRequestWantsJSONObject(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want bool
+	}{
+		{"json_object", `{"response_format":{"type":"json_object"}}`, true},
+		{"json_schema", `{"response_format":{"type":"json_schema","json_schema":{"name":"x"}}}`, true},
+		{"text", `{"response_format":{"type":"text"}}`, false},
+		{"absent", `{"model":"m"}`, false},
+	}
+	for _, tc := range cases {
+		if got := RequestWantsJSONObject([]byte(tc.raw)); got != tc.want {
+			t.Fatalf("%s: RequestWantsJSONObject = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSanitizeJSONModeText(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"already valid", `{"a":1}`, `{"a":1}`},
+		{"whitespace", "  {\"a\":1}\n", `{"a":1}`},
+		{"fenced", "```json\n{\"a\":1}\n```", `{"a":1}`},
+		{"fenced no lang", "```\n{\"a\":1}\n```", `{"a":1}`},
+		{"surrounding prose", "Here is the result:\n{\"a\":1}\nHope that helps!", `{"a":1}`},
+		{"array", "```json\n[1,2]\n```", `[1,2]`},
+		{"unrecoverable", "not json at all", "not json at all"},
+		{"empty", "", ""},
+	}
+	for _, tc := range cases {
+		if got := SanitizeJSONModeText(tc.in); got != tc.want {
+			t.Fatalf("%s: SanitizeJSONModeText = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// ValidateJSONSchema checks a JSON document against a JSON Schema subset
+// covering the keywords models commonly receive in structured output
+// requests: type (string or array), enum, const, required, properties,
+// additionalProperties (false only), items, minItems and maxItems. Unknown
+// keywords are ignored so permissive schemas never fail spuriously. The
+// returned error names the offending path for logging and retry decisions.
+func ValidateJSONSchema(doc, schema gjson.Result) error {
+	return validateSchemaAt(doc, schema, "$")
+}
+
+func validateSchemaAt(doc, schema gjson.Result, path string) error {
+	if !schema.IsObject() {
+		return nil
+	}
+
+	if typeSpec := schema.Get("type"); typeSpec.Exists() {
+		if err := validateSchemaType(doc, typeSpec, path); err != nil {
+			return err
+		}
+	}
+
+	if enum := schema.Get("enum"); enum.IsArray() {
+		matched := false
+		for _, allowed := range enum.Array() {
+			if allowed.Raw == doc.Raw || (allowed.Type == doc.Type && allowed.String() == doc.String()) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: value is not one of the enum values", path)
+		}
+	}
+	if constVal := schema.Get("const"); constVal.Exists() {
+		if constVal.Raw != doc.Raw && !(constVal.Type == doc.Type && constVal.String() == doc.String()) {
+			return fmt.Errorf("%s: value does not match const", path)
+		}
+	}
+
+	if doc.IsObject() {
+		for _, required := range schema.Get("required").Array() {
+			if !doc.Get(requiredKeyPath(required.String())).Exists() {
+				return fmt.Errorf("%s: missing required property %q", path, required.String())
+			}
+		}
+		properties := schema.Get("properties")
+		if properties.IsObject() {
+			var err error
+			properties.ForEach(func(key, propSchema gjson.Result) bool {
+				value := doc.Get(requiredKeyPath(key.String()))
+				if !value.Exists() {
+					return true
+				}
+				err = validateSchemaAt(value, propSchema, path+"."+key.String())
+				return err == nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if additional := schema.Get("additionalProperties"); additional.Type == gjson.False && properties.IsObject() {
+			var err error
+			doc.ForEach(func(key, _ gjson.Result) bool {
+				if !properties.Get(requiredKeyPath(key.String())).Exists() {
+					err = fmt.Errorf("%s: unexpected property %q", path, key.String())
+					return false
+				}
+				return true
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if doc.IsArray() {
+		elements := doc.Array()
+		if minItems := schema.Get("minItems"); minItems.Exists() && int64(len(elements)) < minItems.Int() {
+			return fmt.Errorf("%s: array has fewer than %d items", path, minItems.Int())
+		}
+		if maxItems := schema.Get("maxItems"); maxItems.Exists() && int64(len(elements)) > maxItems.Int() {
+			return fmt.Errorf("%s: array has more than %d items", path, maxItems.Int())
+		}
+		if items := schema.Get("items"); items.IsObject() {
+			for index, element := range elements {
+				if err := validateSchemaAt(element, items, fmt.Sprintf("%s[%d]", path, index)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSchemaType checks the document against a schema type keyword, which
+// may be a single type name or an array of alternatives.
+func validateSchemaType(doc, typeSpec gjson.Result, path string) error {
+	names := []string{typeSpec.String()}
+	if typeSpec.IsArray() {
+		names = names[:0]
+		for _, alternative := range typeSpec.Array() {
+			names = append(names, alternative.String())
+		}
+	}
+	for _, name := range names {
+		if matchesSchemaType(doc, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: expected type %s", path, strings.Join(names, " or "))
+}
+
+// matchesSchemaType reports whether the document satisfies one JSON Schema
+// type name.
+func matchesSchemaType(doc gjson.Result, name string) bool {
+	switch name {
+	case "object":
+		return doc.IsObject()
+	case "array":
+		return doc.IsArray()
+	case "string":
+		return doc.Type == gjson.String
+	case "number":
+		return doc.Type == gjson.Number
+	case "integer":
+		return doc.Type == gjson.Number && doc.Float() == float64(doc.Int())
+	case "boolean":
+		return doc.Type == gjson.True || doc.Type == gjson.False
+	case "null":
+		return doc.Type == gjson.Null
+	default:
+		return true
+	}
+}
+
+// requiredKeyPath escapes a literal property name for gjson lookup so keys
+// containing path syntax characters resolve correctly.
+func requiredKeyPath(key string) string {
+	replacer := strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`)
+	return replacer.Replace(key)
+}
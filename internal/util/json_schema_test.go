@@ -0,0 +1,56 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["name", "age"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer"},
+			"status": {"enum": ["active", "inactive"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1}
+		}
+	}`
+	cases := []struct {
+		name    string
+		doc     string
+		wantErr bool
+	}{
+		{"valid", `{"name":"a","age":3}`, false},
+		{"valid with enum and array", `{"name":"a","age":3,"status":"active","tags":["x"]}`, false},
+		{"missing required", `{"name":"a"}`, true},
+		{"wrong type", `{"name":"a","age":"three"}`, true},
+		{"non-integer number", `{"name":"a","age":3.5}`, true},
+		{"enum mismatch", `{"name":"a","age":3,"status":"gone"}`, true},
+		{"unexpected property", `{"name":"a","age":3,"extra":true}`, true},
+		{"empty array below minItems", `{"name":"a","age":3,"tags":[]}`, true},
+		{"item type mismatch", `{"name":"a","age":3,"tags":[1]}`, true},
+		{"not an object", `[1,2]`, true},
+	}
+	for _, tc := range cases {
+		err := ValidateJSONSchema(gjson.Parse(tc.doc), gjson.Parse(schema))
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%s: ValidateJSONSchema error = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestValidateJSONSchemaTypeAlternatives(t *testing.T) {
+	schema := gjson.Parse(`{"type":["string","null"]}`)
+	if err := ValidateJSONSchema(gjson.Parse(`"text"`), schema); err != nil {
+		t.Fatalf("string alternative: %v", err)
+	}
+	if err := ValidateJSONSchema(gjson.Parse(`null`), schema); err != nil {
+		t.Fatalf("null alternative: %v", err)
+	}
+	if err := ValidateJSONSchema(gjson.Parse(`5`), schema); err == nil {
+		t.Fatal("number should not satisfy string or null")
+	}
+}
@@ -104,6 +104,11 @@ func IsOpenAICompatibilityAlias(modelName string, cfg *config.Config) bool {
 				return true
 			}
 		}
+		for gateway := range compat.ModelMappings {
+			if strings.EqualFold(strings.TrimSpace(gateway), modelName) {
+				return true
+			}
+		}
 	}
 	return false
 }
@@ -133,6 +138,35 @@ func GetOpenAICompatibilityConfig(alias string, cfg *config.Config) (*config.Ope
 	return nil, nil
 }
 
+// ResolveOpenAICompatModel maps a gateway model name to the upstream name used
+// by the given OpenAI-compatible provider. The per-provider ModelMappings table
+// is consulted first (case-insensitive), then the alias table from Models;
+// unmapped names are returned unchanged. This lets the same gateway name target
+// differently-named models on different upstreams.
+//
+// Parameters:
+//   - compat: The OpenAI compatibility provider configuration
+//   - modelName: The gateway model name requested by the client
+//
+// Returns:
+//   - string: The upstream model name to send to this provider
+func ResolveOpenAICompatModel(compat *config.OpenAICompatibility, modelName string) string {
+	if compat == nil || modelName == "" {
+		return modelName
+	}
+	for gateway, upstream := range compat.ModelMappings {
+		if strings.EqualFold(strings.TrimSpace(gateway), modelName) && strings.TrimSpace(upstream) != "" {
+			return strings.TrimSpace(upstream)
+		}
+	}
+	for _, model := range compat.Models {
+		if model.Alias == modelName && strings.TrimSpace(model.Name) != "" {
+			return model.Name
+		}
+	}
+	return modelName
+}
+
 // InArray checks if a string exists in a slice of strings.
 // It iterates through the slice and returns true if the target string is found,
 // otherwise it returns false.
@@ -0,0 +1,42 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/shariqriazz/modelgate/internal/config"
+)
+
+func TestResolveOpenAICompatModel(t *testing.T) {
+	compat := &config.OpenAICompatibility{
+		Name: "openrouter",
+		Models: []config.OpenAICompatibilityModel{
+			{Name: "moonshotai/kimi-k2", Alias: "kimi-k2"},
+		},
+		ModelMappings: map[string]string{
+			"gpt-4o": "openai/gpt-4o",
+		},
+	}
+
+	cases := []struct {
+		name  string
+		model string
+		want  string
+	}{
+		{name: "mapping table", model: "gpt-4o", want: "openai/gpt-4o"},
+		{name: "mapping table case insensitive", model: "GPT-4o", want: "openai/gpt-4o"},
+		{name: "alias fallback", model: "kimi-k2", want: "moonshotai/kimi-k2"},
+		{name: "unmapped passthrough", model: "llama-3", want: "llama-3"},
+		{name: "empty model", model: "", want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ResolveOpenAICompatModel(compat, tc.model); got != tc.want {
+				t.Errorf("ResolveOpenAICompatModel(%q) = %q, want %q", tc.model, got, tc.want)
+			}
+		})
+	}
+
+	if got := ResolveOpenAICompatModel(nil, "gpt-4o"); got != "gpt-4o" {
+		t.Errorf("ResolveOpenAICompatModel(nil) = %q, want passthrough", got)
+	}
+}
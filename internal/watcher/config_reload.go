@@ -7,8 +7,10 @@ import (
 	"encoding/hex"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/shariqriazz/modelgate/internal/audit"
 	"github.com/shariqriazz/modelgate/internal/config"
 	"github.com/shariqriazz/modelgate/internal/util"
 	"github.com/shariqriazz/modelgate/internal/watcher/diff"
@@ -124,6 +126,7 @@ func (w *Watcher) reloadConfig() bool {
 		} else {
 			log.Debugf("no material config field changes detected")
 		}
+		audit.Record("config-watcher", "config-reload", w.configPath, strings.Join(details, "; "))
 	}
 
 	authDirChanged := oldConfig == nil || oldConfig.AuthDir != newConfig.AuthDir
@@ -27,18 +27,86 @@ func BuildConfigChangeDetails(oldCfg, newCfg *config.Config) []string {
 	if oldCfg.Debug != newCfg.Debug {
 		changes = append(changes, fmt.Sprintf("debug: %t -> %t", oldCfg.Debug, newCfg.Debug))
 	}
+	if !equalStringSet(oldCfg.DebugAPIKeys, newCfg.DebugAPIKeys) {
+		changes = append(changes, fmt.Sprintf("debug-api-keys: %d -> %d entries", len(oldCfg.DebugAPIKeys), len(newCfg.DebugAPIKeys)))
+	}
+	if !equalStringSet(oldCfg.FakeStreamingModels, newCfg.FakeStreamingModels) {
+		changes = append(changes, fmt.Sprintf("fake-streaming-models: %d -> %d entries", len(oldCfg.FakeStreamingModels), len(newCfg.FakeStreamingModels)))
+	}
+	if !equalStringSet(oldCfg.StreamUpstreamModels, newCfg.StreamUpstreamModels) {
+		changes = append(changes, fmt.Sprintf("stream-upstream-models: %d -> %d entries", len(oldCfg.StreamUpstreamModels), len(newCfg.StreamUpstreamModels)))
+	}
+	if !equalStringMap(oldCfg.ModelTransport, newCfg.ModelTransport) {
+		changes = append(changes, fmt.Sprintf("model-transport: %d -> %d entries", len(oldCfg.ModelTransport), len(newCfg.ModelTransport)))
+	}
+	if oldCfg.CoalesceIdenticalRequests != newCfg.CoalesceIdenticalRequests {
+		changes = append(changes, fmt.Sprintf("coalesce-identical-requests: %t -> %t", oldCfg.CoalesceIdenticalRequests, newCfg.CoalesceIdenticalRequests))
+	}
+	if !reflect.DeepEqual(oldCfg.StreamScannerLimitMB, newCfg.StreamScannerLimitMB) {
+		changes = append(changes, fmt.Sprintf("stream-scanner-limit-mb: %d -> %d entries", len(oldCfg.StreamScannerLimitMB), len(newCfg.StreamScannerLimitMB)))
+	}
+	if oldCfg.PassthroughUnknownFields != newCfg.PassthroughUnknownFields {
+		changes = append(changes, fmt.Sprintf("passthrough-unknown-fields: %t -> %t", oldCfg.PassthroughUnknownFields, newCfg.PassthroughUnknownFields))
+	}
+	if oldCfg.SameFormatPassthrough != newCfg.SameFormatPassthrough {
+		changes = append(changes, fmt.Sprintf("same-format-passthrough: %t -> %t", oldCfg.SameFormatPassthrough, newCfg.SameFormatPassthrough))
+	}
+	if !reflect.DeepEqual(oldCfg.PassthroughModels, newCfg.PassthroughModels) {
+		changes = append(changes, fmt.Sprintf("passthrough-models: %d -> %d entries", len(oldCfg.PassthroughModels), len(newCfg.PassthroughModels)))
+	}
+	if !reflect.DeepEqual(oldCfg.AuthDailyCaps, newCfg.AuthDailyCaps) {
+		changes = append(changes, fmt.Sprintf("auth-daily-caps: %d -> %d entries", len(oldCfg.AuthDailyCaps), len(newCfg.AuthDailyCaps)))
+	}
+	if !reflect.DeepEqual(oldCfg.Routing.TimeWindows, newCfg.Routing.TimeWindows) {
+		changes = append(changes, fmt.Sprintf("routing.time-windows: %d -> %d entries", len(oldCfg.Routing.TimeWindows), len(newCfg.Routing.TimeWindows)))
+	}
+	if oldCfg.BurstSmoothingRPS != newCfg.BurstSmoothingRPS {
+		changes = append(changes, fmt.Sprintf("burst-smoothing-rps: %g -> %g", oldCfg.BurstSmoothingRPS, newCfg.BurstSmoothingRPS))
+	}
+	if oldCfg.ModelCatalogWebhookURL != newCfg.ModelCatalogWebhookURL {
+		changes = append(changes, fmt.Sprintf("model-catalog-webhook-url: %s -> %s", formatProxyURL(oldCfg.ModelCatalogWebhookURL), formatProxyURL(newCfg.ModelCatalogWebhookURL)))
+	}
 	if oldCfg.LoggingToFile != newCfg.LoggingToFile {
 		changes = append(changes, fmt.Sprintf("logging-to-file: %t -> %t", oldCfg.LoggingToFile, newCfg.LoggingToFile))
 	}
+	if oldCfg.LoggingFormat != newCfg.LoggingFormat {
+		changes = append(changes, fmt.Sprintf("logging-format: %q -> %q", oldCfg.LoggingFormat, newCfg.LoggingFormat))
+	}
+	if oldCfg.LogSampling.ThresholdPerMinute != newCfg.LogSampling.ThresholdPerMinute ||
+		oldCfg.LogSampling.Rate != newCfg.LogSampling.Rate ||
+		!equalIntMap(oldCfg.LogSampling.Levels, newCfg.LogSampling.Levels) ||
+		!equalIntMap(oldCfg.LogSampling.Providers, newCfg.LogSampling.Providers) {
+		changes = append(changes, fmt.Sprintf("log-sampling: threshold-per-minute %d -> %d, rate %d -> %d, levels %d -> %d, providers %d -> %d",
+			oldCfg.LogSampling.ThresholdPerMinute, newCfg.LogSampling.ThresholdPerMinute,
+			oldCfg.LogSampling.Rate, newCfg.LogSampling.Rate,
+			len(oldCfg.LogSampling.Levels), len(newCfg.LogSampling.Levels),
+			len(oldCfg.LogSampling.Providers), len(newCfg.LogSampling.Providers)))
+	}
 	if oldCfg.UsageStatisticsEnabled != newCfg.UsageStatisticsEnabled {
 		changes = append(changes, fmt.Sprintf("usage-statistics-enabled: %t -> %t", oldCfg.UsageStatisticsEnabled, newCfg.UsageStatisticsEnabled))
 	}
+	if oldCfg.UsageHeaders != newCfg.UsageHeaders {
+		changes = append(changes, fmt.Sprintf("usage-headers: %t -> %t", oldCfg.UsageHeaders, newCfg.UsageHeaders))
+	}
 	if oldCfg.DisableCooling != newCfg.DisableCooling {
 		changes = append(changes, fmt.Sprintf("disable-cooling: %t -> %t", oldCfg.DisableCooling, newCfg.DisableCooling))
 	}
 	if oldCfg.RequestLog != newCfg.RequestLog {
 		changes = append(changes, fmt.Sprintf("request-log: %t -> %t", oldCfg.RequestLog, newCfg.RequestLog))
 	}
+	if oldCfg.RequestLogRedaction != newCfg.RequestLogRedaction {
+		changes = append(changes, fmt.Sprintf("request-log-redaction: strip-base64 %t -> %t, mask-credentials %t -> %t, max-body-bytes %d -> %d",
+			oldCfg.RequestLogRedaction.StripBase64, newCfg.RequestLogRedaction.StripBase64,
+			oldCfg.RequestLogRedaction.MaskCredentials, newCfg.RequestLogRedaction.MaskCredentials,
+			oldCfg.RequestLogRedaction.MaxBodyBytes, newCfg.RequestLogRedaction.MaxBodyBytes))
+	}
+	if oldCfg.RequestLogRetention != newCfg.RequestLogRetention {
+		changes = append(changes, fmt.Sprintf("request-log-retention: max-file-size-mb %d -> %d, max-age-days %d -> %d, max-total-size-mb %d -> %d, compress %t -> %t",
+			oldCfg.RequestLogRetention.MaxFileSizeMB, newCfg.RequestLogRetention.MaxFileSizeMB,
+			oldCfg.RequestLogRetention.MaxAgeDays, newCfg.RequestLogRetention.MaxAgeDays,
+			oldCfg.RequestLogRetention.MaxTotalSizeMB, newCfg.RequestLogRetention.MaxTotalSizeMB,
+			oldCfg.RequestLogRetention.Compress, newCfg.RequestLogRetention.Compress))
+	}
 	if oldCfg.RequestRetry != newCfg.RequestRetry {
 		changes = append(changes, fmt.Sprintf("request-retry: %d -> %d", oldCfg.RequestRetry, newCfg.RequestRetry))
 	}
@@ -48,6 +116,18 @@ func BuildConfigChangeDetails(oldCfg, newCfg *config.Config) []string {
 	if oldCfg.ProxyURL != newCfg.ProxyURL {
 		changes = append(changes, fmt.Sprintf("proxy-url: %s -> %s", formatProxyURL(oldCfg.ProxyURL), formatProxyURL(newCfg.ProxyURL)))
 	}
+	if oldCfg.HTTPTransport != newCfg.HTTPTransport {
+		changes = append(changes, fmt.Sprintf("http-transport: max-idle-conns-per-host %d -> %d, idle-conn-timeout-seconds %d -> %d, tls-session-cache-size %d -> %d, dial-timeout-seconds %d -> %d",
+			oldCfg.HTTPTransport.MaxIdleConnsPerHost, newCfg.HTTPTransport.MaxIdleConnsPerHost,
+			oldCfg.HTTPTransport.IdleConnTimeoutSeconds, newCfg.HTTPTransport.IdleConnTimeoutSeconds,
+			oldCfg.HTTPTransport.TLSSessionCacheSize, newCfg.HTTPTransport.TLSSessionCacheSize,
+			oldCfg.HTTPTransport.DialTimeoutSeconds, newCfg.HTTPTransport.DialTimeoutSeconds))
+	}
+	if !equalStringSet(oldCfg.ConnectionWarmup.Hosts, newCfg.ConnectionWarmup.Hosts) || oldCfg.ConnectionWarmup.ConnectionsPerHost != newCfg.ConnectionWarmup.ConnectionsPerHost {
+		changes = append(changes, fmt.Sprintf("connection-warmup: hosts %d -> %d, connections-per-host %d -> %d",
+			len(oldCfg.ConnectionWarmup.Hosts), len(newCfg.ConnectionWarmup.Hosts),
+			oldCfg.ConnectionWarmup.ConnectionsPerHost, newCfg.ConnectionWarmup.ConnectionsPerHost))
+	}
 	if oldCfg.WebsocketAuth != newCfg.WebsocketAuth {
 		changes = append(changes, fmt.Sprintf("ws-auth: %t -> %t", oldCfg.WebsocketAuth, newCfg.WebsocketAuth))
 	}
@@ -65,6 +145,14 @@ func BuildConfigChangeDetails(oldCfg, newCfg *config.Config) []string {
 	if oldCfg.QuotaExceeded.SwitchPreviewModel != newCfg.QuotaExceeded.SwitchPreviewModel {
 		changes = append(changes, fmt.Sprintf("quota-exceeded.switch-preview-model: %t -> %t", oldCfg.QuotaExceeded.SwitchPreviewModel, newCfg.QuotaExceeded.SwitchPreviewModel))
 	}
+	if oldCfg.QuotaExceeded.MaxWaitSeconds != newCfg.QuotaExceeded.MaxWaitSeconds {
+		changes = append(changes, fmt.Sprintf("quota-exceeded.max-wait-seconds: %d -> %d", oldCfg.QuotaExceeded.MaxWaitSeconds, newCfg.QuotaExceeded.MaxWaitSeconds))
+	}
+	if oldCfg.UpstreamRetry != newCfg.UpstreamRetry {
+		changes = append(changes, fmt.Sprintf("upstream-retry: attempts %d -> %d, budget-seconds %d -> %d",
+			oldCfg.UpstreamRetry.Attempts, newCfg.UpstreamRetry.Attempts,
+			oldCfg.UpstreamRetry.BudgetSeconds, newCfg.UpstreamRetry.BudgetSeconds))
+	}
 
 	// API keys (redacted) and counts
 	if len(oldCfg.APIKeys) != len(newCfg.APIKeys) {
@@ -93,6 +181,15 @@ func BuildConfigChangeDetails(oldCfg, newCfg *config.Config) []string {
 			if !equalStringMap(o.Headers, n.Headers) {
 				changes = append(changes, fmt.Sprintf("gemini[%d].headers: updated", i))
 			}
+			if o.RPM != n.RPM {
+				changes = append(changes, fmt.Sprintf("gemini[%d].rpm: %d -> %d", i, o.RPM, n.RPM))
+			}
+			if o.RPD != n.RPD {
+				changes = append(changes, fmt.Sprintf("gemini[%d].rpd: %d -> %d", i, o.RPD, n.RPD))
+			}
+			if o.TPM != n.TPM {
+				changes = append(changes, fmt.Sprintf("gemini[%d].tpm: %d -> %d", i, o.TPM, n.TPM))
+			}
 			oldModels := SummarizeGeminiModels(o.Models)
 			newModels := SummarizeGeminiModels(n.Models)
 			if oldModels.hash != newModels.hash {
@@ -176,6 +273,300 @@ func BuildConfigChangeDetails(oldCfg, newCfg *config.Config) []string {
 		}
 	}
 
+	// Cerebras keys (do not print key material)
+	if len(oldCfg.CerebrasKey) != len(newCfg.CerebrasKey) {
+		changes = append(changes, fmt.Sprintf("cerebras-api-key count: %d -> %d", len(oldCfg.CerebrasKey), len(newCfg.CerebrasKey)))
+	} else {
+		for i := range oldCfg.CerebrasKey {
+			o := oldCfg.CerebrasKey[i]
+			n := newCfg.CerebrasKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].headers: updated", i))
+			}
+			oldModels := SummarizeCerebrasModels(o.Models)
+			newModels := SummarizeCerebrasModels(n.Models)
+			if oldModels.hash != newModels.hash {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].models: updated (%d -> %d entries)", i, oldModels.count, newModels.count))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("cerebras[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// Azure OpenAI resources (do not print key material)
+	if len(oldCfg.AzureOpenAIKey) != len(newCfg.AzureOpenAIKey) {
+		changes = append(changes, fmt.Sprintf("azure-openai count: %d -> %d", len(oldCfg.AzureOpenAIKey), len(newCfg.AzureOpenAIKey)))
+	} else {
+		for i := range oldCfg.AzureOpenAIKey {
+			o := oldCfg.AzureOpenAIKey[i]
+			n := newCfg.AzureOpenAIKey[i]
+			if strings.TrimSpace(o.Endpoint) != strings.TrimSpace(n.Endpoint) {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].endpoint: %s -> %s", i, strings.TrimSpace(o.Endpoint), strings.TrimSpace(n.Endpoint)))
+			}
+			if strings.TrimSpace(o.APIVersion) != strings.TrimSpace(n.APIVersion) {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].api-version: %s -> %s", i, strings.TrimSpace(o.APIVersion), strings.TrimSpace(n.APIVersion)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].headers: updated", i))
+			}
+			oldDeployments := SummarizeAzureOpenAIDeployments(o.Deployments)
+			newDeployments := SummarizeAzureOpenAIDeployments(n.Deployments)
+			if oldDeployments.hash != newDeployments.hash {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].deployments: updated (%d -> %d entries)", i, oldDeployments.count, newDeployments.count))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("azure-openai[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// Ollama servers (models are discovered, not configured)
+	if len(oldCfg.Ollama) != len(newCfg.Ollama) {
+		changes = append(changes, fmt.Sprintf("ollama count: %d -> %d", len(oldCfg.Ollama), len(newCfg.Ollama)))
+	} else {
+		for i := range oldCfg.Ollama {
+			o := oldCfg.Ollama[i]
+			n := newCfg.Ollama[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("ollama[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("ollama[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("ollama[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("ollama[%d].api-key: updated", i))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("ollama[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// OpenRouter keys (do not print key material)
+	if len(oldCfg.OpenRouterKey) != len(newCfg.OpenRouterKey) {
+		changes = append(changes, fmt.Sprintf("openrouter-api-key count: %d -> %d", len(oldCfg.OpenRouterKey), len(newCfg.OpenRouterKey)))
+	} else {
+		for i := range oldCfg.OpenRouterKey {
+			o := oldCfg.OpenRouterKey[i]
+			n := newCfg.OpenRouterKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].api-key: updated", i))
+			}
+			if strings.TrimSpace(o.SiteURL) != strings.TrimSpace(n.SiteURL) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].site-url: %s -> %s", i, strings.TrimSpace(o.SiteURL), strings.TrimSpace(n.SiteURL)))
+			}
+			if strings.TrimSpace(o.SiteName) != strings.TrimSpace(n.SiteName) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].site-name: %s -> %s", i, strings.TrimSpace(o.SiteName), strings.TrimSpace(n.SiteName)))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].headers: updated", i))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("openrouter[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// Mistral keys (do not print key material)
+	if len(oldCfg.MistralKey) != len(newCfg.MistralKey) {
+		changes = append(changes, fmt.Sprintf("mistral-api-key count: %d -> %d", len(oldCfg.MistralKey), len(newCfg.MistralKey)))
+	} else {
+		for i := range oldCfg.MistralKey {
+			o := oldCfg.MistralKey[i]
+			n := newCfg.MistralKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("mistral[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("mistral[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("mistral[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("mistral[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("mistral[%d].headers: updated", i))
+			}
+			oldModels := SummarizeMistralModels(o.Models)
+			newModels := SummarizeMistralModels(n.Models)
+			if oldModels.hash != newModels.hash {
+				changes = append(changes, fmt.Sprintf("mistral[%d].models: updated (%d -> %d entries)", i, oldModels.count, newModels.count))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("mistral[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// Groq keys (do not print key material)
+	if len(oldCfg.GroqKey) != len(newCfg.GroqKey) {
+		changes = append(changes, fmt.Sprintf("groq-api-key count: %d -> %d", len(oldCfg.GroqKey), len(newCfg.GroqKey)))
+	} else {
+		for i := range oldCfg.GroqKey {
+			o := oldCfg.GroqKey[i]
+			n := newCfg.GroqKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("groq[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("groq[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("groq[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("groq[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("groq[%d].headers: updated", i))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("groq[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// DeepSeek keys (do not print key material)
+	if len(oldCfg.DeepSeekKey) != len(newCfg.DeepSeekKey) {
+		changes = append(changes, fmt.Sprintf("deepseek-api-key count: %d -> %d", len(oldCfg.DeepSeekKey), len(newCfg.DeepSeekKey)))
+	} else {
+		for i := range oldCfg.DeepSeekKey {
+			o := oldCfg.DeepSeekKey[i]
+			n := newCfg.DeepSeekKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("deepseek[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("deepseek[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("deepseek[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("deepseek[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("deepseek[%d].headers: updated", i))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("deepseek[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// Cohere keys (do not print key material)
+	if len(oldCfg.CohereKey) != len(newCfg.CohereKey) {
+		changes = append(changes, fmt.Sprintf("cohere-api-key count: %d -> %d", len(oldCfg.CohereKey), len(newCfg.CohereKey)))
+	} else {
+		for i := range oldCfg.CohereKey {
+			o := oldCfg.CohereKey[i]
+			n := newCfg.CohereKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("cohere[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("cohere[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("cohere[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("cohere[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("cohere[%d].headers: updated", i))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("cohere[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
+	// Cloudflare Workers AI accounts (do not print token material)
+	if len(oldCfg.CloudflareKey) != len(newCfg.CloudflareKey) {
+		changes = append(changes, fmt.Sprintf("cloudflare-workers-ai count: %d -> %d", len(oldCfg.CloudflareKey), len(newCfg.CloudflareKey)))
+	} else {
+		for i := range oldCfg.CloudflareKey {
+			o := oldCfg.CloudflareKey[i]
+			n := newCfg.CloudflareKey[i]
+			if strings.TrimSpace(o.AccountID) != strings.TrimSpace(n.AccountID) {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].account-id: %s -> %s", i, strings.TrimSpace(o.AccountID), strings.TrimSpace(n.AccountID)))
+			}
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIToken) != strings.TrimSpace(n.APIToken) {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].api-token: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].headers: updated", i))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("cloudflare[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
 	// AmpCode settings (redacted where needed)
 	oldAmpURL := strings.TrimSpace(oldCfg.AmpCode.UpstreamURL)
 	newAmpURL := strings.TrimSpace(newCfg.AmpCode.UpstreamURL)
@@ -277,6 +668,41 @@ func BuildConfigChangeDetails(oldCfg, newCfg *config.Config) []string {
 		}
 	}
 
+	// Vertex express-mode API keys
+	if len(oldCfg.VertexExpressAPIKey) != len(newCfg.VertexExpressAPIKey) {
+		changes = append(changes, fmt.Sprintf("vertex-express-api-key count: %d -> %d", len(oldCfg.VertexExpressAPIKey), len(newCfg.VertexExpressAPIKey)))
+	} else {
+		for i := range oldCfg.VertexExpressAPIKey {
+			o := oldCfg.VertexExpressAPIKey[i]
+			n := newCfg.VertexExpressAPIKey[i]
+			if strings.TrimSpace(o.BaseURL) != strings.TrimSpace(n.BaseURL) {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].base-url: %s -> %s", i, strings.TrimSpace(o.BaseURL), strings.TrimSpace(n.BaseURL)))
+			}
+			if strings.TrimSpace(o.ProxyURL) != strings.TrimSpace(n.ProxyURL) {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].proxy-url: %s -> %s", i, formatProxyURL(o.ProxyURL), formatProxyURL(n.ProxyURL)))
+			}
+			if strings.TrimSpace(o.Prefix) != strings.TrimSpace(n.Prefix) {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].prefix: %s -> %s", i, strings.TrimSpace(o.Prefix), strings.TrimSpace(n.Prefix)))
+			}
+			if strings.TrimSpace(o.APIKey) != strings.TrimSpace(n.APIKey) {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].api-key: updated", i))
+			}
+			if !equalStringMap(o.Headers, n.Headers) {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].headers: updated", i))
+			}
+			oldModels := SummarizeVertexExpressModels(o.Models)
+			newModels := SummarizeVertexExpressModels(n.Models)
+			if oldModels.hash != newModels.hash {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].models: updated (%d -> %d entries)", i, oldModels.count, newModels.count))
+			}
+			oldExcluded := SummarizeExcludedModels(o.ExcludedModels)
+			newExcluded := SummarizeExcludedModels(n.ExcludedModels)
+			if oldExcluded.hash != newExcluded.hash {
+				changes = append(changes, fmt.Sprintf("vertex-express[%d].excluded-models: updated (%d -> %d entries)", i, oldExcluded.count, newExcluded.count))
+			}
+		}
+	}
+
 	return changes
 }
 
@@ -288,6 +714,18 @@ func trimStrings(in []string) []string {
 	return out
 }
 
+func equalIntMap(a, b map[string]int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
 func equalStringMap(a, b map[string]string) bool {
 	if len(a) != len(b) {
 		return false
@@ -41,6 +41,21 @@ func ComputeVertexCompatModelsHash(models []config.VertexCompatModel) string {
 	return hashJoined(keys)
 }
 
+// ComputeVertexExpressModelsHash returns a stable hash for Vertex express-mode models.
+func ComputeVertexExpressModelsHash(models []config.VertexExpressModel) string {
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, model := range models {
+			name := strings.TrimSpace(model.Name)
+			alias := strings.TrimSpace(model.Alias)
+			if name == "" && alias == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(alias))
+		}
+	})
+	return hashJoined(keys)
+}
+
 // ComputeClaudeModelsHash returns a stable hash for Claude model aliases.
 func ComputeClaudeModelsHash(models []config.ClaudeModel) string {
 	keys := normalizeModelPairs(func(out func(key string)) {
@@ -71,6 +86,51 @@ func ComputeCodexModelsHash(models []config.CodexModel) string {
 	return hashJoined(keys)
 }
 
+// ComputeCerebrasModelsHash returns a stable hash for Cerebras model aliases.
+func ComputeCerebrasModelsHash(models []config.CerebrasModel) string {
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, model := range models {
+			name := strings.TrimSpace(model.Name)
+			alias := strings.TrimSpace(model.Alias)
+			if name == "" && alias == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(alias))
+		}
+	})
+	return hashJoined(keys)
+}
+
+// ComputeMistralModelsHash returns a stable hash for Mistral model aliases.
+func ComputeMistralModelsHash(models []config.MistralModel) string {
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, model := range models {
+			name := strings.TrimSpace(model.Name)
+			alias := strings.TrimSpace(model.Alias)
+			if name == "" && alias == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(alias))
+		}
+	})
+	return hashJoined(keys)
+}
+
+// ComputeAzureOpenAIDeploymentsHash returns a stable hash for Azure OpenAI deployment mappings.
+func ComputeAzureOpenAIDeploymentsHash(deployments []config.AzureOpenAIDeployment) string {
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, dep := range deployments {
+			name := strings.TrimSpace(dep.Name)
+			model := strings.TrimSpace(dep.Model)
+			if name == "" && model == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(model))
+		}
+	})
+	return hashJoined(keys)
+}
+
 // ComputeGeminiModelsHash returns a stable hash for Gemini model aliases.
 func ComputeGeminiModelsHash(models []config.GeminiModel) string {
 	keys := normalizeModelPairs(func(out func(key string)) {
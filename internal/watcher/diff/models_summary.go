@@ -24,11 +24,31 @@ type CodexModelsSummary struct {
 	count int
 }
 
+type CerebrasModelsSummary struct {
+	hash  string
+	count int
+}
+
+type MistralModelsSummary struct {
+	hash  string
+	count int
+}
+
+type AzureOpenAIDeploymentsSummary struct {
+	hash  string
+	count int
+}
+
 type VertexModelsSummary struct {
 	hash  string
 	count int
 }
 
+type VertexExpressModelsSummary struct {
+	hash  string
+	count int
+}
+
 // SummarizeGeminiModels hashes Gemini model aliases for change detection.
 func SummarizeGeminiModels(models []config.GeminiModel) GeminiModelsSummary {
 	if len(models) == 0 {
@@ -92,6 +112,90 @@ func SummarizeCodexModels(models []config.CodexModel) CodexModelsSummary {
 	}
 }
 
+// SummarizeCerebrasModels hashes Cerebras model aliases for change detection.
+func SummarizeCerebrasModels(models []config.CerebrasModel) CerebrasModelsSummary {
+	if len(models) == 0 {
+		return CerebrasModelsSummary{}
+	}
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, model := range models {
+			name := strings.TrimSpace(model.Name)
+			alias := strings.TrimSpace(model.Alias)
+			if name == "" && alias == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(alias))
+		}
+	})
+	return CerebrasModelsSummary{
+		hash:  hashJoined(keys),
+		count: len(keys),
+	}
+}
+
+// SummarizeMistralModels hashes Mistral model aliases for change detection.
+func SummarizeMistralModels(models []config.MistralModel) MistralModelsSummary {
+	if len(models) == 0 {
+		return MistralModelsSummary{}
+	}
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, model := range models {
+			name := strings.TrimSpace(model.Name)
+			alias := strings.TrimSpace(model.Alias)
+			if name == "" && alias == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(alias))
+		}
+	})
+	return MistralModelsSummary{
+		hash:  hashJoined(keys),
+		count: len(keys),
+	}
+}
+
+// SummarizeAzureOpenAIDeployments hashes Azure OpenAI deployment mappings for change detection.
+func SummarizeAzureOpenAIDeployments(deployments []config.AzureOpenAIDeployment) AzureOpenAIDeploymentsSummary {
+	if len(deployments) == 0 {
+		return AzureOpenAIDeploymentsSummary{}
+	}
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, dep := range deployments {
+			name := strings.TrimSpace(dep.Name)
+			model := strings.TrimSpace(dep.Model)
+			if name == "" && model == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(model))
+		}
+	})
+	return AzureOpenAIDeploymentsSummary{
+		hash:  hashJoined(keys),
+		count: len(keys),
+	}
+}
+
+// SummarizeVertexExpressModels hashes Vertex express-mode model aliases for change detection.
+func SummarizeVertexExpressModels(models []config.VertexExpressModel) VertexExpressModelsSummary {
+	if len(models) == 0 {
+		return VertexExpressModelsSummary{}
+	}
+	keys := normalizeModelPairs(func(out func(key string)) {
+		for _, model := range models {
+			name := strings.TrimSpace(model.Name)
+			alias := strings.TrimSpace(model.Alias)
+			if name == "" && alias == "" {
+				continue
+			}
+			out(strings.ToLower(name) + "|" + strings.ToLower(alias))
+		}
+	})
+	return VertexExpressModelsSummary{
+		hash:  hashJoined(keys),
+		count: len(keys),
+	}
+}
+
 // SummarizeVertexModels hashes Vertex-compatible model aliases for change detection.
 func SummarizeVertexModels(models []config.VertexCompatModel) VertexModelsSummary {
 	if len(models) == 0 {
@@ -10,7 +10,7 @@ import (
 )
 
 // ConfigSynthesizer generates Auth entries from configuration API keys.
-// It handles Gemini, Claude, Codex, OpenAI-compat, and Vertex-compat providers.
+// It handles Gemini, Claude, Codex, Cerebras, Vertex express, OpenAI-compat, and Vertex-compat providers.
 type ConfigSynthesizer struct{}
 
 // NewConfigSynthesizer creates a new ConfigSynthesizer instance.
@@ -29,6 +29,26 @@ func (s *ConfigSynthesizer) Synthesize(ctx *SynthesisContext) ([]*coreauth.Auth,
 	out = append(out, s.synthesizeGeminiKeys(ctx)...)
 	// Codex API Keys
 	out = append(out, s.synthesizeCodexKeys(ctx)...)
+	// Cerebras API Keys
+	out = append(out, s.synthesizeCerebrasKeys(ctx)...)
+	// Azure OpenAI resources
+	out = append(out, s.synthesizeAzureOpenAIKeys(ctx)...)
+	// Ollama servers
+	out = append(out, s.synthesizeOllamaServers(ctx)...)
+	// OpenRouter API Keys
+	out = append(out, s.synthesizeOpenRouterKeys(ctx)...)
+	// Mistral API Keys
+	out = append(out, s.synthesizeMistralKeys(ctx)...)
+	// Groq API Keys
+	out = append(out, s.synthesizeGroqKeys(ctx)...)
+	// DeepSeek API Keys
+	out = append(out, s.synthesizeDeepSeekKeys(ctx)...)
+	// Cohere API Keys
+	out = append(out, s.synthesizeCohereKeys(ctx)...)
+	// Cloudflare Workers AI accounts
+	out = append(out, s.synthesizeCloudflareKeys(ctx)...)
+	// Vertex express-mode API Keys
+	out = append(out, s.synthesizeVertexExpressKeys(ctx)...)
 
 	return out, nil
 }
@@ -60,6 +80,18 @@ func (s *ConfigSynthesizer) synthesizeGeminiKeys(ctx *SynthesisContext) []*corea
 		if base != "" {
 			attrs["base_url"] = base
 		}
+		if entry.RPM > 0 {
+			attrs["rpm_limit"] = strconv.Itoa(entry.RPM)
+		}
+		if entry.RPD > 0 {
+			attrs["rpd_limit"] = strconv.Itoa(entry.RPD)
+		}
+		if entry.TPM > 0 {
+			attrs["tpm_limit"] = strconv.Itoa(entry.TPM)
+		}
+		if entry.TPD > 0 {
+			attrs["tpd_limit"] = strconv.Itoa(entry.TPD)
+		}
 		if hash := diff.ComputeGeminiModelsHash(entry.Models); hash != "" {
 			attrs["models_hash"] = hash
 		}
@@ -127,3 +159,479 @@ func (s *ConfigSynthesizer) synthesizeCodexKeys(ctx *SynthesisContext) []*coreau
 	}
 	return out
 }
+
+// synthesizeCerebrasKeys creates Auth entries for Cerebras API keys.
+func (s *ConfigSynthesizer) synthesizeCerebrasKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.CerebrasKey))
+	for i := range cfg.CerebrasKey {
+		entry := cfg.CerebrasKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("cerebras:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:cerebras[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		if hash := diff.ComputeCerebrasModelsHash(entry.Models); hash != "" {
+			attrs["models_hash"] = hash
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "cerebras",
+			Label:      "cerebras-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeAzureOpenAIKeys creates Auth entries for Azure OpenAI resources.
+func (s *ConfigSynthesizer) synthesizeAzureOpenAIKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.AzureOpenAIKey))
+	for i := range cfg.AzureOpenAIKey {
+		entry := cfg.AzureOpenAIKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		endpoint := strings.TrimSpace(entry.Endpoint)
+		if key == "" || endpoint == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("azure-openai:apikey", key, endpoint)
+		attrs := map[string]string{
+			"source":   fmt.Sprintf("config:azure-openai[%s]", token),
+			"api_key":  key,
+			"base_url": endpoint,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if v := strings.TrimSpace(entry.APIVersion); v != "" {
+			attrs["api_version"] = v
+		}
+		if hash := diff.ComputeAzureOpenAIDeploymentsHash(entry.Deployments); hash != "" {
+			attrs["models_hash"] = hash
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "azure-openai",
+			Label:      "azure-openai-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeOllamaServers creates Auth entries for Ollama servers. Models are
+// not synthesized here; they are discovered from the server at registration time.
+func (s *ConfigSynthesizer) synthesizeOllamaServers(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.Ollama))
+	for i := range cfg.Ollama {
+		entry := cfg.Ollama[i]
+		base := strings.TrimSpace(entry.BaseURL)
+		if base == "" {
+			base = "http://127.0.0.1:11434"
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("ollama:server", base, "")
+		attrs := map[string]string{
+			"source":   fmt.Sprintf("config:ollama[%s]", token),
+			"base_url": base,
+		}
+		if key := strings.TrimSpace(entry.APIKey); key != "" {
+			attrs["api_key"] = key
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "ollama",
+			Label:      "ollama",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeOpenRouterKeys creates Auth entries for OpenRouter API keys. The
+// model catalog is discovered from the API at registration time.
+func (s *ConfigSynthesizer) synthesizeOpenRouterKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.OpenRouterKey))
+	for i := range cfg.OpenRouterKey {
+		entry := cfg.OpenRouterKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("openrouter:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:openrouter[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		if v := strings.TrimSpace(entry.SiteURL); v != "" {
+			attrs["site_url"] = v
+		}
+		if v := strings.TrimSpace(entry.SiteName); v != "" {
+			attrs["site_name"] = v
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "openrouter",
+			Label:      "openrouter-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeMistralKeys creates Auth entries for Mistral API keys.
+func (s *ConfigSynthesizer) synthesizeMistralKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.MistralKey))
+	for i := range cfg.MistralKey {
+		entry := cfg.MistralKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("mistral:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:mistral[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		if hash := diff.ComputeMistralModelsHash(entry.Models); hash != "" {
+			attrs["models_hash"] = hash
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "mistral",
+			Label:      "mistral-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeGroqKeys creates Auth entries for Groq API keys. The model catalog
+// is discovered from the API at registration time.
+func (s *ConfigSynthesizer) synthesizeGroqKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.GroqKey))
+	for i := range cfg.GroqKey {
+		entry := cfg.GroqKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("groq:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:groq[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "groq",
+			Label:      "groq-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeDeepSeekKeys creates Auth entries for DeepSeek API keys. The model
+// catalog is discovered from the API at registration time.
+func (s *ConfigSynthesizer) synthesizeDeepSeekKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.DeepSeekKey))
+	for i := range cfg.DeepSeekKey {
+		entry := cfg.DeepSeekKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("deepseek:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:deepseek[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "deepseek",
+			Label:      "deepseek-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeCohereKeys creates Auth entries for Cohere API keys. The model
+// catalog is discovered from the API at registration time.
+func (s *ConfigSynthesizer) synthesizeCohereKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.CohereKey))
+	for i := range cfg.CohereKey {
+		entry := cfg.CohereKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("cohere:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:cohere[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "cohere",
+			Label:      "cohere-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeCloudflareKeys creates Auth entries for Cloudflare Workers AI
+// accounts. Entries require both an account ID and an API token; the model
+// catalog is discovered from the API at registration time.
+func (s *ConfigSynthesizer) synthesizeCloudflareKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.CloudflareKey))
+	for i := range cfg.CloudflareKey {
+		entry := cfg.CloudflareKey[i]
+		token := strings.TrimSpace(entry.APIToken)
+		accountID := strings.TrimSpace(entry.AccountID)
+		if token == "" || accountID == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, tokenLabel := idGen.Next("cloudflare:apikey", token, accountID+"|"+base)
+		attrs := map[string]string{
+			"source":     fmt.Sprintf("config:cloudflare[%s]", tokenLabel),
+			"api_key":    token,
+			"account_id": accountID,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "cloudflare",
+			Label:      "cloudflare-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
+
+// synthesizeVertexExpressKeys creates Auth entries for Vertex express-mode API keys.
+func (s *ConfigSynthesizer) synthesizeVertexExpressKeys(ctx *SynthesisContext) []*coreauth.Auth {
+	cfg := ctx.Config
+	now := ctx.Now
+	idGen := ctx.IDGenerator
+
+	out := make([]*coreauth.Auth, 0, len(cfg.VertexExpressAPIKey))
+	for i := range cfg.VertexExpressAPIKey {
+		entry := cfg.VertexExpressAPIKey[i]
+		key := strings.TrimSpace(entry.APIKey)
+		if key == "" {
+			continue
+		}
+		prefix := strings.TrimSpace(entry.Prefix)
+		base := strings.TrimSpace(entry.BaseURL)
+		proxyURL := strings.TrimSpace(entry.ProxyURL)
+		id, token := idGen.Next("vertex-express:apikey", key, base)
+		attrs := map[string]string{
+			"source":  fmt.Sprintf("config:vertex-express[%s]", token),
+			"api_key": key,
+		}
+		if entry.Priority != 0 {
+			attrs["priority"] = strconv.Itoa(entry.Priority)
+		}
+		if base != "" {
+			attrs["base_url"] = base
+		}
+		if hash := diff.ComputeVertexExpressModelsHash(entry.Models); hash != "" {
+			attrs["models_hash"] = hash
+		}
+		addConfigHeadersToAttrs(entry.Headers, attrs)
+		a := &coreauth.Auth{
+			ID:         id,
+			Provider:   "vertex-express",
+			Label:      "vertex-express-apikey",
+			Prefix:     prefix,
+			Status:     coreauth.StatusActive,
+			ProxyURL:   proxyURL,
+			Attributes: attrs,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		ApplyAuthExcludedModelsMeta(a, cfg, entry.ExcludedModels, "apikey")
+		out = append(out, a)
+	}
+	return out
+}
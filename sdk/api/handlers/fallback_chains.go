@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/interfaces"
+	"github.com/shariqriazz/modelgate/sdk/config"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// fallbackChainBypassKey marks a context as already inside a fallback chain so
+// the per-candidate executions do not re-enter chain resolution. Without it a
+// fallback model that heads its own chain would recurse.
+type fallbackChainBypassKey struct{}
+
+// fallbackChain returns the configured chain headed by the requested model
+// name, or nil when none matches or the context is already inside a chain.
+func (h *BaseAPIHandler) fallbackChain(ctx context.Context, modelName string) *config.FallbackChain {
+	if h == nil || h.Cfg == nil {
+		return nil
+	}
+	if ctx != nil && ctx.Value(fallbackChainBypassKey{}) != nil {
+		return nil
+	}
+	name := strings.TrimSpace(modelName)
+	if name == "" {
+		return nil
+	}
+	for i := range h.Cfg.FallbackChains {
+		chain := &h.Cfg.FallbackChains[i]
+		if len(chain.Fallbacks) > 0 && strings.EqualFold(strings.TrimSpace(chain.Model), name) {
+			return chain
+		}
+	}
+	return nil
+}
+
+// fallbackChainModels returns the chain head followed by its fallbacks, with
+// blanks and entries equal to the head dropped.
+func fallbackChainModels(chain *config.FallbackChain) []string {
+	head := strings.TrimSpace(chain.Model)
+	models := make([]string, 0, 1+len(chain.Fallbacks))
+	models = append(models, head)
+	for _, fallback := range chain.Fallbacks {
+		fallback = strings.TrimSpace(fallback)
+		if fallback == "" || strings.EqualFold(fallback, head) {
+			continue
+		}
+		models = append(models, fallback)
+	}
+	return models
+}
+
+// fallbackChainEligible reports whether a failure should advance the chain:
+// rate limits, server errors, and transport failures with no status. Other
+// client errors would fail identically on every link.
+func fallbackChainEligible(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// emptyChainResponseError is returned when every link in the chain produced an
+// empty body, which counts as a failure the same way a 5xx does.
+func emptyChainResponseError(model string) *interfaces.ErrorMessage {
+	return &interfaces.ErrorMessage{
+		StatusCode: http.StatusBadGateway,
+		Error:      fmt.Errorf("model %s returned an empty response", model),
+	}
+}
+
+// executeFallbackChain runs a non-streaming request down the chain. Each link
+// executes through the normal per-provider translation path, so the response
+// reaches the client in its original format no matter which provider served
+// it.
+func (h *BaseAPIHandler) executeFallbackChain(ctx context.Context, handlerType string, chain *config.FallbackChain, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = context.WithValue(ctx, fallbackChainBypassKey{}, true)
+	models := fallbackChainModels(chain)
+	var lastErr *interfaces.ErrorMessage
+	for i, model := range models {
+		payload, errMsg := h.ExecuteWithAuthManager(ctx, handlerType, model, rawJSON, alt)
+		if errMsg == nil {
+			if len(payload) > 0 {
+				return payload, nil
+			}
+			errMsg = emptyChainResponseError(model)
+		}
+		lastErr = errMsg
+		if ctx.Err() != nil {
+			break
+		}
+		if !fallbackChainEligible(errMsg.StatusCode) {
+			break
+		}
+		if i < len(models)-1 {
+			log.Debugf("fallback chain %s: %s failed (status %d), trying %s",
+				chain.Model, model, errMsg.StatusCode, models[i+1])
+		}
+	}
+	return nil, lastErr
+}
+
+// executeFallbackChainStream runs a streaming request down the chain. A link
+// that fails before emitting any payload — or closes without emitting any —
+// hands the request to the next link; once bytes have been forwarded the
+// stream is committed and errors pass through unchanged.
+func (h *BaseAPIHandler) executeFallbackChainStream(ctx context.Context, handlerType string, chain *config.FallbackChain, rawJSON []byte, alt string) (<-chan []byte, <-chan *interfaces.ErrorMessage) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx = context.WithValue(ctx, fallbackChainBypassKey{}, true)
+	models := fallbackChainModels(chain)
+	outData := make(chan []byte)
+	outErr := make(chan *interfaces.ErrorMessage, 1)
+	go func() {
+		defer close(outData)
+		defer close(outErr)
+		var lastErr *interfaces.ErrorMessage
+		for i, model := range models {
+			dataChan, errChan := h.ExecuteStreamWithAuthManager(ctx, handlerType, model, rawJSON, alt)
+			sentPayload := false
+			failed := (*interfaces.ErrorMessage)(nil)
+			for dataChan != nil || errChan != nil {
+				select {
+				case chunk, ok := <-dataChan:
+					if !ok {
+						dataChan = nil
+						continue
+					}
+					sentPayload = true
+					select {
+					case outData <- chunk:
+					case <-ctx.Done():
+						return
+					}
+				case errMsg, ok := <-errChan:
+					if !ok {
+						errChan = nil
+						continue
+					}
+					if errMsg == nil {
+						continue
+					}
+					if !sentPayload && fallbackChainEligible(errMsg.StatusCode) {
+						failed = errMsg
+						errChan = nil
+						dataChan = nil
+						continue
+					}
+					outErr <- errMsg
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+			if failed == nil {
+				if sentPayload {
+					return
+				}
+				failed = emptyChainResponseError(model)
+			}
+			lastErr = failed
+			if ctx.Err() != nil {
+				break
+			}
+			if i < len(models)-1 {
+				log.Debugf("fallback chain %s: %s failed (status %d), trying %s",
+					chain.Model, model, lastErr.StatusCode, models[i+1])
+			}
+		}
+		if lastErr != nil {
+			outErr <- lastErr
+		}
+	}()
+	return outData, outErr
+}
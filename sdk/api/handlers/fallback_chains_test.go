@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	sdkconfig "github.com/shariqriazz/modelgate/sdk/config"
+)
+
+func TestFallbackChainEligible(t *testing.T) {
+	for _, status := range []int{0, http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway} {
+		if !fallbackChainEligible(status) {
+			t.Fatalf("status %d should advance the chain", status)
+		}
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound} {
+		if fallbackChainEligible(status) {
+			t.Fatalf("status %d would fail on every link and should not advance", status)
+		}
+	}
+}
+
+func TestFallbackChainModels(t *testing.T) {
+	chain := &sdkconfig.FallbackChain{
+		Model:     "claude-sonnet-4-5",
+		Fallbacks: []string{"copilot-claude-sonnet-4", "  ", "claude-sonnet-4-5", "gemini-claude-sonnet-4-5"},
+	}
+	models := fallbackChainModels(chain)
+	want := []string{"claude-sonnet-4-5", "copilot-claude-sonnet-4", "gemini-claude-sonnet-4-5"}
+	if len(models) != len(want) {
+		t.Fatalf("expected %d models, got %v", len(want), models)
+	}
+	for i := range want {
+		if models[i] != want[i] {
+			t.Fatalf("model %d: expected %s, got %s", i, want[i], models[i])
+		}
+	}
+}
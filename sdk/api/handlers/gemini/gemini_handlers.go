@@ -294,6 +294,9 @@ func (h *GeminiAPIHandler) handleStreamGenerateContent(c *gin.Context, modelName
 				// Closed without data
 				if alt == "" {
 					setSSEHeaders()
+				} else if alt == "json" {
+					c.Header("Content-Type", "application/json")
+					_, _ = c.Writer.Write([]byte("[]"))
 				}
 				flusher.Flush()
 				cliCancel(nil)
@@ -303,14 +306,22 @@ func (h *GeminiAPIHandler) handleStreamGenerateContent(c *gin.Context, modelName
 			// Success! Set headers.
 			if alt == "" {
 				setSSEHeaders()
+			} else if alt == "json" {
+				c.Header("Content-Type", "application/json")
 			}
 
 			// Write first chunk
-			if alt == "" {
+			switch alt {
+			case "":
 				_, _ = c.Writer.Write([]byte("data: "))
 				_, _ = c.Writer.Write(chunk)
 				_, _ = c.Writer.Write([]byte("\n\n"))
-			} else {
+			case "json":
+				// alt=json clients expect one JSON array of response chunks;
+				// open it here and close it when the stream terminates.
+				_, _ = c.Writer.Write([]byte("["))
+				_, _ = c.Writer.Write(chunk)
+			default:
 				_, _ = c.Writer.Write(chunk)
 			}
 			flusher.Flush()
@@ -371,22 +382,37 @@ func (h *GeminiAPIHandler) handleGenerateContent(c *gin.Context, modelName strin
 
 func (h *GeminiAPIHandler) forwardGeminiStream(c *gin.Context, flusher http.Flusher, alt string, cancel func(error), data <-chan []byte, errs <-chan *interfaces.ErrorMessage) {
 	var keepAliveInterval *time.Duration
+	var writeUsage func([]byte)
 	if alt != "" {
 		disabled := time.Duration(0)
 		keepAliveInterval = &disabled
+		// alt=json streams a JSON array; an SSE usage event would corrupt it.
+		writeUsage = func([]byte) {}
 	}
 
 	h.ForwardStream(c, flusher, cancel, data, errs, handlers.StreamForwardOptions{
 		KeepAliveInterval: keepAliveInterval,
+		WriteUsage:        writeUsage,
 		WriteChunk: func(chunk []byte) {
-			if alt == "" {
+			switch alt {
+			case "":
 				_, _ = c.Writer.Write([]byte("data: "))
 				_, _ = c.Writer.Write(chunk)
 				_, _ = c.Writer.Write([]byte("\n\n"))
-			} else {
+			case "json":
+				// The opening bracket and first element were already written by
+				// handleStreamGenerateContent; prepend the separator.
+				_, _ = c.Writer.Write([]byte(",\n"))
+				_, _ = c.Writer.Write(chunk)
+			default:
 				_, _ = c.Writer.Write(chunk)
 			}
 		},
+		WriteDone: func() {
+			if alt == "json" {
+				_, _ = c.Writer.Write([]byte("]"))
+			}
+		},
 		WriteTerminalError: func(errMsg *interfaces.ErrorMessage) {
 			if errMsg == nil {
 				return
@@ -400,9 +426,16 @@ func (h *GeminiAPIHandler) forwardGeminiStream(c *gin.Context, flusher http.Flus
 				errText = errMsg.Error.Error()
 			}
 			body := handlers.BuildErrorResponseBody(status, errText)
-			if alt == "" {
+			switch alt {
+			case "":
 				_, _ = fmt.Fprintf(c.Writer, "event: error\ndata: %s\n\n", string(body))
-			} else {
+			case "json":
+				// Append the error as the final array element so the client
+				// still receives valid JSON.
+				_, _ = c.Writer.Write([]byte(",\n"))
+				_, _ = c.Writer.Write(body)
+				_, _ = c.Writer.Write([]byte("]"))
+			default:
 				_, _ = c.Writer.Write(body)
 			}
 		},
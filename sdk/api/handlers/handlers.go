@@ -15,13 +15,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/shariqriazz/modelgate/internal/interfaces"
+	"github.com/shariqriazz/modelgate/internal/jsonrepair"
 	"github.com/shariqriazz/modelgate/internal/logging"
+	"github.com/shariqriazz/modelgate/internal/moderation"
 	"github.com/shariqriazz/modelgate/internal/thinking"
 	"github.com/shariqriazz/modelgate/internal/util"
 	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	coreexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
 	"github.com/shariqriazz/modelgate/sdk/config"
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 	"golang.org/x/net/context"
 )
 
@@ -378,9 +383,158 @@ func appendAPIResponse(c *gin.Context, data []byte) {
 	c.Set("API_RESPONSE", bytes.Clone(data))
 }
 
+// moderateRequest runs the optional moderation pre-check against the inbound payload.
+// It returns a policy error when the prompt is flagged; moderation failures fail open
+// unless block-on-error is configured.
+func (h *BaseAPIHandler) moderateRequest(ctx context.Context, handlerType string, rawJSON []byte) *interfaces.ErrorMessage {
+	if h == nil || h.Cfg == nil || !h.Cfg.Moderation.Enabled {
+		return nil
+	}
+	result, err := moderation.Check(ctx, h.Cfg, handlerType, rawJSON)
+	if err != nil {
+		log.Warnf("moderation pre-check failed: %v", err)
+		if h.Cfg.Moderation.BlockOnError {
+			return &interfaces.ErrorMessage{StatusCode: http.StatusBadGateway, Error: fmt.Errorf("moderation service unavailable")}
+		}
+		return nil
+	}
+	if result == nil || !result.Flagged {
+		return nil
+	}
+	return &interfaces.ErrorMessage{
+		StatusCode: http.StatusBadRequest,
+		Error:      fmt.Errorf("request blocked by moderation policy (categories: %s)", strings.Join(result.Categories, ", ")),
+	}
+}
+
 // ExecuteWithAuthManager executes a non-streaming request via the core auth manager.
 // This path is the only supported execution route.
 func (h *BaseAPIHandler) ExecuteWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
+	if errMsg := h.moderateRequest(ctx, handlerType, rawJSON); errMsg != nil {
+		return nil, errMsg
+	}
+	if rule := h.virtualModelRule(modelName); rule != nil {
+		return h.executeVirtualModel(ctx, handlerType, rule, rawJSON, alt)
+	}
+	if chain := h.fallbackChain(ctx, modelName); chain != nil {
+		return h.executeFallbackChain(ctx, handlerType, chain, rawJSON, alt)
+	}
+	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
+	if errMsg != nil {
+		return nil, errMsg
+	}
+	reqMeta := requestExecutionMetadata(ctx)
+	reqMeta[coreexecutor.RequestedModelMetadataKey] = normalizedModel
+	req := coreexecutor.Request{
+		Model:   normalizedModel,
+		Payload: cloneBytes(rawJSON),
+	}
+	if cloned := cloneMetadata(metadata); cloned != nil {
+		req.Metadata = cloned
+	}
+	opts := coreexecutor.Options{
+		Stream:          false,
+		Alt:             alt,
+		OriginalRequest: cloneBytes(rawJSON),
+		SourceFormat:    sdktranslator.FromString(handlerType),
+	}
+	opts.Metadata = mergeMetadata(cloneMetadata(metadata), reqMeta)
+
+	schema := h.structuredOutputSchema(handlerType, rawJSON)
+	attempts := 1
+	if schema.Exists() && h.Cfg.StructuredOutput.MaxRetries > 0 {
+		attempts += h.Cfg.StructuredOutput.MaxRetries
+	}
+	var lastValidationErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		resp, err := h.AuthManager.Execute(ctx, providers, req, opts)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
+				if code := se.StatusCode(); code > 0 {
+					status = code
+				}
+			}
+			var addon http.Header
+			if he, ok := err.(interface{ Headers() http.Header }); ok && he != nil {
+				if hdr := he.Headers(); hdr != nil {
+					addon = hdr.Clone()
+				}
+			}
+			return nil, &interfaces.ErrorMessage{StatusCode: status, Error: err, Addon: addon}
+		}
+		payload := cloneBytes(resp.Payload)
+		if mode := h.toolValidationMode(handlerType, modelName); mode != "" && sdktranslator.FromString(handlerType) == sdktranslator.FormatOpenAI {
+			validated, errMsg := validateToolCallsNonStream(payload, rawJSON, mode)
+			if errMsg != nil {
+				return nil, errMsg
+			}
+			payload = validated
+		}
+		if !schema.Exists() {
+			return payload, nil
+		}
+		enforced, errValidate := enforceStructuredOutput(payload, schema)
+		if errValidate == nil {
+			return enforced, nil
+		}
+		lastValidationErr = errValidate
+		log.Debugf("structured output validation failed (attempt %d/%d): %v", attempt+1, attempts, errValidate)
+	}
+	return nil, &interfaces.ErrorMessage{
+		StatusCode: http.StatusBadGateway,
+		Error:      fmt.Errorf("structured output validation failed: %w", lastValidationErr),
+	}
+}
+
+// structuredOutputSchema returns the json_schema requested by an OpenAI-format
+// chat completion request when structured output enforcement is enabled.
+func (h *BaseAPIHandler) structuredOutputSchema(handlerType string, rawJSON []byte) gjson.Result {
+	if h == nil || h.Cfg == nil || !h.Cfg.StructuredOutput.Enabled {
+		return gjson.Result{}
+	}
+	if sdktranslator.FromString(handlerType) != sdktranslator.FormatOpenAI {
+		return gjson.Result{}
+	}
+	schema := gjson.GetBytes(rawJSON, "response_format.json_schema.schema")
+	if !schema.IsObject() {
+		return gjson.Result{}
+	}
+	return schema
+}
+
+// enforceStructuredOutput validates the assistant message content against the
+// requested schema. Fenced or slightly malformed JSON is repaired in place so
+// a recoverable response never triggers a retry.
+func enforceStructuredOutput(payload []byte, schema gjson.Result) ([]byte, error) {
+	content := gjson.GetBytes(payload, "choices.0.message.content")
+	if content.Type != gjson.String || content.String() == "" {
+		return nil, fmt.Errorf("response carries no message content")
+	}
+	candidate := util.SanitizeJSONModeText(content.String())
+	if !gjson.Valid(candidate) {
+		repaired, ok := jsonrepair.Repair(candidate)
+		if !ok {
+			return nil, fmt.Errorf("message content is not valid JSON")
+		}
+		candidate = repaired
+	}
+	if err := util.ValidateJSONSchema(gjson.Parse(candidate), schema); err != nil {
+		return nil, err
+	}
+	if candidate == content.String() {
+		return payload, nil
+	}
+	updated, errSet := sjson.SetBytes(payload, "choices.0.message.content", candidate)
+	if errSet != nil {
+		return payload, nil
+	}
+	return updated, nil
+}
+
+// ExecuteCountWithAuthManager executes a non-streaming request via the core auth manager.
+// This path is the only supported execution route.
+func (h *BaseAPIHandler) ExecuteCountWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
 	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
 	if errMsg != nil {
 		return nil, errMsg
@@ -401,7 +555,7 @@ func (h *BaseAPIHandler) ExecuteWithAuthManager(ctx context.Context, handlerType
 		SourceFormat:    sdktranslator.FromString(handlerType),
 	}
 	opts.Metadata = mergeMetadata(cloneMetadata(metadata), reqMeta)
-	resp, err := h.AuthManager.Execute(ctx, providers, req, opts)
+	resp, err := h.AuthManager.ExecuteCount(ctx, providers, req, opts)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
@@ -420,9 +574,9 @@ func (h *BaseAPIHandler) ExecuteWithAuthManager(ctx context.Context, handlerType
 	return cloneBytes(resp.Payload), nil
 }
 
-// ExecuteCountWithAuthManager executes a non-streaming request via the core auth manager.
-// This path is the only supported execution route.
-func (h *BaseAPIHandler) ExecuteCountWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
+// ExecuteEmbeddingsWithAuthManager executes an embeddings request via the core auth manager.
+// Routing, key pooling, and usage accounting follow the same path as chat requests.
+func (h *BaseAPIHandler) ExecuteEmbeddingsWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
 	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
 	if errMsg != nil {
 		return nil, errMsg
@@ -443,7 +597,97 @@ func (h *BaseAPIHandler) ExecuteCountWithAuthManager(ctx context.Context, handle
 		SourceFormat:    sdktranslator.FromString(handlerType),
 	}
 	opts.Metadata = mergeMetadata(cloneMetadata(metadata), reqMeta)
-	resp, err := h.AuthManager.ExecuteCount(ctx, providers, req, opts)
+	resp, err := h.AuthManager.ExecuteEmbeddings(ctx, providers, req, opts)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
+			if code := se.StatusCode(); code > 0 {
+				status = code
+			}
+		}
+		var addon http.Header
+		if he, ok := err.(interface{ Headers() http.Header }); ok && he != nil {
+			if hdr := he.Headers(); hdr != nil {
+				addon = hdr.Clone()
+			}
+		}
+		return nil, &interfaces.ErrorMessage{StatusCode: status, Error: err, Addon: addon}
+	}
+	return cloneBytes(resp.Payload), nil
+}
+
+// ExecuteImagesWithAuthManager executes an image generation request via the core
+// auth manager. Routing, key pooling, and usage accounting follow the same path
+// as chat requests; moderation applies because the prompt is user content.
+func (h *BaseAPIHandler) ExecuteImagesWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
+	if errMsg := h.moderateRequest(ctx, handlerType, rawJSON); errMsg != nil {
+		return nil, errMsg
+	}
+	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
+	if errMsg != nil {
+		return nil, errMsg
+	}
+	reqMeta := requestExecutionMetadata(ctx)
+	reqMeta[coreexecutor.RequestedModelMetadataKey] = normalizedModel
+	req := coreexecutor.Request{
+		Model:   normalizedModel,
+		Payload: cloneBytes(rawJSON),
+	}
+	if cloned := cloneMetadata(metadata); cloned != nil {
+		req.Metadata = cloned
+	}
+	opts := coreexecutor.Options{
+		Stream:          false,
+		Alt:             alt,
+		OriginalRequest: cloneBytes(rawJSON),
+		SourceFormat:    sdktranslator.FromString(handlerType),
+	}
+	opts.Metadata = mergeMetadata(cloneMetadata(metadata), reqMeta)
+	resp, err := h.AuthManager.ExecuteImages(ctx, providers, req, opts)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
+			if code := se.StatusCode(); code > 0 {
+				status = code
+			}
+		}
+		var addon http.Header
+		if he, ok := err.(interface{ Headers() http.Header }); ok && he != nil {
+			if hdr := he.Headers(); hdr != nil {
+				addon = hdr.Clone()
+			}
+		}
+		return nil, &interfaces.ErrorMessage{StatusCode: status, Error: err, Addon: addon}
+	}
+	return cloneBytes(resp.Payload), nil
+}
+
+// ExecuteTranscriptionsWithAuthManager executes an audio transcription request via
+// the core auth manager. The raw JSON is the internal envelope built from the
+// multipart upload; routing, key pooling, and usage accounting follow the same
+// path as chat requests.
+func (h *BaseAPIHandler) ExecuteTranscriptionsWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
+	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
+	if errMsg != nil {
+		return nil, errMsg
+	}
+	reqMeta := requestExecutionMetadata(ctx)
+	reqMeta[coreexecutor.RequestedModelMetadataKey] = normalizedModel
+	req := coreexecutor.Request{
+		Model:   normalizedModel,
+		Payload: cloneBytes(rawJSON),
+	}
+	if cloned := cloneMetadata(metadata); cloned != nil {
+		req.Metadata = cloned
+	}
+	opts := coreexecutor.Options{
+		Stream:          false,
+		Alt:             alt,
+		OriginalRequest: cloneBytes(rawJSON),
+		SourceFormat:    sdktranslator.FromString(handlerType),
+	}
+	opts.Metadata = mergeMetadata(cloneMetadata(metadata), reqMeta)
+	resp, err := h.AuthManager.ExecuteTranscriptions(ctx, providers, req, opts)
 	if err != nil {
 		status := http.StatusInternalServerError
 		if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
@@ -462,9 +706,97 @@ func (h *BaseAPIHandler) ExecuteCountWithAuthManager(ctx context.Context, handle
 	return cloneBytes(resp.Payload), nil
 }
 
+// ExecuteSpeechWithAuthManager executes a text-to-speech request via the core
+// auth manager and returns the raw audio chunks as they arrive. Moderation
+// applies because the input text is user content.
+func (h *BaseAPIHandler) ExecuteSpeechWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) (<-chan []byte, <-chan *interfaces.ErrorMessage) {
+	if errMsg := h.moderateRequest(ctx, handlerType, rawJSON); errMsg != nil {
+		errChan := make(chan *interfaces.ErrorMessage, 1)
+		errChan <- errMsg
+		close(errChan)
+		return nil, errChan
+	}
+	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
+	if errMsg != nil {
+		errChan := make(chan *interfaces.ErrorMessage, 1)
+		errChan <- errMsg
+		close(errChan)
+		return nil, errChan
+	}
+	reqMeta := requestExecutionMetadata(ctx)
+	reqMeta[coreexecutor.RequestedModelMetadataKey] = normalizedModel
+	req := coreexecutor.Request{
+		Model:   normalizedModel,
+		Payload: cloneBytes(rawJSON),
+	}
+	if cloned := cloneMetadata(metadata); cloned != nil {
+		req.Metadata = cloned
+	}
+	opts := coreexecutor.Options{
+		Stream:          true,
+		Alt:             alt,
+		OriginalRequest: cloneBytes(rawJSON),
+		SourceFormat:    sdktranslator.FromString(handlerType),
+	}
+	opts.Metadata = mergeMetadata(cloneMetadata(metadata), reqMeta)
+	chunks, err := h.AuthManager.ExecuteSpeech(ctx, providers, req, opts)
+	if err != nil {
+		errChan := make(chan *interfaces.ErrorMessage, 1)
+		status := http.StatusInternalServerError
+		if se, ok := err.(interface{ StatusCode() int }); ok && se != nil {
+			if code := se.StatusCode(); code > 0 {
+				status = code
+			}
+		}
+		var addon http.Header
+		if he, ok := err.(interface{ Headers() http.Header }); ok && he != nil {
+			if hdr := he.Headers(); hdr != nil {
+				addon = hdr.Clone()
+			}
+		}
+		errChan <- &interfaces.ErrorMessage{StatusCode: status, Error: err, Addon: addon}
+		close(errChan)
+		return nil, errChan
+	}
+	dataChan := make(chan []byte)
+	errChan := make(chan *interfaces.ErrorMessage, 1)
+	go func() {
+		defer close(dataChan)
+		defer close(errChan)
+		for chunk := range chunks {
+			if chunk.Err != nil {
+				status := statusFromError(chunk.Err)
+				if status == 0 {
+					status = http.StatusInternalServerError
+				}
+				errChan <- &interfaces.ErrorMessage{StatusCode: status, Error: chunk.Err}
+				return
+			}
+			select {
+			case dataChan <- cloneBytes(chunk.Payload):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return dataChan, errChan
+}
+
 // ExecuteStreamWithAuthManager executes a streaming request via the core auth manager.
 // This path is the only supported execution route.
 func (h *BaseAPIHandler) ExecuteStreamWithAuthManager(ctx context.Context, handlerType, modelName string, rawJSON []byte, alt string) (<-chan []byte, <-chan *interfaces.ErrorMessage) {
+	if errMsg := h.moderateRequest(ctx, handlerType, rawJSON); errMsg != nil {
+		errChan := make(chan *interfaces.ErrorMessage, 1)
+		errChan <- errMsg
+		close(errChan)
+		return nil, errChan
+	}
+	if rule := h.virtualModelRule(modelName); rule != nil {
+		return h.executeVirtualModelStream(ctx, handlerType, rule, rawJSON, alt)
+	}
+	if chain := h.fallbackChain(ctx, modelName); chain != nil {
+		return h.executeFallbackChainStream(ctx, handlerType, chain, rawJSON, alt)
+	}
 	providers, normalizedModel, metadata, errMsg := h.getRequestDetails(modelName)
 	if errMsg != nil {
 		errChan := make(chan *interfaces.ErrorMessage, 1)
@@ -509,6 +841,7 @@ func (h *BaseAPIHandler) ExecuteStreamWithAuthManager(ctx context.Context, handl
 	}
 	dataChan := make(chan []byte)
 	errChan := make(chan *interfaces.ErrorMessage, 1)
+	toolValidator := h.newToolCallStreamValidator(handlerType, modelName, rawJSON)
 	go func() {
 		defer close(dataChan)
 		defer close(errChan)
@@ -545,6 +878,13 @@ func (h *BaseAPIHandler) ExecuteStreamWithAuthManager(ctx context.Context, handl
 					chunk, ok = <-chunks
 				}
 				if !ok {
+					if flushed, errMsg := toolValidator.finish(); errMsg != nil {
+						errChan <- errMsg
+					} else {
+						for _, payload := range flushed {
+							dataChan <- payload
+						}
+					}
 					return
 				}
 				if chunk.Err != nil {
@@ -580,7 +920,14 @@ func (h *BaseAPIHandler) ExecuteStreamWithAuthManager(ctx context.Context, handl
 				}
 				if len(chunk.Payload) > 0 {
 					sentPayload = true
-					dataChan <- cloneBytes(chunk.Payload)
+					forwarded, errMsg := toolValidator.process(cloneBytes(chunk.Payload))
+					if errMsg != nil {
+						errChan <- errMsg
+						return
+					}
+					for _, payload := range forwarded {
+						dataChan <- payload
+					}
 				}
 			}
 		}
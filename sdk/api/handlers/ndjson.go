@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NDJSONContentType is the Content-Type served when a client opts into
+// newline-delimited JSON streaming.
+const NDJSONContentType = "application/x-ndjson"
+
+// NDJSONRequested reports whether the client asked for newline-delimited JSON
+// streaming instead of SSE, either via `Accept: application/x-ndjson` or the
+// `?ndjson=true` query flag. NDJSON framing is easier to consume from backends
+// and log processors that do not speak the SSE wire format.
+func NDJSONRequested(c *gin.Context) bool {
+	if c == nil || c.Request == nil {
+		return false
+	}
+	if strings.Contains(strings.ToLower(c.Request.Header.Get("Accept")), NDJSONContentType) {
+		return true
+	}
+	if raw := strings.TrimSpace(c.Query("ndjson")); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		return err == nil && parsed
+	}
+	return false
+}
+
+// StreamContentType returns the response Content-Type for the chosen framing.
+func StreamContentType(ndjson bool) string {
+	if ndjson {
+		return NDJSONContentType
+	}
+	return "text/event-stream"
+}
+
+// WriteStreamChunk writes one JSON chunk in the chosen framing: a bare line
+// for NDJSON, a `data:` event for SSE. It does not flush.
+func WriteStreamChunk(c *gin.Context, ndjson bool, payload []byte) {
+	if len(payload) == 0 {
+		return
+	}
+	if ndjson {
+		_, _ = fmt.Fprintf(c.Writer, "%s\n", payload)
+		return
+	}
+	_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", payload)
+}
+
+// WriteStreamDone writes the SSE terminal marker. NDJSON streams omit it; the
+// end of the response body already delimits the stream.
+func WriteStreamDone(c *gin.Context, ndjson bool) {
+	if ndjson {
+		return
+	}
+	_, _ = fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+}
+
+// WriteStreamUsage writes the terminal usage announcement in the chosen
+// framing: a bare JSON line for NDJSON, a dedicated `modelgate.usage` event
+// for SSE. The payload keys mirror the X-ModelGate-Usage-* response headers.
+func WriteStreamUsage(c *gin.Context, ndjson bool, payload []byte) {
+	if len(payload) == 0 {
+		return
+	}
+	if ndjson {
+		_, _ = fmt.Fprintf(c.Writer, "%s\n", payload)
+		return
+	}
+	_, _ = fmt.Fprintf(c.Writer, "event: modelgate.usage\ndata: %s\n\n", payload)
+}
+
+// WriteStreamKeepAlive writes a heartbeat the chosen framing tolerates: a bare
+// newline for NDJSON (consumers skip empty lines), an SSE comment otherwise.
+func WriteStreamKeepAlive(c *gin.Context, ndjson bool) {
+	if ndjson {
+		_, _ = c.Writer.Write([]byte("\n"))
+		return
+	}
+	_, _ = c.Writer.Write([]byte(": keep-alive\n\n"))
+}
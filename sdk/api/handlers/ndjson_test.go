@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNDJSONRequested(t *testing.T) {
+	cases := []struct {
+		name   string
+		accept string
+		query  string
+		want   bool
+	}{
+		{name: "accept header", accept: "application/x-ndjson", want: true},
+		{name: "accept header mixed list", accept: "text/event-stream, application/x-ndjson", want: true},
+		{name: "query flag", query: "?ndjson=true", want: true},
+		{name: "query flag numeric", query: "?ndjson=1", want: true},
+		{name: "query flag false", query: "?ndjson=false", want: false},
+		{name: "default sse", want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("POST", "/v1/chat/completions"+tc.query, nil)
+			if tc.accept != "" {
+				c.Request.Header.Set("Accept", tc.accept)
+			}
+			if got := NDJSONRequested(c); got != tc.want {
+				t.Fatalf("NDJSONRequested() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteStreamChunkFraming(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	WriteStreamChunk(c, true, []byte(`{"a":1}`))
+	WriteStreamDone(c, true)
+	if got := recorder.Body.String(); got != "{\"a\":1}\n" {
+		t.Fatalf("ndjson framing = %q", got)
+	}
+
+	recorder = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(recorder)
+	WriteStreamChunk(c, false, []byte(`{"a":1}`))
+	WriteStreamDone(c, false)
+	if got := recorder.Body.String(); got != "data: {\"a\":1}\n\ndata: [DONE]\n\n" {
+		t.Fatalf("sse framing = %q", got)
+	}
+}
+
+func TestWriteStreamUsageFraming(t *testing.T) {
+	payload := []byte(`{"x-modelgate-usage-input-tokens":10,"x-modelgate-usage-output-tokens":5}`)
+
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	WriteStreamUsage(c, true, payload)
+	if got := recorder.Body.String(); got != string(payload)+"\n" {
+		t.Fatalf("ndjson usage framing = %q", got)
+	}
+
+	recorder = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(recorder)
+	WriteStreamUsage(c, false, payload)
+	if got := recorder.Body.String(); got != "event: modelgate.usage\ndata: "+string(payload)+"\n\n" {
+		t.Fatalf("sse usage framing = %q", got)
+	}
+}
@@ -0,0 +1,195 @@
+// Package ollama provides HTTP handlers for the Ollama-compatible API facade.
+// It serves the native Ollama endpoints (/api/chat, /api/generate, /api/tags)
+// so tools that only speak the Ollama protocol can use the proxy's cloud
+// providers transparently. Requests are translated into OpenAI Chat
+// Completions form and routed through the regular execution pipeline, and
+// responses are converted back into Ollama's NDJSON object stream.
+package ollama
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/shariqriazz/modelgate/internal/constant"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	ollamaconverter "github.com/shariqriazz/modelgate/internal/translator/openai/ollama"
+	"github.com/shariqriazz/modelgate/sdk/api/handlers"
+	"github.com/tidwall/gjson"
+)
+
+// OllamaAPIHandler contains the handlers for the Ollama-compatible endpoints.
+type OllamaAPIHandler struct {
+	*handlers.BaseAPIHandler
+}
+
+// NewOllamaAPIHandler creates a new Ollama API handlers instance.
+//
+// Parameters:
+//   - apiHandlers: The base API handlers instance
+//
+// Returns:
+//   - *OllamaAPIHandler: A new Ollama API handlers instance
+func NewOllamaAPIHandler(apiHandlers *handlers.BaseAPIHandler) *OllamaAPIHandler {
+	return &OllamaAPIHandler{
+		BaseAPIHandler: apiHandlers,
+	}
+}
+
+// HandlerType returns the identifier for this handler implementation.
+func (h *OllamaAPIHandler) HandlerType() string {
+	return Ollama
+}
+
+// Models returns the model metadata supported by this handler.
+func (h *OllamaAPIHandler) Models() []map[string]any {
+	return registry.GetGlobalRegistry().GetAvailableModels("openai")
+}
+
+// OllamaTags handles the /api/tags endpoint.
+// It lists the proxy's available models in the shape Ollama clients expect
+// from a local model library.
+func (h *OllamaAPIHandler) OllamaTags(c *gin.Context) {
+	allModels := h.Models()
+	models := make([]map[string]any, 0, len(allModels))
+	for _, model := range allModels {
+		id, _ := model["id"].(string)
+		if id == "" {
+			continue
+		}
+		models = append(models, map[string]any{
+			"name":        id,
+			"model":       id,
+			"modified_at": time.Now().UTC().Format(time.RFC3339Nano),
+			"size":        0,
+			"digest":      "",
+			"details": map[string]any{
+				"format":             "",
+				"family":             "",
+				"parameter_size":     "",
+				"quantization_level": "",
+			},
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"models": models})
+}
+
+// OllamaChat handles the /api/chat endpoint.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+func (h *OllamaAPIHandler) OllamaChat(c *gin.Context) {
+	h.handleOllamaRequest(c)
+}
+
+// OllamaGenerate handles the /api/generate endpoint. The prompt shape is
+// converted into a single-turn chat request; the response converter keys off
+// the original payload to render generate-style objects.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+func (h *OllamaAPIHandler) OllamaGenerate(c *gin.Context) {
+	h.handleOllamaRequest(c)
+}
+
+// handleOllamaRequest translates the inbound Ollama payload into an OpenAI
+// chat completion request, executes it through the auth manager, and converts
+// the response back. Ollama defaults to streaming unless stream=false.
+func (h *OllamaAPIHandler) handleOllamaRequest(c *gin.Context) {
+	rawJSON, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+	modelName := gjson.GetBytes(rawJSON, "model").String()
+	if modelName == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "model is required"})
+		return
+	}
+
+	streamResult := gjson.GetBytes(rawJSON, "stream")
+	stream := !(streamResult.Exists() && streamResult.Type == gjson.False)
+
+	openAIJSON := ollamaconverter.ConvertOllamaRequestToOpenAI(modelName, rawJSON, stream)
+
+	if stream {
+		h.handleStreamingResponse(c, modelName, rawJSON, openAIJSON)
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	cliCtx, cliCancel := h.GetContextWithCancel(h, c, context.Background())
+	resp, errMsg := h.ExecuteWithAuthManager(cliCtx, OpenAI, modelName, openAIJSON, h.GetAlt(c))
+	if errMsg != nil {
+		h.WriteErrorResponse(c, errMsg)
+		cliCancel(errMsg.Error)
+		return
+	}
+	var param any
+	out := ollamaconverter.ConvertOpenAIResponseToOllamaNonStream(cliCtx, modelName, rawJSON, openAIJSON, resp, &param)
+	_, _ = c.Writer.WriteString(out)
+	cliCancel()
+}
+
+// handleStreamingResponse relays the OpenAI-format stream as Ollama NDJSON
+// objects, emitting the terminal done object when the upstream stream ends.
+func (h *OllamaAPIHandler) handleStreamingResponse(c *gin.Context, modelName string, rawJSON, openAIJSON []byte) {
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "streaming not supported"})
+		return
+	}
+
+	cliCtx, cliCancel := h.GetContextWithCancel(h, c, context.Background())
+	dataChan, errChan := h.ExecuteStreamWithAuthManager(cliCtx, OpenAI, modelName, openAIJSON, h.GetAlt(c))
+
+	var param any
+	headerWritten := false
+	writeLines := func(lines []string) {
+		for _, line := range lines {
+			if line == "" {
+				continue
+			}
+			if !headerWritten {
+				c.Header("Content-Type", "application/x-ndjson")
+				c.Status(http.StatusOK)
+				headerWritten = true
+			}
+			_, _ = fmt.Fprintf(c.Writer, "%s\n", line)
+			flusher.Flush()
+		}
+	}
+	finish := func() {
+		writeLines(ollamaconverter.ConvertOpenAIResponseToOllama(cliCtx, modelName, rawJSON, openAIJSON, []byte("data: [DONE]"), &param))
+	}
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			cliCancel(c.Request.Context().Err())
+			return
+		case chunk, chunkOk := <-dataChan:
+			if !chunkOk {
+				finish()
+				cliCancel()
+				return
+			}
+			writeLines(ollamaconverter.ConvertOpenAIResponseToOllama(cliCtx, modelName, rawJSON, openAIJSON, chunk, &param))
+		case errMsg, errOk := <-errChan:
+			if !errOk {
+				errChan = nil
+				continue
+			}
+			if errMsg == nil {
+				continue
+			}
+			if !headerWritten {
+				h.WriteErrorResponse(c, errMsg)
+			}
+			cliCancel(errMsg.Error)
+			return
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shariqriazz/modelgate/sdk/api/handlers"
+	"github.com/tidwall/gjson"
+)
+
+// transcriptionEnvelope is the internal JSON contract between the multipart
+// front end and transcription-capable executors. The audio bytes travel as
+// base64 so the payload stays a regular JSON request through the auth manager.
+type transcriptionEnvelope struct {
+	Model          string `json:"model"`
+	FileName       string `json:"file_name"`
+	MimeType       string `json:"mime_type,omitempty"`
+	AudioB64       string `json:"audio_b64"`
+	Language       string `json:"language,omitempty"`
+	Prompt         string `json:"prompt,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+	Temperature    string `json:"temperature,omitempty"`
+}
+
+// speechContentType maps an OpenAI response_format onto the audio MIME type
+// sent back to the client. The upstream bytes pass through unchanged.
+func speechContentType(responseFormat string) string {
+	switch responseFormat {
+	case "opus":
+		return "audio/ogg"
+	case "aac":
+		return "audio/aac"
+	case "flac":
+		return "audio/flac"
+	case "wav":
+		return "audio/wav"
+	case "pcm":
+		return "audio/pcm"
+	default:
+		return "audio/mpeg"
+	}
+}
+
+// AudioSpeech handles the /v1/audio/speech endpoint.
+// The request is routed through the auth manager so speech synthesis shares
+// the same key pooling, retry, and usage accounting as chat, and the upstream
+// audio stream is passed through to the client as it arrives.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+func (h *OpenAIAPIHandler) AudioSpeech(c *gin.Context) {
+	rawJSON, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("Invalid request: %v", err),
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+
+	modelName := gjson.GetBytes(rawJSON, "model").String()
+	if modelName == "" {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: "model is required",
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+	if gjson.GetBytes(rawJSON, "input").String() == "" {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: "input is required",
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+
+	cliCtx, cliCancel := h.GetContextWithCancel(h, c, context.Background())
+	dataChan, errChan := h.ExecuteSpeechWithAuthManager(cliCtx, h.HandlerType(), modelName, rawJSON, h.GetAlt(c))
+	if dataChan == nil {
+		if errMsg := <-errChan; errMsg != nil {
+			h.WriteErrorResponse(c, errMsg)
+			cliCancel(errMsg.Error)
+			return
+		}
+		cliCancel()
+		return
+	}
+
+	headerWritten := false
+	flusher, _ := c.Writer.(http.Flusher)
+	for {
+		select {
+		case chunk, ok := <-dataChan:
+			if !ok {
+				cliCancel()
+				return
+			}
+			if !headerWritten {
+				c.Header("Content-Type", speechContentType(gjson.GetBytes(rawJSON, "response_format").String()))
+				c.Status(http.StatusOK)
+				headerWritten = true
+			}
+			_, _ = c.Writer.Write(chunk)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case errMsg, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				continue
+			}
+			if errMsg == nil {
+				continue
+			}
+			if !headerWritten {
+				h.WriteErrorResponse(c, errMsg)
+			}
+			cliCancel(errMsg.Error)
+			return
+		}
+	}
+}
+
+// AudioTranscriptions handles the /v1/audio/transcriptions endpoint.
+// The multipart upload is repacked into an internal JSON envelope and routed
+// through the auth manager so transcriptions share the same key pooling,
+// retry, and usage accounting as chat; providers that do not implement the
+// transcription capability are skipped during selection.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+func (h *OpenAIAPIHandler) AudioTranscriptions(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("Invalid request: missing audio file: %v", err),
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+	modelName := c.PostForm("model")
+	if modelName == "" {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: "model is required",
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("Invalid request: cannot read audio file: %v", err),
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+	audio, err := io.ReadAll(file)
+	_ = file.Close()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("Invalid request: cannot read audio file: %v", err),
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+
+	envelope := transcriptionEnvelope{
+		Model:          modelName,
+		FileName:       fileHeader.Filename,
+		MimeType:       fileHeader.Header.Get("Content-Type"),
+		AudioB64:       base64.StdEncoding.EncodeToString(audio),
+		Language:       c.PostForm("language"),
+		Prompt:         c.PostForm("prompt"),
+		ResponseFormat: c.PostForm("response_format"),
+		Temperature:    c.PostForm("temperature"),
+	}
+	rawJSON, err := json.Marshal(envelope)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("failed to encode transcription request: %v", err),
+				Type:    "server_error",
+			},
+		})
+		return
+	}
+
+	c.Header("Content-Type", "application/json")
+	cliCtx, cliCancel := h.GetContextWithCancel(h, c, context.Background())
+	resp, errMsg := h.ExecuteTranscriptionsWithAuthManager(cliCtx, h.HandlerType(), modelName, rawJSON, h.GetAlt(c))
+	if errMsg != nil {
+		h.WriteErrorResponse(c, errMsg)
+		cliCancel(errMsg.Error)
+		return
+	}
+	_, _ = c.Writer.Write(resp)
+	cliCancel()
+}
@@ -0,0 +1,52 @@
+package openai
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shariqriazz/modelgate/internal/moderation"
+	"github.com/shariqriazz/modelgate/sdk/api/handlers"
+)
+
+// Moderations handles the /v1/moderations endpoint.
+// It forwards the request body unchanged to the configured moderation provider
+// so clients depending on the moderations API can keep using the gateway as
+// their single base URL.
+//
+// Parameters:
+//   - c: The Gin context containing the HTTP request and response
+func (h *OpenAIAPIHandler) Moderations(c *gin.Context) {
+	rawJSON, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("Invalid request: %v", err),
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+
+	if h.Cfg == nil || h.Cfg.Moderation.BaseURL == "" {
+		c.JSON(http.StatusNotImplemented, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: "moderations endpoint is not configured; set moderation.base-url in the config",
+				Type:    "invalid_request_error",
+			},
+		})
+		return
+	}
+
+	status, payload, errForward := moderation.Forward(c.Request.Context(), h.Cfg, rawJSON)
+	if errForward != nil {
+		c.JSON(http.StatusBadGateway, handlers.ErrorResponse{
+			Error: handlers.ErrorDetail{
+				Message: fmt.Sprintf("moderation provider request failed: %v", errForward),
+				Type:    "server_error",
+			},
+		})
+		return
+	}
+	c.Data(status, "application/json", payload)
+}
@@ -302,16 +302,18 @@ func wrapResponsesPayloadAsCompleted(payload []byte) []byte {
 }
 
 func writeConvertedResponsesChunk(c *gin.Context, ctx context.Context, modelName string, originalChatJSON, responsesRequestJSON, chunk []byte, param *any) {
+	ndjson := handlers.NDJSONRequested(c)
 	outputs := codexconverter.ConvertCodexResponseToOpenAI(ctx, modelName, originalChatJSON, responsesRequestJSON, chunk, param)
 	for _, out := range outputs {
 		if out == "" {
 			continue
 		}
-		_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", out)
+		handlers.WriteStreamChunk(c, ndjson, []byte(out))
 	}
 }
 
 func (h *OpenAIAPIHandler) forwardResponsesAsChatStream(c *gin.Context, flusher http.Flusher, cancel func(error), data <-chan []byte, errs <-chan *interfaces.ErrorMessage, ctx context.Context, modelName string, originalChatJSON, responsesRequestJSON []byte, param *any) {
+	ndjson := handlers.NDJSONRequested(c)
 	h.ForwardStream(c, flusher, cancel, data, errs, handlers.StreamForwardOptions{
 		WriteChunk: func(chunk []byte) {
 			outputs := codexconverter.ConvertCodexResponseToOpenAI(ctx, modelName, originalChatJSON, responsesRequestJSON, chunk, param)
@@ -319,7 +321,7 @@ func (h *OpenAIAPIHandler) forwardResponsesAsChatStream(c *gin.Context, flusher
 				if out == "" {
 					continue
 				}
-				_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", out)
+				handlers.WriteStreamChunk(c, ndjson, []byte(out))
 			}
 		},
 		WriteTerminalError: func(errMsg *interfaces.ErrorMessage) {
@@ -335,10 +337,16 @@ func (h *OpenAIAPIHandler) forwardResponsesAsChatStream(c *gin.Context, flusher
 				errText = errMsg.Error.Error()
 			}
 			body := handlers.BuildErrorResponseBody(status, errText)
-			_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", string(body))
+			handlers.WriteStreamChunk(c, ndjson, body)
 		},
 		WriteDone: func() {
-			_, _ = fmt.Fprintf(c.Writer, "data: [DONE]\n\n")
+			handlers.WriteStreamDone(c, ndjson)
+		},
+		WriteKeepAlive: func() {
+			handlers.WriteStreamKeepAlive(c, ndjson)
+		},
+		WriteUsage: func(payload []byte) {
+			handlers.WriteStreamUsage(c, ndjson, payload)
 		},
 	})
 }
@@ -581,8 +589,9 @@ func (h *OpenAIAPIHandler) handleStreamingResponse(c *gin.Context, rawJSON []byt
 	cliCtx, cliCancel := h.GetContextWithCancel(h, c, context.Background())
 	dataChan, errChan := h.ExecuteStreamWithAuthManager(cliCtx, h.HandlerType(), modelName, rawJSON, h.GetAlt(c))
 
-	setSSEHeaders := func() {
-		c.Header("Content-Type", "text/event-stream")
+	ndjson := handlers.NDJSONRequested(c)
+	setStreamHeaders := func() {
+		c.Header("Content-Type", handlers.StreamContentType(ndjson))
 		c.Header("Cache-Control", "no-cache")
 		c.Header("Connection", "keep-alive")
 		c.Header("Access-Control-Allow-Origin", "*")
@@ -611,17 +620,17 @@ func (h *OpenAIAPIHandler) handleStreamingResponse(c *gin.Context, rawJSON []byt
 		case chunk, ok := <-dataChan:
 			if !ok {
 				// Stream closed without data? Send DONE or just headers.
-				setSSEHeaders()
-				_, _ = fmt.Fprintf(c.Writer, "data: [DONE]\n\n")
+				setStreamHeaders()
+				handlers.WriteStreamDone(c, ndjson)
 				flusher.Flush()
 				cliCancel(nil)
 				return
 			}
 
 			// Success! Commit to streaming headers.
-			setSSEHeaders()
+			setStreamHeaders()
 
-			_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", string(chunk))
+			handlers.WriteStreamChunk(c, ndjson, chunk)
 			flusher.Flush()
 
 			// Continue streaming the rest
@@ -648,8 +657,9 @@ func (h *OpenAIAPIHandler) handleStreamingResponseViaResponses(c *gin.Context, r
 	dataChan, errChan := h.ExecuteStreamWithAuthManager(cliCtx, OpenaiResponse, modelName, rawJSON, h.GetAlt(c))
 	var param any
 
-	setSSEHeaders := func() {
-		c.Header("Content-Type", "text/event-stream")
+	ndjson := handlers.NDJSONRequested(c)
+	setStreamHeaders := func() {
+		c.Header("Content-Type", handlers.StreamContentType(ndjson))
 		c.Header("Cache-Control", "no-cache")
 		c.Header("Connection", "keep-alive")
 		c.Header("Access-Control-Allow-Origin", "*")
@@ -675,14 +685,14 @@ func (h *OpenAIAPIHandler) handleStreamingResponseViaResponses(c *gin.Context, r
 			return
 		case chunk, ok := <-dataChan:
 			if !ok {
-				setSSEHeaders()
-				_, _ = fmt.Fprintf(c.Writer, "data: [DONE]\n\n")
+				setStreamHeaders()
+				handlers.WriteStreamDone(c, ndjson)
 				flusher.Flush()
 				cliCancel(nil)
 				return
 			}
 
-			setSSEHeaders()
+			setStreamHeaders()
 			writeConvertedResponsesChunk(c, cliCtx, modelName, originalChatJSON, rawJSON, chunk, &param)
 			flusher.Flush()
 
@@ -747,8 +757,9 @@ func (h *OpenAIAPIHandler) handleCompletionsStreamingResponse(c *gin.Context, ra
 	cliCtx, cliCancel := h.GetContextWithCancel(h, c, context.Background())
 	dataChan, errChan := h.ExecuteStreamWithAuthManager(cliCtx, h.HandlerType(), modelName, chatCompletionsJSON, "")
 
-	setSSEHeaders := func() {
-		c.Header("Content-Type", "text/event-stream")
+	ndjson := handlers.NDJSONRequested(c)
+	setStreamHeaders := func() {
+		c.Header("Content-Type", handlers.StreamContentType(ndjson))
 		c.Header("Cache-Control", "no-cache")
 		c.Header("Connection", "keep-alive")
 		c.Header("Access-Control-Allow-Origin", "*")
@@ -775,20 +786,20 @@ func (h *OpenAIAPIHandler) handleCompletionsStreamingResponse(c *gin.Context, ra
 			return
 		case chunk, ok := <-dataChan:
 			if !ok {
-				setSSEHeaders()
-				_, _ = fmt.Fprintf(c.Writer, "data: [DONE]\n\n")
+				setStreamHeaders()
+				handlers.WriteStreamDone(c, ndjson)
 				flusher.Flush()
 				cliCancel(nil)
 				return
 			}
 
 			// Success! Set headers.
-			setSSEHeaders()
+			setStreamHeaders()
 
 			// Write the first chunk
 			converted := convertChatCompletionsStreamChunkToCompletions(chunk)
 			if converted != nil {
-				_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", string(converted))
+				handlers.WriteStreamChunk(c, ndjson, converted)
 				flusher.Flush()
 			}
 
@@ -829,9 +840,10 @@ func (h *OpenAIAPIHandler) handleCompletionsStreamingResponse(c *gin.Context, ra
 	}
 }
 func (h *OpenAIAPIHandler) handleStreamResult(c *gin.Context, flusher http.Flusher, cancel func(error), data <-chan []byte, errs <-chan *interfaces.ErrorMessage) {
+	ndjson := handlers.NDJSONRequested(c)
 	h.ForwardStream(c, flusher, cancel, data, errs, handlers.StreamForwardOptions{
 		WriteChunk: func(chunk []byte) {
-			_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", string(chunk))
+			handlers.WriteStreamChunk(c, ndjson, chunk)
 		},
 		WriteTerminalError: func(errMsg *interfaces.ErrorMessage) {
 			if errMsg == nil {
@@ -846,10 +858,16 @@ func (h *OpenAIAPIHandler) handleStreamResult(c *gin.Context, flusher http.Flush
 				errText = errMsg.Error.Error()
 			}
 			body := handlers.BuildErrorResponseBody(status, errText)
-			_, _ = fmt.Fprintf(c.Writer, "data: %s\n\n", string(body))
+			handlers.WriteStreamChunk(c, ndjson, body)
 		},
 		WriteDone: func() {
-			_, _ = fmt.Fprint(c.Writer, "data: [DONE]\n\n")
+			handlers.WriteStreamDone(c, ndjson)
+		},
+		WriteKeepAlive: func() {
+			handlers.WriteStreamKeepAlive(c, ndjson)
+		},
+		WriteUsage: func(payload []byte) {
+			handlers.WriteStreamUsage(c, ndjson, payload)
 		},
 	})
 }
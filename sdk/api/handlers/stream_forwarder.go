@@ -27,6 +27,29 @@ type StreamForwardOptions struct {
 	// WriteKeepAlive optionally writes a keep-alive heartbeat. It should not flush.
 	// When nil, a standard SSE comment heartbeat is used.
 	WriteKeepAlive func()
+
+	// WriteUsage writes the pending usage announcement when the stream closes
+	// cleanly. When nil, a standard `modelgate.usage` SSE event is used; set a
+	// no-op for framings where an SSE event would corrupt the stream.
+	WriteUsage func(payload []byte)
+}
+
+// usageAnnouncementKey is the gin context key the runtime stores the rendered
+// usage payload under once response headers are already committed.
+const usageAnnouncementKey = "API_USAGE"
+
+// pendingUsagePayload returns the usage announcement stashed on the gin
+// context, or nil when usage headers are disabled or no usage was parsed.
+func pendingUsagePayload(c *gin.Context) []byte {
+	if c == nil {
+		return nil
+	}
+	if value, exists := c.Get(usageAnnouncementKey); exists {
+		if payload, ok := value.([]byte); ok {
+			return payload
+		}
+	}
+	return nil
 }
 
 func (h *BaseAPIHandler) ForwardStream(c *gin.Context, flusher http.Flusher, cancel func(error), data <-chan []byte, errs <-chan *interfaces.ErrorMessage, opts StreamForwardOptions) {
@@ -49,6 +72,15 @@ func (h *BaseAPIHandler) ForwardStream(c *gin.Context, flusher http.Flusher, can
 		}
 	}
 
+	writeUsage := opts.WriteUsage
+	if writeUsage == nil {
+		writeUsage = func(payload []byte) {
+			_, _ = c.Writer.Write([]byte("event: modelgate.usage\ndata: "))
+			_, _ = c.Writer.Write(payload)
+			_, _ = c.Writer.Write([]byte("\n\n"))
+		}
+	}
+
 	keepAliveInterval := StreamingKeepAliveInterval(h.Cfg)
 	if opts.KeepAliveInterval != nil {
 		keepAliveInterval = *opts.KeepAliveInterval
@@ -87,6 +119,9 @@ func (h *BaseAPIHandler) ForwardStream(c *gin.Context, flusher http.Flusher, can
 					cancel(terminalErr.Error)
 					return
 				}
+				if payload := pendingUsagePayload(c); len(payload) > 0 {
+					writeUsage(payload)
+				}
 				if opts.WriteDone != nil {
 					opts.WriteDone()
 				}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/interfaces"
+	"github.com/shariqriazz/modelgate/internal/jsonrepair"
+	"github.com/shariqriazz/modelgate/internal/util"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Tool-call validation modes configurable per model in tool-validation rules.
+const (
+	toolValidationRepair   = "repair"
+	toolValidationReject   = "reject"
+	toolValidationAnnotate = "annotate"
+)
+
+// toolValidationMode resolves the configured validation mode for a model on an
+// OpenAI-format request; the first matching rule wins. Empty means disabled.
+func (h *BaseAPIHandler) toolValidationMode(handlerType, modelName string) string {
+	if h == nil || h.Cfg == nil || len(h.Cfg.ToolValidation.Rules) == 0 {
+		return ""
+	}
+	for i := range h.Cfg.ToolValidation.Rules {
+		rule := &h.Cfg.ToolValidation.Rules[i]
+		for _, entry := range rule.Models {
+			name := strings.TrimSpace(entry.Name)
+			if name == "" {
+				continue
+			}
+			if protocol := strings.TrimSpace(entry.Protocol); protocol != "" && !strings.EqualFold(protocol, handlerType) {
+				continue
+			}
+			if matchToolValidationModel(name, modelName) {
+				switch mode := strings.ToLower(strings.TrimSpace(rule.Mode)); mode {
+				case toolValidationRepair, toolValidationReject, toolValidationAnnotate:
+					return mode
+				default:
+					return toolValidationAnnotate
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// toolSchemasFromRequest maps declared tool names to their parameter schemas.
+func toolSchemasFromRequest(rawJSON []byte) map[string]gjson.Result {
+	tools := gjson.GetBytes(rawJSON, "tools")
+	if !tools.IsArray() {
+		return nil
+	}
+	schemas := make(map[string]gjson.Result)
+	for _, tool := range tools.Array() {
+		name := tool.Get("function.name").String()
+		if name == "" {
+			continue
+		}
+		if parameters := tool.Get("function.parameters"); parameters.IsObject() {
+			schemas[name] = parameters
+		}
+	}
+	return schemas
+}
+
+// toolCallProblem records one invalid tool call for annotation.
+type toolCallProblem struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// validateToolCallArguments checks one call's arguments against its declared
+// schema, attempting repair when the mode allows it. It returns the arguments
+// to forward (possibly repaired) and a validation error when still invalid.
+func validateToolCallArguments(name, arguments string, schemas map[string]gjson.Result, mode string) (string, error) {
+	schema, declared := schemas[name]
+	if !declared {
+		return arguments, nil
+	}
+	candidate := arguments
+	if !gjson.Valid(candidate) {
+		if mode != toolValidationRepair {
+			return arguments, fmt.Errorf("arguments are not valid JSON")
+		}
+		repaired, ok := jsonrepair.Repair(candidate)
+		if !ok {
+			return arguments, fmt.Errorf("arguments are not valid JSON")
+		}
+		candidate = repaired
+	}
+	if err := util.ValidateJSONSchema(gjson.Parse(candidate), schema); err != nil {
+		return arguments, err
+	}
+	return candidate, nil
+}
+
+// validateToolCallsNonStream applies the configured mode to a non-streaming
+// OpenAI chat completion payload. It returns the (possibly rewritten) payload
+// or an error message when the mode rejects invalid calls.
+func validateToolCallsNonStream(payload, rawJSON []byte, mode string) ([]byte, *interfaces.ErrorMessage) {
+	calls := gjson.GetBytes(payload, "choices.0.message.tool_calls")
+	if !calls.IsArray() {
+		return payload, nil
+	}
+	schemas := toolSchemasFromRequest(rawJSON)
+	if len(schemas) == 0 {
+		return payload, nil
+	}
+	out := payload
+	var problems []toolCallProblem
+	for index, call := range calls.Array() {
+		name := call.Get("function.name").String()
+		arguments := call.Get("function.arguments").String()
+		fixed, err := validateToolCallArguments(name, arguments, schemas, mode)
+		if err != nil {
+			if mode == toolValidationReject {
+				return nil, &interfaces.ErrorMessage{
+					StatusCode: http.StatusBadGateway,
+					Error:      fmt.Errorf("tool call %q arguments failed validation: %w", name, err),
+				}
+			}
+			problems = append(problems, toolCallProblem{Index: index, Name: name, Error: err.Error()})
+			continue
+		}
+		if fixed != arguments {
+			out, _ = sjson.SetBytes(out, fmt.Sprintf("choices.0.message.tool_calls.%d.function.arguments", index), fixed)
+		}
+	}
+	if len(problems) > 0 {
+		out, _ = sjson.SetBytes(out, "tool_call_validation", problems)
+	}
+	return out, nil
+}
+
+// toolCallStreamValidator buffers streamed tool-call delta chunks so complete
+// arguments can be validated against the declared schemas before they are
+// forwarded. Non-tool-call chunks pass through untouched.
+type toolCallStreamValidator struct {
+	mode     string
+	schemas  map[string]gjson.Result
+	buffered [][]byte
+	order    []int
+	calls    map[int]*toolCallStreamState
+}
+
+// toolCallStreamState accumulates one streamed tool call by index.
+type toolCallStreamState struct {
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// newToolCallStreamValidator returns a validator for the stream, or nil when
+// validation does not apply to this request.
+func (h *BaseAPIHandler) newToolCallStreamValidator(handlerType, modelName string, rawJSON []byte) *toolCallStreamValidator {
+	if sdktranslator.FromString(handlerType) != sdktranslator.FormatOpenAI {
+		return nil
+	}
+	mode := h.toolValidationMode(handlerType, modelName)
+	if mode == "" {
+		return nil
+	}
+	schemas := toolSchemasFromRequest(rawJSON)
+	if len(schemas) == 0 {
+		return nil
+	}
+	return &toolCallStreamValidator{
+		mode:    mode,
+		schemas: schemas,
+		calls:   make(map[int]*toolCallStreamState),
+	}
+}
+
+// process consumes one translated chunk and returns the chunks to forward.
+func (v *toolCallStreamValidator) process(chunk []byte) ([][]byte, *interfaces.ErrorMessage) {
+	if v == nil {
+		return [][]byte{chunk}, nil
+	}
+	deltaCalls := gjson.GetBytes(chunk, "choices.0.delta.tool_calls")
+	if deltaCalls.IsArray() {
+		v.buffered = append(v.buffered, chunk)
+		for _, call := range deltaCalls.Array() {
+			index := int(call.Get("index").Int())
+			state, ok := v.calls[index]
+			if !ok {
+				state = &toolCallStreamState{}
+				v.calls[index] = state
+				v.order = append(v.order, index)
+			}
+			if id := call.Get("id").String(); id != "" {
+				state.id = id
+			}
+			if name := call.Get("function.name").String(); name != "" {
+				state.name = name
+			}
+			state.arguments.WriteString(call.Get("function.arguments").String())
+		}
+		return nil, nil
+	}
+	if len(v.buffered) > 0 && gjson.GetBytes(chunk, "choices.0.finish_reason").String() != "" {
+		flushed, errMsg := v.flush()
+		if errMsg != nil {
+			return nil, errMsg
+		}
+		return append(flushed, chunk), nil
+	}
+	return [][]byte{chunk}, nil
+}
+
+// finish flushes any buffered tool-call chunks once the stream has ended.
+func (v *toolCallStreamValidator) finish() ([][]byte, *interfaces.ErrorMessage) {
+	if v == nil || len(v.buffered) == 0 {
+		return nil, nil
+	}
+	return v.flush()
+}
+
+// flush validates the accumulated calls and returns the chunks to forward:
+// the buffered originals when everything is valid, a synthetic rewrite when
+// repair changed arguments, or the originals plus an annotation chunk.
+func (v *toolCallStreamValidator) flush() ([][]byte, *interfaces.ErrorMessage) {
+	buffered := v.buffered
+	v.buffered = nil
+	repaired := false
+	var problems []toolCallProblem
+	finalArgs := make(map[int]string, len(v.calls))
+	for _, index := range v.order {
+		state := v.calls[index]
+		arguments := state.arguments.String()
+		fixed, err := validateToolCallArguments(state.name, arguments, v.schemas, v.mode)
+		if err != nil {
+			if v.mode == toolValidationReject {
+				return nil, &interfaces.ErrorMessage{
+					StatusCode: http.StatusBadGateway,
+					Error:      fmt.Errorf("tool call %q arguments failed validation: %w", state.name, err),
+				}
+			}
+			problems = append(problems, toolCallProblem{Index: index, Name: state.name, Error: err.Error()})
+			finalArgs[index] = arguments
+			continue
+		}
+		if fixed != arguments {
+			repaired = true
+		}
+		finalArgs[index] = fixed
+	}
+
+	out := buffered
+	if repaired {
+		// Replace the buffered fragments with one chunk carrying the complete
+		// calls so clients never see the malformed argument stream.
+		if synthetic := v.syntheticToolCallChunk(buffered[0], finalArgs); synthetic != nil {
+			out = [][]byte{synthetic}
+		}
+	}
+	if len(problems) > 0 {
+		annotation, errSet := sjson.SetBytes(cloneBytes(buffered[0]), "tool_call_validation", problems)
+		if errSet == nil {
+			annotation, _ = sjson.SetRawBytes(annotation, "choices.0.delta", []byte(`{}`))
+			out = append(out, annotation)
+		}
+	}
+	v.calls = make(map[int]*toolCallStreamState)
+	v.order = nil
+	return out, nil
+}
+
+// syntheticToolCallChunk rewrites the first buffered chunk to carry every
+// accumulated call with its full (possibly repaired) arguments.
+func (v *toolCallStreamValidator) syntheticToolCallChunk(template []byte, finalArgs map[int]string) []byte {
+	out, errSet := sjson.SetRawBytes(cloneBytes(template), "choices.0.delta.tool_calls", []byte(`[]`))
+	if errSet != nil {
+		return nil
+	}
+	for _, index := range v.order {
+		state := v.calls[index]
+		entry := `{"type":"function"}`
+		entry, _ = sjson.Set(entry, "index", index)
+		if state.id != "" {
+			entry, _ = sjson.Set(entry, "id", state.id)
+		}
+		entry, _ = sjson.Set(entry, "function.name", state.name)
+		entry, _ = sjson.Set(entry, "function.arguments", finalArgs[index])
+		out, _ = sjson.SetRawBytes(out, "choices.0.delta.tool_calls.-1", []byte(entry))
+	}
+	return out
+}
+
+// matchToolValidationModel performs the same wildcard matching as payload
+// rules, where '*' matches zero or more characters.
+func matchToolValidationModel(pattern, model string) bool {
+	pattern = strings.TrimSpace(pattern)
+	model = strings.TrimSpace(model)
+	if pattern == "" {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	pi, si := 0, 0
+	starIdx := -1
+	matchIdx := 0
+	for si < len(model) {
+		if pi < len(pattern) && pattern[pi] == model[si] {
+			pi++
+			si++
+			continue
+		}
+		if pi < len(pattern) && pattern[pi] == '*' {
+			starIdx = pi
+			matchIdx = si
+			pi++
+			continue
+		}
+		if starIdx != -1 {
+			pi = starIdx + 1
+			matchIdx++
+			si = matchIdx
+			continue
+		}
+		return false
+	}
+	for pi < len(pattern) && pattern[pi] == '*' {
+		pi++
+	}
+	return pi == len(pattern)
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/shariqriazz/modelgate/internal/interfaces"
+	"github.com/shariqriazz/modelgate/internal/registry"
+	"github.com/shariqriazz/modelgate/internal/util"
+	"github.com/shariqriazz/modelgate/sdk/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/tidwall/gjson"
+	"golang.org/x/net/context"
+)
+
+// virtualRequestNeeds captures what a request demands from a candidate model:
+// tool calling, vision input, and a rough prompt size for context-window
+// filtering.
+type virtualRequestNeeds struct {
+	tools        bool
+	vision       bool
+	approxTokens int
+}
+
+// virtualModelRule returns the configured rule whose alias matches the
+// requested model name, or nil when the name is a concrete model.
+func (h *BaseAPIHandler) virtualModelRule(modelName string) *config.VirtualModelRule {
+	if h == nil || h.Cfg == nil {
+		return nil
+	}
+	name := strings.TrimSpace(modelName)
+	if name == "" {
+		return nil
+	}
+	for i := range h.Cfg.VirtualModels {
+		if strings.EqualFold(strings.TrimSpace(h.Cfg.VirtualModels[i].Alias), name) {
+			return &h.Cfg.VirtualModels[i]
+		}
+	}
+	return nil
+}
+
+// detectVirtualRequestNeeds inspects the raw request for capability demands.
+// Detection is deliberately format-agnostic: "tools" is a top-level array in
+// the OpenAI, Claude, and Gemini request shapes alike, and inline images
+// always carry either an image_url part or an "image/..." media type.
+func detectVirtualRequestNeeds(rawJSON []byte) virtualRequestNeeds {
+	needs := virtualRequestNeeds{approxTokens: len(rawJSON) / 4}
+	if tools := gjson.GetBytes(rawJSON, "tools"); tools.IsArray() && len(tools.Array()) > 0 {
+		needs.tools = true
+	}
+	if bytes.Contains(rawJSON, []byte(`"image_url"`)) || bytes.Contains(rawJSON, []byte(`image/`)) {
+		needs.vision = true
+	}
+	return needs
+}
+
+// candidateSatisfies reports whether the candidate can serve the request.
+// Declared capabilities only restrict when present; a candidate with no list
+// is assumed fully capable. Context length is checked against the registry
+// when the model declares one.
+func candidateSatisfies(cand *config.VirtualModelCandidate, needs virtualRequestNeeds) bool {
+	if len(cand.Capabilities) > 0 {
+		declared := make(map[string]bool, len(cand.Capabilities))
+		for _, capability := range cand.Capabilities {
+			declared[strings.ToLower(strings.TrimSpace(capability))] = true
+		}
+		if needs.tools && !declared["tools"] {
+			return false
+		}
+		if needs.vision && !declared["vision"] {
+			return false
+		}
+	}
+	if limit := candidateContextLimit(cand.Model); limit > 0 && needs.approxTokens > limit {
+		return false
+	}
+	return true
+}
+
+// candidateContextLimit returns the model's input window from the registry,
+// preferring the explicit input token limit over the total context length.
+// 0 means unknown, which never filters.
+func candidateContextLimit(model string) int {
+	providers := util.GetProviderName(model)
+	for _, provider := range append(providers, "") {
+		info := registry.GetGlobalRegistry().GetModelInfo(model, provider)
+		if info == nil {
+			continue
+		}
+		if info.InputTokenLimit > 0 {
+			return info.InputTokenLimit
+		}
+		if info.ContextLength > 0 {
+			return info.ContextLength
+		}
+	}
+	return 0
+}
+
+// virtualCandidateCost scores a candidate by its pricing-table rates (input
+// plus output USD per million tokens). Models without pricing sort after
+// priced ones so unknown cost never beats known cost.
+func virtualCandidateCost(model string) (float64, bool) {
+	pricing, ok := registry.LookupModelPricing(model)
+	if !ok {
+		return 0, false
+	}
+	return pricing.InputUSDPerMillion + pricing.OutputUSDPerMillion, true
+}
+
+// orderedVirtualCandidates filters the rule's candidates down to the ones
+// that satisfy the request and sorts them cheapest-first. The alias itself is
+// skipped so a misconfigured rule cannot recurse.
+func (h *BaseAPIHandler) orderedVirtualCandidates(rule *config.VirtualModelRule, rawJSON []byte) []config.VirtualModelCandidate {
+	needs := detectVirtualRequestNeeds(rawJSON)
+	eligible := make([]config.VirtualModelCandidate, 0, len(rule.Candidates))
+	for _, cand := range rule.Candidates {
+		model := strings.TrimSpace(cand.Model)
+		if model == "" || strings.EqualFold(model, strings.TrimSpace(rule.Alias)) {
+			continue
+		}
+		if len(util.GetProviderName(model)) == 0 {
+			continue
+		}
+		if !candidateSatisfies(&cand, needs) {
+			continue
+		}
+		eligible = append(eligible, cand)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		costI, okI := virtualCandidateCost(eligible[i].Model)
+		costJ, okJ := virtualCandidateCost(eligible[j].Model)
+		if okI != okJ {
+			return okI
+		}
+		return okI && costI < costJ
+	})
+	return eligible
+}
+
+// virtualFallbackEligible reports whether a candidate failure should move the
+// request up the cost ladder. Client-side errors like a malformed payload
+// would fail identically everywhere, so only availability-shaped statuses
+// (and transport errors with no status) advance.
+func virtualFallbackEligible(status int) bool {
+	switch status {
+	case 0, http.StatusUnauthorized, http.StatusPaymentRequired, http.StatusForbidden,
+		http.StatusNotFound, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	default:
+		return status >= http.StatusInternalServerError
+	}
+}
+
+// executeVirtualModel resolves a virtual alias for a non-streaming request:
+// candidates are tried cheapest-first and availability failures fall back to
+// the next-cheapest one.
+func (h *BaseAPIHandler) executeVirtualModel(ctx context.Context, handlerType string, rule *config.VirtualModelRule, rawJSON []byte, alt string) ([]byte, *interfaces.ErrorMessage) {
+	candidates := h.orderedVirtualCandidates(rule, rawJSON)
+	if len(candidates) == 0 {
+		return nil, &interfaces.ErrorMessage{
+			StatusCode: http.StatusBadRequest,
+			Error:      fmt.Errorf("virtual model %s has no candidate satisfying the request", rule.Alias),
+		}
+	}
+	var lastErr *interfaces.ErrorMessage
+	for i, cand := range candidates {
+		payload, errMsg := h.ExecuteWithAuthManager(ctx, handlerType, cand.Model, rawJSON, alt)
+		if errMsg == nil {
+			return payload, nil
+		}
+		lastErr = errMsg
+		if ctx != nil && ctx.Err() != nil {
+			break
+		}
+		if !virtualFallbackEligible(errMsg.StatusCode) {
+			break
+		}
+		if i < len(candidates)-1 {
+			log.Debugf("virtual model %s: candidate %s failed (status %d), trying %s",
+				rule.Alias, cand.Model, errMsg.StatusCode, candidates[i+1].Model)
+		}
+	}
+	return nil, lastErr
+}
+
+// executeVirtualModelStream resolves a virtual alias for a streaming request.
+// A candidate that fails before emitting any payload falls back to the next
+// one; once bytes have been forwarded the stream is committed and errors pass
+// through unchanged.
+func (h *BaseAPIHandler) executeVirtualModelStream(ctx context.Context, handlerType string, rule *config.VirtualModelRule, rawJSON []byte, alt string) (<-chan []byte, <-chan *interfaces.ErrorMessage) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	candidates := h.orderedVirtualCandidates(rule, rawJSON)
+	if len(candidates) == 0 {
+		errChan := make(chan *interfaces.ErrorMessage, 1)
+		errChan <- &interfaces.ErrorMessage{
+			StatusCode: http.StatusBadRequest,
+			Error:      fmt.Errorf("virtual model %s has no candidate satisfying the request", rule.Alias),
+		}
+		close(errChan)
+		return nil, errChan
+	}
+	outData := make(chan []byte)
+	outErr := make(chan *interfaces.ErrorMessage, 1)
+	go func() {
+		defer close(outData)
+		defer close(outErr)
+		var lastErr *interfaces.ErrorMessage
+		for i, cand := range candidates {
+			dataChan, errChan := h.ExecuteStreamWithAuthManager(ctx, handlerType, cand.Model, rawJSON, alt)
+			sentPayload := false
+			failed := (*interfaces.ErrorMessage)(nil)
+			for dataChan != nil || errChan != nil {
+				select {
+				case chunk, ok := <-dataChan:
+					if !ok {
+						dataChan = nil
+						continue
+					}
+					sentPayload = true
+					select {
+					case outData <- chunk:
+					case <-ctx.Done():
+						return
+					}
+				case errMsg, ok := <-errChan:
+					if !ok {
+						errChan = nil
+						continue
+					}
+					if errMsg == nil {
+						continue
+					}
+					if !sentPayload && virtualFallbackEligible(errMsg.StatusCode) {
+						failed = errMsg
+						errChan = nil
+						dataChan = nil
+						continue
+					}
+					outErr <- errMsg
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+			if failed == nil {
+				return
+			}
+			lastErr = failed
+			if ctx.Err() != nil {
+				break
+			}
+			if i < len(candidates)-1 {
+				log.Debugf("virtual model %s: candidate %s failed (status %d), trying %s",
+					rule.Alias, cand.Model, lastErr.StatusCode, candidates[i+1].Model)
+			}
+		}
+		if lastErr != nil {
+			outErr <- lastErr
+		}
+	}()
+	return outData, outErr
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	sdkconfig "github.com/shariqriazz/modelgate/sdk/config"
+)
+
+func TestDetectVirtualRequestNeeds(t *testing.T) {
+	plain := detectVirtualRequestNeeds([]byte(`{"model":"m","messages":[{"role":"user","content":"hi"}]}`))
+	if plain.tools || plain.vision {
+		t.Fatalf("plain request should need neither tools nor vision, got %+v", plain)
+	}
+	if plain.approxTokens <= 0 {
+		t.Fatalf("expected a positive token estimate, got %d", plain.approxTokens)
+	}
+
+	withTools := detectVirtualRequestNeeds([]byte(`{"tools":[{"type":"function","function":{"name":"f"}}]}`))
+	if !withTools.tools {
+		t.Fatal("request with a tools array should need tools")
+	}
+
+	withImage := detectVirtualRequestNeeds([]byte(`{"messages":[{"content":[{"type":"image_url","image_url":{"url":"data:image/png;base64,AAAA"}}]}]}`))
+	if !withImage.vision {
+		t.Fatal("request with an image part should need vision")
+	}
+}
+
+func TestCandidateSatisfiesCapabilities(t *testing.T) {
+	undeclared := sdkconfig.VirtualModelCandidate{Model: "model-a"}
+	if !candidateSatisfies(&undeclared, virtualRequestNeeds{tools: true, vision: true}) {
+		t.Fatal("candidate without a capabilities list should never be filtered by capability")
+	}
+
+	toolsOnly := sdkconfig.VirtualModelCandidate{Model: "model-b", Capabilities: []string{"tools"}}
+	if !candidateSatisfies(&toolsOnly, virtualRequestNeeds{tools: true}) {
+		t.Fatal("declared tools capability should satisfy a tools request")
+	}
+	if candidateSatisfies(&toolsOnly, virtualRequestNeeds{vision: true}) {
+		t.Fatal("candidate declaring only tools should be skipped for a vision request")
+	}
+}
+
+func TestVirtualFallbackEligible(t *testing.T) {
+	for _, status := range []int{0, http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		if !virtualFallbackEligible(status) {
+			t.Fatalf("status %d should advance the cost ladder", status)
+		}
+	}
+	for _, status := range []int{http.StatusBadRequest, http.StatusUnprocessableEntity} {
+		if virtualFallbackEligible(status) {
+			t.Fatalf("status %d would fail on every candidate and should not advance", status)
+		}
+	}
+}
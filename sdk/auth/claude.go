@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/auth/claude"
+	"github.com/shariqriazz/modelgate/internal/browser"
+	"github.com/shariqriazz/modelgate/internal/config"
+	"github.com/shariqriazz/modelgate/internal/misc"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// ClaudeAuthenticator implements the Anthropic consumer OAuth login flow for
+// claude.ai Pro/Max subscriptions.
+type ClaudeAuthenticator struct{}
+
+// NewClaudeAuthenticator constructs a Claude authenticator.
+func NewClaudeAuthenticator() *ClaudeAuthenticator {
+	return &ClaudeAuthenticator{}
+}
+
+func (a *ClaudeAuthenticator) Provider() string {
+	return "claude"
+}
+
+func (a *ClaudeAuthenticator) RefreshLead() *time.Duration {
+	d := 1 * time.Hour
+	return &d
+}
+
+func (a *ClaudeAuthenticator) Login(ctx context.Context, cfg *config.Config, opts *LoginOptions) (*coreauth.Auth, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("modelgate auth: configuration is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = &LoginOptions{}
+	}
+	if opts.Prompt == nil {
+		return nil, fmt.Errorf("claude authentication requires an interactive prompt")
+	}
+
+	pkceCodes, err := claude.GeneratePKCECodes()
+	if err != nil {
+		return nil, fmt.Errorf("claude pkce generation failed: %w", err)
+	}
+
+	state, err := misc.GenerateRandomState()
+	if err != nil {
+		return nil, fmt.Errorf("claude state generation failed: %w", err)
+	}
+
+	authSvc := claude.NewClaudeAuth(cfg)
+	authURL := authSvc.AuthorizationURL(state, pkceCodes)
+
+	if !opts.NoBrowser {
+		fmt.Println("Opening browser for Claude authentication")
+		if !browser.IsAvailable() {
+			log.Warn("No browser available; please open the URL manually")
+			fmt.Printf("Visit the following URL to continue authentication:\n%s\n", authURL)
+		} else if err = browser.OpenURL(authURL); err != nil {
+			log.Warnf("Failed to open browser automatically: %v", err)
+			fmt.Printf("Visit the following URL to continue authentication:\n%s\n", authURL)
+		}
+	} else {
+		fmt.Printf("Visit the following URL to continue authentication:\n%s\n", authURL)
+	}
+
+	code, err := opts.Prompt("Paste the authorization code shown after approving access: ")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(code) == "" {
+		return nil, fmt.Errorf("claude authentication failed: no authorization code provided")
+	}
+
+	tokenData, err := authSvc.ExchangeCode(ctx, code, state, pkceCodes)
+	if err != nil {
+		return nil, fmt.Errorf("claude authentication failed: %w", err)
+	}
+
+	tokenStorage := authSvc.CreateTokenStorage(tokenData)
+
+	email := tokenStorage.Email
+	if email == "" && opts.Metadata != nil {
+		email = strings.TrimSpace(opts.Metadata["email"])
+	}
+	if email == "" {
+		email, err = opts.Prompt("Please input your email address or alias for Claude:")
+		if err != nil {
+			return nil, err
+		}
+		email = strings.TrimSpace(email)
+	}
+	if email == "" {
+		return nil, &EmailRequiredError{Prompt: "Please provide an email address or alias for Claude."}
+	}
+	tokenStorage.Email = email
+
+	fileName := fmt.Sprintf("claude-%s.json", tokenStorage.Email)
+	metadata := map[string]any{
+		"email": tokenStorage.Email,
+	}
+	if tokenStorage.Subscription != "" {
+		metadata["subscription"] = tokenStorage.Subscription
+	}
+
+	fmt.Println("Claude authentication successful")
+
+	return &coreauth.Auth{
+		ID:       fileName,
+		Provider: a.Provider(),
+		FileName: fileName,
+		Storage:  tokenStorage,
+		Metadata: metadata,
+	}, nil
+}
@@ -13,6 +13,9 @@ import (
 )
 
 // GitHubCopilotAuthenticator implements the OAuth device flow login for GitHub Copilot.
+// The flow is fully built in: it prints the user code and verification URL and
+// polls GitHub's token endpoint directly, so no callback server or local
+// browser is required — pass NoBrowser to run it on a headless machine.
 type GitHubCopilotAuthenticator struct{}
 
 // NewGitHubCopilotAuthenticator constructs a new GitHub Copilot authenticator.
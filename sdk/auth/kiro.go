@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shariqriazz/modelgate/internal/auth/kiro"
+	"github.com/shariqriazz/modelgate/internal/browser"
+	"github.com/shariqriazz/modelgate/internal/config"
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+	log "github.com/sirupsen/logrus"
+)
+
+// KiroAuthenticator implements the IAM Identity Center (SSO) device flow for
+// the Kiro provider, alongside the social login tokens picked up from the
+// Kiro IDE cache.
+type KiroAuthenticator struct{}
+
+// NewKiroAuthenticator constructs a Kiro authenticator.
+func NewKiroAuthenticator() *KiroAuthenticator {
+	return &KiroAuthenticator{}
+}
+
+func (a *KiroAuthenticator) Provider() string {
+	return "kiro"
+}
+
+func (a *KiroAuthenticator) RefreshLead() *time.Duration {
+	d := 15 * time.Minute
+	return &d
+}
+
+func (a *KiroAuthenticator) Login(ctx context.Context, cfg *config.Config, opts *LoginOptions) (*coreauth.Auth, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("modelgate auth: configuration is required")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if opts == nil {
+		opts = &LoginOptions{}
+	}
+
+	startURL := ""
+	region := ""
+	if opts.Metadata != nil {
+		startURL = strings.TrimSpace(opts.Metadata["start-url"])
+		region = strings.TrimSpace(opts.Metadata["region"])
+	}
+	if startURL == "" && opts.Prompt != nil {
+		value, err := opts.Prompt("Please input your AWS access portal start URL (empty for AWS Builder ID):")
+		if err != nil {
+			return nil, err
+		}
+		startURL = strings.TrimSpace(value)
+	}
+	if region == "" && opts.Prompt != nil {
+		value, err := opts.Prompt(fmt.Sprintf("Please input your Identity Center region (empty for %s):", kiro.DefaultSSORegion))
+		if err != nil {
+			return nil, err
+		}
+		region = strings.TrimSpace(value)
+	}
+
+	authSvc := kiro.NewSSOAuth(cfg)
+
+	deviceFlow, err := authSvc.InitiateDeviceFlow(ctx, region, startURL)
+	if err != nil {
+		return nil, fmt.Errorf("kiro device flow initiation failed: %w", err)
+	}
+
+	authURL := deviceFlow.VerificationURIComplete
+	if authURL == "" {
+		authURL = deviceFlow.VerificationURI
+	}
+
+	fmt.Printf("Confirm the code %s to continue authentication\n", deviceFlow.UserCode)
+	if !opts.NoBrowser {
+		fmt.Println("Opening browser for Kiro authentication")
+		if !browser.IsAvailable() {
+			log.Warn("No browser available; please open the URL manually")
+			fmt.Printf("Visit the following URL to continue authentication:\n%s\n", authURL)
+		} else if err = browser.OpenURL(authURL); err != nil {
+			log.Warnf("Failed to open browser automatically: %v", err)
+			fmt.Printf("Visit the following URL to continue authentication:\n%s\n", authURL)
+		}
+	} else {
+		fmt.Printf("Visit the following URL to continue authentication:\n%s\n", authURL)
+	}
+
+	fmt.Println("Waiting for Kiro authentication...")
+
+	tokenData, err := authSvc.PollForToken(ctx, deviceFlow)
+	if err != nil {
+		return nil, fmt.Errorf("kiro authentication failed: %w", err)
+	}
+
+	tokenStorage := authSvc.CreateTokenStorage(tokenData)
+	tokenStorage.Email = kiroSessionLabel(tokenData.StartURL)
+
+	fileName := fmt.Sprintf("kiro-idc-%s.json", tokenStorage.Email)
+	metadata := map[string]any{
+		"email":       tokenStorage.Email,
+		"auth_method": "idc",
+		"region":      tokenData.Region,
+		"start_url":   tokenData.StartURL,
+	}
+
+	fmt.Println("Kiro authentication successful")
+
+	return &coreauth.Auth{
+		ID:       fileName,
+		Provider: a.Provider(),
+		FileName: fileName,
+		Storage:  tokenStorage,
+		Metadata: metadata,
+	}, nil
+}
+
+// kiroSessionLabel derives a stable file label from the access portal URL.
+func kiroSessionLabel(startURL string) string {
+	parsed, err := url.Parse(startURL)
+	if err != nil || parsed.Host == "" {
+		return "builder-id"
+	}
+	label := strings.TrimSuffix(parsed.Host, ".awsapps.com")
+	label = strings.ReplaceAll(label, ".", "-")
+	if label == "" {
+		return "builder-id"
+	}
+	return label
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// burstSmoothingRPS holds the config-wide per-credential dispatch rate in
+// requests per second as IEEE 754 bits; zero disables smoothing.
+var burstSmoothingRPS atomic.Uint64
+
+// SetBurstSmoothingRPS configures the default upstream dispatch rate applied
+// to every credential. A credential can override it with a smoothing_rps
+// attribute; zero or negative disables smoothing.
+func SetBurstSmoothingRPS(rps float64) {
+	if rps < 0 || math.IsNaN(rps) || math.IsInf(rps, 0) {
+		rps = 0
+	}
+	burstSmoothingRPS.Store(math.Float64bits(rps))
+}
+
+// smoothingRateFor resolves the dispatch rate for a credential: the
+// smoothing_rps attribute wins over the config-wide default.
+func smoothingRateFor(auth *Auth) float64 {
+	if auth != nil && auth.Attributes != nil {
+		if raw := strings.TrimSpace(auth.Attributes["smoothing_rps"]); raw != "" {
+			if v, err := strconv.ParseFloat(raw, 64); err == nil {
+				if v < 0 {
+					return 0
+				}
+				return v
+			}
+		}
+	}
+	return math.Float64frombits(burstSmoothingRPS.Load())
+}
+
+// burstSmoother spaces upstream dispatches per credential with a leaky bucket:
+// each dispatch reserves the credential's next free slot, so bursts drain at
+// the configured rate instead of hitting the provider all at once.
+type burstSmoother struct {
+	mu   sync.Mutex
+	next map[string]time.Time
+}
+
+var defaultBurstSmoother = &burstSmoother{next: make(map[string]time.Time)}
+
+// reserve claims the credential's next dispatch slot and advances it by one
+// interval. Slots in the past collapse to now, so an idle credential never
+// accumulates burst credit.
+func (s *burstSmoother) reserve(authID string, interval time.Duration, now time.Time) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	slot := s.next[authID]
+	if slot.Before(now) {
+		slot = now
+	}
+	s.next[authID] = slot.Add(interval)
+	return slot
+}
+
+// smoothDispatch blocks until the credential's next dispatch slot, spacing
+// bursts at the configured rate. It returns immediately when smoothing is
+// disabled and propagates context cancellation while waiting.
+func smoothDispatch(ctx context.Context, auth *Auth) error {
+	if auth == nil || auth.ID == "" {
+		return nil
+	}
+	rps := smoothingRateFor(auth)
+	if rps <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / rps)
+	slot := defaultBurstSmoother.reserve(auth.ID, interval, time.Now())
+	return waitForCooldown(ctx, time.Until(slot))
+}
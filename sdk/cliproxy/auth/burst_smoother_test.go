@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSmoothingRateFor(t *testing.T) {
+	SetBurstSmoothingRPS(2)
+	defer SetBurstSmoothingRPS(0)
+
+	cases := []struct {
+		name string
+		auth *Auth
+		want float64
+	}{
+		{name: "global default", auth: &Auth{ID: "a"}, want: 2},
+		{name: "attribute override", auth: &Auth{ID: "a", Attributes: map[string]string{"smoothing_rps": "0.5"}}, want: 0.5},
+		{name: "negative attribute disables", auth: &Auth{ID: "a", Attributes: map[string]string{"smoothing_rps": "-1"}}, want: 0},
+		{name: "invalid attribute falls back", auth: &Auth{ID: "a", Attributes: map[string]string{"smoothing_rps": "fast"}}, want: 2},
+		{name: "nil auth uses global", auth: nil, want: 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := smoothingRateFor(tc.auth); got != tc.want {
+				t.Fatalf("smoothingRateFor = %g, want %g", got, tc.want)
+			}
+		})
+	}
+
+	SetBurstSmoothingRPS(-3)
+	if got := smoothingRateFor(&Auth{ID: "a"}); got != 0 {
+		t.Fatalf("negative global rate = %g, want 0", got)
+	}
+}
+
+func TestBurstSmootherReserve(t *testing.T) {
+	smoother := &burstSmoother{next: make(map[string]time.Time)}
+	now := time.Unix(1000, 0)
+	interval := 100 * time.Millisecond
+
+	if slot := smoother.reserve("auth-1", interval, now); !slot.Equal(now) {
+		t.Fatalf("first slot = %s, want now", slot)
+	}
+	if slot := smoother.reserve("auth-1", interval, now); !slot.Equal(now.Add(interval)) {
+		t.Fatalf("second slot = %s, want now+interval", slot)
+	}
+	if slot := smoother.reserve("auth-1", interval, now); !slot.Equal(now.Add(2 * interval)) {
+		t.Fatalf("third slot = %s, want now+2*interval", slot)
+	}
+	// Separate credentials do not share a bucket.
+	if slot := smoother.reserve("auth-2", interval, now); !slot.Equal(now) {
+		t.Fatalf("other auth slot = %s, want now", slot)
+	}
+	// Idle time collapses the slot to now instead of granting burst credit.
+	later := now.Add(time.Hour)
+	if slot := smoother.reserve("auth-1", interval, later); !slot.Equal(later) {
+		t.Fatalf("post-idle slot = %s, want later", slot)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shariqriazz/modelgate/internal/audit"
 	"github.com/shariqriazz/modelgate/internal/logging"
 	"github.com/shariqriazz/modelgate/internal/registry"
 	"github.com/shariqriazz/modelgate/internal/util"
@@ -39,6 +41,49 @@ type ProviderExecutor interface {
 	HttpRequest(ctx context.Context, auth *Auth, req *http.Request) (*http.Response, error)
 }
 
+// EmbeddingsExecutor is an optional capability interface for providers that can
+// serve embeddings requests. Executors that implement it receive /v1/embeddings
+// traffic through the same selection, retry, and usage accounting machinery as
+// chat; providers that do not implement it are skipped with a not_implemented
+// error.
+type EmbeddingsExecutor interface {
+	// ExecuteEmbeddings handles a non-streaming embeddings request and returns
+	// the provider response payload in OpenAI embeddings format.
+	ExecuteEmbeddings(ctx context.Context, auth *Auth, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error)
+}
+
+// ImagesExecutor is an optional capability interface for providers that can
+// serve image generation requests. Executors that implement it receive
+// /v1/images/generations traffic through the same selection, retry, and usage
+// accounting machinery as chat; providers that do not implement it are skipped
+// with a not_implemented error.
+type ImagesExecutor interface {
+	// ExecuteImages handles a non-streaming image generation request and returns
+	// the provider response payload in OpenAI images format.
+	ExecuteImages(ctx context.Context, auth *Auth, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error)
+}
+
+// TranscriptionsExecutor is an optional capability interface for providers that
+// can serve audio transcription requests. The request payload is the internal
+// JSON envelope built by the front end from the multipart upload; executors that
+// implement it receive /v1/audio/transcriptions traffic through the same
+// selection, retry, and usage accounting machinery as chat.
+type TranscriptionsExecutor interface {
+	// ExecuteTranscriptions handles a non-streaming transcription request and
+	// returns the provider response payload in OpenAI transcription format.
+	ExecuteTranscriptions(ctx context.Context, auth *Auth, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error)
+}
+
+// SpeechExecutor is an optional capability interface for providers that can
+// serve text-to-speech requests. The returned chunks carry raw audio bytes so
+// the front end can pass the upstream stream through unchanged; providers that
+// do not implement it are skipped with a not_implemented error.
+type SpeechExecutor interface {
+	// ExecuteSpeech handles a text-to-speech request and returns a channel of
+	// raw audio chunks.
+	ExecuteSpeech(ctx context.Context, auth *Auth, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (<-chan cliproxyexecutor.StreamChunk, error)
+}
+
 // RefreshEvaluator allows runtime state to override refresh decisions.
 type RefreshEvaluator interface {
 	ShouldRefresh(now time.Time, auth *Auth) bool
@@ -73,6 +118,8 @@ type Result struct {
 	RetryAfter *time.Duration
 	// Error describes the failure when Success is false.
 	Error *Error
+	// Duration is the wall-clock time the execution attempt took, when known.
+	Duration time.Duration
 }
 
 // Selector chooses an auth candidate for execution.
@@ -80,6 +127,13 @@ type Selector interface {
 	Pick(ctx context.Context, provider, model string, opts cliproxyexecutor.Options, auths []*Auth) (*Auth, error)
 }
 
+// ResultObserver is an optional interface selectors can implement to be
+// notified when an execution attempt completes, e.g. to release concurrency
+// slots reserved during Pick.
+type ResultObserver interface {
+	ObserveResult(result Result)
+}
+
 // Hook captures lifecycle callbacks for observing auth changes.
 type Hook interface {
 	// OnAuthRegistered fires when a new auth is registered.
@@ -221,6 +275,7 @@ func (m *Manager) Register(ctx context.Context, auth *Auth) (*Auth, error) {
 	m.mu.Unlock()
 	_ = m.persist(ctx, auth)
 	m.hook.OnAuthRegistered(ctx, auth.Clone())
+	audit.Record("runtime", "auth-register", auth.Provider+"/"+auth.ID, strings.TrimSpace(auth.Label))
 	return auth.Clone(), nil
 }
 
@@ -239,6 +294,11 @@ func (m *Manager) Update(ctx context.Context, auth *Auth) (*Auth, error) {
 	m.mu.Unlock()
 	_ = m.persist(ctx, auth)
 	m.hook.OnAuthUpdated(ctx, auth.Clone())
+	detail := strings.TrimSpace(auth.Label)
+	if auth.Disabled {
+		detail = strings.TrimSpace(detail + " disabled")
+	}
+	audit.Record("runtime", "auth-update", auth.Provider+"/"+auth.ID, detail)
 	return auth.Clone(), nil
 }
 
@@ -271,20 +331,49 @@ func (m *Manager) Execute(ctx context.Context, providers []string, req cliproxye
 	if len(normalized) == 0 {
 		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
 	}
+	if key, ok := coalesceKey(req, opts); ok {
+		return defaultRequestCoalescer.do(ctx, key, func() (cliproxyexecutor.Response, error) {
+			return m.executeWithRetries(ctx, normalized, req, opts)
+		})
+	}
+	return m.executeWithRetries(ctx, normalized, req, opts)
+}
 
+// executeWithRetries runs the retry loop around executeMixedOnce for an
+// already-normalized provider list.
+func (m *Manager) executeWithRetries(ctx context.Context, normalized []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
 	retryTimes, maxWait := m.retrySettings()
 	attempts := retryTimes + 1
 	if attempts < 1 {
 		attempts = 1
 	}
 
+	started := time.Now()
 	var lastErr error
+	var held time.Duration
+	var upstreamRetries int
 	for attempt := 0; attempt < attempts; attempt++ {
 		resp, errExec := m.executeMixedOnce(ctx, normalized, req, opts)
 		if errExec == nil {
 			return resp, nil
 		}
 		lastErr = errExec
+		if wait, hold := quotaHoldWait(errExec, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return cliproxyexecutor.Response{}, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
+		if delay, retry := upstreamRetryDelay(errExec, upstreamRetries, started); retry {
+			if errWait := waitForCooldown(ctx, delay); errWait != nil {
+				return cliproxyexecutor.Response{}, errWait
+			}
+			upstreamRetries++
+			attempt-- // 5xx retries have their own attempt and latency budget
+			continue
+		}
 		wait, shouldRetry := m.shouldRetryAfterError(errExec, attempt, attempts, normalized, req.Model, maxWait)
 		if !shouldRetry {
 			break
@@ -314,12 +403,156 @@ func (m *Manager) ExecuteCount(ctx context.Context, providers []string, req clip
 	}
 
 	var lastErr error
+	var held time.Duration
 	for attempt := 0; attempt < attempts; attempt++ {
 		resp, errExec := m.executeCountMixedOnce(ctx, normalized, req, opts)
 		if errExec == nil {
 			return resp, nil
 		}
 		lastErr = errExec
+		if wait, hold := quotaHoldWait(errExec, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return cliproxyexecutor.Response{}, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
+		wait, shouldRetry := m.shouldRetryAfterError(errExec, attempt, attempts, normalized, req.Model, maxWait)
+		if !shouldRetry {
+			break
+		}
+		if errWait := waitForCooldown(ctx, wait); errWait != nil {
+			return cliproxyexecutor.Response{}, errWait
+		}
+	}
+	if lastErr != nil {
+		return cliproxyexecutor.Response{}, lastErr
+	}
+	return cliproxyexecutor.Response{}, &Error{Code: "auth_not_found", Message: "no auth available"}
+}
+
+// ExecuteEmbeddings performs a non-streaming embeddings execution using the configured
+// selector and executor. Only auths whose executor implements EmbeddingsExecutor are
+// eligible; others are skipped within the attempt.
+func (m *Manager) ExecuteEmbeddings(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	normalized := m.normalizeProviders(providers)
+	if len(normalized) == 0 {
+		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+
+	retryTimes, maxWait := m.retrySettings()
+	attempts := retryTimes + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	var held time.Duration
+	for attempt := 0; attempt < attempts; attempt++ {
+		resp, errExec := m.executeEmbeddingsMixedOnce(ctx, normalized, req, opts)
+		if errExec == nil {
+			return resp, nil
+		}
+		lastErr = errExec
+		if wait, hold := quotaHoldWait(errExec, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return cliproxyexecutor.Response{}, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
+		wait, shouldRetry := m.shouldRetryAfterError(errExec, attempt, attempts, normalized, req.Model, maxWait)
+		if !shouldRetry {
+			break
+		}
+		if errWait := waitForCooldown(ctx, wait); errWait != nil {
+			return cliproxyexecutor.Response{}, errWait
+		}
+	}
+	if lastErr != nil {
+		return cliproxyexecutor.Response{}, lastErr
+	}
+	return cliproxyexecutor.Response{}, &Error{Code: "auth_not_found", Message: "no auth available"}
+}
+
+// ExecuteImages performs a non-streaming image generation execution using the
+// configured selector and executor. Only auths whose executor implements
+// ImagesExecutor are eligible; others are skipped within the attempt.
+func (m *Manager) ExecuteImages(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	normalized := m.normalizeProviders(providers)
+	if len(normalized) == 0 {
+		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+
+	retryTimes, maxWait := m.retrySettings()
+	attempts := retryTimes + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	var held time.Duration
+	for attempt := 0; attempt < attempts; attempt++ {
+		resp, errExec := m.executeImagesMixedOnce(ctx, normalized, req, opts)
+		if errExec == nil {
+			return resp, nil
+		}
+		lastErr = errExec
+		if wait, hold := quotaHoldWait(errExec, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return cliproxyexecutor.Response{}, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
+		wait, shouldRetry := m.shouldRetryAfterError(errExec, attempt, attempts, normalized, req.Model, maxWait)
+		if !shouldRetry {
+			break
+		}
+		if errWait := waitForCooldown(ctx, wait); errWait != nil {
+			return cliproxyexecutor.Response{}, errWait
+		}
+	}
+	if lastErr != nil {
+		return cliproxyexecutor.Response{}, lastErr
+	}
+	return cliproxyexecutor.Response{}, &Error{Code: "auth_not_found", Message: "no auth available"}
+}
+
+// ExecuteTranscriptions performs a non-streaming transcription execution using
+// the configured selector and executor. Only auths whose executor implements
+// TranscriptionsExecutor are eligible; others are skipped within the attempt.
+func (m *Manager) ExecuteTranscriptions(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	normalized := m.normalizeProviders(providers)
+	if len(normalized) == 0 {
+		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+
+	retryTimes, maxWait := m.retrySettings()
+	attempts := retryTimes + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	var held time.Duration
+	for attempt := 0; attempt < attempts; attempt++ {
+		resp, errExec := m.executeTranscriptionsMixedOnce(ctx, normalized, req, opts)
+		if errExec == nil {
+			return resp, nil
+		}
+		lastErr = errExec
+		if wait, hold := quotaHoldWait(errExec, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return cliproxyexecutor.Response{}, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
 		wait, shouldRetry := m.shouldRetryAfterError(errExec, attempt, attempts, normalized, req.Model, maxWait)
 		if !shouldRetry {
 			break
@@ -349,12 +582,21 @@ func (m *Manager) ExecuteStream(ctx context.Context, providers []string, req cli
 	}
 
 	var lastErr error
+	var held time.Duration
 	for attempt := 0; attempt < attempts; attempt++ {
 		chunks, errStream := m.executeStreamMixedOnce(ctx, normalized, req, opts)
 		if errStream == nil {
 			return chunks, nil
 		}
 		lastErr = errStream
+		if wait, hold := quotaHoldWait(errStream, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return nil, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
 		wait, shouldRetry := m.shouldRetryAfterError(errStream, attempt, attempts, normalized, req.Model, maxWait)
 		if !shouldRetry {
 			break
@@ -374,6 +616,7 @@ func (m *Manager) executeMixedOnce(ctx context.Context, providers []string, req
 		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
 	}
 	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
 	tried := make(map[string]struct{})
 	var lastErr error
 	for {
@@ -397,8 +640,9 @@ func (m *Manager) executeMixedOnce(ctx context.Context, providers []string, req
 		execReq := req
 		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
 		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
-		resp, errExec := executor.Execute(execCtx, auth, execReq, opts)
-		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil}
+		started := time.Now()
+		resp, errExec := m.executeOrBuffer(execCtx, executor, auth, execReq, opts)
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
 		if errExec != nil {
 			result.Error = &Error{Message: errExec.Error()}
 			var se cliproxyexecutor.StatusError
@@ -422,6 +666,7 @@ func (m *Manager) executeCountMixedOnce(ctx context.Context, providers []string,
 		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
 	}
 	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
 	tried := make(map[string]struct{})
 	var lastErr error
 	for {
@@ -445,8 +690,9 @@ func (m *Manager) executeCountMixedOnce(ctx context.Context, providers []string,
 		execReq := req
 		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
 		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
+		started := time.Now()
 		resp, errExec := executor.CountTokens(execCtx, auth, execReq, opts)
-		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil}
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
 		if errExec != nil {
 			result.Error = &Error{Message: errExec.Error()}
 			var se cliproxyexecutor.StatusError
@@ -465,11 +711,294 @@ func (m *Manager) executeCountMixedOnce(ctx context.Context, providers []string,
 	}
 }
 
+func (m *Manager) executeEmbeddingsMixedOnce(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	if len(providers) == 0 {
+		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
+	tried := make(map[string]struct{})
+	var lastErr error
+	for {
+		auth, executor, provider, errPick := m.pickNextMixed(ctx, providers, routeModel, opts, tried)
+		if errPick != nil {
+			if lastErr != nil {
+				return cliproxyexecutor.Response{}, lastErr
+			}
+			return cliproxyexecutor.Response{}, errPick
+		}
+
+		entry := logEntryWithRequestID(ctx)
+		debugLogAuthSelection(entry, auth, provider, req.Model)
+
+		tried[auth.ID] = struct{}{}
+		embedder, ok := executor.(EmbeddingsExecutor)
+		if !ok {
+			lastErr = &Error{Code: "not_implemented", Message: fmt.Sprintf("provider %s does not support embeddings", provider)}
+			continue
+		}
+		execCtx := ctx
+		if rt := m.roundTripperFor(auth); rt != nil {
+			execCtx = context.WithValue(execCtx, roundTripperContextKey{}, rt)
+			execCtx = context.WithValue(execCtx, "cliproxy.roundtripper", rt)
+		}
+		execReq := req
+		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
+		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
+		started := time.Now()
+		resp, errExec := embedder.ExecuteEmbeddings(execCtx, auth, execReq, opts)
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
+		if errExec != nil {
+			result.Error = &Error{Message: errExec.Error()}
+			var se cliproxyexecutor.StatusError
+			if errors.As(errExec, &se) && se != nil {
+				result.Error.HTTPStatus = se.StatusCode()
+			}
+			if ra := retryAfterFromError(errExec); ra != nil {
+				result.RetryAfter = ra
+			}
+			m.MarkResult(execCtx, result)
+			lastErr = errExec
+			continue
+		}
+		m.MarkResult(execCtx, result)
+		return resp, nil
+	}
+}
+
+func (m *Manager) executeImagesMixedOnce(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	if len(providers) == 0 {
+		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
+	tried := make(map[string]struct{})
+	var lastErr error
+	for {
+		auth, executor, provider, errPick := m.pickNextMixed(ctx, providers, routeModel, opts, tried)
+		if errPick != nil {
+			if lastErr != nil {
+				return cliproxyexecutor.Response{}, lastErr
+			}
+			return cliproxyexecutor.Response{}, errPick
+		}
+
+		entry := logEntryWithRequestID(ctx)
+		debugLogAuthSelection(entry, auth, provider, req.Model)
+
+		tried[auth.ID] = struct{}{}
+		imager, ok := executor.(ImagesExecutor)
+		if !ok {
+			lastErr = &Error{Code: "not_implemented", Message: fmt.Sprintf("provider %s does not support image generation", provider)}
+			continue
+		}
+		execCtx := ctx
+		if rt := m.roundTripperFor(auth); rt != nil {
+			execCtx = context.WithValue(execCtx, roundTripperContextKey{}, rt)
+			execCtx = context.WithValue(execCtx, "cliproxy.roundtripper", rt)
+		}
+		execReq := req
+		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
+		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
+		started := time.Now()
+		resp, errExec := imager.ExecuteImages(execCtx, auth, execReq, opts)
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
+		if errExec != nil {
+			result.Error = &Error{Message: errExec.Error()}
+			var se cliproxyexecutor.StatusError
+			if errors.As(errExec, &se) && se != nil {
+				result.Error.HTTPStatus = se.StatusCode()
+			}
+			if ra := retryAfterFromError(errExec); ra != nil {
+				result.RetryAfter = ra
+			}
+			m.MarkResult(execCtx, result)
+			lastErr = errExec
+			continue
+		}
+		m.MarkResult(execCtx, result)
+		return resp, nil
+	}
+}
+
+func (m *Manager) executeTranscriptionsMixedOnce(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	if len(providers) == 0 {
+		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
+	tried := make(map[string]struct{})
+	var lastErr error
+	for {
+		auth, executor, provider, errPick := m.pickNextMixed(ctx, providers, routeModel, opts, tried)
+		if errPick != nil {
+			if lastErr != nil {
+				return cliproxyexecutor.Response{}, lastErr
+			}
+			return cliproxyexecutor.Response{}, errPick
+		}
+
+		entry := logEntryWithRequestID(ctx)
+		debugLogAuthSelection(entry, auth, provider, req.Model)
+
+		tried[auth.ID] = struct{}{}
+		transcriber, ok := executor.(TranscriptionsExecutor)
+		if !ok {
+			lastErr = &Error{Code: "not_implemented", Message: fmt.Sprintf("provider %s does not support transcriptions", provider)}
+			continue
+		}
+		execCtx := ctx
+		if rt := m.roundTripperFor(auth); rt != nil {
+			execCtx = context.WithValue(execCtx, roundTripperContextKey{}, rt)
+			execCtx = context.WithValue(execCtx, "cliproxy.roundtripper", rt)
+		}
+		execReq := req
+		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
+		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
+		started := time.Now()
+		resp, errExec := transcriber.ExecuteTranscriptions(execCtx, auth, execReq, opts)
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
+		if errExec != nil {
+			result.Error = &Error{Message: errExec.Error()}
+			var se cliproxyexecutor.StatusError
+			if errors.As(errExec, &se) && se != nil {
+				result.Error.HTTPStatus = se.StatusCode()
+			}
+			if ra := retryAfterFromError(errExec); ra != nil {
+				result.RetryAfter = ra
+			}
+			m.MarkResult(execCtx, result)
+			lastErr = errExec
+			continue
+		}
+		m.MarkResult(execCtx, result)
+		return resp, nil
+	}
+}
+
+// ExecuteSpeech performs a streaming text-to-speech execution using the
+// configured selector and executor. Only auths whose executor implements
+// SpeechExecutor are eligible; others are skipped within the attempt.
+func (m *Manager) ExecuteSpeech(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (<-chan cliproxyexecutor.StreamChunk, error) {
+	normalized := m.normalizeProviders(providers)
+	if len(normalized) == 0 {
+		return nil, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+
+	retryTimes, maxWait := m.retrySettings()
+	attempts := retryTimes + 1
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var lastErr error
+	var held time.Duration
+	for attempt := 0; attempt < attempts; attempt++ {
+		chunks, errStream := m.executeSpeechMixedOnce(ctx, normalized, req, opts)
+		if errStream == nil {
+			return chunks, nil
+		}
+		lastErr = errStream
+		if wait, hold := quotaHoldWait(errStream, held); hold {
+			if errWait := waitForCooldown(ctx, wait); errWait != nil {
+				return nil, errWait
+			}
+			held += wait
+			attempt-- // held requests do not consume the retry budget
+			continue
+		}
+		wait, shouldRetry := m.shouldRetryAfterError(errStream, attempt, attempts, normalized, req.Model, maxWait)
+		if !shouldRetry {
+			break
+		}
+		if errWait := waitForCooldown(ctx, wait); errWait != nil {
+			return nil, errWait
+		}
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, &Error{Code: "auth_not_found", Message: "no auth available"}
+}
+
+func (m *Manager) executeSpeechMixedOnce(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (<-chan cliproxyexecutor.StreamChunk, error) {
+	if len(providers) == 0 {
+		return nil, &Error{Code: "provider_not_found", Message: "no provider supplied"}
+	}
+	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
+	tried := make(map[string]struct{})
+	var lastErr error
+	for {
+		auth, executor, provider, errPick := m.pickNextMixed(ctx, providers, routeModel, opts, tried)
+		if errPick != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, errPick
+		}
+
+		entry := logEntryWithRequestID(ctx)
+		debugLogAuthSelection(entry, auth, provider, req.Model)
+
+		tried[auth.ID] = struct{}{}
+		speaker, ok := executor.(SpeechExecutor)
+		if !ok {
+			lastErr = &Error{Code: "not_implemented", Message: fmt.Sprintf("provider %s does not support speech synthesis", provider)}
+			continue
+		}
+		execCtx := ctx
+		if rt := m.roundTripperFor(auth); rt != nil {
+			execCtx = context.WithValue(execCtx, roundTripperContextKey{}, rt)
+			execCtx = context.WithValue(execCtx, "cliproxy.roundtripper", rt)
+		}
+		execReq := req
+		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
+		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
+		started := time.Now()
+		chunks, errStream := speaker.ExecuteSpeech(execCtx, auth, execReq, opts)
+		if errStream != nil {
+			rerr := &Error{Message: errStream.Error()}
+			var se cliproxyexecutor.StatusError
+			if errors.As(errStream, &se) && se != nil {
+				rerr.HTTPStatus = se.StatusCode()
+			}
+			result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: false, Error: rerr, Duration: time.Since(started)}
+			result.RetryAfter = retryAfterFromError(errStream)
+			m.MarkResult(execCtx, result)
+			lastErr = errStream
+			continue
+		}
+		out := make(chan cliproxyexecutor.StreamChunk)
+		go func(streamCtx context.Context, streamAuth *Auth, streamProvider string, streamChunks <-chan cliproxyexecutor.StreamChunk) {
+			defer close(out)
+			var failed bool
+			for chunk := range streamChunks {
+				if chunk.Err != nil && !failed {
+					failed = true
+					rerr := &Error{Message: chunk.Err.Error()}
+					var se cliproxyexecutor.StatusError
+					if errors.As(chunk.Err, &se) && se != nil {
+						rerr.HTTPStatus = se.StatusCode()
+					}
+					m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: false, Error: rerr, Duration: time.Since(started)})
+				}
+				out <- chunk
+			}
+			if !failed {
+				m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: true, Duration: time.Since(started)})
+			}
+		}(execCtx, auth.Clone(), provider, chunks)
+		return out, nil
+	}
+}
+
 func (m *Manager) executeStreamMixedOnce(ctx context.Context, providers []string, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (<-chan cliproxyexecutor.StreamChunk, error) {
 	if len(providers) == 0 {
 		return nil, &Error{Code: "provider_not_found", Message: "no provider supplied"}
 	}
 	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
 	tried := make(map[string]struct{})
 	var lastErr error
 	for {
@@ -493,14 +1022,15 @@ func (m *Manager) executeStreamMixedOnce(ctx context.Context, providers []string
 		execReq := req
 		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
 		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
-		chunks, errStream := executor.ExecuteStream(execCtx, auth, execReq, opts)
+		started := time.Now()
+		chunks, errStream := m.streamOrSynthesize(execCtx, executor, auth, execReq, opts)
 		if errStream != nil {
 			rerr := &Error{Message: errStream.Error()}
 			var se cliproxyexecutor.StatusError
 			if errors.As(errStream, &se) && se != nil {
 				rerr.HTTPStatus = se.StatusCode()
 			}
-			result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: false, Error: rerr}
+			result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: false, Error: rerr, Duration: time.Since(started)}
 			result.RetryAfter = retryAfterFromError(errStream)
 			m.MarkResult(execCtx, result)
 			lastErr = errStream
@@ -518,12 +1048,12 @@ func (m *Manager) executeStreamMixedOnce(ctx context.Context, providers []string
 					if errors.As(chunk.Err, &se) && se != nil {
 						rerr.HTTPStatus = se.StatusCode()
 					}
-					m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: false, Error: rerr})
+					m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: false, Error: rerr, Duration: time.Since(started)})
 				}
 				out <- chunk
 			}
 			if !failed {
-				m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: true})
+				m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: true, Duration: time.Since(started)})
 			}
 		}(execCtx, auth.Clone(), provider, chunks)
 		return out, nil
@@ -535,6 +1065,7 @@ func (m *Manager) executeWithProvider(ctx context.Context, provider string, req
 		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "provider identifier is empty"}
 	}
 	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
 	tried := make(map[string]struct{})
 	var lastErr error
 	for {
@@ -558,8 +1089,9 @@ func (m *Manager) executeWithProvider(ctx context.Context, provider string, req
 		execReq := req
 		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
 		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
-		resp, errExec := executor.Execute(execCtx, auth, execReq, opts)
-		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil}
+		started := time.Now()
+		resp, errExec := m.executeOrBuffer(execCtx, executor, auth, execReq, opts)
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
 		if errExec != nil {
 			result.Error = &Error{Message: errExec.Error()}
 			var se cliproxyexecutor.StatusError
@@ -583,6 +1115,7 @@ func (m *Manager) executeCountWithProvider(ctx context.Context, provider string,
 		return cliproxyexecutor.Response{}, &Error{Code: "provider_not_found", Message: "provider identifier is empty"}
 	}
 	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
 	tried := make(map[string]struct{})
 	var lastErr error
 	for {
@@ -606,8 +1139,9 @@ func (m *Manager) executeCountWithProvider(ctx context.Context, provider string,
 		execReq := req
 		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
 		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
+		started := time.Now()
 		resp, errExec := executor.CountTokens(execCtx, auth, execReq, opts)
-		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil}
+		result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: errExec == nil, Duration: time.Since(started)}
 		if errExec != nil {
 			result.Error = &Error{Message: errExec.Error()}
 			var se cliproxyexecutor.StatusError
@@ -631,6 +1165,7 @@ func (m *Manager) executeStreamWithProvider(ctx context.Context, provider string
 		return nil, &Error{Code: "provider_not_found", Message: "provider identifier is empty"}
 	}
 	routeModel := req.Model
+	opts = withSessionPinOption(opts, sessionPinKey(req))
 	tried := make(map[string]struct{})
 	var lastErr error
 	for {
@@ -654,14 +1189,15 @@ func (m *Manager) executeStreamWithProvider(ctx context.Context, provider string
 		execReq := req
 		execReq.Model, execReq.Metadata = rewriteModelForAuth(routeModel, req.Metadata, auth)
 		execReq.Model, execReq.Metadata = m.applyOAuthModelMapping(auth, execReq.Model, execReq.Metadata)
-		chunks, errStream := executor.ExecuteStream(execCtx, auth, execReq, opts)
+		started := time.Now()
+		chunks, errStream := m.streamOrSynthesize(execCtx, executor, auth, execReq, opts)
 		if errStream != nil {
 			rerr := &Error{Message: errStream.Error()}
 			var se cliproxyexecutor.StatusError
 			if errors.As(errStream, &se) && se != nil {
 				rerr.HTTPStatus = se.StatusCode()
 			}
-			result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: false, Error: rerr}
+			result := Result{AuthID: auth.ID, Provider: provider, Model: routeModel, Success: false, Error: rerr, Duration: time.Since(started)}
 			result.RetryAfter = retryAfterFromError(errStream)
 			m.MarkResult(execCtx, result)
 			lastErr = errStream
@@ -679,12 +1215,12 @@ func (m *Manager) executeStreamWithProvider(ctx context.Context, provider string
 					if errors.As(chunk.Err, &se) && se != nil {
 						rerr.HTTPStatus = se.StatusCode()
 					}
-					m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: false, Error: rerr})
+					m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: false, Error: rerr, Duration: time.Since(started)})
 				}
 				out <- chunk
 			}
 			if !failed {
-				m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: true})
+				m.MarkResult(streamCtx, Result{AuthID: streamAuth.ID, Provider: streamProvider, Model: routeModel, Success: true, Duration: time.Since(started)})
 			}
 		}(execCtx, auth.Clone(), provider, chunks)
 		return out, nil
@@ -927,7 +1463,8 @@ func (m *Manager) MarkResult(ctx context.Context, result Result) {
 				state := ensureModelState(auth, result.Model)
 				resetModelState(state, now)
 				updateAggregatedAvailability(auth, now)
-				if !hasModelError(auth, now) {
+				// In-flight successes must not un-drain an auth parked for maintenance.
+				if !hasModelError(auth, now) && auth.Status != StatusDraining {
 					auth.LastError = nil
 					auth.StatusMessage = ""
 					auth.Status = StatusActive
@@ -1021,9 +1558,54 @@ func (m *Manager) MarkResult(ctx context.Context, result Result) {
 		registry.GetGlobalRegistry().SuspendClientModel(result.AuthID, result.Model, suspendReason)
 	}
 
+	defaultAuthHealthTracker.record(result.AuthID, result.Duration, !result.Success, time.Now())
+
+	if transition := defaultAuthRollouts.observe(result.AuthID, result.Success); transition != nil {
+		m.applyRolloutTransition(transition)
+	}
+
+	fields := log.Fields{
+		"provider":   result.Provider,
+		"auth_id":    result.AuthID,
+		"model":      result.Model,
+		"latency_ms": result.Duration.Milliseconds(),
+		"success":    result.Success,
+	}
+	if !result.Success && result.Error != nil {
+		category := cliproxyexecutor.Classify(result.Error.HTTPStatus, result.Error.Message)
+		fields["error_category"] = string(category)
+		defaultErrorMetricsTracker.record(result.Provider, category, result.Error.HTTPStatus, result.Error.Message, time.Now())
+	}
+	logEntryWithRequestID(ctx).WithFields(fields).Debug("execution result")
+
+	if observer, ok := m.selector.(ResultObserver); ok {
+		observer.ObserveResult(result)
+	}
 	m.hook.OnResult(ctx, result)
 }
 
+// applyRolloutTransition reacts to a staged cutover verdict: a promotion
+// retires the old credential so the new one takes all traffic, while an abort
+// only logs — the selector already pins traffic back to the old credential.
+func (m *Manager) applyRolloutTransition(transition *rolloutTransition) {
+	if m == nil || transition == nil {
+		return
+	}
+	if !transition.promote {
+		log.Warnf("auth rollout aborted: canary %s failed verification, traffic stays on %s", transition.newID, transition.oldID)
+		return
+	}
+	m.mu.Lock()
+	if oldAuth, ok := m.auths[transition.oldID]; ok && oldAuth != nil {
+		oldAuth.Disabled = true
+		oldAuth.Status = StatusDisabled
+		oldAuth.StatusMessage = fmt.Sprintf("retired after staged rollout to %s", transition.newID)
+		oldAuth.UpdatedAt = time.Now()
+	}
+	m.mu.Unlock()
+	log.Infof("auth rollout promoted: %s verified healthy, retired %s", transition.newID, transition.oldID)
+}
+
 func ensureModelState(auth *Auth, model string) *ModelState {
 	if auth == nil || model == "" {
 		return nil
@@ -1138,8 +1720,11 @@ func clearAuthStateOnSuccess(auth *Auth, now time.Time) {
 		return
 	}
 	auth.Unavailable = false
-	auth.Status = StatusActive
-	auth.StatusMessage = ""
+	// In-flight successes must not un-drain an auth parked for maintenance.
+	if auth.Status != StatusDraining {
+		auth.Status = StatusActive
+		auth.StatusMessage = ""
+	}
 	auth.Quota.Exceeded = false
 	auth.Quota.Reason = ""
 	auth.Quota.NextRecoverAt = time.Time{}
@@ -1321,10 +1906,14 @@ func (m *Manager) pickNext(ctx context.Context, provider, model string, opts cli
 		m.mu.RUnlock()
 		return nil, nil, &Error{Code: "auth_not_found", Message: "no auth available"}
 	}
-	selected, errPick := m.selector.Pick(ctx, provider, model, opts, candidates)
-	if errPick != nil {
-		m.mu.RUnlock()
-		return nil, nil, errPick
+	selected := pinnedCandidate(opts, model, candidates, time.Now())
+	if selected == nil {
+		var errPick error
+		selected, errPick = m.selector.Pick(ctx, provider, model, opts, candidates)
+		if errPick != nil {
+			m.mu.RUnlock()
+			return nil, nil, errPick
+		}
 	}
 	if selected == nil {
 		m.mu.RUnlock()
@@ -1332,6 +1921,8 @@ func (m *Manager) pickNext(ctx context.Context, provider, model string, opts cli
 	}
 	authCopy := selected.Clone()
 	m.mu.RUnlock()
+	bindSessionPin(opts, authCopy.ID, time.Now())
+	defaultKeyBudgetTracker.RecordRequest(authCopy, time.Now())
 	if !selected.indexAssigned {
 		m.mu.Lock()
 		if current := m.auths[authCopy.ID]; current != nil && !current.indexAssigned {
@@ -1386,10 +1977,14 @@ func (m *Manager) pickNextMixed(ctx context.Context, providers []string, model s
 		m.mu.RUnlock()
 		return nil, nil, "", &Error{Code: "auth_not_found", Message: "no auth available"}
 	}
-	selected, errPick := m.selector.Pick(ctx, "mixed", model, opts, candidates)
-	if errPick != nil {
-		m.mu.RUnlock()
-		return nil, nil, "", errPick
+	selected := pinnedCandidate(opts, model, candidates, time.Now())
+	if selected == nil {
+		var errPick error
+		selected, errPick = m.selector.Pick(ctx, "mixed", model, opts, candidates)
+		if errPick != nil {
+			m.mu.RUnlock()
+			return nil, nil, "", errPick
+		}
 	}
 	if selected == nil {
 		m.mu.RUnlock()
@@ -1403,6 +1998,8 @@ func (m *Manager) pickNextMixed(ctx context.Context, providers []string, model s
 	}
 	authCopy := selected.Clone()
 	m.mu.RUnlock()
+	bindSessionPin(opts, authCopy.ID, time.Now())
+	defaultKeyBudgetTracker.RecordRequest(authCopy, time.Now())
 	if !selected.indexAssigned {
 		m.mu.Lock()
 		if current := m.auths[authCopy.ID]; current != nil && !current.indexAssigned {
@@ -1746,6 +2343,11 @@ func (m *Manager) refreshAuth(ctx context.Context, id string) {
 		return
 	}
 	log.Debugf("refreshed %s, %s, %v", auth.Provider, auth.ID, err)
+	refreshDetail := "ok"
+	if err != nil {
+		refreshDetail = err.Error()
+	}
+	audit.Record("auto-refresh", "token-refresh", auth.Provider+"/"+auth.ID, refreshDetail)
 	now := time.Now()
 	if err != nil {
 		m.mu.Lock()
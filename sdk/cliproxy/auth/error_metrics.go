@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+const (
+	// errorMetricsMaxRecent caps how many distinct recent errors are kept per
+	// provider; the stalest entries are evicted first.
+	errorMetricsMaxRecent = 50
+
+	// errorMetricsMessageLimit caps the stored upstream message length.
+	errorMetricsMessageLimit = 512
+)
+
+// RecentUpstreamError describes one distinct upstream failure, deduplicated by
+// category and message, with occurrence counts and first/last seen times.
+type RecentUpstreamError struct {
+	Category   string    `json:"category"`
+	HTTPStatus int       `json:"http_status,omitempty"`
+	Message    string    `json:"message"`
+	Count      uint64    `json:"count"`
+	FirstSeen  time.Time `json:"first_seen"`
+	LastSeen   time.Time `json:"last_seen"`
+}
+
+// ProviderErrorStats aggregates error counters and recent distinct errors for
+// one provider.
+type ProviderErrorStats struct {
+	Provider string                `json:"provider"`
+	Counts   map[string]uint64     `json:"counts"`
+	Recent   []RecentUpstreamError `json:"recent"`
+}
+
+// errorMetricsTracker counts upstream failures per provider and category and
+// keeps a bounded set of recent distinct errors for the management API.
+type errorMetricsTracker struct {
+	mu       sync.Mutex
+	counters map[string]map[string]uint64
+	recent   map[string]map[string]*RecentUpstreamError
+}
+
+var defaultErrorMetricsTracker = &errorMetricsTracker{
+	counters: make(map[string]map[string]uint64),
+	recent:   make(map[string]map[string]*RecentUpstreamError),
+}
+
+func (t *errorMetricsTracker) record(provider string, category cliproxyexecutor.ErrorCategory, httpStatus int, message string, now time.Time) {
+	provider = strings.TrimSpace(provider)
+	if provider == "" {
+		provider = "unknown"
+	}
+	message = strings.TrimSpace(message)
+	if len(message) > errorMetricsMessageLimit {
+		message = message[:errorMetricsMessageLimit]
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	counts := t.counters[provider]
+	if counts == nil {
+		counts = make(map[string]uint64)
+		t.counters[provider] = counts
+	}
+	counts[string(category)]++
+
+	entries := t.recent[provider]
+	if entries == nil {
+		entries = make(map[string]*RecentUpstreamError)
+		t.recent[provider] = entries
+	}
+	key := string(category) + "|" + message
+	if entry, ok := entries[key]; ok {
+		entry.Count++
+		entry.LastSeen = now
+		if httpStatus > 0 {
+			entry.HTTPStatus = httpStatus
+		}
+		return
+	}
+	if len(entries) >= errorMetricsMaxRecent {
+		evictStalestErrorLocked(entries)
+	}
+	entries[key] = &RecentUpstreamError{
+		Category:   string(category),
+		HTTPStatus: httpStatus,
+		Message:    message,
+		Count:      1,
+		FirstSeen:  now,
+		LastSeen:   now,
+	}
+}
+
+func evictStalestErrorLocked(entries map[string]*RecentUpstreamError) {
+	stalestKey := ""
+	var stalestSeen time.Time
+	for key, entry := range entries {
+		if stalestKey == "" || entry.LastSeen.Before(stalestSeen) {
+			stalestKey = key
+			stalestSeen = entry.LastSeen
+		}
+	}
+	if stalestKey != "" {
+		delete(entries, stalestKey)
+	}
+}
+
+// SnapshotErrorMetrics returns the per-provider error counters and recent
+// distinct errors, sorted by provider name with recent errors newest first.
+func SnapshotErrorMetrics() []ProviderErrorStats {
+	t := defaultErrorMetricsTracker
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := make([]ProviderErrorStats, 0, len(t.counters))
+	for provider, counts := range t.counters {
+		countsCopy := make(map[string]uint64, len(counts))
+		for category, count := range counts {
+			countsCopy[category] = count
+		}
+		recent := make([]RecentUpstreamError, 0, len(t.recent[provider]))
+		for _, entry := range t.recent[provider] {
+			recent = append(recent, *entry)
+		}
+		sort.Slice(recent, func(i, j int) bool { return recent[i].LastSeen.After(recent[j].LastSeen) })
+		stats = append(stats, ProviderErrorStats{Provider: provider, Counts: countsCopy, Recent: recent})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Provider < stats[j].Provider })
+	return stats
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+func TestErrorMetricsTracker_CountsAndDedupes(t *testing.T) {
+	tracker := &errorMetricsTracker{
+		counters: make(map[string]map[string]uint64),
+		recent:   make(map[string]map[string]*RecentUpstreamError),
+	}
+	now := time.Now()
+
+	tracker.record("gemini", cliproxyexecutor.CategoryRateLimited, 429, "too many requests", now)
+	tracker.record("gemini", cliproxyexecutor.CategoryRateLimited, 429, "too many requests", now.Add(time.Second))
+	tracker.record("gemini", cliproxyexecutor.CategoryQuota, 429, "quota exceeded", now)
+
+	if got := tracker.counters["gemini"]["rate_limited"]; got != 2 {
+		t.Fatalf("expected rate_limited count 2, got %d", got)
+	}
+	if got := tracker.counters["gemini"]["quota"]; got != 1 {
+		t.Fatalf("expected quota count 1, got %d", got)
+	}
+	if got := len(tracker.recent["gemini"]); got != 2 {
+		t.Fatalf("expected 2 distinct recent errors, got %d", got)
+	}
+	entry := tracker.recent["gemini"]["rate_limited|too many requests"]
+	if entry == nil || entry.Count != 2 {
+		t.Fatalf("expected deduplicated entry with count 2, got %+v", entry)
+	}
+}
+
+func TestErrorMetricsTracker_EvictsStalest(t *testing.T) {
+	tracker := &errorMetricsTracker{
+		counters: make(map[string]map[string]uint64),
+		recent:   make(map[string]map[string]*RecentUpstreamError),
+	}
+	now := time.Now()
+
+	for i := 0; i < errorMetricsMaxRecent+1; i++ {
+		tracker.record("claude", cliproxyexecutor.CategoryOverloaded, 529, fmt.Sprintf("overloaded %d", i), now.Add(time.Duration(i)*time.Second))
+	}
+
+	entries := tracker.recent["claude"]
+	if len(entries) != errorMetricsMaxRecent {
+		t.Fatalf("expected %d entries after eviction, got %d", errorMetricsMaxRecent, len(entries))
+	}
+	if _, ok := entries["overloaded|overloaded 0"]; ok {
+		t.Fatalf("expected stalest entry to be evicted")
+	}
+}
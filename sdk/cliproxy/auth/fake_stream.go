@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+// fakeStreamingModels holds the model name patterns (with '*' wildcards)
+// whose streaming requests are served by a non-streamed upstream call plus
+// synthesized client-side chunks.
+var fakeStreamingModels atomic.Value
+
+// SetFakeStreamingModels replaces the set of models served via fake streaming.
+// It is wired to the fake-streaming-models config key and safe on reload.
+func SetFakeStreamingModels(patterns []string) {
+	cleaned := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if trimmed := strings.TrimSpace(pattern); trimmed != "" {
+			cleaned = append(cleaned, trimmed)
+		}
+	}
+	fakeStreamingModels.Store(cleaned)
+}
+
+func fakeStreamingFor(model string) bool {
+	patterns, _ := fakeStreamingModels.Load().([]string)
+	for _, pattern := range patterns {
+		if matchModelWildcard(pattern, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamOrSynthesize dispatches to the provider's real streaming path unless
+// the model is configured for fake streaming, in which case the upstream call
+// is made non-streamed (more reliable on some providers) and the streaming
+// chunks are synthesized from the full response.
+func (m *Manager) streamOrSynthesize(ctx context.Context, executor ProviderExecutor, auth *Auth, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (<-chan cliproxyexecutor.StreamChunk, error) {
+	if errSmooth := smoothDispatch(ctx, auth); errSmooth != nil {
+		return nil, errSmooth
+	}
+	if transportModeFor(req.Model) != transportNonStream || !cliproxyexecutor.CanSynthesizeStream(string(opts.SourceFormat)) {
+		chunks, errStream := executor.ExecuteStream(ctx, auth, req, opts)
+		if errStream != nil {
+			return nil, errStream
+		}
+		chunks = cliproxyexecutor.RechunkToolArgStream(string(opts.SourceFormat), chunks)
+		return cliproxyexecutor.RepairToolArgStream(string(opts.SourceFormat), chunks), nil
+	}
+	nonStreamOpts := opts
+	nonStreamOpts.Stream = false
+	resp, errExec := executor.Execute(ctx, auth, req, nonStreamOpts)
+	if errExec != nil {
+		return nil, errExec
+	}
+	payloads := cliproxyexecutor.SynthesizeStreamChunks(string(opts.SourceFormat), resp.Payload)
+	out := make(chan cliproxyexecutor.StreamChunk, len(payloads))
+	for _, payload := range payloads {
+		out <- cliproxyexecutor.StreamChunk{Payload: payload}
+	}
+	close(out)
+	return out, nil
+}
+
+// matchModelWildcard performs simple glob matching where '*' matches zero or
+// more characters, mirroring the payload rule matcher.
+func matchModelWildcard(pattern, model string) bool {
+	pattern = strings.TrimSpace(pattern)
+	model = strings.TrimSpace(model)
+	if pattern == "" {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	pi, si := 0, 0
+	starIdx := -1
+	matchIdx := 0
+	for si < len(model) {
+		if pi < len(pattern) && pattern[pi] == model[si] {
+			pi++
+			si++
+			continue
+		}
+		if pi < len(pattern) && pattern[pi] == '*' {
+			starIdx = pi
+			matchIdx = si
+			pi++
+			continue
+		}
+		if starIdx != -1 {
+			pi = starIdx + 1
+			matchIdx++
+			si = matchIdx
+			continue
+		}
+		return false
+	}
+	for pi < len(pattern) && pattern[pi] == '*' {
+		pi++
+	}
+	return pi == len(pattern)
+}
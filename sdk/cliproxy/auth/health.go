@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// healthWindow bounds how far back samples count toward an auth's health.
+	healthWindow = 5 * time.Minute
+	// healthMaxSamples caps the per-auth ring so hot auths stay cheap to score.
+	healthMaxSamples = 64
+	// healthMinSamples is the minimum evidence required before demoting an auth.
+	healthMinSamples = 8
+	// healthErrorRateThreshold is the windowed failure ratio that triggers demotion.
+	healthErrorRateThreshold = 0.5
+	// healthSlowLatency is the p95 latency above which an auth counts as slow.
+	healthSlowLatency = 30 * time.Second
+	// healthErrorDemotion and healthSlowDemotion are the priority levels shaved
+	// off an unhealthy auth; erroring weighs heavier than merely being slow.
+	healthErrorDemotion = 2
+	healthSlowDemotion  = 1
+)
+
+// healthSample records the outcome of a single execution attempt.
+type healthSample struct {
+	at      time.Time
+	latency time.Duration
+	failed  bool
+}
+
+// authHealthTracker keeps a rolling window of execution outcomes per auth and
+// translates them into priority demotions so slow or erroring auths sink below
+// healthy peers in selection order. The penalty fades as offending samples age
+// out of the window, letting a recovered auth climb back to its configured
+// priority instead of flapping between fully in and fully out of rotation.
+type authHealthTracker struct {
+	mu      sync.Mutex
+	entries map[string][]healthSample
+}
+
+var defaultAuthHealthTracker = newAuthHealthTracker()
+
+func newAuthHealthTracker() *authHealthTracker {
+	return &authHealthTracker{entries: make(map[string][]healthSample)}
+}
+
+// record appends an execution outcome for the auth. Samples without a measured
+// latency still count toward the error rate when they represent failures.
+func (t *authHealthTracker) record(authID string, latency time.Duration, failed bool, now time.Time) {
+	if t == nil || authID == "" {
+		return
+	}
+	if latency <= 0 && !failed {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	samples := pruneHealthSamples(t.entries[authID], now)
+	samples = append(samples, healthSample{at: now, latency: latency, failed: failed})
+	if len(samples) > healthMaxSamples {
+		samples = samples[len(samples)-healthMaxSamples:]
+	}
+	t.entries[authID] = samples
+}
+
+// demotionFor returns how many priority levels the auth should currently be
+// demoted based on its recent error rate and p95 latency. Once the newest
+// failure has aged past half the window the penalty is halved, so recovery is
+// gradual rather than an all-or-nothing flip when the window empties.
+func (t *authHealthTracker) demotionFor(authID string, now time.Time) int {
+	if t == nil || authID == "" {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	samples := pruneHealthSamples(t.entries[authID], now)
+	if len(samples) == 0 {
+		delete(t.entries, authID)
+		return 0
+	}
+	t.entries[authID] = samples
+	if len(samples) < healthMinSamples {
+		return 0
+	}
+	demotion := 0
+	failures := 0
+	var lastFailure time.Time
+	latencies := make([]time.Duration, 0, len(samples))
+	for _, sample := range samples {
+		if sample.failed {
+			failures++
+			if sample.at.After(lastFailure) {
+				lastFailure = sample.at
+			}
+		}
+		if sample.latency > 0 {
+			latencies = append(latencies, sample.latency)
+		}
+	}
+	if float64(failures)/float64(len(samples)) >= healthErrorRateThreshold {
+		demotion += healthErrorDemotion
+		if now.Sub(lastFailure) > healthWindow/2 {
+			demotion = healthErrorDemotion / 2
+		}
+	}
+	if p95Latency(latencies) >= healthSlowLatency {
+		demotion += healthSlowDemotion
+	}
+	return demotion
+}
+
+// pruneHealthSamples drops samples older than the health window; samples are
+// appended in time order so the first surviving index bounds the slice.
+func pruneHealthSamples(samples []healthSample, now time.Time) []healthSample {
+	cutoff := now.Add(-healthWindow)
+	idx := 0
+	for idx < len(samples) && !samples[idx].at.After(cutoff) {
+		idx++
+	}
+	if idx == 0 {
+		return samples
+	}
+	return append([]healthSample(nil), samples[idx:]...)
+}
+
+// p95Latency returns the 95th percentile of the supplied latencies, or zero
+// when there are none.
+func p95Latency(latencies []time.Duration) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := (len(sorted)*95 + 99) / 100
+	if idx > 0 {
+		idx--
+	}
+	return sorted[idx]
+}
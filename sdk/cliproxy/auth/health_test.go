@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuthHealthTracker_ErrorRateDemotes(t *testing.T) {
+	tracker := newAuthHealthTracker()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < healthMinSamples-1; i++ {
+		tracker.record("auth-1", 100*time.Millisecond, true, now)
+	}
+	if got := tracker.demotionFor("auth-1", now); got != 0 {
+		t.Fatalf("demotionFor() below min samples = %d, want 0", got)
+	}
+
+	tracker.record("auth-1", 100*time.Millisecond, true, now)
+	if got := tracker.demotionFor("auth-1", now); got != healthErrorDemotion {
+		t.Fatalf("demotionFor() with all failures = %d, want %d", got, healthErrorDemotion)
+	}
+}
+
+func TestAuthHealthTracker_SlowLatencyDemotes(t *testing.T) {
+	tracker := newAuthHealthTracker()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < healthMinSamples; i++ {
+		tracker.record("slow", healthSlowLatency+5*time.Second, false, now)
+		tracker.record("fast", 200*time.Millisecond, false, now)
+	}
+	if got := tracker.demotionFor("slow", now); got != healthSlowDemotion {
+		t.Fatalf("demotionFor(slow) = %d, want %d", got, healthSlowDemotion)
+	}
+	if got := tracker.demotionFor("fast", now); got != 0 {
+		t.Fatalf("demotionFor(fast) = %d, want 0", got)
+	}
+}
+
+func TestAuthHealthTracker_GradualRecovery(t *testing.T) {
+	tracker := newAuthHealthTracker()
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < healthMinSamples; i++ {
+		tracker.record("auth-1", 100*time.Millisecond, true, start)
+	}
+	if got := tracker.demotionFor("auth-1", start.Add(time.Minute)); got != healthErrorDemotion {
+		t.Fatalf("demotionFor() while failures are fresh = %d, want %d", got, healthErrorDemotion)
+	}
+	// Past half the window the penalty is halved; past the full window it is gone.
+	if got := tracker.demotionFor("auth-1", start.Add(healthWindow/2+time.Minute)); got != healthErrorDemotion/2 {
+		t.Fatalf("demotionFor() after half window = %d, want %d", got, healthErrorDemotion/2)
+	}
+	if got := tracker.demotionFor("auth-1", start.Add(healthWindow+time.Minute)); got != 0 {
+		t.Fatalf("demotionFor() after window expiry = %d, want 0", got)
+	}
+}
+
+func TestAuthHealthTracker_SuccessesDiluteErrors(t *testing.T) {
+	tracker := newAuthHealthTracker()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for i := 0; i < healthMinSamples/2; i++ {
+		tracker.record("auth-1", 100*time.Millisecond, true, now)
+	}
+	for i := 0; i < healthMinSamples; i++ {
+		tracker.record("auth-1", 100*time.Millisecond, false, now.Add(time.Second))
+	}
+	if got := tracker.demotionFor("auth-1", now.Add(2*time.Second)); got != 0 {
+		t.Fatalf("demotionFor() after successes diluted failures = %d, want 0", got)
+	}
+}
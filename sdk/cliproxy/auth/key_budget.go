@@ -0,0 +1,306 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
+)
+
+func init() {
+	usage.RegisterPlugin(keyBudgetUsagePlugin{})
+}
+
+// KeyBudgetLimits describes the optional request/token budgets configured for
+// an API key credential. Zero values mean the corresponding budget is unlimited.
+type KeyBudgetLimits struct {
+	// RPM caps requests per rolling minute window.
+	RPM int
+	// RPD caps requests per UTC day.
+	RPD int
+	// TPM caps tokens per rolling minute window.
+	TPM int64
+	// TPD caps tokens per UTC day.
+	TPD int64
+}
+
+func (l KeyBudgetLimits) empty() bool {
+	return l.RPM <= 0 && l.RPD <= 0 && l.TPM <= 0 && l.TPD <= 0
+}
+
+// KeyBudgetSnapshot reports budget consumption for a single credential so the
+// management API can surface remaining headroom per key.
+type KeyBudgetSnapshot struct {
+	RPMLimit     int       `json:"rpm_limit,omitempty"`
+	RPMUsed      int       `json:"rpm_used,omitempty"`
+	RPMRemaining int       `json:"rpm_remaining,omitempty"`
+	RPMResetAt   time.Time `json:"rpm_reset_at,omitempty"`
+
+	RPDLimit     int       `json:"rpd_limit,omitempty"`
+	RPDUsed      int       `json:"rpd_used,omitempty"`
+	RPDRemaining int       `json:"rpd_remaining,omitempty"`
+	RPDResetAt   time.Time `json:"rpd_reset_at,omitempty"`
+
+	TPMLimit     int64     `json:"tpm_limit,omitempty"`
+	TPMUsed      int64     `json:"tpm_used,omitempty"`
+	TPMRemaining int64     `json:"tpm_remaining,omitempty"`
+	TPMResetAt   time.Time `json:"tpm_reset_at,omitempty"`
+
+	TPDLimit     int64     `json:"tpd_limit,omitempty"`
+	TPDUsed      int64     `json:"tpd_used,omitempty"`
+	TPDRemaining int64     `json:"tpd_remaining,omitempty"`
+	TPDResetAt   time.Time `json:"tpd_reset_at,omitempty"`
+}
+
+type keyBudgetEntry struct {
+	minuteStart    time.Time
+	minuteRequests int
+	minuteTokens   int64
+	dayStart       time.Time
+	dayRequests    int
+	dayTokens      int64
+}
+
+func (e *keyBudgetEntry) roll(now time.Time) {
+	minute := now.Truncate(time.Minute)
+	if !minute.Equal(e.minuteStart) {
+		e.minuteStart = minute
+		e.minuteRequests = 0
+		e.minuteTokens = 0
+	}
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(e.dayStart) {
+		e.dayStart = day
+		e.dayRequests = 0
+		e.dayTokens = 0
+	}
+}
+
+// KeyBudgetTracker counts requests and tokens per credential against the
+// budgets declared in auth attributes (rpm_limit, rpd_limit, tpm_limit,
+// tpd_limit) and the auth-daily-caps config. Credentials without budgets are
+// never tracked or blocked.
+type KeyBudgetTracker struct {
+	mu      sync.Mutex
+	entries map[string]*keyBudgetEntry
+}
+
+func newKeyBudgetTracker() *KeyBudgetTracker {
+	return &KeyBudgetTracker{entries: make(map[string]*keyBudgetEntry)}
+}
+
+var defaultKeyBudgetTracker = newKeyBudgetTracker()
+
+// DefaultKeyBudgetTracker returns the process-wide budget tracker shared by
+// the selector, the runtime manager and the management API.
+func DefaultKeyBudgetTracker() *KeyBudgetTracker { return defaultKeyBudgetTracker }
+
+// authDailyCaps holds the auth-daily-caps config: an auth ID, credential file
+// name, or provider key mapped to a hard daily token cap (UTC window).
+var authDailyCaps atomic.Value // map[string]int64
+
+// SetAuthDailyCaps replaces the per-auth daily token caps from config. Keys
+// may be an auth ID, a credential file name, or a provider key; lookups are
+// case-insensitive and zero or negative caps are dropped.
+func SetAuthDailyCaps(caps map[string]int64) {
+	cleaned := make(map[string]int64, len(caps))
+	for key, limit := range caps {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" && limit > 0 {
+			cleaned[key] = limit
+		}
+	}
+	authDailyCaps.Store(cleaned)
+}
+
+// dailyCapFor resolves the configured daily token cap for a credential,
+// preferring the most specific key: ID, then file name, then provider.
+func dailyCapFor(auth *Auth) int64 {
+	caps, _ := authDailyCaps.Load().(map[string]int64)
+	if len(caps) == 0 || auth == nil {
+		return 0
+	}
+	for _, key := range []string{auth.ID, auth.FileName, auth.Provider} {
+		if key = strings.ToLower(strings.TrimSpace(key)); key == "" {
+			continue
+		}
+		if limit, ok := caps[key]; ok {
+			return limit
+		}
+	}
+	return 0
+}
+
+// keyBudgetLimitsFromAuth parses the budget attributes synthesized from config
+// and folds in any configured daily token cap for the credential.
+func keyBudgetLimitsFromAuth(auth *Auth) KeyBudgetLimits {
+	var limits KeyBudgetLimits
+	if auth == nil {
+		return limits
+	}
+	if auth.Attributes != nil {
+		if v, err := strconv.Atoi(strings.TrimSpace(auth.Attributes["rpm_limit"])); err == nil {
+			limits.RPM = v
+		}
+		if v, err := strconv.Atoi(strings.TrimSpace(auth.Attributes["rpd_limit"])); err == nil {
+			limits.RPD = v
+		}
+		if v, err := strconv.ParseInt(strings.TrimSpace(auth.Attributes["tpm_limit"]), 10, 64); err == nil {
+			limits.TPM = v
+		}
+		if v, err := strconv.ParseInt(strings.TrimSpace(auth.Attributes["tpd_limit"]), 10, 64); err == nil {
+			limits.TPD = v
+		}
+	}
+	if capTokens := dailyCapFor(auth); capTokens > 0 && (limits.TPD <= 0 || capTokens < limits.TPD) {
+		limits.TPD = capTokens
+	}
+	return limits
+}
+
+// RecordRequest charges one request against the credential's budgets.
+// It is a no-op for credentials without configured budgets.
+func (t *KeyBudgetTracker) RecordRequest(auth *Auth, now time.Time) {
+	if t == nil || auth == nil || keyBudgetLimitsFromAuth(auth).empty() {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry := t.entry(auth.ID)
+	entry.roll(now)
+	entry.minuteRequests++
+	entry.dayRequests++
+}
+
+// RecordTokens charges consumed tokens against the credential's minute window.
+// Tokens are only tracked for credentials that already have a budget entry,
+// i.e. those with configured limits.
+func (t *KeyBudgetTracker) RecordTokens(authID string, tokens int64, now time.Time) {
+	if t == nil || authID == "" || tokens <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, ok := t.entries[authID]
+	if !ok {
+		return
+	}
+	entry.roll(now)
+	entry.minuteTokens += tokens
+	entry.dayTokens += tokens
+}
+
+// blockedUntil reports whether the credential has spent one of its budgets and,
+// if so, when the last exhausted window resets.
+func (t *KeyBudgetTracker) blockedUntil(auth *Auth, now time.Time) (bool, time.Time) {
+	if t == nil || auth == nil {
+		return false, time.Time{}
+	}
+	limits := keyBudgetLimitsFromAuth(auth)
+	if limits.empty() {
+		return false, time.Time{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry, ok := t.entries[auth.ID]
+	if !ok {
+		return false, time.Time{}
+	}
+	entry.roll(now)
+	var next time.Time
+	if limits.RPM > 0 && entry.minuteRequests >= limits.RPM {
+		next = entry.minuteStart.Add(time.Minute)
+	}
+	if limits.TPM > 0 && entry.minuteTokens >= limits.TPM {
+		if reset := entry.minuteStart.Add(time.Minute); reset.After(next) {
+			next = reset
+		}
+	}
+	if limits.RPD > 0 && entry.dayRequests >= limits.RPD {
+		if reset := entry.dayStart.Add(24 * time.Hour); reset.After(next) {
+			next = reset
+		}
+	}
+	if limits.TPD > 0 && entry.dayTokens >= limits.TPD {
+		if reset := entry.dayStart.Add(24 * time.Hour); reset.After(next) {
+			next = reset
+		}
+	}
+	if next.IsZero() {
+		return false, time.Time{}
+	}
+	return true, next
+}
+
+// Snapshot reports current consumption against the credential's budgets.
+// The second return value is false when the credential has no budgets.
+func (t *KeyBudgetTracker) Snapshot(auth *Auth, now time.Time) (KeyBudgetSnapshot, bool) {
+	limits := keyBudgetLimitsFromAuth(auth)
+	if t == nil || limits.empty() {
+		return KeyBudgetSnapshot{}, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entry := t.entry(auth.ID)
+	entry.roll(now)
+	snapshot := KeyBudgetSnapshot{}
+	if limits.RPM > 0 {
+		snapshot.RPMLimit = limits.RPM
+		snapshot.RPMUsed = entry.minuteRequests
+		snapshot.RPMRemaining = clampIntFloor(limits.RPM - entry.minuteRequests)
+		snapshot.RPMResetAt = entry.minuteStart.Add(time.Minute)
+	}
+	if limits.RPD > 0 {
+		snapshot.RPDLimit = limits.RPD
+		snapshot.RPDUsed = entry.dayRequests
+		snapshot.RPDRemaining = clampIntFloor(limits.RPD - entry.dayRequests)
+		snapshot.RPDResetAt = entry.dayStart.Add(24 * time.Hour)
+	}
+	if limits.TPM > 0 {
+		snapshot.TPMLimit = limits.TPM
+		snapshot.TPMUsed = entry.minuteTokens
+		snapshot.TPMRemaining = clampInt64Floor(limits.TPM - entry.minuteTokens)
+		snapshot.TPMResetAt = entry.minuteStart.Add(time.Minute)
+	}
+	if limits.TPD > 0 {
+		snapshot.TPDLimit = limits.TPD
+		snapshot.TPDUsed = entry.dayTokens
+		snapshot.TPDRemaining = clampInt64Floor(limits.TPD - entry.dayTokens)
+		snapshot.TPDResetAt = entry.dayStart.Add(24 * time.Hour)
+	}
+	return snapshot, true
+}
+
+func (t *KeyBudgetTracker) entry(authID string) *keyBudgetEntry {
+	entry, ok := t.entries[authID]
+	if !ok {
+		entry = &keyBudgetEntry{}
+		t.entries[authID] = entry
+	}
+	return entry
+}
+
+func clampIntFloor(v int) int {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+func clampInt64Floor(v int64) int64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+// keyBudgetUsagePlugin feeds token consumption from usage records into the
+// default tracker so tpm_limit budgets reflect actual upstream usage.
+type keyBudgetUsagePlugin struct{}
+
+func (keyBudgetUsagePlugin) HandleUsage(_ context.Context, record usage.Record) {
+	defaultKeyBudgetTracker.RecordTokens(record.AuthID, record.Detail.TotalTokens, time.Now())
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestKeyBudgetTracker_RPMBlocksAndResets(t *testing.T) {
+	t.Parallel()
+
+	tracker := newKeyBudgetTracker()
+	auth := &Auth{ID: "gemini-key-1", Provider: "gemini", Attributes: map[string]string{"rpm_limit": "2"}}
+	now := time.Date(2026, 8, 26, 10, 0, 30, 0, time.UTC)
+
+	if blocked, _ := tracker.blockedUntil(auth, now); blocked {
+		t.Fatalf("blockedUntil() = true before any request")
+	}
+	tracker.RecordRequest(auth, now)
+	tracker.RecordRequest(auth, now)
+
+	blocked, next := tracker.blockedUntil(auth, now)
+	if !blocked {
+		t.Fatalf("blockedUntil() = false after spending the rpm budget")
+	}
+	want := time.Date(2026, 8, 26, 10, 1, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("blockedUntil() next = %v, want %v", next, want)
+	}
+	if blocked, _ = tracker.blockedUntil(auth, want.Add(time.Second)); blocked {
+		t.Fatalf("blockedUntil() = true after the minute window reset")
+	}
+}
+
+func TestKeyBudgetTracker_TPMCountsTrackedKeysOnly(t *testing.T) {
+	t.Parallel()
+
+	tracker := newKeyBudgetTracker()
+	auth := &Auth{ID: "gemini-key-2", Provider: "gemini", Attributes: map[string]string{"tpm_limit": "1000"}}
+	now := time.Date(2026, 8, 26, 10, 0, 0, 0, time.UTC)
+
+	// Tokens for unknown credentials (no prior request) are dropped.
+	tracker.RecordTokens("gemini-unknown", 5000, now)
+
+	tracker.RecordRequest(auth, now)
+	tracker.RecordTokens(auth.ID, 600, now)
+	if blocked, _ := tracker.blockedUntil(auth, now); blocked {
+		t.Fatalf("blockedUntil() = true under the tpm budget")
+	}
+	tracker.RecordTokens(auth.ID, 600, now)
+	if blocked, _ := tracker.blockedUntil(auth, now); !blocked {
+		t.Fatalf("blockedUntil() = false after spending the tpm budget")
+	}
+}
+
+func TestKeyBudgetTracker_TPDBlocksUntilNextDay(t *testing.T) {
+	t.Parallel()
+
+	tracker := newKeyBudgetTracker()
+	auth := &Auth{ID: "gemini-key-4", Provider: "gemini", Attributes: map[string]string{"tpd_limit": "1000"}}
+	now := time.Date(2026, 8, 26, 10, 0, 0, 0, time.UTC)
+
+	tracker.RecordRequest(auth, now)
+	tracker.RecordTokens(auth.ID, 999, now)
+	if blocked, _ := tracker.blockedUntil(auth, now); blocked {
+		t.Fatalf("blockedUntil() = true under the tpd budget")
+	}
+	// The minute window rolling over must not release a spent daily budget.
+	later := now.Add(2 * time.Minute)
+	tracker.RecordTokens(auth.ID, 1, later)
+	blocked, next := tracker.blockedUntil(auth, later)
+	if !blocked {
+		t.Fatalf("blockedUntil() = false after spending the tpd budget")
+	}
+	want := time.Date(2026, 8, 27, 0, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("blockedUntil() next = %v, want %v", next, want)
+	}
+	if blocked, _ = tracker.blockedUntil(auth, want.Add(time.Second)); blocked {
+		t.Fatalf("blockedUntil() = true after the day window reset")
+	}
+}
+
+func TestKeyBudgetTracker_DailyCapFromConfig(t *testing.T) {
+	SetAuthDailyCaps(map[string]int64{"codex": 500})
+	defer SetAuthDailyCaps(nil)
+
+	tracker := newKeyBudgetTracker()
+	auth := &Auth{ID: "codex-user-pro.json", Provider: "codex"}
+	now := time.Date(2026, 8, 26, 10, 0, 0, 0, time.UTC)
+
+	tracker.RecordRequest(auth, now)
+	tracker.RecordTokens(auth.ID, 500, now)
+	if blocked, _ := tracker.blockedUntil(auth, now); !blocked {
+		t.Fatalf("blockedUntil() = false after spending the provider daily cap")
+	}
+	snapshot, ok := tracker.Snapshot(auth, now)
+	if !ok {
+		t.Fatalf("Snapshot() ok = false for a capped auth")
+	}
+	if snapshot.TPDLimit != 500 || snapshot.TPDRemaining != 0 {
+		t.Fatalf("Snapshot() tpd limit/remaining = %d/%d, want 500/0", snapshot.TPDLimit, snapshot.TPDRemaining)
+	}
+}
+
+func TestKeyBudgetTracker_Snapshot(t *testing.T) {
+	t.Parallel()
+
+	tracker := newKeyBudgetTracker()
+	now := time.Date(2026, 8, 26, 10, 0, 0, 0, time.UTC)
+	auth := &Auth{ID: "gemini-key-3", Provider: "gemini", Attributes: map[string]string{"rpm_limit": "10", "rpd_limit": "250"}}
+
+	if _, ok := tracker.Snapshot(&Auth{ID: "plain", Provider: "gemini"}, now); ok {
+		t.Fatalf("Snapshot() ok = true for a key without budgets")
+	}
+
+	tracker.RecordRequest(auth, now)
+	tracker.RecordRequest(auth, now)
+	snapshot, ok := tracker.Snapshot(auth, now)
+	if !ok {
+		t.Fatalf("Snapshot() ok = false for a budgeted key")
+	}
+	if snapshot.RPMUsed != 2 || snapshot.RPMRemaining != 8 {
+		t.Fatalf("Snapshot() rpm used/remaining = %d/%d, want 2/8", snapshot.RPMUsed, snapshot.RPMRemaining)
+	}
+	if snapshot.RPDUsed != 2 || snapshot.RPDRemaining != 248 {
+		t.Fatalf("Snapshot() rpd used/remaining = %d/%d, want 2/248", snapshot.RPDUsed, snapshot.RPDRemaining)
+	}
+	if snapshot.TPMLimit != 0 {
+		t.Fatalf("Snapshot() tpm limit = %d, want 0 (not configured)", snapshot.TPMLimit)
+	}
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+// codexPlanNames lists the ChatGPT subscription plan tokens that the Codex
+// credential filename logic appends after the account email.
+var codexPlanNames = map[string]struct{}{
+	"free":       {},
+	"plus":       {},
+	"pro":        {},
+	"team":       {},
+	"business":   {},
+	"enterprise": {},
+	"edu":        {},
+}
+
+// defaultCodexPlanWeights routes proportionally more traffic to subscriptions
+// with higher usage caps when the operator does not configure explicit weights.
+var defaultCodexPlanWeights = map[string]int{
+	"free":       1,
+	"plus":       1,
+	"pro":        3,
+	"team":       3,
+	"business":   4,
+	"enterprise": 5,
+	"edu":        1,
+}
+
+// CodexPlanWeightedSelector wraps a base selector and applies plan-aware
+// weighted routing for Codex OAuth accounts: higher-tier subscriptions (Pro,
+// Team) receive proportionally more traffic than Plus accounts, and optional
+// per-plan concurrency ceilings shed excess load to other plan buckets.
+// Non-codex providers are delegated to the wrapped selector unchanged.
+type CodexPlanWeightedSelector struct {
+	delegate       Selector
+	weights        map[string]int
+	maxConcurrency map[string]int
+
+	mu         sync.Mutex
+	cursors    map[string]int
+	inFlight   map[string]int
+	planByAuth map[string]string
+}
+
+// NewCodexPlanWeightedSelector constructs a plan-weighted selector around the
+// supplied delegate. Weights default to built-in per-plan values when not
+// configured; a zero or missing concurrency entry means unlimited.
+func NewCodexPlanWeightedSelector(delegate Selector, weights, maxConcurrency map[string]int) *CodexPlanWeightedSelector {
+	if delegate == nil {
+		delegate = &RoundRobinSelector{}
+	}
+	normalizedWeights := make(map[string]int, len(weights))
+	for plan, weight := range weights {
+		normalizedWeights[strings.ToLower(strings.TrimSpace(plan))] = weight
+	}
+	normalizedLimits := make(map[string]int, len(maxConcurrency))
+	for plan, limit := range maxConcurrency {
+		normalizedLimits[strings.ToLower(strings.TrimSpace(plan))] = limit
+	}
+	return &CodexPlanWeightedSelector{
+		delegate:       delegate,
+		weights:        normalizedWeights,
+		maxConcurrency: normalizedLimits,
+		cursors:        make(map[string]int),
+		inFlight:       make(map[string]int),
+		planByAuth:     make(map[string]string),
+	}
+}
+
+// Pick selects a codex auth using plan-weighted round-robin, or delegates for
+// other providers.
+func (s *CodexPlanWeightedSelector) Pick(ctx context.Context, provider, model string, opts cliproxyexecutor.Options, auths []*Auth) (*Auth, error) {
+	if !strings.EqualFold(provider, "codex") {
+		return s.delegate.Pick(ctx, provider, model, opts, auths)
+	}
+	now := time.Now()
+	available, err := getAvailableAuths(auths, provider, model, now)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	candidates := make([]*Auth, 0, len(available))
+	for _, candidate := range available {
+		plan := s.planFor(candidate)
+		if limit := s.maxConcurrency[plan]; limit > 0 && s.inFlight[plan] >= limit {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+	// When every plan bucket is at its ceiling, serve from the full set rather
+	// than failing the request outright.
+	if len(candidates) == 0 {
+		candidates = available
+	}
+
+	expanded := make([]*Auth, 0, len(candidates))
+	for _, candidate := range candidates {
+		weight := s.weightFor(s.planFor(candidate))
+		for i := 0; i < weight; i++ {
+			expanded = append(expanded, candidate)
+		}
+	}
+
+	key := provider + ":" + model
+	index := s.cursors[key]
+	if index >= 2_147_483_640 {
+		index = 0
+	}
+	s.cursors[key] = index + 1
+
+	selected := expanded[index%len(expanded)]
+	plan := s.planFor(selected)
+	s.inFlight[plan]++
+	s.planByAuth[selected.ID] = plan
+	return selected, nil
+}
+
+// ObserveResult releases the concurrency slot reserved by Pick once the
+// execution attempt completes.
+func (s *CodexPlanWeightedSelector) ObserveResult(result Result) {
+	if result.AuthID == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	plan, ok := s.planByAuth[result.AuthID]
+	if !ok {
+		return
+	}
+	if s.inFlight[plan] > 0 {
+		s.inFlight[plan]--
+	}
+}
+
+func (s *CodexPlanWeightedSelector) planFor(auth *Auth) string {
+	plan := codexPlanFromAuth(auth)
+	if plan == "" {
+		return "unknown"
+	}
+	return plan
+}
+
+func (s *CodexPlanWeightedSelector) weightFor(plan string) int {
+	if weight, ok := s.weights[plan]; ok && weight > 0 {
+		return weight
+	}
+	if weight, ok := defaultCodexPlanWeights[plan]; ok {
+		return weight
+	}
+	return 1
+}
+
+// codexPlanFromAuth derives the subscription plan for a Codex auth. It prefers
+// explicit metadata and falls back to the plan suffix embedded in the
+// credential filename (codex-<hash>-<email>-<plan>.json).
+func codexPlanFromAuth(auth *Auth) string {
+	if auth == nil {
+		return ""
+	}
+	if auth.Metadata != nil {
+		if v, ok := auth.Metadata["plan_type"].(string); ok {
+			if plan := strings.ToLower(strings.TrimSpace(v)); plan != "" {
+				return plan
+			}
+		}
+	}
+	name := strings.TrimSuffix(strings.TrimSpace(auth.FileName), ".json")
+	if name == "" {
+		name = strings.TrimSuffix(strings.TrimSpace(auth.ID), ".json")
+	}
+	if idx := strings.LastIndex(name, "-"); idx >= 0 && idx < len(name)-1 {
+		suffix := strings.ToLower(name[idx+1:])
+		if _, ok := codexPlanNames[suffix]; ok {
+			return suffix
+		}
+	}
+	return ""
+}
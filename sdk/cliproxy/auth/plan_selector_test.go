@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+func TestCodexPlanFromAuth_FileNameSuffix(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		auth *Auth
+		want string
+	}{
+		{name: "plus suffix", auth: &Auth{FileName: "codex-a1b2c3d4-user@example.com-plus.json"}, want: "plus"},
+		{name: "team suffix", auth: &Auth{FileName: "codex-user@example.com-team.json"}, want: "team"},
+		{name: "no plan suffix", auth: &Auth{FileName: "codex-user@example.com.json"}, want: ""},
+		{name: "metadata override", auth: &Auth{FileName: "codex-user@example.com.json", Metadata: map[string]any{"plan_type": "Pro"}}, want: "pro"},
+		{name: "nil auth", auth: nil, want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := codexPlanFromAuth(tc.auth); got != tc.want {
+				t.Fatalf("codexPlanFromAuth() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCodexPlanWeightedSelectorPick_WeightedDistribution(t *testing.T) {
+	t.Parallel()
+
+	selector := NewCodexPlanWeightedSelector(&RoundRobinSelector{}, map[string]int{"plus": 1, "team": 3}, nil)
+	auths := []*Auth{
+		{ID: "codex-user-a@example.com-plus.json", FileName: "codex-user-a@example.com-plus.json"},
+		{ID: "codex-user-b@example.com-team.json", FileName: "codex-user-b@example.com-team.json"},
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i < 8; i++ {
+		got, err := selector.Pick(context.Background(), "codex", "gpt-5", cliproxyexecutor.Options{}, auths)
+		if err != nil {
+			t.Fatalf("Pick() #%d error = %v", i, err)
+		}
+		counts[got.ID]++
+		selector.ObserveResult(Result{AuthID: got.ID, Success: true})
+	}
+
+	if counts["codex-user-b@example.com-team.json"] != 6 {
+		t.Fatalf("team picks = %d, want 6 (counts: %v)", counts["codex-user-b@example.com-team.json"], counts)
+	}
+	if counts["codex-user-a@example.com-plus.json"] != 2 {
+		t.Fatalf("plus picks = %d, want 2 (counts: %v)", counts["codex-user-a@example.com-plus.json"], counts)
+	}
+}
+
+func TestCodexPlanWeightedSelectorPick_ConcurrencyCeiling(t *testing.T) {
+	t.Parallel()
+
+	selector := NewCodexPlanWeightedSelector(&RoundRobinSelector{}, map[string]int{"plus": 1, "team": 1}, map[string]int{"team": 1})
+	teamID := "codex-user-b@example.com-team.json"
+	plusID := "codex-user-a@example.com-plus.json"
+	auths := []*Auth{
+		{ID: plusID, FileName: plusID},
+		{ID: teamID, FileName: teamID},
+	}
+
+	first, err := selector.Pick(context.Background(), "codex", "gpt-5", cliproxyexecutor.Options{}, auths)
+	if err != nil {
+		t.Fatalf("Pick() #1 error = %v", err)
+	}
+	if first.ID != plusID {
+		// Advance until the team account holds its single slot.
+		for first.ID != teamID {
+			selector.ObserveResult(Result{AuthID: first.ID, Success: true})
+			first, err = selector.Pick(context.Background(), "codex", "gpt-5", cliproxyexecutor.Options{}, auths)
+			if err != nil {
+				t.Fatalf("Pick() error = %v", err)
+			}
+		}
+	} else {
+		selector.ObserveResult(Result{AuthID: first.ID, Success: true})
+		first, err = selector.Pick(context.Background(), "codex", "gpt-5", cliproxyexecutor.Options{}, auths)
+		if err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+	}
+	if first.ID != teamID {
+		t.Fatalf("expected team auth to be selected, got %q", first.ID)
+	}
+
+	// While the team slot is held, further picks must avoid the capped plan.
+	for i := 0; i < 4; i++ {
+		got, errPick := selector.Pick(context.Background(), "codex", "gpt-5", cliproxyexecutor.Options{}, auths)
+		if errPick != nil {
+			t.Fatalf("Pick() capped #%d error = %v", i, errPick)
+		}
+		if got.ID != plusID {
+			t.Fatalf("Pick() capped #%d auth.ID = %q, want %q", i, got.ID, plusID)
+		}
+		selector.ObserveResult(Result{AuthID: got.ID, Success: true})
+	}
+
+	// Releasing the team slot makes the team account eligible again.
+	selector.ObserveResult(Result{AuthID: teamID, Success: true})
+	sawTeam := false
+	for i := 0; i < 4; i++ {
+		got, errPick := selector.Pick(context.Background(), "codex", "gpt-5", cliproxyexecutor.Options{}, auths)
+		if errPick != nil {
+			t.Fatalf("Pick() released #%d error = %v", i, errPick)
+		}
+		if got.ID == teamID {
+			sawTeam = true
+		}
+		selector.ObserveResult(Result{AuthID: got.ID, Success: true})
+	}
+	if !sawTeam {
+		t.Fatalf("team auth was never selected after releasing its slot")
+	}
+}
+
+func TestCodexPlanWeightedSelectorPick_DelegatesOtherProviders(t *testing.T) {
+	t.Parallel()
+
+	selector := NewCodexPlanWeightedSelector(&FillFirstSelector{}, nil, nil)
+	auths := []*Auth{
+		{ID: "b"},
+		{ID: "a"},
+	}
+
+	got, err := selector.Pick(context.Background(), "gemini", "", cliproxyexecutor.Options{}, auths)
+	if err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	if got.ID != "a" {
+		t.Fatalf("Pick() auth.ID = %q, want %q", got.ID, "a")
+	}
+}
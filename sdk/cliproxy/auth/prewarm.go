@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Warmer is an optional capability for provider executors that can pay
+// per-credential cold-start costs ahead of the first request, such as minting
+// a short-lived API token or resolving project metadata. Warmup receives a
+// clone of the stored auth; it reports updated=true when it mutated the
+// auth's metadata so the manager persists the change.
+type Warmer interface {
+	Warmup(ctx context.Context, auth *Auth) (updated bool, err error)
+}
+
+// prewarmConcurrency bounds how many credentials are warmed in parallel so a
+// large auth directory does not stampede provider endpoints on boot.
+const prewarmConcurrency = 8
+
+// Prewarm walks every stored auth once at startup so the first user request
+// does not pay multi-second cold-start costs: credentials that are due for a
+// refresh (e.g. near-expiry OAuth tokens) are refreshed immediately instead of
+// waiting for the background loop, and executors implementing Warmer run their
+// provider-specific warmup. Failures are logged and never block boot.
+func (m *Manager) Prewarm(ctx context.Context) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	snapshot := m.snapshotAuths()
+
+	sem := make(chan struct{}, prewarmConcurrency)
+	var wg sync.WaitGroup
+	var warmed, failed atomic.Int64
+	for _, a := range snapshot {
+		if a == nil || a.Disabled {
+			continue
+		}
+		executor := m.executorFor(a.Provider)
+		if executor == nil {
+			continue
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(a *Auth, executor ProviderExecutor) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if typ, _ := a.AccountInfo(); typ != "api_key" {
+				now := time.Now()
+				if m.shouldRefresh(a, now) && m.markRefreshPending(a.ID, now) {
+					m.refreshAuth(ctx, a.ID)
+					// Warm the post-refresh state, not the stale snapshot.
+					if fresh, ok := m.GetByID(a.ID); ok && fresh != nil {
+						a = fresh
+					}
+				}
+			}
+			warmer, ok := executor.(Warmer)
+			if !ok {
+				return
+			}
+			updated, err := warmer.Warmup(ctx, a)
+			if err != nil {
+				failed.Add(1)
+				log.Debugf("prewarm: warmup failed for %s %s: %v", a.Provider, a.ID, err)
+				return
+			}
+			warmed.Add(1)
+			if updated {
+				a.UpdatedAt = time.Now()
+				_, _ = m.Update(ctx, a)
+			}
+		}(a, executor)
+	}
+	wg.Wait()
+	log.Infof("auth prewarm finished: %d warmed, %d failed, %d auths in %s",
+		warmed.Load(), failed.Load(), len(snapshot), time.Since(start).Round(time.Millisecond))
+}
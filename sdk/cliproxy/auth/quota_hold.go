@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// quotaHoldThreshold holds the quota-exceeded.max-wait-seconds setting in
+// nanoseconds; zero disables holding and 429s propagate as before.
+var quotaHoldThreshold atomic.Int64
+
+// quotaHoldTotalCap bounds the cumulative time a single request may be held
+// across repeated quota 429s, so a client without a deadline cannot be parked
+// indefinitely on a credential pool that never recovers.
+const quotaHoldTotalCap = 2 * time.Minute
+
+// SetQuotaWaitRetryThreshold configures the longest provider retry hint the
+// gateway will absorb by holding the request and retrying, instead of
+// propagating the quota 429 to the client. Zero or negative disables the mode.
+func SetQuotaWaitRetryThreshold(threshold time.Duration) {
+	if threshold < 0 {
+		threshold = 0
+	}
+	quotaHoldThreshold.Store(int64(threshold))
+}
+
+// quotaHoldWait reports whether the error is a quota 429 whose retry hint is
+// short enough to wait out. held is the time this request has already spent
+// waiting, so repeated short hints cannot park the request past the cap.
+// Holding does not consume the request's retry budget: the point of the mode
+// is to absorb transient quota exhaustion that the normal budget would turn
+// into a client-visible failure.
+func quotaHoldWait(err error, held time.Duration) (time.Duration, bool) {
+	threshold := time.Duration(quotaHoldThreshold.Load())
+	if threshold <= 0 || err == nil {
+		return 0, false
+	}
+	if statusCodeFromError(err) != http.StatusTooManyRequests {
+		return 0, false
+	}
+	retryAfter := retryAfterFromError(err)
+	if retryAfter == nil || *retryAfter <= 0 || *retryAfter > threshold {
+		return 0, false
+	}
+	if held+*retryAfter > quotaHoldTotalCap {
+		return 0, false
+	}
+	return *retryAfter, true
+}
@@ -0,0 +1,55 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// holdTestErr mimics the executor status errors that carry a retry hint.
+type holdTestErr struct {
+	code       int
+	retryAfter *time.Duration
+}
+
+func (e holdTestErr) Error() string              { return "hold test error" }
+func (e holdTestErr) StatusCode() int            { return e.code }
+func (e holdTestErr) RetryAfter() *time.Duration { return e.retryAfter }
+
+func TestQuotaHoldWait(t *testing.T) {
+	SetQuotaWaitRetryThreshold(10 * time.Second)
+	defer SetQuotaWaitRetryThreshold(0)
+
+	short := 3 * time.Second
+	long := 30 * time.Second
+
+	cases := []struct {
+		name     string
+		err      error
+		held     time.Duration
+		wantWait time.Duration
+		wantHold bool
+	}{
+		{name: "short hint held", err: holdTestErr{code: http.StatusTooManyRequests, retryAfter: &short}, wantWait: short, wantHold: true},
+		{name: "hint above threshold", err: holdTestErr{code: http.StatusTooManyRequests, retryAfter: &long}},
+		{name: "missing hint", err: holdTestErr{code: http.StatusTooManyRequests}},
+		{name: "non-429 status", err: holdTestErr{code: http.StatusServiceUnavailable, retryAfter: &short}},
+		{name: "plain error", err: errors.New("boom")},
+		{name: "nil error"},
+		{name: "cumulative cap reached", err: holdTestErr{code: http.StatusTooManyRequests, retryAfter: &short}, held: quotaHoldTotalCap},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			wait, hold := quotaHoldWait(tc.err, tc.held)
+			if hold != tc.wantHold || wait != tc.wantWait {
+				t.Fatalf("quotaHoldWait = %s, %t, want %s, %t", wait, hold, tc.wantWait, tc.wantHold)
+			}
+		})
+	}
+
+	SetQuotaWaitRetryThreshold(0)
+	if _, hold := quotaHoldWait(holdTestErr{code: http.StatusTooManyRequests, retryAfter: &short}, 0); hold {
+		t.Fatalf("quotaHoldWait active while disabled")
+	}
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"sync/atomic"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	"github.com/tidwall/gjson"
+)
+
+// coalesceIdenticalRequests gates request coalescing; it is off unless the
+// coalesce-identical-requests config key enables it.
+var coalesceIdenticalRequests atomic.Bool
+
+// SetRequestCoalescingEnabled toggles coalescing of identical concurrent
+// non-streaming requests into a single upstream call.
+func SetRequestCoalescingEnabled(enabled bool) {
+	coalesceIdenticalRequests.Store(enabled)
+}
+
+// coalesceCall tracks one in-flight upstream execution shared by every caller
+// with the same coalesce key. done is closed once resp and err are final.
+type coalesceCall struct {
+	done chan struct{}
+	resp cliproxyexecutor.Response
+	err  error
+}
+
+// requestCoalescer deduplicates concurrent executions by key: the first caller
+// becomes the leader and runs the request, later callers wait for its result.
+type requestCoalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalesceCall
+}
+
+var defaultRequestCoalescer = &requestCoalescer{calls: make(map[string]*coalesceCall)}
+
+// coalesceKey derives the deduplication key for a request, returning false when
+// the request must not be coalesced. Only requests with an explicit temperature
+// of zero qualify: agent frameworks fire duplicate deterministic calls on retry
+// races, while sampled requests are expected to yield distinct completions.
+func coalesceKey(req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (string, bool) {
+	if !coalesceIdenticalRequests.Load() || opts.Stream || len(req.Payload) == 0 {
+		return "", false
+	}
+	temperature := gjson.GetBytes(req.Payload, "temperature")
+	if !temperature.Exists() {
+		temperature = gjson.GetBytes(req.Payload, "generationConfig.temperature")
+	}
+	if !temperature.Exists() || temperature.Float() != 0 {
+		return "", false
+	}
+	sum := sha256.New()
+	sum.Write([]byte(req.Model))
+	sum.Write([]byte{'|'})
+	sum.Write([]byte(opts.SourceFormat))
+	sum.Write([]byte{'|'})
+	sum.Write(req.Payload)
+	return hex.EncodeToString(sum.Sum(nil)), true
+}
+
+// do executes fn once per key across concurrent callers. The leader runs fn
+// and every follower that arrives before it finishes receives a copy of the
+// same result, including any error. A follower whose context expires while
+// waiting returns its own context error without affecting the leader.
+func (c *requestCoalescer) do(ctx context.Context, key string, fn func() (cliproxyexecutor.Response, error)) (cliproxyexecutor.Response, error) {
+	c.mu.Lock()
+	if call, ok := c.calls[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return cloneCoalescedResponse(call.resp), call.err
+		case <-ctx.Done():
+			return cliproxyexecutor.Response{}, ctx.Err()
+		}
+	}
+	call := &coalesceCall{done: make(chan struct{})}
+	c.calls[key] = call
+	c.mu.Unlock()
+
+	call.resp, call.err = fn()
+
+	c.mu.Lock()
+	delete(c.calls, key)
+	c.mu.Unlock()
+	close(call.done)
+	return call.resp, call.err
+}
+
+// cloneCoalescedResponse copies the payload so followers and the leader never
+// share a mutable byte slice with downstream translation.
+func cloneCoalescedResponse(resp cliproxyexecutor.Response) cliproxyexecutor.Response {
+	if len(resp.Payload) > 0 {
+		payload := make([]byte, len(resp.Payload))
+		copy(payload, resp.Payload)
+		resp.Payload = payload
+	}
+	return resp
+}
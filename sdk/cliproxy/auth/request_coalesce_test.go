@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+func TestCoalesceKey(t *testing.T) {
+	SetRequestCoalescingEnabled(true)
+	defer SetRequestCoalescingEnabled(false)
+
+	base := cliproxyexecutor.Request{Model: "gpt-5", Payload: []byte(`{"model":"gpt-5","temperature":0,"messages":[]}`)}
+	opts := cliproxyexecutor.Options{SourceFormat: "openai"}
+
+	key, ok := coalesceKey(base, opts)
+	if !ok || key == "" {
+		t.Fatalf("coalesceKey(temperature 0) = %q, %t, want key, true", key, ok)
+	}
+	if again, _ := coalesceKey(base, opts); again != key {
+		t.Fatalf("coalesceKey not stable: %q vs %q", key, again)
+	}
+
+	cases := []struct {
+		name string
+		req  cliproxyexecutor.Request
+		opts cliproxyexecutor.Options
+	}{
+		{name: "nonzero temperature", req: cliproxyexecutor.Request{Model: "gpt-5", Payload: []byte(`{"temperature":0.7}`)}, opts: opts},
+		{name: "missing temperature", req: cliproxyexecutor.Request{Model: "gpt-5", Payload: []byte(`{"messages":[]}`)}, opts: opts},
+		{name: "empty payload", req: cliproxyexecutor.Request{Model: "gpt-5"}, opts: opts},
+		{name: "streaming", req: base, opts: cliproxyexecutor.Options{SourceFormat: "openai", Stream: true}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, ok := coalesceKey(tc.req, tc.opts); ok {
+				t.Fatalf("coalesceKey unexpectedly coalescable")
+			}
+		})
+	}
+
+	geminiReq := cliproxyexecutor.Request{Model: "gemini-2.5-pro", Payload: []byte(`{"generationConfig":{"temperature":0},"contents":[]}`)}
+	if _, ok := coalesceKey(geminiReq, cliproxyexecutor.Options{SourceFormat: "gemini"}); !ok {
+		t.Fatalf("coalesceKey missed generationConfig.temperature")
+	}
+
+	if otherModel, _ := coalesceKey(cliproxyexecutor.Request{Model: "gpt-4o", Payload: base.Payload}, opts); otherModel == key {
+		t.Fatalf("coalesceKey ignored model")
+	}
+	if otherFormat, _ := coalesceKey(base, cliproxyexecutor.Options{SourceFormat: "claude"}); otherFormat == key {
+		t.Fatalf("coalesceKey ignored source format")
+	}
+
+	SetRequestCoalescingEnabled(false)
+	if _, ok := coalesceKey(base, opts); ok {
+		t.Fatalf("coalesceKey active while disabled")
+	}
+	SetRequestCoalescingEnabled(true)
+}
+
+func TestRequestCoalescerFanOut(t *testing.T) {
+	coalescer := &requestCoalescer{calls: make(map[string]*coalesceCall)}
+
+	// Pre-register the in-flight call so every caller below deterministically
+	// takes the follower path.
+	call := &coalesceCall{done: make(chan struct{})}
+	coalescer.mu.Lock()
+	coalescer.calls["key"] = call
+	coalescer.mu.Unlock()
+
+	var followerExecutions atomic.Int64
+	followerFn := func() (cliproxyexecutor.Response, error) {
+		followerExecutions.Add(1)
+		return cliproxyexecutor.Response{}, nil
+	}
+
+	const followers = 4
+	var wg sync.WaitGroup
+	results := make([]cliproxyexecutor.Response, followers)
+	errs := make([]error, followers)
+	for i := 0; i < followers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = coalescer.do(context.Background(), "key", followerFn)
+		}(i)
+	}
+
+	// Give the followers time to park on call.done before completing it.
+	time.Sleep(50 * time.Millisecond)
+
+	call.resp = cliproxyexecutor.Response{Payload: []byte(`{"id":"shared"}`)}
+	coalescer.mu.Lock()
+	delete(coalescer.calls, "key")
+	coalescer.mu.Unlock()
+	close(call.done)
+	wg.Wait()
+
+	if got := followerExecutions.Load(); got != 0 {
+		t.Fatalf("followers executed fn %d times, want 0", got)
+	}
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("follower %d error: %v", i, errs[i])
+		}
+		if string(results[i].Payload) != `{"id":"shared"}` {
+			t.Fatalf("follower %d payload = %s", i, results[i].Payload)
+		}
+	}
+	// Followers must not share the leader's backing slice.
+	results[0].Payload[0] = 'X'
+	if string(results[1].Payload) != `{"id":"shared"}` {
+		t.Fatalf("follower payloads share a backing slice")
+	}
+
+	// With the call finished and deregistered, the same key runs fresh.
+	fresh, err := coalescer.do(context.Background(), "key", func() (cliproxyexecutor.Response, error) {
+		return cliproxyexecutor.Response{Payload: []byte(`{"id":"fresh"}`)}, nil
+	})
+	if err != nil || string(fresh.Payload) != `{"id":"fresh"}` {
+		t.Fatalf("post-completion call = %s, %v, want fresh execution", fresh.Payload, err)
+	}
+	coalescer.mu.Lock()
+	remaining := len(coalescer.calls)
+	coalescer.mu.Unlock()
+	if remaining != 0 {
+		t.Fatalf("coalescer left %d stale calls registered", remaining)
+	}
+}
+
+func TestRequestCoalescerFollowerContextCancel(t *testing.T) {
+	coalescer := &requestCoalescer{calls: make(map[string]*coalesceCall)}
+	release := make(chan struct{})
+	leaderRunning := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _ = coalescer.do(context.Background(), "key", func() (cliproxyexecutor.Response, error) {
+			close(leaderRunning)
+			<-release
+			return cliproxyexecutor.Response{}, nil
+		})
+	}()
+	<-leaderRunning
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := coalescer.do(ctx, "key", nil); err != context.Canceled {
+		t.Fatalf("cancelled follower error = %v, want context.Canceled", err)
+	}
+	close(release)
+	wg.Wait()
+}
@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rolloutDefaultCanaryPercent is the share of traffic routed to the new
+	// credential when the operator does not specify one.
+	rolloutDefaultCanaryPercent = 10
+	// rolloutMinCanaryRequests is how many canary requests must complete
+	// before the rollout is judged.
+	rolloutMinCanaryRequests = 20
+	// rolloutMaxFailureRate is the canary failure ratio above which the
+	// rollout is aborted instead of promoted.
+	rolloutMaxFailureRate = 0.2
+	// rolloutEarlyAbortFailures aborts a clearly broken credential before the
+	// full canary sample is collected.
+	rolloutEarlyAbortFailures = 5
+)
+
+// Rollout states reported by the management API.
+const (
+	RolloutStateCanary   = "canary"
+	RolloutStatePromoted = "promoted"
+	RolloutStateAborted  = "aborted"
+)
+
+// AuthRollout describes one staged credential cutover: a canary share of
+// traffic is routed to the new auth while the old one keeps serving the rest;
+// once enough canary requests succeed the new auth takes all traffic and the
+// old entry is retired, and a failing canary falls back to the old auth.
+type AuthRollout struct {
+	OldID         string    `json:"old_id"`
+	NewID         string    `json:"new_id"`
+	CanaryPercent int       `json:"canary_percent"`
+	State         string    `json:"state"`
+	Requests      int       `json:"requests"`
+	Failures      int       `json:"failures"`
+	StartedAt     time.Time `json:"started_at"`
+	CompletedAt   time.Time `json:"completed_at,omitempty"`
+}
+
+// rolloutTransition tells the manager how to react to a canary verdict.
+type rolloutTransition struct {
+	promote bool
+	oldID   string
+	newID   string
+}
+
+// authRolloutController tracks active staged cutovers keyed by the new auth ID.
+type authRolloutController struct {
+	mu       sync.Mutex
+	rollouts map[string]*AuthRollout
+	cursor   int
+}
+
+var defaultAuthRollouts = &authRolloutController{rollouts: make(map[string]*AuthRollout)}
+
+// StartAuthRollout begins a staged cutover from oldID to newID with the given
+// canary percentage (1-99; zero selects the default). Starting a rollout that
+// involves an auth already part of an active one fails.
+func StartAuthRollout(oldID, newID string, canaryPercent int) (*AuthRollout, error) {
+	oldID = strings.TrimSpace(oldID)
+	newID = strings.TrimSpace(newID)
+	if oldID == "" || newID == "" {
+		return nil, fmt.Errorf("rollout: old and new auth IDs are required")
+	}
+	if oldID == newID {
+		return nil, fmt.Errorf("rollout: old and new auth IDs must differ")
+	}
+	if canaryPercent == 0 {
+		canaryPercent = rolloutDefaultCanaryPercent
+	}
+	if canaryPercent < 1 || canaryPercent > 99 {
+		return nil, fmt.Errorf("rollout: canary percent must be between 1 and 99")
+	}
+	t := defaultAuthRollouts
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, rollout := range t.rollouts {
+		if rollout.State != RolloutStateCanary {
+			continue
+		}
+		for _, id := range []string{rollout.OldID, rollout.NewID} {
+			if id == oldID || id == newID {
+				return nil, fmt.Errorf("rollout: auth %s is already part of an active rollout", id)
+			}
+		}
+	}
+	rollout := &AuthRollout{
+		OldID:         oldID,
+		NewID:         newID,
+		CanaryPercent: canaryPercent,
+		State:         RolloutStateCanary,
+		StartedAt:     time.Now(),
+	}
+	t.rollouts[newID] = rollout
+	clone := *rollout
+	return &clone, nil
+}
+
+// CancelAuthRollout removes the rollout for the given new auth ID, returning
+// false when none exists.
+func CancelAuthRollout(newID string) bool {
+	t := defaultAuthRollouts
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.rollouts[strings.TrimSpace(newID)]; !ok {
+		return false
+	}
+	delete(t.rollouts, strings.TrimSpace(newID))
+	return true
+}
+
+// AuthRollouts returns a snapshot of all tracked rollouts, including finished
+// ones that have not been cancelled yet.
+func AuthRollouts() []*AuthRollout {
+	t := defaultAuthRollouts
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]*AuthRollout, 0, len(t.rollouts))
+	for _, rollout := range t.rollouts {
+		clone := *rollout
+		out = append(out, &clone)
+	}
+	return out
+}
+
+// observe records the outcome of a canary request and returns a transition
+// when the verdict is in: promote routes everything to the new auth and
+// retires the old one, abort falls back to the old auth.
+func (t *authRolloutController) observe(authID string, success bool) *rolloutTransition {
+	if t == nil || authID == "" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rollout, ok := t.rollouts[authID]
+	if !ok || rollout.State != RolloutStateCanary {
+		return nil
+	}
+	rollout.Requests++
+	if !success {
+		rollout.Failures++
+	}
+	failureRate := float64(rollout.Failures) / float64(rollout.Requests)
+	switch {
+	case rollout.Failures >= rolloutEarlyAbortFailures && failureRate > rolloutMaxFailureRate:
+		rollout.State = RolloutStateAborted
+	case rollout.Requests >= rolloutMinCanaryRequests && failureRate <= rolloutMaxFailureRate:
+		rollout.State = RolloutStatePromoted
+	case rollout.Requests >= rolloutMinCanaryRequests:
+		rollout.State = RolloutStateAborted
+	default:
+		return nil
+	}
+	rollout.CompletedAt = time.Now()
+	return &rolloutTransition{promote: rollout.State == RolloutStatePromoted, oldID: rollout.OldID, newID: rollout.NewID}
+}
+
+// routeFor decides which side of an active rollout should serve the next
+// request when both credentials are available: the verdict states pin traffic
+// to one side, and the canary state deals out the configured percentage to the
+// new auth round-robin style.
+func (t *authRolloutController) routeFor(oldAuth, newAuth *Auth) *Auth {
+	if t == nil || oldAuth == nil || newAuth == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rollout, ok := t.rollouts[newAuth.ID]
+	if !ok || rollout.OldID != oldAuth.ID {
+		return nil
+	}
+	switch rollout.State {
+	case RolloutStatePromoted:
+		return newAuth
+	case RolloutStateAborted:
+		return oldAuth
+	}
+	t.cursor++
+	if t.cursor%100 < rollout.CanaryPercent {
+		return newAuth
+	}
+	return oldAuth
+}
+
+// applyRolloutRouting resolves active rollouts inside the available priority
+// buckets: when both sides of a rollout are available only the routed one is
+// kept, so the canary percentage (or the final verdict) decides which
+// credential serves the request.
+func applyRolloutRouting(availableByPriority map[int][]*Auth) map[int][]*Auth {
+	t := defaultAuthRollouts
+	t.mu.Lock()
+	empty := len(t.rollouts) == 0
+	t.mu.Unlock()
+	if empty {
+		return availableByPriority
+	}
+
+	byID := make(map[string]*Auth)
+	for _, candidates := range availableByPriority {
+		for _, candidate := range candidates {
+			byID[candidate.ID] = candidate
+		}
+	}
+	drop := make(map[string]struct{})
+	t.mu.Lock()
+	pairs := make([][2]string, 0, len(t.rollouts))
+	for _, rollout := range t.rollouts {
+		pairs = append(pairs, [2]string{rollout.OldID, rollout.NewID})
+	}
+	t.mu.Unlock()
+	for _, pair := range pairs {
+		oldAuth, newAuth := byID[pair[0]], byID[pair[1]]
+		if oldAuth == nil || newAuth == nil {
+			continue
+		}
+		routed := t.routeFor(oldAuth, newAuth)
+		if routed == nil {
+			continue
+		}
+		if routed.ID == oldAuth.ID {
+			drop[newAuth.ID] = struct{}{}
+		} else {
+			drop[oldAuth.ID] = struct{}{}
+		}
+	}
+	if len(drop) == 0 {
+		return availableByPriority
+	}
+	filtered := make(map[int][]*Auth, len(availableByPriority))
+	for priority, candidates := range availableByPriority {
+		for _, candidate := range candidates {
+			if _, skip := drop[candidate.ID]; skip {
+				continue
+			}
+			filtered[priority] = append(filtered[priority], candidate)
+		}
+	}
+	if len(filtered) == 0 {
+		return availableByPriority
+	}
+	return filtered
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestAuthRolloutPromotesAfterHealthyCanary(t *testing.T) {
+	if _, err := StartAuthRollout("old-auth", "new-auth", 50); err != nil {
+		t.Fatalf("StartAuthRollout() error = %v", err)
+	}
+	defer CancelAuthRollout("new-auth")
+
+	for i := 0; i < rolloutMinCanaryRequests-1; i++ {
+		if transition := defaultAuthRollouts.observe("new-auth", true); transition != nil {
+			t.Fatalf("observe() returned a verdict after %d requests", i+1)
+		}
+	}
+	transition := defaultAuthRollouts.observe("new-auth", true)
+	if transition == nil || !transition.promote {
+		t.Fatalf("observe() = %+v, want promotion after a healthy canary", transition)
+	}
+
+	oldAuth := &Auth{ID: "old-auth", Provider: "gemini"}
+	newAuth := &Auth{ID: "new-auth", Provider: "gemini"}
+	available := map[int][]*Auth{0: {oldAuth, newAuth}}
+	filtered := applyRolloutRouting(available)
+	if len(filtered[0]) != 1 || filtered[0][0] != newAuth {
+		t.Fatalf("applyRolloutRouting() after promotion = %v, want new auth only", filtered[0])
+	}
+}
+
+func TestAuthRolloutAbortsOnFailingCanary(t *testing.T) {
+	if _, err := StartAuthRollout("old-bad", "new-bad", 50); err != nil {
+		t.Fatalf("StartAuthRollout() error = %v", err)
+	}
+	defer CancelAuthRollout("new-bad")
+
+	var transition *rolloutTransition
+	for i := 0; i < rolloutEarlyAbortFailures; i++ {
+		transition = defaultAuthRollouts.observe("new-bad", false)
+	}
+	if transition == nil || transition.promote {
+		t.Fatalf("observe() = %+v, want early abort after repeated failures", transition)
+	}
+
+	oldAuth := &Auth{ID: "old-bad", Provider: "gemini"}
+	newAuth := &Auth{ID: "new-bad", Provider: "gemini"}
+	available := map[int][]*Auth{0: {oldAuth, newAuth}}
+	filtered := applyRolloutRouting(available)
+	if len(filtered[0]) != 1 || filtered[0][0] != oldAuth {
+		t.Fatalf("applyRolloutRouting() after abort = %v, want old auth only", filtered[0])
+	}
+}
+
+func TestAuthRolloutRejectsOverlappingPairs(t *testing.T) {
+	if _, err := StartAuthRollout("old-x", "new-x", 0); err != nil {
+		t.Fatalf("StartAuthRollout() error = %v", err)
+	}
+	defer CancelAuthRollout("new-x")
+
+	if _, err := StartAuthRollout("old-x", "new-y", 10); err == nil {
+		CancelAuthRollout("new-y")
+		t.Fatalf("StartAuthRollout() accepted an auth already in an active rollout")
+	}
+	if _, err := StartAuthRollout("old-x", "old-x", 10); err == nil {
+		t.Fatalf("StartAuthRollout() accepted identical old and new IDs")
+	}
+}
@@ -125,7 +125,9 @@ func collectAvailableByPriority(auths []*Auth, model string, now time.Time) (ava
 		candidate := auths[i]
 		blocked, reason, next := isAuthBlockedForModel(candidate, model, now)
 		if !blocked {
-			priority := authPriority(candidate)
+			// Demote auths whose rolling window shows elevated error rates or
+			// slow p95 latency so healthy peers are preferred until they recover.
+			priority := authPriority(candidate) - defaultAuthHealthTracker.demotionFor(candidate.ID, now)
 			available[priority] = append(available[priority], candidate)
 			continue
 		}
@@ -160,6 +162,9 @@ func getAvailableAuths(auths []*Auth, provider, model string, now time.Time) ([]
 		return nil, &Error{Code: "auth_unavailable", Message: "no auth available"}
 	}
 
+	availableByPriority = applyTimeWindowRouting(availableByPriority, now)
+	availableByPriority = applyRolloutRouting(availableByPriority)
+
 	bestPriority := 0
 	found := false
 	for priority := range availableByPriority {
@@ -218,9 +223,12 @@ func isAuthBlockedForModel(auth *Auth, model string, now time.Time) (bool, block
 	if auth == nil {
 		return true, blockReasonOther, time.Time{}
 	}
-	if auth.Disabled || auth.Status == StatusDisabled {
+	if auth.Disabled || auth.Status == StatusDisabled || auth.Status == StatusDraining {
 		return true, blockReasonDisabled, time.Time{}
 	}
+	if spent, next := defaultKeyBudgetTracker.blockedUntil(auth, now); spent {
+		return true, blockReasonCooldown, next
+	}
 	if model != "" {
 		if len(auth.ModelStates) > 0 {
 			if state, ok := auth.ModelStates[model]; ok && state != nil {
@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+	"github.com/tidwall/gjson"
+)
+
+// sessionPinMetadataKey stores the conversation hash in Options.Metadata so it
+// travels from the execute loops into auth selection.
+const sessionPinMetadataKey = "session_pin"
+
+const (
+	// sessionPinTTL bounds how long an idle conversation stays pinned to an auth.
+	sessionPinTTL = 2 * time.Hour
+	// sessionPinMaxEntries caps the pin table; the stalest pin is evicted on overflow.
+	sessionPinMaxEntries = 4096
+)
+
+// sessionPinEntry remembers which auth served a pinned conversation.
+type sessionPinEntry struct {
+	authID   string
+	lastSeen time.Time
+}
+
+// sessionPinStore maps conversation hashes to the auth that produced their
+// thinking signatures. Claude/Antigravity thought signatures only validate
+// against the account that emitted them, so follow-up turns of a thinking
+// conversation must be routed to the same auth.
+type sessionPinStore struct {
+	mu      sync.Mutex
+	entries map[string]sessionPinEntry
+}
+
+var defaultSessionPinStore = newSessionPinStore()
+
+func newSessionPinStore() *sessionPinStore {
+	return &sessionPinStore{entries: make(map[string]sessionPinEntry)}
+}
+
+// lookup returns the auth pinned to the conversation key, if the pin has not expired.
+func (s *sessionPinStore) lookup(key string, now time.Time) (string, bool) {
+	if s == nil || key == "" {
+		return "", false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return "", false
+	}
+	if now.Sub(entry.lastSeen) > sessionPinTTL {
+		delete(s.entries, key)
+		return "", false
+	}
+	return entry.authID, true
+}
+
+// bind pins the conversation key to the auth, refreshing the TTL on every turn.
+func (s *sessionPinStore) bind(key, authID string, now time.Time) {
+	if s == nil || key == "" || authID == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= sessionPinMaxEntries {
+		s.evictStalestLocked(now)
+	}
+	s.entries[key] = sessionPinEntry{authID: authID, lastSeen: now}
+}
+
+// evictStalestLocked drops expired pins, falling back to the stalest live pin
+// when nothing has expired. Callers must hold s.mu.
+func (s *sessionPinStore) evictStalestLocked(now time.Time) {
+	stalestKey := ""
+	var stalestSeen time.Time
+	for key, entry := range s.entries {
+		if now.Sub(entry.lastSeen) > sessionPinTTL {
+			delete(s.entries, key)
+			continue
+		}
+		if stalestKey == "" || entry.lastSeen.Before(stalestSeen) {
+			stalestKey = key
+			stalestSeen = entry.lastSeen
+		}
+	}
+	if len(s.entries) >= sessionPinMaxEntries && stalestKey != "" {
+		delete(s.entries, stalestKey)
+	}
+}
+
+// sessionPinKey derives a stable conversation hash for requests that carry
+// thinking signatures. The hash covers the model, system prompt, and first
+// message — the prefix that stays constant as a conversation grows — so every
+// turn of the same conversation yields the same key. Requests without
+// signature material return an empty key and are not pinned.
+func sessionPinKey(req cliproxyexecutor.Request) string {
+	payload := req.Payload
+	if len(payload) == 0 {
+		return ""
+	}
+	if !bytes.Contains(payload, []byte(`"signature"`)) && !bytes.Contains(payload, []byte(`"thoughtSignature"`)) {
+		return ""
+	}
+	first := gjson.GetBytes(payload, "messages.0")
+	if !first.Exists() {
+		first = gjson.GetBytes(payload, "contents.0")
+	}
+	if !first.Exists() {
+		return ""
+	}
+	system := gjson.GetBytes(payload, "system")
+	if !system.Exists() {
+		system = gjson.GetBytes(payload, "systemInstruction")
+	}
+	sum := sha256.Sum256([]byte(req.Model + "|" + system.Raw + "|" + first.Raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// withSessionPinOption stores the conversation key on a copy of the options
+// metadata so selection can see it without mutating the caller's map.
+func withSessionPinOption(opts cliproxyexecutor.Options, key string) cliproxyexecutor.Options {
+	if key == "" {
+		return opts
+	}
+	metadata := make(map[string]any, len(opts.Metadata)+1)
+	for k, v := range opts.Metadata {
+		metadata[k] = v
+	}
+	metadata[sessionPinMetadataKey] = key
+	opts.Metadata = metadata
+	return opts
+}
+
+// sessionPinFromOptions extracts the conversation key set by withSessionPinOption.
+func sessionPinFromOptions(opts cliproxyexecutor.Options) string {
+	if opts.Metadata == nil {
+		return ""
+	}
+	key, _ := opts.Metadata[sessionPinMetadataKey].(string)
+	return key
+}
+
+// pinnedCandidate returns the candidate the conversation is pinned to, when it
+// is still present and not blocked for the model. A missing or blocked pin
+// falls back to normal selection; the subsequent bind repins the conversation
+// to whichever auth ends up serving it.
+func pinnedCandidate(opts cliproxyexecutor.Options, model string, candidates []*Auth, now time.Time) *Auth {
+	key := sessionPinFromOptions(opts)
+	if key == "" {
+		return nil
+	}
+	authID, ok := defaultSessionPinStore.lookup(key, now)
+	if !ok {
+		return nil
+	}
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.ID != authID {
+			continue
+		}
+		if blocked, _, _ := isAuthBlockedForModel(candidate, model, now); blocked {
+			return nil
+		}
+		return candidate
+	}
+	return nil
+}
+
+// bindSessionPin records the auth that served a pinned conversation.
+func bindSessionPin(opts cliproxyexecutor.Options, authID string, now time.Time) {
+	key := sessionPinFromOptions(opts)
+	if key == "" {
+		return
+	}
+	defaultSessionPinStore.bind(key, authID, now)
+}
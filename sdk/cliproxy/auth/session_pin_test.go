@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+func TestSessionPinKey(t *testing.T) {
+	turnOne := []byte(`{"system":"be brief","messages":[{"role":"user","content":"hi"}],"thinking":{"type":"enabled"}}`)
+	turnTwo := []byte(`{"system":"be brief","messages":[{"role":"user","content":"hi"},{"role":"assistant","content":[{"type":"thinking","thinking":"...","signature":"abc"}]},{"role":"user","content":"more"}]}`)
+
+	keyOne := sessionPinKey(cliproxyexecutor.Request{Model: "claude-sonnet-4", Payload: turnTwo})
+	if keyOne == "" {
+		t.Fatalf("sessionPinKey() = empty for payload with signature")
+	}
+	keyTwo := sessionPinKey(cliproxyexecutor.Request{Model: "claude-sonnet-4", Payload: turnTwo})
+	if keyTwo != keyOne {
+		t.Fatalf("sessionPinKey() not stable: %q vs %q", keyOne, keyTwo)
+	}
+
+	if got := sessionPinKey(cliproxyexecutor.Request{Model: "claude-sonnet-4", Payload: turnOne}); got != "" {
+		t.Fatalf("sessionPinKey() = %q for payload without signature, want empty", got)
+	}
+	otherModel := sessionPinKey(cliproxyexecutor.Request{Model: "claude-opus-4", Payload: turnTwo})
+	if otherModel == keyOne {
+		t.Fatalf("sessionPinKey() ignored the model")
+	}
+}
+
+func TestSessionPinStore_TTL(t *testing.T) {
+	store := newSessionPinStore()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	store.bind("conv", "auth-1", now)
+	if got, ok := store.lookup("conv", now.Add(time.Minute)); !ok || got != "auth-1" {
+		t.Fatalf("lookup() = %q, %v; want auth-1, true", got, ok)
+	}
+	// Each turn refreshes the TTL.
+	store.bind("conv", "auth-1", now.Add(sessionPinTTL))
+	if _, ok := store.lookup("conv", now.Add(sessionPinTTL+time.Minute)); !ok {
+		t.Fatalf("lookup() expired despite refreshed pin")
+	}
+	if _, ok := store.lookup("conv", now.Add(3*sessionPinTTL)); ok {
+		t.Fatalf("lookup() returned an expired pin")
+	}
+}
+
+func TestPinnedCandidate(t *testing.T) {
+	now := time.Now()
+	candidates := []*Auth{
+		{ID: "auth-1", Provider: "claude", Status: StatusActive},
+		{ID: "auth-2", Provider: "claude", Status: StatusActive},
+	}
+	key := "conversation-hash"
+	defaultSessionPinStore.bind(key, "auth-2", now)
+	defer func() {
+		defaultSessionPinStore.mu.Lock()
+		delete(defaultSessionPinStore.entries, key)
+		defaultSessionPinStore.mu.Unlock()
+	}()
+
+	opts := withSessionPinOption(cliproxyexecutor.Options{}, key)
+	if got := pinnedCandidate(opts, "claude-sonnet-4", candidates, now); got == nil || got.ID != "auth-2" {
+		t.Fatalf("pinnedCandidate() = %v, want auth-2", got)
+	}
+	// A pinned auth that dropped out of the candidate set falls back to selection.
+	if got := pinnedCandidate(opts, "claude-sonnet-4", candidates[:1], now); got != nil {
+		t.Fatalf("pinnedCandidate() = %v for absent pin, want nil", got)
+	}
+	if got := pinnedCandidate(cliproxyexecutor.Options{}, "claude-sonnet-4", candidates, now); got != nil {
+		t.Fatalf("pinnedCandidate() = %v without a pin key, want nil", got)
+	}
+}
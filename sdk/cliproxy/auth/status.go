@@ -16,4 +16,8 @@ const (
 	StatusError Status = "error"
 	// StatusDisabled marks the auth as intentionally disabled.
 	StatusDisabled Status = "disabled"
+	// StatusDraining marks the auth as draining for maintenance: no new
+	// requests are routed to it while in-flight streams finish, so it can be
+	// safely re-logged-in or removed.
+	StatusDraining Status = "draining"
 )
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	cliproxyexecutor "github.com/shariqriazz/modelgate/sdk/cliproxy/executor"
+)
+
+// streamUpstreamModels holds the model name patterns (with '*' wildcards)
+// whose non-streaming requests are executed against the provider's streaming
+// endpoint and buffered into a single blocking response.
+var streamUpstreamModels atomic.Value
+
+// SetStreamUpstreamModels replaces the set of models forced to stream
+// upstream. It is wired to the stream-upstream-models config key and safe on
+// reload.
+func SetStreamUpstreamModels(patterns []string) {
+	cleaned := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if trimmed := strings.TrimSpace(pattern); trimmed != "" {
+			cleaned = append(cleaned, trimmed)
+		}
+	}
+	streamUpstreamModels.Store(cleaned)
+}
+
+func streamUpstreamFor(model string) bool {
+	patterns, _ := streamUpstreamModels.Load().([]string)
+	for _, pattern := range patterns {
+		if matchModelWildcard(pattern, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// executeOrBuffer dispatches to the provider's real non-streaming path unless
+// the model is configured to stream upstream, in which case the streaming
+// endpoint is used (more reliable on some providers) and the chunks are
+// buffered and folded back into a blocking response. This generalizes the
+// stream-to-nonstream buffering the Antigravity executor applies to Claude
+// payloads.
+func (m *Manager) executeOrBuffer(ctx context.Context, executor ProviderExecutor, auth *Auth, req cliproxyexecutor.Request, opts cliproxyexecutor.Options) (cliproxyexecutor.Response, error) {
+	if errSmooth := smoothDispatch(ctx, auth); errSmooth != nil {
+		return cliproxyexecutor.Response{}, errSmooth
+	}
+	if transportModeFor(req.Model) != transportStream || !cliproxyexecutor.CanAggregateStream(string(opts.SourceFormat)) {
+		return executor.Execute(ctx, auth, req, opts)
+	}
+	streamOpts := opts
+	streamOpts.Stream = true
+	chunks, errStream := executor.ExecuteStream(ctx, auth, req, streamOpts)
+	if errStream != nil {
+		return cliproxyexecutor.Response{}, errStream
+	}
+	var collected [][]byte
+	for chunk := range chunks {
+		if chunk.Err != nil {
+			return cliproxyexecutor.Response{}, chunk.Err
+		}
+		collected = append(collected, chunk.Payload)
+	}
+	return cliproxyexecutor.Response{Payload: cliproxyexecutor.AggregateStreamChunks(string(opts.SourceFormat), collected)}, nil
+}
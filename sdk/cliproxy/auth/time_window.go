@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// TimeWindowRule expresses a time-of-day routing preference: while the window
+// is active, selection prefers credentials matching one of the Prefer keys and
+// falls back to the remaining candidates only when none are available. This
+// lets operators exploit quota resets and off-peak allowances, e.g. free-tier
+// keys overnight and subscription accounts during the day.
+type TimeWindowRule struct {
+	// Start is the inclusive window start in "HH:MM" server-local time.
+	Start string
+	// End is the exclusive window end in "HH:MM". A window whose end is not
+	// after its start wraps past midnight; equal start and end means all day.
+	End string
+	// Prefer lists provider keys, auth IDs, credential file names, or labels.
+	Prefer []string
+}
+
+type timeWindowRule struct {
+	startMinute int
+	endMinute   int
+	prefer      []string
+}
+
+var routingTimeWindows atomic.Value // []timeWindowRule
+
+// SetRoutingTimeWindows replaces the time-window routing rules from config.
+// Rules with unparsable times or an empty prefer list are dropped; the first
+// rule whose window contains the current time wins.
+func SetRoutingTimeWindows(rules []TimeWindowRule) {
+	parsed := make([]timeWindowRule, 0, len(rules))
+	for _, rule := range rules {
+		start, okStart := parseClockMinutes(rule.Start)
+		end, okEnd := parseClockMinutes(rule.End)
+		if !okStart || !okEnd {
+			continue
+		}
+		prefer := make([]string, 0, len(rule.Prefer))
+		for _, key := range rule.Prefer {
+			if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+				prefer = append(prefer, key)
+			}
+		}
+		if len(prefer) == 0 {
+			continue
+		}
+		parsed = append(parsed, timeWindowRule{startMinute: start, endMinute: end, prefer: prefer})
+	}
+	routingTimeWindows.Store(parsed)
+}
+
+// parseClockMinutes parses an "HH:MM" clock value into minutes since midnight.
+func parseClockMinutes(value string) (int, bool) {
+	parts := strings.SplitN(strings.TrimSpace(value), ":", 2)
+	if len(parts) != 2 {
+		return 0, false
+	}
+	hour, errHour := strconv.Atoi(parts[0])
+	minute, errMinute := strconv.Atoi(parts[1])
+	if errHour != nil || errMinute != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, false
+	}
+	return hour*60 + minute, true
+}
+
+func (r timeWindowRule) contains(minuteOfDay int) bool {
+	if r.startMinute == r.endMinute {
+		return true
+	}
+	if r.startMinute < r.endMinute {
+		return minuteOfDay >= r.startMinute && minuteOfDay < r.endMinute
+	}
+	// Window wraps past midnight.
+	return minuteOfDay >= r.startMinute || minuteOfDay < r.endMinute
+}
+
+// activeTimeWindowPrefer returns the prefer keys of the first rule whose
+// window contains now, or nil when no rule is active.
+func activeTimeWindowPrefer(now time.Time) []string {
+	rules, _ := routingTimeWindows.Load().([]timeWindowRule)
+	if len(rules) == 0 {
+		return nil
+	}
+	minuteOfDay := now.Hour()*60 + now.Minute()
+	for _, rule := range rules {
+		if rule.contains(minuteOfDay) {
+			return rule.prefer
+		}
+	}
+	return nil
+}
+
+// authMatchesRoutingKeys reports whether the credential matches any of the
+// normalized routing keys by provider, ID, file name, or label.
+func authMatchesRoutingKeys(auth *Auth, keys []string) bool {
+	if auth == nil {
+		return false
+	}
+	for _, key := range keys {
+		for _, candidate := range []string{auth.Provider, auth.ID, auth.FileName, auth.Label} {
+			if candidate != "" && strings.EqualFold(strings.TrimSpace(candidate), key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applyTimeWindowRouting narrows the available priority buckets to credentials
+// preferred by the active time window. When no available credential matches,
+// the original buckets are returned so a window can never cause an outage on
+// its own.
+func applyTimeWindowRouting(availableByPriority map[int][]*Auth, now time.Time) map[int][]*Auth {
+	prefer := activeTimeWindowPrefer(now)
+	if len(prefer) == 0 {
+		return availableByPriority
+	}
+	filtered := make(map[int][]*Auth, len(availableByPriority))
+	for priority, candidates := range availableByPriority {
+		for _, candidate := range candidates {
+			if authMatchesRoutingKeys(candidate, prefer) {
+				filtered[priority] = append(filtered[priority], candidate)
+			}
+		}
+	}
+	if len(filtered) == 0 {
+		return availableByPriority
+	}
+	return filtered
+}
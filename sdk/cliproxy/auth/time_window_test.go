@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClockMinutes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		value string
+		want  int
+		ok    bool
+	}{
+		{value: "00:00", want: 0, ok: true},
+		{value: "08:30", want: 510, ok: true},
+		{value: " 23:59 ", want: 1439, ok: true},
+		{value: "24:00", ok: false},
+		{value: "12:60", ok: false},
+		{value: "noon", ok: false},
+		{value: "", ok: false},
+	}
+	for _, tc := range cases {
+		got, ok := parseClockMinutes(tc.value)
+		if ok != tc.ok || (ok && got != tc.want) {
+			t.Fatalf("parseClockMinutes(%q) = %d, %t, want %d, %t", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestTimeWindowRuleContains(t *testing.T) {
+	t.Parallel()
+
+	overnight := timeWindowRule{startMinute: 22 * 60, endMinute: 6 * 60}
+	if !overnight.contains(23 * 60) {
+		t.Fatalf("wrapped window does not contain 23:00")
+	}
+	if !overnight.contains(5 * 60) {
+		t.Fatalf("wrapped window does not contain 05:00")
+	}
+	if overnight.contains(12 * 60) {
+		t.Fatalf("wrapped window contains 12:00")
+	}
+	allDay := timeWindowRule{startMinute: 0, endMinute: 0}
+	if !allDay.contains(17 * 60) {
+		t.Fatalf("all-day window does not contain 17:00")
+	}
+}
+
+func TestApplyTimeWindowRouting(t *testing.T) {
+	SetRoutingTimeWindows([]TimeWindowRule{
+		{Start: "00:00", End: "08:00", Prefer: []string{"gemini"}},
+		{Start: "08:00", End: "00:00", Prefer: []string{"antigravity"}},
+	})
+	defer SetRoutingTimeWindows(nil)
+
+	geminiAuth := &Auth{ID: "gemini-key", Provider: "gemini"}
+	antigravityAuth := &Auth{ID: "antigravity-user", Provider: "antigravity"}
+	available := map[int][]*Auth{0: {geminiAuth, antigravityAuth}}
+
+	night := time.Date(2026, 8, 26, 3, 0, 0, 0, time.Local)
+	filtered := applyTimeWindowRouting(available, night)
+	if len(filtered[0]) != 1 || filtered[0][0] != geminiAuth {
+		t.Fatalf("night window selected %v, want gemini only", filtered[0])
+	}
+
+	day := time.Date(2026, 8, 26, 14, 0, 0, 0, time.Local)
+	filtered = applyTimeWindowRouting(available, day)
+	if len(filtered[0]) != 1 || filtered[0][0] != antigravityAuth {
+		t.Fatalf("day window selected %v, want antigravity only", filtered[0])
+	}
+
+	// A window whose preferred credentials are all unavailable must not filter.
+	onlyGemini := map[int][]*Auth{0: {geminiAuth}}
+	filtered = applyTimeWindowRouting(onlyGemini, day)
+	if len(filtered[0]) != 1 || filtered[0][0] != geminiAuth {
+		t.Fatalf("fallback returned %v, want the original candidates", filtered[0])
+	}
+}
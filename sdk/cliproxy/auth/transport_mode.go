@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// transportMode selects which upstream call style a model uses regardless of
+// what the client requested.
+type transportMode string
+
+const (
+	// transportAuto mirrors the client's choice (the default).
+	transportAuto transportMode = "auto"
+	// transportStream forces the provider's streaming endpoint; blocking
+	// clients get the buffered aggregate.
+	transportStream transportMode = "stream"
+	// transportNonStream forces the provider's non-streaming endpoint;
+	// streaming clients get synthesized chunks.
+	transportNonStream transportMode = "nonstream"
+)
+
+type modelTransportRule struct {
+	pattern string
+	mode    transportMode
+}
+
+// modelTransports holds the explicit per-model transport rules from the
+// model-transport config map, sorted by pattern for deterministic matching.
+var modelTransports atomic.Value
+
+// SetModelTransports replaces the per-model transport rules. Values other
+// than "stream" and "nonstream" (including "auto") clear the override for
+// their pattern. It is wired to the model-transport config key.
+func SetModelTransports(transports map[string]string) {
+	rules := make([]modelTransportRule, 0, len(transports))
+	for pattern, value := range transports {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		switch mode := transportMode(strings.ToLower(strings.TrimSpace(value))); mode {
+		case transportStream, transportNonStream:
+			rules = append(rules, modelTransportRule{pattern: pattern, mode: mode})
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].pattern < rules[j].pattern })
+	modelTransports.Store(rules)
+}
+
+// transportModeFor resolves the upstream transport for a model. An exact
+// (case-insensitive) model-transport entry wins over wildcard entries, which
+// in turn win over the legacy fake-streaming-models and
+// stream-upstream-models pattern lists.
+func transportModeFor(model string) transportMode {
+	rules, _ := modelTransports.Load().([]modelTransportRule)
+	var wildcard transportMode
+	for _, rule := range rules {
+		if strings.EqualFold(rule.pattern, strings.TrimSpace(model)) {
+			return rule.mode
+		}
+		if wildcard == "" && matchModelWildcard(rule.pattern, model) {
+			wildcard = rule.mode
+		}
+	}
+	if wildcard != "" {
+		return wildcard
+	}
+	if fakeStreamingFor(model) {
+		return transportNonStream
+	}
+	if streamUpstreamFor(model) {
+		return transportStream
+	}
+	return transportAuto
+}
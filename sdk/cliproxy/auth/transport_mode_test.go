@@ -0,0 +1,60 @@
+package auth
+
+import "testing"
+
+func TestTransportModeFor(t *testing.T) {
+	SetModelTransports(map[string]string{
+		"claude-*":          "stream",
+		"claude-sonnet-4-5": "nonstream",
+		"gpt-5":             "auto",
+		"broken":            "bogus",
+	})
+	SetFakeStreamingModels([]string{"qwen-*"})
+	SetStreamUpstreamModels([]string{"gemini-2.5-pro"})
+	defer func() {
+		SetModelTransports(nil)
+		SetFakeStreamingModels(nil)
+		SetStreamUpstreamModels(nil)
+	}()
+
+	cases := []struct {
+		name  string
+		model string
+		want  transportMode
+	}{
+		{name: "exact beats wildcard", model: "claude-sonnet-4-5", want: transportNonStream},
+		{name: "wildcard match", model: "claude-opus-4-1", want: transportStream},
+		{name: "auto clears override", model: "gpt-5", want: transportAuto},
+		{name: "invalid value ignored", model: "broken", want: transportAuto},
+		{name: "legacy fake streaming list", model: "qwen-max", want: transportNonStream},
+		{name: "legacy stream upstream list", model: "gemini-2.5-pro", want: transportStream},
+		{name: "unconfigured", model: "gemini-2.5-flash", want: transportAuto},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := transportModeFor(tc.model); got != tc.want {
+				t.Fatalf("transportModeFor(%q) = %q, want %q", tc.model, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMatchModelWildcard(t *testing.T) {
+	cases := []struct {
+		pattern string
+		model   string
+		want    bool
+	}{
+		{pattern: "*", model: "anything", want: true},
+		{pattern: "claude-*", model: "claude-opus-4-1", want: true},
+		{pattern: "*-pro", model: "gemini-2.5-pro", want: true},
+		{pattern: "gemini-*-pro", model: "gemini-2.5-pro", want: true},
+		{pattern: "claude-*", model: "gpt-5", want: false},
+		{pattern: "", model: "gpt-5", want: false},
+	}
+	for _, tc := range cases {
+		if got := matchModelWildcard(tc.pattern, tc.model); got != tc.want {
+			t.Fatalf("matchModelWildcard(%q, %q) = %t, want %t", tc.pattern, tc.model, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// upstreamRetryAttempts holds the upstream-retry.attempts setting; zero
+// disables 5xx retries. upstreamRetryBudget caps total request latency
+// including retries, in nanoseconds; zero means no latency cap.
+var (
+	upstreamRetryAttempts atomic.Int64
+	upstreamRetryBudget   atomic.Int64
+)
+
+// upstreamRetryBaseDelay is the backoff before the first 5xx retry; it doubles
+// per retry so repeated provider failures back off quickly.
+const upstreamRetryBaseDelay = 200 * time.Millisecond
+
+// SetUpstreamRetryPolicy configures automatic retries of non-streaming
+// requests that failed with an upstream 500/502/503. attempts is the number of
+// extra attempts; budget caps the total request latency including retries,
+// with zero meaning no cap.
+func SetUpstreamRetryPolicy(attempts int, budget time.Duration) {
+	if attempts < 0 {
+		attempts = 0
+	}
+	if budget < 0 {
+		budget = 0
+	}
+	upstreamRetryAttempts.Store(int64(attempts))
+	upstreamRetryBudget.Store(int64(budget))
+}
+
+// upstreamRetryDelay reports whether a request that failed with a retryable
+// upstream status (500/502/503) should be retried and after what backoff.
+// retries is how many 5xx retries this request has already run and started is
+// when the request began, so the total-latency budget covers the whole
+// request, not each attempt. Re-dispatch goes through normal selection, which
+// rotates to a different auth or base URL when one is available.
+func upstreamRetryDelay(err error, retries int, started time.Time) (time.Duration, bool) {
+	attempts := int(upstreamRetryAttempts.Load())
+	if attempts <= 0 || retries >= attempts || err == nil {
+		return 0, false
+	}
+	switch statusCodeFromError(err) {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
+	default:
+		return 0, false
+	}
+	delay := upstreamRetryBaseDelay << retries
+	if budget := time.Duration(upstreamRetryBudget.Load()); budget > 0 && time.Since(started)+delay >= budget {
+		return 0, false
+	}
+	return delay, true
+}
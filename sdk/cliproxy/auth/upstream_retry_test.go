@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestUpstreamRetryDelay(t *testing.T) {
+	SetUpstreamRetryPolicy(2, 10*time.Second)
+	defer SetUpstreamRetryPolicy(0, 0)
+
+	now := time.Now()
+	serverErr := holdTestErr{code: http.StatusInternalServerError}
+
+	cases := []struct {
+		name      string
+		err       error
+		retries   int
+		started   time.Time
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{name: "first retry", err: serverErr, started: now, wantDelay: upstreamRetryBaseDelay, wantRetry: true},
+		{name: "second retry doubles backoff", err: holdTestErr{code: http.StatusBadGateway}, retries: 1, started: now, wantDelay: 2 * upstreamRetryBaseDelay, wantRetry: true},
+		{name: "service unavailable", err: holdTestErr{code: http.StatusServiceUnavailable}, started: now, wantDelay: upstreamRetryBaseDelay, wantRetry: true},
+		{name: "attempts exhausted", err: serverErr, retries: 2, started: now},
+		{name: "non-retryable status", err: holdTestErr{code: http.StatusGatewayTimeout}, started: now},
+		{name: "plain error", err: errors.New("boom"), started: now},
+		{name: "nil error", started: now},
+		{name: "budget exhausted", err: serverErr, started: now.Add(-time.Minute)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			delay, retry := upstreamRetryDelay(tc.err, tc.retries, tc.started)
+			if retry != tc.wantRetry || delay != tc.wantDelay {
+				t.Fatalf("upstreamRetryDelay = %s, %t, want %s, %t", delay, retry, tc.wantDelay, tc.wantRetry)
+			}
+		})
+	}
+
+	SetUpstreamRetryPolicy(0, 0)
+	if _, retry := upstreamRetryDelay(serverErr, 0, now); retry {
+		t.Fatalf("upstreamRetryDelay active while disabled")
+	}
+}
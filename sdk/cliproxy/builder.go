@@ -47,6 +47,9 @@ type Builder struct {
 
 	// serverOptions contains additional server configuration options.
 	serverOptions []api.ServerOption
+
+	// providerExecutors holds out-of-tree provider executors registered during Build.
+	providerExecutors []coreauth.ProviderExecutor
 }
 
 // Hooks allows callers to plug into service lifecycle stages.
@@ -137,6 +140,14 @@ func (b *Builder) WithCoreAuthManager(mgr *coreauth.Manager) *Builder {
 	return b
 }
 
+// WithProviderExecutors registers out-of-tree provider executors on the runtime auth
+// manager during Build. Executors are validated with the same rules as
+// Service.RegisterProviderExecutor; invalid entries cause Build to fail.
+func (b *Builder) WithProviderExecutors(executors ...coreauth.ProviderExecutor) *Builder {
+	b.providerExecutors = append(b.providerExecutors, executors...)
+	return b
+}
+
 // WithServerOptions appends server configuration options used during construction.
 func (b *Builder) WithServerOptions(opts ...api.ServerOption) *Builder {
 	b.serverOptions = append(b.serverOptions, opts...)
@@ -210,6 +221,9 @@ func (b *Builder) Build() (*Service, error) {
 		default:
 			selector = &coreauth.RoundRobinSelector{}
 		}
+		if b.cfg != nil && (len(b.cfg.Routing.CodexPlanWeights) > 0 || len(b.cfg.Routing.CodexPlanMaxConcurrency) > 0) {
+			selector = coreauth.NewCodexPlanWeightedSelector(selector, b.cfg.Routing.CodexPlanWeights, b.cfg.Routing.CodexPlanMaxConcurrency)
+		}
 
 		coreManager = coreauth.NewManager(tokenStore, selector, nil)
 	}
@@ -217,6 +231,13 @@ func (b *Builder) Build() (*Service, error) {
 	coreManager.SetRoundTripperProvider(newDefaultRoundTripperProvider())
 	coreManager.SetOAuthModelMappings(b.cfg.OAuthModelMappings)
 
+	for _, executor := range b.providerExecutors {
+		if err := validateProviderExecutor(executor); err != nil {
+			return nil, err
+		}
+		coreManager.RegisterExecutor(executor)
+	}
+
 	service := &Service{
 		cfg:            b.cfg,
 		configPath:     b.configPath,
@@ -0,0 +1,135 @@
+package executor
+
+import "strings"
+
+// ErrorCategory is a canonical label for an upstream failure. Categories are
+// stable strings suitable for metrics labels, retry decisions, and client
+// messaging, independent of which provider produced the error.
+type ErrorCategory string
+
+const (
+	// CategoryQuota marks exhausted quota or billing limits; retrying the same
+	// credential will not help until the quota resets.
+	CategoryQuota ErrorCategory = "quota"
+	// CategoryRateLimited marks transient rate limiting distinct from quota
+	// exhaustion; retrying after a backoff or on another credential can succeed.
+	CategoryRateLimited ErrorCategory = "rate_limited"
+	// CategorySafety marks requests or responses blocked by content policy.
+	CategorySafety ErrorCategory = "safety"
+	// CategoryInvalidSchema marks malformed requests rejected by upstream
+	// validation (bad parameters, invalid JSON, unsupported fields).
+	CategoryInvalidSchema ErrorCategory = "invalid_schema"
+	// CategoryOverloaded marks upstream capacity problems (503, 529, model
+	// overloaded); retrying elsewhere usually succeeds.
+	CategoryOverloaded ErrorCategory = "overloaded"
+	// CategoryAuth marks rejected or expired credentials.
+	CategoryAuth ErrorCategory = "auth"
+	// CategoryModelNotFound marks requests for models unknown to the upstream.
+	CategoryModelNotFound ErrorCategory = "model_not_found"
+	// CategoryUnknown is returned when no rule matches.
+	CategoryUnknown ErrorCategory = "unknown"
+)
+
+// quotaMarkers match quota/billing exhaustion messages across providers.
+var quotaMarkers = []string{
+	"insufficient_quota",
+	"quota exceeded",
+	"quota_exceeded",
+	"exceeded your current quota",
+	"billing",
+	"resource_exhausted",
+	"out of credits",
+	"free tier",
+}
+
+// safetyMarkers match content policy blocks across providers.
+var safetyMarkers = []string{
+	"content_filter",
+	"content filter",
+	"blocked by safety",
+	"prohibited_content",
+	"safety_settings",
+	"harm_category",
+	"content management policy",
+	"violates our usage policy",
+}
+
+// schemaMarkers match request validation failures across providers.
+var schemaMarkers = []string{
+	"invalid json payload",
+	"invalid_request_error",
+	"invalid argument",
+	"invalid_argument",
+	"unknown field",
+	"schema validation",
+	"unsupported parameter",
+	"missing required parameter",
+	"failed_precondition",
+}
+
+// overloadMarkers match capacity problems across providers.
+var overloadMarkers = []string{
+	"overloaded_error",
+	"overloaded",
+	"model is currently overloaded",
+	"unavailable",
+	"no capacity",
+	"server is busy",
+}
+
+// Classify maps an upstream HTTP status and error body to a canonical
+// category. Message markers take precedence over the status fallback because
+// several providers reuse generic codes (e.g. 429 for both quota and rate
+// limiting, 400 for both schema and safety failures).
+func Classify(statusCode int, message string) ErrorCategory {
+	lower := strings.ToLower(message)
+
+	if containsAny(lower, safetyMarkers) {
+		return CategorySafety
+	}
+	if containsAny(lower, quotaMarkers) {
+		return CategoryQuota
+	}
+	if containsAny(lower, overloadMarkers) {
+		return CategoryOverloaded
+	}
+	if containsAny(lower, schemaMarkers) {
+		return CategoryInvalidSchema
+	}
+
+	switch statusCode {
+	case 429:
+		return CategoryRateLimited
+	case 401, 403:
+		return CategoryAuth
+	case 404:
+		return CategoryModelNotFound
+	case 400, 422:
+		return CategoryInvalidSchema
+	case 500, 502, 503, 504, 529:
+		return CategoryOverloaded
+	default:
+		return CategoryUnknown
+	}
+}
+
+// Retryable reports whether a failure in the category is worth retrying,
+// typically on another credential. Schema, safety, and model errors are
+// deterministic and retrying them only burns quota.
+func (c ErrorCategory) Retryable() bool {
+	switch c {
+	case CategoryRateLimited, CategoryOverloaded, CategoryQuota, CategoryAuth:
+		return true
+	default:
+		return false
+	}
+}
+
+func containsAny(haystack string, needles []string) bool {
+	for _, needle := range needles {
+		if strings.Contains(haystack, needle) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package executor
+
+import "testing"
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		message string
+		want    ErrorCategory
+	}{
+		{name: "openai quota", status: 429, message: `{"error":{"type":"insufficient_quota","message":"You exceeded your current quota"}}`, want: CategoryQuota},
+		{name: "gemini quota", status: 429, message: `{"error":{"status":"RESOURCE_EXHAUSTED"}}`, want: CategoryQuota},
+		{name: "plain rate limit", status: 429, message: "too many requests", want: CategoryRateLimited},
+		{name: "gemini safety", status: 400, message: "The response was blocked by safety settings", want: CategorySafety},
+		{name: "openai content filter", status: 400, message: `{"error":{"code":"content_filter"}}`, want: CategorySafety},
+		{name: "gemini schema", status: 400, message: `Invalid JSON payload received. Unknown field "foo"`, want: CategoryInvalidSchema},
+		{name: "openai schema", status: 400, message: `{"error":{"type":"invalid_request_error"}}`, want: CategoryInvalidSchema},
+		{name: "anthropic overloaded", status: 529, message: `{"error":{"type":"overloaded_error"}}`, want: CategoryOverloaded},
+		{name: "status 503", status: 503, message: "", want: CategoryOverloaded},
+		{name: "auth expired", status: 401, message: "invalid api key", want: CategoryAuth},
+		{name: "model not found", status: 404, message: "model does not exist", want: CategoryModelNotFound},
+		{name: "unknown", status: 418, message: "teapot", want: CategoryUnknown},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Classify(tc.status, tc.message); got != tc.want {
+				t.Fatalf("Classify(%d, %q) = %q, want %q", tc.status, tc.message, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestErrorCategoryRetryable(t *testing.T) {
+	cases := []struct {
+		category ErrorCategory
+		want     bool
+	}{
+		{CategoryRateLimited, true},
+		{CategoryOverloaded, true},
+		{CategoryQuota, true},
+		{CategoryAuth, true},
+		{CategorySafety, false},
+		{CategoryInvalidSchema, false},
+		{CategoryModelNotFound, false},
+		{CategoryUnknown, false},
+	}
+	for _, tc := range cases {
+		if got := tc.category.Retryable(); got != tc.want {
+			t.Fatalf("%s.Retryable() = %t, want %t", tc.category, got, tc.want)
+		}
+	}
+}
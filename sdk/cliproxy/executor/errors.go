@@ -0,0 +1,128 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Sentinel errors classify provider failures by kind so callers can branch with
+// errors.Is regardless of which executor produced the failure. Executors wrap
+// these via the typed errors below or match them through StatusMatches.
+var (
+	// ErrRateLimited indicates the upstream rejected the request due to rate limiting.
+	ErrRateLimited = errors.New("executor: rate limited")
+	// ErrAuthExpired indicates the credential was rejected and needs a refresh or re-login.
+	ErrAuthExpired = errors.New("executor: auth expired")
+	// ErrModelNotFound indicates the requested model is unknown to the upstream.
+	ErrModelNotFound = errors.New("executor: model not found")
+	// ErrContentFiltered indicates the upstream blocked the request or response for policy reasons.
+	ErrContentFiltered = errors.New("executor: content filtered")
+)
+
+// RateLimitError reports an upstream rate limit with an optional retry hint.
+// It matches ErrRateLimited under errors.Is.
+type RateLimitError struct {
+	// Message is the upstream error body, when available.
+	Message string
+	// RetryAfter is the wait hint parsed from the upstream response, when present.
+	RetryAfter *time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return ErrRateLimited.Error()
+}
+
+// StatusCode implements StatusError.
+func (e *RateLimitError) StatusCode() int { return 429 }
+
+// Is reports whether target is ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool { return target == ErrRateLimited }
+
+// AuthExpiredError reports a rejected credential. It matches ErrAuthExpired under errors.Is.
+type AuthExpiredError struct {
+	// Message is the upstream error body, when available.
+	Message string
+}
+
+func (e *AuthExpiredError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return ErrAuthExpired.Error()
+}
+
+// StatusCode implements StatusError.
+func (e *AuthExpiredError) StatusCode() int { return 401 }
+
+// Is reports whether target is ErrAuthExpired.
+func (e *AuthExpiredError) Is(target error) bool { return target == ErrAuthExpired }
+
+// ModelNotFoundError reports an unknown model. It matches ErrModelNotFound under errors.Is.
+type ModelNotFoundError struct {
+	// Model is the identifier that was rejected, when known.
+	Model string
+	// Message is the upstream error body, when available.
+	Message string
+}
+
+func (e *ModelNotFoundError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Model != "" {
+		return fmt.Sprintf("executor: model %s not found", e.Model)
+	}
+	return ErrModelNotFound.Error()
+}
+
+// StatusCode implements StatusError.
+func (e *ModelNotFoundError) StatusCode() int { return 404 }
+
+// Is reports whether target is ErrModelNotFound.
+func (e *ModelNotFoundError) Is(target error) bool { return target == ErrModelNotFound }
+
+// ContentFilteredError reports a policy block. It matches ErrContentFiltered under errors.Is.
+type ContentFilteredError struct {
+	// Message is the upstream error body, when available.
+	Message string
+}
+
+func (e *ContentFilteredError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return ErrContentFiltered.Error()
+}
+
+// StatusCode implements StatusError.
+func (e *ContentFilteredError) StatusCode() int { return 400 }
+
+// Is reports whether target is ErrContentFiltered.
+func (e *ContentFilteredError) Is(target error) bool { return target == ErrContentFiltered }
+
+// StatusMatches reports whether an HTTP status code and error body match one of
+// the taxonomy sentinels. Executors whose error types only carry a status code
+// can delegate their errors.Is support to this helper.
+func StatusMatches(target error, code int, msg string) bool {
+	switch target {
+	case ErrRateLimited:
+		return code == 429
+	case ErrAuthExpired:
+		return code == 401
+	case ErrModelNotFound:
+		return code == 404
+	case ErrContentFiltered:
+		lower := strings.ToLower(msg)
+		return strings.Contains(lower, "content_filter") ||
+			strings.Contains(lower, "content filter") ||
+			strings.Contains(lower, "blocked by safety") ||
+			strings.Contains(lower, "prohibited_content")
+	default:
+		return false
+	}
+}
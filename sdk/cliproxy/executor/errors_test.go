@@ -0,0 +1,66 @@
+package executor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTypedErrorsMatchSentinels(t *testing.T) {
+	wait := 2 * time.Second
+	cases := []struct {
+		name     string
+		err      error
+		sentinel error
+		code     int
+	}{
+		{name: "rate limited", err: &RateLimitError{RetryAfter: &wait}, sentinel: ErrRateLimited, code: 429},
+		{name: "auth expired", err: &AuthExpiredError{Message: "token revoked"}, sentinel: ErrAuthExpired, code: 401},
+		{name: "model not found", err: &ModelNotFoundError{Model: "gpt-0"}, sentinel: ErrModelNotFound, code: 404},
+		{name: "content filtered", err: &ContentFilteredError{}, sentinel: ErrContentFiltered, code: 400},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !errors.Is(tc.err, tc.sentinel) {
+				t.Fatalf("errors.Is(%v, sentinel) = false, want true", tc.err)
+			}
+			se, ok := tc.err.(StatusError)
+			if !ok {
+				t.Fatalf("%T does not implement StatusError", tc.err)
+			}
+			if se.StatusCode() != tc.code {
+				t.Fatalf("StatusCode() = %d, want %d", se.StatusCode(), tc.code)
+			}
+			for _, other := range cases {
+				if other.sentinel != tc.sentinel && errors.Is(tc.err, other.sentinel) {
+					t.Fatalf("errors.Is(%v, %v) = true, want false", tc.err, other.sentinel)
+				}
+			}
+		})
+	}
+}
+
+func TestStatusMatches(t *testing.T) {
+	cases := []struct {
+		name   string
+		target error
+		code   int
+		msg    string
+		want   bool
+	}{
+		{name: "429 rate limited", target: ErrRateLimited, code: 429, want: true},
+		{name: "503 not rate limited", target: ErrRateLimited, code: 503, want: false},
+		{name: "401 auth expired", target: ErrAuthExpired, code: 401, want: true},
+		{name: "404 model not found", target: ErrModelNotFound, code: 404, want: true},
+		{name: "content filter body", target: ErrContentFiltered, code: 400, msg: `{"error":{"code":"content_filter"}}`, want: true},
+		{name: "plain 400", target: ErrContentFiltered, code: 400, msg: "bad request", want: false},
+		{name: "unrelated target", target: errors.New("other"), code: 429, want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := StatusMatches(tc.target, tc.code, tc.msg); got != tc.want {
+				t.Fatalf("StatusMatches() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// CanSynthesizeStream reports whether SynthesizeStreamChunks understands the
+// given source format. Unsupported formats fall back to real streaming.
+func CanSynthesizeStream(format string) bool {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "openai", "claude", "gemini":
+		return true
+	default:
+		return false
+	}
+}
+
+// SynthesizeStreamChunks converts a non-streaming response payload into the
+// ordered chunk sequence the format's real streaming path would emit, with
+// text content split word-by-word. It backs the fake streaming mode where the
+// upstream call is performed non-streamed for reliability and the client still
+// receives an incremental stream.
+func SynthesizeStreamChunks(format string, payload []byte) [][]byte {
+	if len(payload) == 0 {
+		return nil
+	}
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "openai":
+		return synthesizeOpenAIChunks(payload)
+	case "claude":
+		return synthesizeClaudeChunks(payload)
+	case "gemini":
+		return synthesizeGeminiChunks(payload)
+	default:
+		return nil
+	}
+}
+
+func synthesizeOpenAIChunks(payload []byte) [][]byte {
+	root := gjson.ParseBytes(payload)
+	template := `{"id":"","object":"chat.completion.chunk","created":0,"model":"","choices":[{"index":0,"delta":{},"finish_reason":null}]}`
+	template, _ = sjson.Set(template, "id", root.Get("id").String())
+	template, _ = sjson.Set(template, "created", root.Get("created").Int())
+	template, _ = sjson.Set(template, "model", root.Get("model").String())
+
+	var chunks [][]byte
+	first, _ := sjson.Set(template, "choices.0.delta.role", "assistant")
+	chunks = append(chunks, []byte(first))
+
+	message := root.Get("choices.0.message")
+	if reasoning := message.Get("reasoning_content"); reasoning.Exists() && reasoning.String() != "" {
+		chunk, _ := sjson.Set(template, "choices.0.delta.reasoning_content", reasoning.String())
+		chunks = append(chunks, []byte(chunk))
+	}
+	for _, word := range splitStreamWords(message.Get("content").String()) {
+		chunk, _ := sjson.Set(template, "choices.0.delta.content", word)
+		chunks = append(chunks, []byte(chunk))
+	}
+	if toolCalls := message.Get("tool_calls"); toolCalls.IsArray() {
+		chunk, _ := sjson.SetRaw(template, "choices.0.delta.tool_calls", toolCalls.Raw)
+		for i := range toolCalls.Array() {
+			chunk, _ = sjson.Set(chunk, fmt.Sprintf("choices.0.delta.tool_calls.%d.index", i), i)
+		}
+		chunks = append(chunks, []byte(chunk))
+	}
+
+	finish := root.Get("choices.0.finish_reason").String()
+	if finish == "" {
+		finish = "stop"
+	}
+	final, _ := sjson.Set(template, "choices.0.finish_reason", finish)
+	if usage := root.Get("usage"); usage.Exists() {
+		final, _ = sjson.SetRaw(final, "usage", usage.Raw)
+	}
+	chunks = append(chunks, []byte(final))
+	return chunks
+}
+
+func synthesizeClaudeChunks(payload []byte) [][]byte {
+	root := gjson.ParseBytes(payload)
+	event := func(name, data string) []byte {
+		return []byte("event: " + name + "\ndata: " + data + "\n\n")
+	}
+
+	shell := `{"id":"","type":"message","role":"assistant","model":"","content":[],"stop_reason":null,"stop_sequence":null,"usage":{"input_tokens":0,"output_tokens":0}}`
+	shell, _ = sjson.Set(shell, "id", root.Get("id").String())
+	shell, _ = sjson.Set(shell, "model", root.Get("model").String())
+	if input := root.Get("usage.input_tokens"); input.Exists() {
+		shell, _ = sjson.Set(shell, "usage.input_tokens", input.Int())
+	}
+	start, _ := sjson.SetRaw(`{"type":"message_start"}`, "message", shell)
+	chunks := [][]byte{event("message_start", start)}
+
+	root.Get("content").ForEach(func(key, block gjson.Result) bool {
+		index := int(key.Int())
+		switch block.Get("type").String() {
+		case "text":
+			blockStart, _ := sjson.Set(`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`, "index", index)
+			chunks = append(chunks, event("content_block_start", blockStart))
+			for _, word := range splitStreamWords(block.Get("text").String()) {
+				delta, _ := sjson.Set(`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":""}}`, "index", index)
+				delta, _ = sjson.Set(delta, "delta.text", word)
+				chunks = append(chunks, event("content_block_delta", delta))
+			}
+		case "thinking":
+			blockStart, _ := sjson.Set(`{"type":"content_block_start","index":0,"content_block":{"type":"thinking","thinking":""}}`, "index", index)
+			chunks = append(chunks, event("content_block_start", blockStart))
+			delta, _ := sjson.Set(`{"type":"content_block_delta","index":0,"delta":{"type":"thinking_delta","thinking":""}}`, "index", index)
+			delta, _ = sjson.Set(delta, "delta.thinking", block.Get("thinking").String())
+			chunks = append(chunks, event("content_block_delta", delta))
+		case "tool_use":
+			emptyInput, _ := sjson.SetRaw(block.Raw, "input", `{}`)
+			blockStart, _ := sjson.Set(`{"type":"content_block_start","index":0}`, "index", index)
+			blockStart, _ = sjson.SetRaw(blockStart, "content_block", emptyInput)
+			chunks = append(chunks, event("content_block_start", blockStart))
+			delta, _ := sjson.Set(`{"type":"content_block_delta","index":0,"delta":{"type":"input_json_delta","partial_json":""}}`, "index", index)
+			delta, _ = sjson.Set(delta, "delta.partial_json", block.Get("input").Raw)
+			chunks = append(chunks, event("content_block_delta", delta))
+		default:
+			blockStart, _ := sjson.Set(`{"type":"content_block_start","index":0}`, "index", index)
+			blockStart, _ = sjson.SetRaw(blockStart, "content_block", block.Raw)
+			chunks = append(chunks, event("content_block_start", blockStart))
+		}
+		blockStop, _ := sjson.Set(`{"type":"content_block_stop","index":0}`, "index", index)
+		chunks = append(chunks, event("content_block_stop", blockStop))
+		return true
+	})
+
+	stopReason := root.Get("stop_reason").String()
+	if stopReason == "" {
+		stopReason = "end_turn"
+	}
+	messageDelta, _ := sjson.Set(`{"type":"message_delta","delta":{"stop_reason":"","stop_sequence":null},"usage":{"output_tokens":0}}`, "delta.stop_reason", stopReason)
+	if output := root.Get("usage.output_tokens"); output.Exists() {
+		messageDelta, _ = sjson.Set(messageDelta, "usage.output_tokens", output.Int())
+	}
+	chunks = append(chunks, event("message_delta", messageDelta))
+	chunks = append(chunks, event("message_stop", `{"type":"message_stop"}`))
+	return chunks
+}
+
+func synthesizeGeminiChunks(payload []byte) [][]byte {
+	root := gjson.ParseBytes(payload)
+	template := `{"candidates":[{"content":{"parts":[],"role":"model"},"index":0}]}`
+	if version := root.Get("modelVersion"); version.Exists() {
+		template, _ = sjson.Set(template, "modelVersion", version.String())
+	}
+	if responseID := root.Get("responseId"); responseID.Exists() {
+		template, _ = sjson.Set(template, "responseId", responseID.String())
+	}
+
+	var chunks []string
+	root.Get("candidates.0.content.parts").ForEach(func(_, part gjson.Result) bool {
+		if text := part.Get("text"); text.Exists() && !part.Get("functionCall").Exists() {
+			for _, word := range splitStreamWords(text.String()) {
+				partJSON, _ := sjson.Set(part.Raw, "text", word)
+				chunk, _ := sjson.SetRaw(template, "candidates.0.content.parts.0", partJSON)
+				chunks = append(chunks, chunk)
+			}
+			return true
+		}
+		chunk, _ := sjson.SetRaw(template, "candidates.0.content.parts.0", part.Raw)
+		chunks = append(chunks, chunk)
+		return true
+	})
+	if len(chunks) == 0 {
+		chunks = append(chunks, template)
+	}
+
+	finish := root.Get("candidates.0.finishReason").String()
+	if finish == "" {
+		finish = "STOP"
+	}
+	last := chunks[len(chunks)-1]
+	last, _ = sjson.Set(last, "candidates.0.finishReason", finish)
+	if usage := root.Get("usageMetadata"); usage.Exists() {
+		last, _ = sjson.SetRaw(last, "usageMetadata", usage.Raw)
+	}
+	chunks[len(chunks)-1] = last
+
+	out := make([][]byte, 0, len(chunks))
+	for _, chunk := range chunks {
+		out = append(out, []byte(chunk))
+	}
+	return out
+}
+
+// splitStreamWords splits text into word-sized fragments, each carrying its
+// trailing whitespace so the concatenation reproduces the original exactly.
+func splitStreamWords(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var words []string
+	start := 0
+	inSpace := false
+	for i, r := range s {
+		isSpace := unicode.IsSpace(r)
+		if inSpace && !isSpace {
+			words = append(words, s[start:i])
+			start = i
+		}
+		inSpace = isSpace
+	}
+	return append(words, s[start:])
+}
@@ -0,0 +1,104 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestSynthesizeOpenAIChunks(t *testing.T) {
+	payload := []byte(`{"id":"chatcmpl-1","object":"chat.completion","created":1700000000,"model":"gpt-test","choices":[{"index":0,"message":{"role":"assistant","content":"hello streaming world"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`)
+	chunks := SynthesizeStreamChunks("openai", payload)
+	if len(chunks) != 5 {
+		t.Fatalf("chunk count = %d, want 5 (role + 3 words + final)", len(chunks))
+	}
+	first := gjson.ParseBytes(chunks[0])
+	if first.Get("choices.0.delta.role").String() != "assistant" {
+		t.Fatalf("first chunk missing role delta: %s", chunks[0])
+	}
+	var rebuilt strings.Builder
+	for _, chunk := range chunks {
+		rebuilt.WriteString(gjson.GetBytes(chunk, "choices.0.delta.content").String())
+	}
+	if rebuilt.String() != "hello streaming world" {
+		t.Fatalf("reassembled content = %q", rebuilt.String())
+	}
+	final := gjson.ParseBytes(chunks[len(chunks)-1])
+	if final.Get("choices.0.finish_reason").String() != "stop" {
+		t.Fatalf("final chunk missing finish_reason: %s", chunks[len(chunks)-1])
+	}
+	if final.Get("usage.total_tokens").Int() != 8 {
+		t.Fatalf("final chunk missing usage: %s", chunks[len(chunks)-1])
+	}
+}
+
+func TestSynthesizeClaudeChunks(t *testing.T) {
+	payload := []byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-test","content":[{"type":"text","text":"two words"}],"stop_reason":"end_turn","usage":{"input_tokens":4,"output_tokens":2}}`)
+	chunks := SynthesizeStreamChunks("claude", payload)
+	joined := make([]string, 0, len(chunks))
+	for _, chunk := range chunks {
+		joined = append(joined, string(chunk))
+	}
+	stream := strings.Join(joined, "")
+	for _, want := range []string{"event: message_start", "event: content_block_start", "event: content_block_delta", "event: content_block_stop", "event: message_delta", "event: message_stop"} {
+		if !strings.Contains(stream, want) {
+			t.Fatalf("stream missing %q:\n%s", want, stream)
+		}
+	}
+	var text strings.Builder
+	for _, chunk := range chunks {
+		for _, line := range strings.Split(string(chunk), "\n") {
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+			node := gjson.Parse(strings.TrimPrefix(line, "data: "))
+			if node.Get("type").String() == "content_block_delta" {
+				text.WriteString(node.Get("delta.text").String())
+			}
+		}
+	}
+	if text.String() != "two words" {
+		t.Fatalf("reassembled text = %q", text.String())
+	}
+}
+
+func TestSynthesizeGeminiChunks(t *testing.T) {
+	payload := []byte(`{"candidates":[{"content":{"parts":[{"text":"a b"}],"role":"model"},"finishReason":"STOP","index":0}],"usageMetadata":{"promptTokenCount":1,"candidatesTokenCount":2,"totalTokenCount":3},"modelVersion":"gemini-test"}`)
+	chunks := SynthesizeStreamChunks("gemini", payload)
+	if len(chunks) != 2 {
+		t.Fatalf("chunk count = %d, want 2", len(chunks))
+	}
+	var text strings.Builder
+	for _, chunk := range chunks {
+		text.WriteString(gjson.GetBytes(chunk, "candidates.0.content.parts.0.text").String())
+	}
+	if text.String() != "a b" {
+		t.Fatalf("reassembled text = %q", text.String())
+	}
+	last := gjson.ParseBytes(chunks[len(chunks)-1])
+	if last.Get("candidates.0.finishReason").String() != "STOP" {
+		t.Fatalf("last chunk missing finishReason: %s", chunks[len(chunks)-1])
+	}
+	if last.Get("usageMetadata.totalTokenCount").Int() != 3 {
+		t.Fatalf("last chunk missing usageMetadata: %s", chunks[len(chunks)-1])
+	}
+}
+
+func TestCanSynthesizeStream(t *testing.T) {
+	cases := []struct {
+		format string
+		want   bool
+	}{
+		{format: "openai", want: true},
+		{format: "Claude", want: true},
+		{format: "gemini", want: true},
+		{format: "openai-response", want: false},
+		{format: "", want: false},
+	}
+	for _, tc := range cases {
+		if got := CanSynthesizeStream(tc.format); got != tc.want {
+			t.Fatalf("CanSynthesizeStream(%q) = %t, want %t", tc.format, got, tc.want)
+		}
+	}
+}
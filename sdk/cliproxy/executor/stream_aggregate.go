@@ -0,0 +1,231 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// CanAggregateStream reports whether AggregateStreamChunks understands the
+// given source format. Unsupported formats fall back to the provider's real
+// non-streaming path.
+func CanAggregateStream(format string) bool {
+	return CanSynthesizeStream(format)
+}
+
+// AggregateStreamChunks buffers the chunk sequence a format's streaming path
+// emits and folds it back into the equivalent non-streaming response payload.
+// It is the inverse of SynthesizeStreamChunks and backs the mode where a
+// provider whose non-stream endpoint is flaky is forced to stream upstream
+// while the client receives a single blocking response.
+func AggregateStreamChunks(format string, chunks [][]byte) []byte {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "openai":
+		return aggregateOpenAIChunks(chunks)
+	case "claude":
+		return aggregateClaudeChunks(chunks)
+	case "gemini":
+		return aggregateGeminiChunks(chunks)
+	default:
+		return nil
+	}
+}
+
+func aggregateOpenAIChunks(chunks [][]byte) []byte {
+	out := `{"id":"","object":"chat.completion","created":0,"model":"","choices":[{"index":0,"message":{"role":"assistant","content":""},"finish_reason":null}]}`
+	var content, reasoning strings.Builder
+	toolArgs := map[int]*strings.Builder{}
+	toolMeta := map[int]gjson.Result{}
+	seeded := false
+	for _, chunk := range chunks {
+		node := gjson.ParseBytes(streamChunkJSON(chunk))
+		if !node.IsObject() {
+			continue
+		}
+		if !seeded && node.Get("id").String() != "" {
+			out, _ = sjson.Set(out, "id", node.Get("id").String())
+			out, _ = sjson.Set(out, "created", node.Get("created").Int())
+			out, _ = sjson.Set(out, "model", node.Get("model").String())
+			seeded = true
+		}
+		delta := node.Get("choices.0.delta")
+		content.WriteString(delta.Get("content").String())
+		reasoning.WriteString(delta.Get("reasoning_content").String())
+		delta.Get("tool_calls").ForEach(func(_, call gjson.Result) bool {
+			index := int(call.Get("index").Int())
+			builder, ok := toolArgs[index]
+			if !ok {
+				builder = &strings.Builder{}
+				toolArgs[index] = builder
+			}
+			builder.WriteString(call.Get("function.arguments").String())
+			if call.Get("id").String() != "" || call.Get("function.name").String() != "" {
+				toolMeta[index] = call
+			}
+			return true
+		})
+		if finish := node.Get("choices.0.finish_reason"); finish.Exists() && finish.String() != "" {
+			out, _ = sjson.Set(out, "choices.0.finish_reason", finish.String())
+		}
+		if usage := node.Get("usage"); usage.IsObject() {
+			out, _ = sjson.SetRaw(out, "usage", usage.Raw)
+		}
+	}
+	out, _ = sjson.Set(out, "choices.0.message.content", content.String())
+	if reasoning.Len() > 0 {
+		out, _ = sjson.Set(out, "choices.0.message.reasoning_content", reasoning.String())
+	}
+	for index := 0; index < len(toolMeta); index++ {
+		meta, ok := toolMeta[index]
+		if !ok {
+			continue
+		}
+		call := `{"id":"","type":"function","function":{"name":"","arguments":""}}`
+		call, _ = sjson.Set(call, "id", meta.Get("id").String())
+		call, _ = sjson.Set(call, "function.name", meta.Get("function.name").String())
+		if builder := toolArgs[index]; builder != nil {
+			call, _ = sjson.Set(call, "function.arguments", builder.String())
+		}
+		out, _ = sjson.SetRaw(out, fmt.Sprintf("choices.0.message.tool_calls.%d", index), call)
+	}
+	return []byte(out)
+}
+
+func aggregateClaudeChunks(chunks [][]byte) []byte {
+	out := `{"id":"","type":"message","role":"assistant","model":"","content":[],"stop_reason":null,"stop_sequence":null,"usage":{"input_tokens":0,"output_tokens":0}}`
+	blockText := map[int]*strings.Builder{}
+	blockShell := map[int]string{}
+	blockKind := map[int]string{}
+	maxIndex := -1
+	for _, chunk := range chunks {
+		for _, line := range bytes.Split(chunk, []byte("\n")) {
+			payload := streamChunkJSON(line)
+			if payload == nil {
+				continue
+			}
+			node := gjson.ParseBytes(payload)
+			switch node.Get("type").String() {
+			case "message_start":
+				message := node.Get("message")
+				out, _ = sjson.Set(out, "id", message.Get("id").String())
+				out, _ = sjson.Set(out, "model", message.Get("model").String())
+				if input := message.Get("usage.input_tokens"); input.Exists() {
+					out, _ = sjson.Set(out, "usage.input_tokens", input.Int())
+				}
+			case "content_block_start":
+				index := int(node.Get("index").Int())
+				if index > maxIndex {
+					maxIndex = index
+				}
+				blockShell[index] = node.Get("content_block").Raw
+				blockKind[index] = node.Get("content_block.type").String()
+				blockText[index] = &strings.Builder{}
+			case "content_block_delta":
+				index := int(node.Get("index").Int())
+				builder, ok := blockText[index]
+				if !ok {
+					continue
+				}
+				delta := node.Get("delta")
+				switch delta.Get("type").String() {
+				case "text_delta":
+					builder.WriteString(delta.Get("text").String())
+				case "thinking_delta":
+					builder.WriteString(delta.Get("thinking").String())
+				case "input_json_delta":
+					builder.WriteString(delta.Get("partial_json").String())
+				}
+			case "message_delta":
+				if stop := node.Get("delta.stop_reason"); stop.Exists() && stop.String() != "" {
+					out, _ = sjson.Set(out, "stop_reason", stop.String())
+				}
+				if output := node.Get("usage.output_tokens"); output.Exists() {
+					out, _ = sjson.Set(out, "usage.output_tokens", output.Int())
+				}
+			}
+		}
+	}
+	for index := 0; index <= maxIndex; index++ {
+		shell, ok := blockShell[index]
+		if !ok {
+			continue
+		}
+		text := ""
+		if builder := blockText[index]; builder != nil {
+			text = builder.String()
+		}
+		switch blockKind[index] {
+		case "text":
+			shell, _ = sjson.Set(shell, "text", text)
+		case "thinking":
+			shell, _ = sjson.Set(shell, "thinking", text)
+		case "tool_use":
+			if gjson.Valid(text) {
+				shell, _ = sjson.SetRaw(shell, "input", text)
+			}
+		}
+		out, _ = sjson.SetRaw(out, fmt.Sprintf("content.%d", index), shell)
+	}
+	return []byte(out)
+}
+
+func aggregateGeminiChunks(chunks [][]byte) []byte {
+	out := `{"candidates":[{"content":{"parts":[],"role":"model"},"index":0}]}`
+	var text strings.Builder
+	var extraParts []string
+	for _, chunk := range chunks {
+		node := gjson.ParseBytes(streamChunkJSON(chunk))
+		if !node.IsObject() {
+			continue
+		}
+		if version := node.Get("modelVersion"); version.Exists() {
+			out, _ = sjson.Set(out, "modelVersion", version.String())
+		}
+		if responseID := node.Get("responseId"); responseID.Exists() {
+			out, _ = sjson.Set(out, "responseId", responseID.String())
+		}
+		node.Get("candidates.0.content.parts").ForEach(func(_, part gjson.Result) bool {
+			if partText := part.Get("text"); partText.Exists() && !part.Get("functionCall").Exists() && !part.Get("thought").Bool() {
+				text.WriteString(partText.String())
+				return true
+			}
+			extraParts = append(extraParts, part.Raw)
+			return true
+		})
+		if finish := node.Get("candidates.0.finishReason"); finish.Exists() && finish.String() != "" {
+			out, _ = sjson.Set(out, "candidates.0.finishReason", finish.String())
+		}
+		if usage := node.Get("usageMetadata"); usage.IsObject() {
+			out, _ = sjson.SetRaw(out, "usageMetadata", usage.Raw)
+		}
+	}
+	partIndex := 0
+	for _, raw := range extraParts {
+		out, _ = sjson.SetRaw(out, fmt.Sprintf("candidates.0.content.parts.%d", partIndex), raw)
+		partIndex++
+	}
+	if text.Len() > 0 {
+		textPart, _ := sjson.Set(`{"text":""}`, "text", text.String())
+		out, _ = sjson.SetRaw(out, fmt.Sprintf("candidates.0.content.parts.%d", partIndex), textPart)
+	}
+	return []byte(out)
+}
+
+// streamChunkJSON extracts the JSON payload from a chunk line, tolerating SSE
+// `data:` prefixes and skipping terminal markers and event name lines.
+func streamChunkJSON(chunk []byte) []byte {
+	trimmed := bytes.TrimSpace(chunk)
+	if len(trimmed) == 0 || bytes.HasPrefix(trimmed, []byte("event:")) {
+		return nil
+	}
+	if bytes.HasPrefix(trimmed, []byte("data:")) {
+		trimmed = bytes.TrimSpace(trimmed[len("data:"):])
+	}
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("[DONE]")) || trimmed[0] != '{' {
+		return nil
+	}
+	return trimmed
+}
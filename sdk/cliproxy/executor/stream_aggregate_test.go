@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestAggregateOpenAIChunksRoundTrip(t *testing.T) {
+	payload := []byte(`{"id":"chatcmpl-1","object":"chat.completion","created":1700000000,"model":"gpt-test","choices":[{"index":0,"message":{"role":"assistant","content":"hello streaming world"},"finish_reason":"stop"}],"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`)
+	out := AggregateStreamChunks("openai", SynthesizeStreamChunks("openai", payload))
+	node := gjson.ParseBytes(out)
+	if node.Get("choices.0.message.content").String() != "hello streaming world" {
+		t.Fatalf("content = %q", node.Get("choices.0.message.content").String())
+	}
+	if node.Get("id").String() != "chatcmpl-1" || node.Get("model").String() != "gpt-test" {
+		t.Fatalf("metadata not carried over: %s", out)
+	}
+	if node.Get("choices.0.finish_reason").String() != "stop" {
+		t.Fatalf("finish_reason = %q", node.Get("choices.0.finish_reason").String())
+	}
+	if node.Get("usage.total_tokens").Int() != 8 {
+		t.Fatalf("usage not carried over: %s", out)
+	}
+}
+
+func TestAggregateOpenAIChunksToolCalls(t *testing.T) {
+	chunks := [][]byte{
+		[]byte(`{"id":"chatcmpl-2","created":1,"model":"gpt-test","choices":[{"index":0,"delta":{"role":"assistant"},"finish_reason":null}]}`),
+		[]byte(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"lookup","arguments":"{\"q\":"}}]},"finish_reason":null}]}`),
+		[]byte(`{"choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"x\"}"}}]},"finish_reason":null}]}`),
+		[]byte(`{"choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`),
+	}
+	node := gjson.ParseBytes(AggregateStreamChunks("openai", chunks))
+	call := node.Get("choices.0.message.tool_calls.0")
+	if call.Get("id").String() != "call_1" || call.Get("function.name").String() != "lookup" {
+		t.Fatalf("tool call metadata missing: %s", call.Raw)
+	}
+	if call.Get("function.arguments").String() != `{"q":"x"}` {
+		t.Fatalf("arguments = %q", call.Get("function.arguments").String())
+	}
+	if node.Get("choices.0.finish_reason").String() != "tool_calls" {
+		t.Fatalf("finish_reason = %q", node.Get("choices.0.finish_reason").String())
+	}
+}
+
+func TestAggregateClaudeChunksRoundTrip(t *testing.T) {
+	payload := []byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-test","content":[{"type":"text","text":"two words"},{"type":"tool_use","id":"tu_1","name":"lookup","input":{"q":"x"}}],"stop_reason":"tool_use","usage":{"input_tokens":4,"output_tokens":2}}`)
+	out := AggregateStreamChunks("claude", SynthesizeStreamChunks("claude", payload))
+	node := gjson.ParseBytes(out)
+	if node.Get("content.0.text").String() != "two words" {
+		t.Fatalf("text block = %q", node.Get("content.0.text").String())
+	}
+	if node.Get("content.1.name").String() != "lookup" || node.Get("content.1.input.q").String() != "x" {
+		t.Fatalf("tool_use block not rebuilt: %s", node.Get("content.1").Raw)
+	}
+	if node.Get("stop_reason").String() != "tool_use" {
+		t.Fatalf("stop_reason = %q", node.Get("stop_reason").String())
+	}
+	if node.Get("usage.input_tokens").Int() != 4 || node.Get("usage.output_tokens").Int() != 2 {
+		t.Fatalf("usage not carried over: %s", node.Get("usage").Raw)
+	}
+}
+
+func TestAggregateGeminiChunksRoundTrip(t *testing.T) {
+	payload := []byte(`{"candidates":[{"content":{"parts":[{"text":"a b"}],"role":"model"},"finishReason":"STOP","index":0}],"usageMetadata":{"promptTokenCount":1,"candidatesTokenCount":2,"totalTokenCount":3},"modelVersion":"gemini-test"}`)
+	out := AggregateStreamChunks("gemini", SynthesizeStreamChunks("gemini", payload))
+	node := gjson.ParseBytes(out)
+	if node.Get("candidates.0.content.parts.0.text").String() != "a b" {
+		t.Fatalf("text = %q", node.Get("candidates.0.content.parts.0.text").String())
+	}
+	if node.Get("candidates.0.finishReason").String() != "STOP" {
+		t.Fatalf("finishReason = %q", node.Get("candidates.0.finishReason").String())
+	}
+	if node.Get("usageMetadata.totalTokenCount").Int() != 3 {
+		t.Fatalf("usageMetadata not carried over: %s", out)
+	}
+}
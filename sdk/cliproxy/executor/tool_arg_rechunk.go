@@ -0,0 +1,103 @@
+package executor
+
+import (
+	"unicode/utf8"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// toolArgDeltaMaxBytes bounds the size of a single input_json_delta piece so
+// re-chunked tool arguments stream like natively fragmented ones.
+const toolArgDeltaMaxBytes = 512
+
+// RechunkToolArgStream adapts tool-use streaming to the client format's
+// expectations. Claude SSE consumers expect tool inputs to arrive as
+// input_json_delta pieces, but some upstreams emit the complete arguments in
+// one chunk: tool_use blocks whose content_block_start already carries the
+// full input are rewritten into an empty start plus delta pieces, and
+// oversized argument deltas are split into bounded pieces. Other formats pass
+// through untouched, since their clients accept whole arguments as one delta.
+func RechunkToolArgStream(format string, in <-chan StreamChunk) <-chan StreamChunk {
+	if format != "claude" || in == nil {
+		return in
+	}
+	out := make(chan StreamChunk, 1)
+	go func() {
+		defer close(out)
+		for chunk := range in {
+			if chunk.Err != nil || len(chunk.Payload) == 0 {
+				out <- chunk
+				continue
+			}
+			for _, payload := range rechunkClaudeToolArgs(chunk.Payload) {
+				out <- StreamChunk{Payload: payload}
+			}
+		}
+	}()
+	return out
+}
+
+func rechunkClaudeToolArgs(payload []byte) [][]byte {
+	data := claudeEventJSON(payload)
+	if data == nil {
+		return [][]byte{payload}
+	}
+	switch gjson.GetBytes(data, "type").String() {
+	case "content_block_start":
+		if gjson.GetBytes(data, "content_block.type").String() != "tool_use" {
+			break
+		}
+		input := gjson.GetBytes(data, "content_block.input")
+		if !input.Exists() || !input.IsObject() || input.Raw == "{}" {
+			break
+		}
+		index := gjson.GetBytes(data, "index").Int()
+		emptied, errSet := sjson.SetRawBytes(data, "content_block.input", []byte("{}"))
+		if errSet != nil {
+			break
+		}
+		out := [][]byte{claudeEventFrame("content_block_start", emptied)}
+		for _, piece := range splitToolArgPieces(input.Raw) {
+			out = append(out, claudeInputJSONDeltaEvent(index, piece))
+		}
+		return out
+	case "content_block_delta":
+		if gjson.GetBytes(data, "delta.type").String() != "input_json_delta" {
+			break
+		}
+		partial := gjson.GetBytes(data, "delta.partial_json").String()
+		if len(partial) <= toolArgDeltaMaxBytes {
+			break
+		}
+		index := gjson.GetBytes(data, "index").Int()
+		var out [][]byte
+		for _, piece := range splitToolArgPieces(partial) {
+			out = append(out, claudeInputJSONDeltaEvent(index, piece))
+		}
+		return out
+	}
+	return [][]byte{payload}
+}
+
+// splitToolArgPieces cuts an argument JSON string into pieces of at most
+// toolArgDeltaMaxBytes, never splitting inside a UTF-8 rune so each piece
+// survives JSON string encoding on its own.
+func splitToolArgPieces(s string) []string {
+	var pieces []string
+	for len(s) > toolArgDeltaMaxBytes {
+		cut := toolArgDeltaMaxBytes
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			cut = toolArgDeltaMaxBytes
+		}
+		pieces = append(pieces, s[:cut])
+		s = s[cut:]
+	}
+	if s != "" {
+		pieces = append(pieces, s)
+	}
+	return pieces
+}
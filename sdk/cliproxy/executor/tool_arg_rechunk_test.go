@@ -0,0 +1,106 @@
+package executor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestRechunkClaudeFullInputStart(t *testing.T) {
+	start := []byte("event: content_block_start\ndata: {\"type\":\"content_block_start\",\"index\":1,\"content_block\":{\"type\":\"tool_use\",\"id\":\"t1\",\"name\":\"lookup\",\"input\":{\"query\":\"weather\",\"unit\":\"c\"}}}\n\n")
+	out := rechunkClaudeToolArgs(start)
+	if len(out) < 2 {
+		t.Fatalf("full-input start not re-chunked: %d chunks", len(out))
+	}
+	startData := claudeEventJSON(out[0])
+	if got := gjson.GetBytes(startData, "content_block.input").Raw; got != "{}" {
+		t.Fatalf("rewritten start input = %s, want {}", got)
+	}
+	if got := gjson.GetBytes(startData, "content_block.name").String(); got != "lookup" {
+		t.Fatalf("start lost tool name: %s", startData)
+	}
+	var rebuilt strings.Builder
+	for _, piece := range out[1:] {
+		data := claudeEventJSON(piece)
+		if got := gjson.GetBytes(data, "delta.type").String(); got != "input_json_delta" {
+			t.Fatalf("piece delta type = %q", got)
+		}
+		if got := gjson.GetBytes(data, "index").Int(); got != 1 {
+			t.Fatalf("piece index = %d, want 1", got)
+		}
+		rebuilt.WriteString(gjson.GetBytes(data, "delta.partial_json").String())
+	}
+	if rebuilt.String() != `{"query":"weather","unit":"c"}` {
+		t.Fatalf("reassembled input = %s", rebuilt.String())
+	}
+}
+
+func TestRechunkClaudePassthrough(t *testing.T) {
+	cases := []struct {
+		name  string
+		chunk []byte
+	}{
+		{name: "empty input start", chunk: []byte("event: content_block_start\ndata: {\"type\":\"content_block_start\",\"index\":1,\"content_block\":{\"type\":\"tool_use\",\"id\":\"t1\",\"name\":\"lookup\",\"input\":{}}}\n\n")},
+		{name: "text block start", chunk: []byte("event: content_block_start\ndata: {\"type\":\"content_block_start\",\"index\":0,\"content_block\":{\"type\":\"text\",\"text\":\"\"}}\n\n")},
+		{name: "small delta", chunk: []byte("event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":1,\"delta\":{\"type\":\"input_json_delta\",\"partial_json\":\"{\\\"a\\\":1}\"}}\n\n")},
+		{name: "text delta", chunk: []byte("event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"hi\"}}\n\n")},
+		{name: "non-event chunk", chunk: []byte("event: ping\ndata: {\"type\":\"ping\"}\n\n")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := rechunkClaudeToolArgs(tc.chunk)
+			if len(out) != 1 || string(out[0]) != string(tc.chunk) {
+				t.Fatalf("chunk not passed through untouched: %d chunks", len(out))
+			}
+		})
+	}
+}
+
+func TestRechunkClaudeOversizedDelta(t *testing.T) {
+	big, errMarshal := json.Marshal(map[string]string{"text": strings.Repeat("é", 600)})
+	if errMarshal != nil {
+		t.Fatalf("marshal: %v", errMarshal)
+	}
+	delta := `{"type":"content_block_delta","index":2,"delta":{"type":"input_json_delta"}}`
+	data, errSet := json.Marshal(string(big))
+	if errSet != nil {
+		t.Fatalf("marshal fragment: %v", errSet)
+	}
+	chunk := []byte("event: content_block_delta\ndata: " + strings.Replace(delta, `"input_json_delta"}`, `"input_json_delta","partial_json":`+string(data)+"}", 1) + "\n\n")
+	out := rechunkClaudeToolArgs(chunk)
+	if len(out) < 2 {
+		t.Fatalf("oversized delta not split: %d chunks", len(out))
+	}
+	var rebuilt strings.Builder
+	for _, piece := range out {
+		pieceData := claudeEventJSON(piece)
+		fragment := gjson.GetBytes(pieceData, "delta.partial_json").String()
+		if len(fragment) > toolArgDeltaMaxBytes {
+			t.Fatalf("piece exceeds max size: %d bytes", len(fragment))
+		}
+		rebuilt.WriteString(fragment)
+	}
+	if rebuilt.String() != string(big) {
+		t.Fatalf("reassembled fragment differs from original")
+	}
+}
+
+func TestSplitToolArgPiecesRuneBoundaries(t *testing.T) {
+	s := strings.Repeat("é", toolArgDeltaMaxBytes) // 2 bytes per rune
+	pieces := splitToolArgPieces(s)
+	if len(pieces) < 2 {
+		t.Fatalf("expected multiple pieces, got %d", len(pieces))
+	}
+	var rebuilt strings.Builder
+	for _, piece := range pieces {
+		if !strings.HasPrefix(piece, "é") {
+			t.Fatalf("piece split mid-rune: %q...", piece[:4])
+		}
+		rebuilt.WriteString(piece)
+	}
+	if rebuilt.String() != s {
+		t.Fatalf("pieces do not reassemble the input")
+	}
+}
@@ -0,0 +1,281 @@
+package executor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// RepairTruncatedJSON completes a truncated JSON fragment by closing any open
+// string and unwinding the bracket stack, appending only: the input is always
+// a prefix of the result so the completion can be emitted as a stream delta.
+// Dangling commas and colons are finished with a null (or empty-key member
+// inside objects) before closing. The boolean reports whether the result is
+// valid JSON; inputs that cannot be completed by appending (e.g. a truncated
+// number literal) return false.
+func RepairTruncatedJSON(fragment string) (string, bool) {
+	if strings.TrimSpace(fragment) == "" {
+		return fragment + "{}", true
+	}
+	if json.Valid([]byte(fragment)) {
+		return fragment, true
+	}
+	var stack []byte
+	inString := false
+	escaped := false
+	for i := 0; i < len(fragment); i++ {
+		c := fragment[i]
+		if inString {
+			if escaped {
+				escaped = false
+				continue
+			}
+			if c == '\\' {
+				escaped = true
+				continue
+			}
+			if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			stack = append(stack, c)
+		case '}':
+			if len(stack) > 0 && stack[len(stack)-1] == '{' {
+				stack = stack[:len(stack)-1]
+			}
+		case ']':
+			if len(stack) > 0 && stack[len(stack)-1] == '[' {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	var b strings.Builder
+	b.WriteString(fragment)
+	if escaped {
+		b.WriteByte('\\')
+	}
+	if inString {
+		b.WriteByte('"')
+	}
+	switch tail := strings.TrimRight(b.String(), " \t\r\n"); {
+	case strings.HasSuffix(tail, ":"):
+		b.WriteString("null")
+	case strings.HasSuffix(tail, ","):
+		if len(stack) > 0 && stack[len(stack)-1] == '{' {
+			b.WriteString(`"":null`)
+		} else {
+			b.WriteString("null")
+		}
+	}
+	var closers strings.Builder
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == '{' {
+			closers.WriteByte('}')
+		} else {
+			closers.WriteByte(']')
+		}
+	}
+	repaired := b.String() + closers.String()
+	if json.Valid([]byte(repaired)) {
+		return repaired, true
+	}
+	// A string closed in key position leaves a dangling key; give it a value.
+	repaired = b.String() + ":null" + closers.String()
+	return repaired, json.Valid([]byte(repaired))
+}
+
+// ToolArgStreamRepairer accumulates streamed function-call argument fragments
+// per tool call and, when a call's arguments end truncated, emits the
+// completion as a final corrective delta so clients reassemble valid JSON.
+type ToolArgStreamRepairer struct {
+	format string
+	args   map[int64]*strings.Builder
+
+	// openai: chunk envelope fields copied from the first tool_call chunk so
+	// corrective deltas carry matching identifiers.
+	chunkID      string
+	chunkModel   string
+	chunkCreated int64
+	finished     bool
+}
+
+// NewToolArgStreamRepairer returns a repairer for the source format, or nil
+// for formats whose tool arguments never stream as fragments.
+func NewToolArgStreamRepairer(format string) *ToolArgStreamRepairer {
+	switch format {
+	case "openai", "claude":
+		return &ToolArgStreamRepairer{format: format, args: make(map[int64]*strings.Builder)}
+	default:
+		return nil
+	}
+}
+
+// Process observes one stream chunk and returns the chunks to forward in its
+// place: usually just the chunk itself, with corrective argument deltas
+// inserted ahead of the terminal chunk when accumulated arguments are invalid.
+func (r *ToolArgStreamRepairer) Process(payload []byte) [][]byte {
+	switch r.format {
+	case "openai":
+		return r.processOpenAI(payload)
+	case "claude":
+		return r.processClaude(payload)
+	default:
+		return [][]byte{payload}
+	}
+}
+
+func (r *ToolArgStreamRepairer) processOpenAI(payload []byte) [][]byte {
+	data := streamChunkJSON(payload)
+	if data == nil {
+		return [][]byte{payload}
+	}
+	if calls := gjson.GetBytes(data, "choices.0.delta.tool_calls"); calls.IsArray() {
+		if r.chunkID == "" {
+			r.chunkID = gjson.GetBytes(data, "id").String()
+			r.chunkModel = gjson.GetBytes(data, "model").String()
+			r.chunkCreated = gjson.GetBytes(data, "created").Int()
+		}
+		for _, call := range calls.Array() {
+			idx := call.Get("index").Int()
+			builder, ok := r.args[idx]
+			if !ok {
+				builder = &strings.Builder{}
+				r.args[idx] = builder
+			}
+			builder.WriteString(call.Get("function.arguments").String())
+		}
+	}
+	if finish := gjson.GetBytes(data, "choices.0.finish_reason"); finish.Exists() && finish.Type != gjson.Null && !r.finished {
+		r.finished = true
+		var out [][]byte
+		for idx, builder := range r.args {
+			if suffix, ok := repairSuffix(builder.String()); ok {
+				out = append(out, r.openAICorrection(idx, suffix))
+			}
+		}
+		return append(out, payload)
+	}
+	return [][]byte{payload}
+}
+
+func (r *ToolArgStreamRepairer) openAICorrection(index int64, suffix string) []byte {
+	chunk := `{"object":"chat.completion.chunk"}`
+	chunk, _ = sjson.Set(chunk, "id", r.chunkID)
+	chunk, _ = sjson.Set(chunk, "created", r.chunkCreated)
+	chunk, _ = sjson.Set(chunk, "model", r.chunkModel)
+	chunk, _ = sjson.Set(chunk, "choices.0.index", 0)
+	chunk, _ = sjson.Set(chunk, "choices.0.delta.tool_calls.0.index", index)
+	chunk, _ = sjson.Set(chunk, "choices.0.delta.tool_calls.0.function.arguments", suffix)
+	chunk, _ = sjson.SetRaw(chunk, "choices.0.finish_reason", "null")
+	return []byte(chunk)
+}
+
+func (r *ToolArgStreamRepairer) processClaude(payload []byte) [][]byte {
+	data := claudeEventJSON(payload)
+	if data == nil {
+		return [][]byte{payload}
+	}
+	switch gjson.GetBytes(data, "type").String() {
+	case "content_block_start":
+		if gjson.GetBytes(data, "content_block.type").String() == "tool_use" {
+			r.args[gjson.GetBytes(data, "index").Int()] = &strings.Builder{}
+		}
+	case "content_block_delta":
+		if gjson.GetBytes(data, "delta.type").String() == "input_json_delta" {
+			if builder, ok := r.args[gjson.GetBytes(data, "index").Int()]; ok {
+				builder.WriteString(gjson.GetBytes(data, "delta.partial_json").String())
+			}
+		}
+	case "content_block_stop":
+		idx := gjson.GetBytes(data, "index").Int()
+		builder, ok := r.args[idx]
+		if !ok {
+			break
+		}
+		delete(r.args, idx)
+		// Blocks that streamed no fragments carry their input in the start
+		// event; there is nothing to complete.
+		if builder.Len() == 0 {
+			break
+		}
+		if suffix, ok := repairSuffix(builder.String()); ok {
+			return [][]byte{claudeInputJSONDeltaEvent(idx, suffix), payload}
+		}
+	}
+	return [][]byte{payload}
+}
+
+// claudeInputJSONDeltaEvent frames one input_json_delta piece for a tool_use
+// block, shared by the stream repairer and the re-chunking layer.
+func claudeInputJSONDeltaEvent(index int64, fragment string) []byte {
+	data := `{"type":"content_block_delta","delta":{"type":"input_json_delta"}}`
+	data, _ = sjson.Set(data, "index", index)
+	data, _ = sjson.Set(data, "delta.partial_json", fragment)
+	return claudeEventFrame("content_block_delta", []byte(data))
+}
+
+// claudeEventFrame wraps an event payload in Claude SSE framing.
+func claudeEventFrame(event string, data []byte) []byte {
+	return []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event, data))
+}
+
+// claudeEventJSON extracts the data payload from a Claude SSE event block.
+func claudeEventJSON(chunk []byte) []byte {
+	for _, line := range bytes.Split(chunk, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if bytes.HasPrefix(line, []byte("data:")) {
+			if data := bytes.TrimSpace(line[len("data:"):]); len(data) > 0 && data[0] == '{' {
+				return data
+			}
+		}
+	}
+	return nil
+}
+
+// repairSuffix returns the completion that turns accumulated arguments into
+// valid JSON, when the repair is append-only. Arguments that are already valid
+// or cannot be completed by appending produce no correction.
+func repairSuffix(accumulated string) (string, bool) {
+	if json.Valid([]byte(accumulated)) {
+		return "", false
+	}
+	repaired, ok := RepairTruncatedJSON(accumulated)
+	if !ok || !strings.HasPrefix(repaired, accumulated) || repaired == accumulated {
+		return "", false
+	}
+	return repaired[len(accumulated):], true
+}
+
+// RepairToolArgStream wraps a stream channel with incremental tool-argument
+// validation: fragments are accumulated per tool call and truncated argument
+// JSON is completed with a corrective delta before the terminal chunk. Formats
+// without fragmented tool arguments pass through untouched.
+func RepairToolArgStream(format string, in <-chan StreamChunk) <-chan StreamChunk {
+	repairer := NewToolArgStreamRepairer(format)
+	if repairer == nil || in == nil {
+		return in
+	}
+	out := make(chan StreamChunk, 1)
+	go func() {
+		defer close(out)
+		for chunk := range in {
+			if chunk.Err != nil || len(chunk.Payload) == 0 {
+				out <- chunk
+				continue
+			}
+			for _, payload := range repairer.Process(chunk.Payload) {
+				out <- StreamChunk{Payload: payload}
+			}
+		}
+	}()
+	return out
+}
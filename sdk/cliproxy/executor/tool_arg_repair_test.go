@@ -0,0 +1,134 @@
+package executor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestRepairTruncatedJSON(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+		ok   bool
+	}{
+		{name: "already valid", in: `{"a":1}`, want: `{"a":1}`, ok: true},
+		{name: "empty fragment", in: "", want: "{}", ok: true},
+		{name: "open object", in: `{"a":1`, want: `{"a":1}`, ok: true},
+		{name: "open string", in: `{"path":"/tmp/fo`, want: `{"path":"/tmp/fo"}`, ok: true},
+		{name: "open nested array", in: `{"items":["a","b"`, want: `{"items":["a","b"]}`, ok: true},
+		{name: "dangling colon", in: `{"a":`, want: `{"a":null}`, ok: true},
+		{name: "dangling comma in array", in: `[1,2,`, want: `[1,2,null]`, ok: true},
+		{name: "dangling comma in object", in: `{"a":1,`, want: `{"a":1,"":null}`, ok: true},
+		{name: "dangling escape", in: `{"a":"x\`, want: `{"a":"x\\"}`, ok: true},
+		{name: "truncated number", in: `{"a":1.`, ok: false},
+		{name: "brace inside string", in: `{"a":"{`, want: `{"a":"{"}`, ok: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := RepairTruncatedJSON(tc.in)
+			if ok != tc.ok {
+				t.Fatalf("RepairTruncatedJSON(%q) ok = %t, want %t", tc.in, ok, tc.ok)
+			}
+			if !ok {
+				return
+			}
+			if got != tc.want {
+				t.Fatalf("RepairTruncatedJSON(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if !strings.HasPrefix(got, tc.in) {
+				t.Fatalf("repair is not append-only: %q -> %q", tc.in, got)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Fatalf("repair produced invalid JSON: %q", got)
+			}
+		})
+	}
+}
+
+func TestToolArgStreamRepairerOpenAI(t *testing.T) {
+	repairer := NewToolArgStreamRepairer("openai")
+	chunks := [][]byte{
+		[]byte(`{"id":"c1","object":"chat.completion.chunk","created":1,"model":"gpt-5","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","function":{"name":"lookup","arguments":"{\"query\":\"weath"}}]},"finish_reason":null}]}`),
+		[]byte(`{"id":"c1","object":"chat.completion.chunk","created":1,"model":"gpt-5","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"er\",\"unit"}}]},"finish_reason":null}]}`),
+		[]byte(`{"id":"c1","object":"chat.completion.chunk","created":1,"model":"gpt-5","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`),
+	}
+	var forwarded [][]byte
+	for _, chunk := range chunks {
+		forwarded = append(forwarded, repairer.Process(chunk)...)
+	}
+	if len(forwarded) != len(chunks)+1 {
+		t.Fatalf("forwarded %d chunks, want %d", len(forwarded), len(chunks)+1)
+	}
+	correction := forwarded[len(forwarded)-2]
+	if string(forwarded[len(forwarded)-1]) != string(chunks[2]) {
+		t.Fatalf("terminal chunk not forwarded last")
+	}
+	suffix := gjson.GetBytes(correction, "choices.0.delta.tool_calls.0.function.arguments").String()
+	if suffix == "" {
+		t.Fatalf("correction carries no arguments: %s", correction)
+	}
+	if got := gjson.GetBytes(correction, "id").String(); got != "c1" {
+		t.Fatalf("correction id = %q, want c1", got)
+	}
+	full := `{"query":"weath` + `er","unit` + suffix
+	if !json.Valid([]byte(full)) {
+		t.Fatalf("reassembled arguments invalid: %s", full)
+	}
+}
+
+func TestToolArgStreamRepairerOpenAIValidArgsUntouched(t *testing.T) {
+	repairer := NewToolArgStreamRepairer("openai")
+	chunks := [][]byte{
+		[]byte(`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"a\":1}"}}]},"finish_reason":null}]}`),
+		[]byte(`{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{},"finish_reason":"tool_calls"}]}`),
+	}
+	var forwarded [][]byte
+	for _, chunk := range chunks {
+		forwarded = append(forwarded, repairer.Process(chunk)...)
+	}
+	if len(forwarded) != len(chunks) {
+		t.Fatalf("valid arguments produced a correction: %d chunks forwarded", len(forwarded))
+	}
+}
+
+func TestToolArgStreamRepairerClaude(t *testing.T) {
+	repairer := NewToolArgStreamRepairer("claude")
+	chunks := [][]byte{
+		[]byte("event: content_block_start\ndata: {\"type\":\"content_block_start\",\"index\":1,\"content_block\":{\"type\":\"tool_use\",\"id\":\"t1\",\"name\":\"lookup\"}}\n\n"),
+		[]byte("event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":1,\"delta\":{\"type\":\"input_json_delta\",\"partial_json\":\"{\\\"query\\\":\\\"wea\"}}\n\n"),
+		[]byte("event: content_block_stop\ndata: {\"type\":\"content_block_stop\",\"index\":1}\n\n"),
+	}
+	var forwarded [][]byte
+	for _, chunk := range chunks {
+		forwarded = append(forwarded, repairer.Process(chunk)...)
+	}
+	if len(forwarded) != len(chunks)+1 {
+		t.Fatalf("forwarded %d chunks, want %d", len(forwarded), len(chunks)+1)
+	}
+	correction := forwarded[len(forwarded)-2]
+	data := claudeEventJSON(correction)
+	if data == nil {
+		t.Fatalf("correction is not a framed event: %s", correction)
+	}
+	if got := gjson.GetBytes(data, "delta.type").String(); got != "input_json_delta" {
+		t.Fatalf("correction delta type = %q", got)
+	}
+	suffix := gjson.GetBytes(data, "delta.partial_json").String()
+	full := `{"query":"wea` + suffix
+	if !json.Valid([]byte(full)) {
+		t.Fatalf("reassembled input invalid: %s", full)
+	}
+	if string(forwarded[len(forwarded)-1]) != string(chunks[2]) {
+		t.Fatalf("stop event not forwarded after correction")
+	}
+}
+
+func TestNewToolArgStreamRepairerUnsupportedFormat(t *testing.T) {
+	if repairer := NewToolArgStreamRepairer("gemini"); repairer != nil {
+		t.Fatalf("gemini format should not be wrapped")
+	}
+}
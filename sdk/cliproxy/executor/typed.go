@@ -0,0 +1,174 @@
+package executor
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// This file provides optional typed views over the raw request/response payloads
+// carried by Request and Response. Parsing is lossless: the typed structs are
+// decoded copies for convenient inspection, while the original raw bytes remain
+// authoritative and are passed through unchanged. Fields not modelled here are
+// preserved in the payload and can still be accessed via gjson when needed.
+
+// ChatRequest is a typed view of an OpenAI-style chat completion request payload.
+type ChatRequest struct {
+	// Model is the requested model identifier.
+	Model string `json:"model,omitempty"`
+	// Messages holds the conversation turns.
+	Messages []Message `json:"messages,omitempty"`
+	// Tools lists the function tools offered to the model.
+	Tools []Tool `json:"tools,omitempty"`
+	// ToolChoice carries the raw tool_choice value ("auto", "none", or an object).
+	ToolChoice json.RawMessage `json:"tool_choice,omitempty"`
+	// Stream toggles streaming mode.
+	Stream bool `json:"stream,omitempty"`
+	// MaxTokens caps the completion length when provided.
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// Temperature is the sampling temperature when provided.
+	Temperature *float64 `json:"temperature,omitempty"`
+
+	raw []byte
+}
+
+// Message represents a single conversation turn.
+type Message struct {
+	// Role identifies the author ("system", "user", "assistant", "tool").
+	Role string `json:"role,omitempty"`
+	// Content holds the raw content value, which may be a string or a part array.
+	Content json.RawMessage `json:"content,omitempty"`
+	// Name optionally names the author of the message.
+	Name string `json:"name,omitempty"`
+	// ToolCalls lists tool invocations requested by the assistant.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID references the tool call a tool message responds to.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// Text returns the message content as plain text. String content is returned
+// as-is; part arrays are flattened by concatenating their text parts.
+func (m Message) Text() string {
+	if len(m.Content) == 0 {
+		return ""
+	}
+	var asString string
+	if err := json.Unmarshal(m.Content, &asString); err == nil {
+		return asString
+	}
+	var parts []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(m.Content, &parts); err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range parts {
+		if part.Text != "" {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+// Tool describes a function tool offered to the model.
+type Tool struct {
+	// Type is the tool type, normally "function".
+	Type string `json:"type,omitempty"`
+	// Function holds the function declaration.
+	Function ToolFunction `json:"function,omitempty"`
+}
+
+// ToolFunction describes a callable function declaration.
+type ToolFunction struct {
+	// Name is the function identifier.
+	Name string `json:"name,omitempty"`
+	// Description explains the function to the model.
+	Description string `json:"description,omitempty"`
+	// Parameters carries the raw JSON schema for the arguments.
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// ToolCall represents a tool invocation emitted by the model.
+type ToolCall struct {
+	// ID uniquely identifies the call within the response.
+	ID string `json:"id,omitempty"`
+	// Type is the call type, normally "function".
+	Type string `json:"type,omitempty"`
+	// Function holds the invoked function name and arguments.
+	Function ToolCallFunction `json:"function,omitempty"`
+}
+
+// ToolCallFunction carries the function name and serialized arguments of a tool call.
+type ToolCallFunction struct {
+	// Name is the invoked function identifier.
+	Name string `json:"name,omitempty"`
+	// Arguments is the JSON-encoded argument payload.
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// Usage reports token accounting for a completed request.
+type Usage struct {
+	// PromptTokens counts tokens consumed by the input.
+	PromptTokens int64 `json:"prompt_tokens,omitempty"`
+	// CompletionTokens counts tokens produced by the model.
+	CompletionTokens int64 `json:"completion_tokens,omitempty"`
+	// TotalTokens is the sum of prompt and completion tokens.
+	TotalTokens int64 `json:"total_tokens,omitempty"`
+}
+
+// ChatResponse is a typed view of an OpenAI-style chat completion response payload.
+type ChatResponse struct {
+	// ID is the provider-assigned completion identifier.
+	ID string `json:"id,omitempty"`
+	// Model echoes the model that produced the completion.
+	Model string `json:"model,omitempty"`
+	// Choices holds the completion alternatives.
+	Choices []Choice `json:"choices,omitempty"`
+	// Usage reports token accounting when the provider supplies it.
+	Usage *Usage `json:"usage,omitempty"`
+
+	raw []byte
+}
+
+// Choice is a single completion alternative.
+type Choice struct {
+	// Index is the zero-based choice position.
+	Index int `json:"index"`
+	// Message holds the generated message.
+	Message Message `json:"message,omitempty"`
+	// FinishReason explains why generation stopped.
+	FinishReason string `json:"finish_reason,omitempty"`
+}
+
+// ParseChatRequest decodes an OpenAI-style chat payload into a typed view while
+// retaining the original bytes for lossless passthrough via Raw.
+func ParseChatRequest(payload []byte) (*ChatRequest, error) {
+	out := &ChatRequest{raw: append([]byte(nil), payload...)}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Raw returns the original request payload bytes unchanged.
+func (c *ChatRequest) Raw() []byte { return c.raw }
+
+// ParseChatResponse decodes an OpenAI-style chat response into a typed view while
+// retaining the original bytes for lossless passthrough via Raw.
+func ParseChatResponse(payload []byte) (*ChatResponse, error) {
+	out := &ChatResponse{raw: append([]byte(nil), payload...)}
+	if err := json.Unmarshal(payload, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Raw returns the original response payload bytes unchanged.
+func (c *ChatResponse) Raw() []byte { return c.raw }
+
+// Chat decodes the request payload into a typed ChatRequest view.
+func (r Request) Chat() (*ChatRequest, error) { return ParseChatRequest(r.Payload) }
+
+// Chat decodes the response payload into a typed ChatResponse view.
+func (r Response) Chat() (*ChatResponse, error) { return ParseChatResponse(r.Payload) }
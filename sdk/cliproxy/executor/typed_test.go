@@ -0,0 +1,72 @@
+package executor
+
+import "testing"
+
+func TestParseChatRequestLossless(t *testing.T) {
+	payload := []byte(`{"model":"gpt-4o","messages":[{"role":"user","content":"hello"}],"stream":true,"custom_field":{"nested":1}}`)
+	req := Request{Model: "gpt-4o", Payload: payload}
+
+	chat, err := req.Chat()
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if chat.Model != "gpt-4o" {
+		t.Fatalf("Model = %q, want %q", chat.Model, "gpt-4o")
+	}
+	if !chat.Stream {
+		t.Fatalf("Stream = false, want true")
+	}
+	if len(chat.Messages) != 1 || chat.Messages[0].Role != "user" {
+		t.Fatalf("Messages = %+v, want single user message", chat.Messages)
+	}
+	if got := string(chat.Raw()); got != string(payload) {
+		t.Fatalf("Raw() = %q, want original payload %q", got, payload)
+	}
+}
+
+func TestMessageText(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "string content", content: `"hello"`, want: "hello"},
+		{name: "part array", content: `[{"type":"text","text":"foo"},{"type":"text","text":"bar"}]`, want: "foobar"},
+		{name: "empty", content: ``, want: ""},
+		{name: "non text parts", content: `[{"type":"image_url","image_url":{"url":"x"}}]`, want: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg := Message{Content: []byte(tc.content)}
+			if got := msg.Text(); got != tc.want {
+				t.Fatalf("Text() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseChatResponse(t *testing.T) {
+	payload := []byte(`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"message":{"role":"assistant","content":"hi","tool_calls":[{"id":"call_1","type":"function","function":{"name":"lookup","arguments":"{\"q\":1}"}}]},"finish_reason":"tool_calls"}],"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`)
+	resp := Response{Payload: payload}
+
+	chat, err := resp.Chat()
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(chat.Choices) != 1 {
+		t.Fatalf("Choices length = %d, want 1", len(chat.Choices))
+	}
+	choice := chat.Choices[0]
+	if choice.FinishReason != "tool_calls" {
+		t.Fatalf("FinishReason = %q, want %q", choice.FinishReason, "tool_calls")
+	}
+	if len(choice.Message.ToolCalls) != 1 || choice.Message.ToolCalls[0].Function.Name != "lookup" {
+		t.Fatalf("ToolCalls = %+v, want single lookup call", choice.Message.ToolCalls)
+	}
+	if chat.Usage == nil || chat.Usage.TotalTokens != 8 {
+		t.Fatalf("Usage = %+v, want total 8", chat.Usage)
+	}
+	if got := string(chat.Raw()); got != string(payload) {
+		t.Fatalf("Raw() = %q, want original payload", got)
+	}
+}
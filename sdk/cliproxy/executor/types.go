@@ -3,6 +3,7 @@ package executor
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
 )
@@ -28,8 +29,15 @@ type Options struct {
 	Stream bool
 	// Alt carries optional alternate format hint (e.g. SSE JSON key).
 	Alt string
-	// Headers are forwarded to the provider request builder.
+	// Headers are forwarded to the provider request builder and merged into the
+	// outbound upstream request, overriding defaults on key collisions.
 	Headers http.Header
+	// Timeout bounds the upstream HTTP call when positive; zero keeps the
+	// provider default (no client timeout).
+	Timeout time.Duration
+	// Tags carries arbitrary caller labels that flow into upstream request logs
+	// and usage records.
+	Tags map[string]string
 	// Query contains optional query string parameters.
 	Query url.Values
 	// OriginalRequest preserves the inbound request bytes prior to translation.
@@ -0,0 +1,65 @@
+package cliproxy
+
+import (
+	"fmt"
+	"strings"
+
+	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
+)
+
+// builtinProviderKeys lists provider identifiers whose executors are owned by the
+// service itself. External registrations must not shadow them, because the service
+// rebinds these executors whenever the configuration reloads.
+var builtinProviderKeys = map[string]struct{}{
+	"gemini":         {},
+	"gemini-cli":     {},
+	"vertex":         {},
+	"vertex-express": {},
+	"aistudio":       {},
+	"antigravity":    {},
+	"claude":         {},
+	"codex":          {},
+	"cerebras":       {},
+	"azure-openai":   {},
+	"ollama":         {},
+	"openrouter":     {},
+	"mistral":        {},
+	"groq":           {},
+	"deepseek":       {},
+	"cohere":         {},
+	"cloudflare":     {},
+	"qwen":           {},
+	"iflow":          {},
+	"kiro":           {},
+	"github-copilot": {},
+}
+
+// validateProviderExecutor checks that an executor is usable for external registration.
+func validateProviderExecutor(executor coreauth.ProviderExecutor) error {
+	if executor == nil {
+		return fmt.Errorf("cliproxy: provider executor is nil")
+	}
+	identifier := strings.ToLower(strings.TrimSpace(executor.Identifier()))
+	if identifier == "" {
+		return fmt.Errorf("cliproxy: provider executor identifier is empty")
+	}
+	if _, reserved := builtinProviderKeys[identifier]; reserved {
+		return fmt.Errorf("cliproxy: provider key %q is reserved for a built-in executor", identifier)
+	}
+	return nil
+}
+
+// RegisterProviderExecutor registers an out-of-tree provider executor on the runtime
+// auth manager. Auths whose Provider matches the executor identifier are routed to it,
+// and auth synthesis, selection, and usage reporting apply as for built-in providers.
+// The provider key must not collide with a built-in executor.
+func (s *Service) RegisterProviderExecutor(executor coreauth.ProviderExecutor) error {
+	if s == nil || s.coreManager == nil {
+		return fmt.Errorf("cliproxy: service is not initialised")
+	}
+	if err := validateProviderExecutor(executor); err != nil {
+		return err
+	}
+	s.coreManager.RegisterExecutor(executor)
+	return nil
+}
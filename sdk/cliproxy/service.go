@@ -8,14 +8,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/shariqriazz/modelgate/internal/api"
+	"github.com/shariqriazz/modelgate/internal/grpcserver"
 	"github.com/shariqriazz/modelgate/internal/registry"
 	"github.com/shariqriazz/modelgate/internal/runtime/executor"
-	_ "github.com/shariqriazz/modelgate/internal/usage"
+	internalusage "github.com/shariqriazz/modelgate/internal/usage"
 	"github.com/shariqriazz/modelgate/internal/watcher"
 	"github.com/shariqriazz/modelgate/internal/wsrelay"
 	sdkaccess "github.com/shariqriazz/modelgate/sdk/access"
@@ -23,6 +25,7 @@ import (
 	coreauth "github.com/shariqriazz/modelgate/sdk/cliproxy/auth"
 	"github.com/shariqriazz/modelgate/sdk/cliproxy/usage"
 	"github.com/shariqriazz/modelgate/sdk/config"
+	sdktranslator "github.com/shariqriazz/modelgate/sdk/translator"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -86,6 +89,12 @@ type Service struct {
 
 	// wsGateway manages websocket Gemini providers.
 	wsGateway *wsrelay.Manager
+
+	// grpcServer hosts the optional gRPC interface when configured.
+	grpcServer *grpcserver.Server
+
+	// usageStore persists usage records when usage storage is configured.
+	usageStore internalusage.Store
 }
 
 // RegisterUsagePlugin registers a usage plugin on the global usage manager.
@@ -354,10 +363,32 @@ func (s *Service) ensureExecutorsForAuth(a *coreauth.Auth) {
 		s.coreManager.RegisterExecutor(executor.NewAntigravityExecutor(s.cfg))
 	case "codex":
 		s.coreManager.RegisterExecutor(executor.NewCodexExecutor(s.cfg))
+	case "claude":
+		s.coreManager.RegisterExecutor(executor.NewClaudeExecutor(s.cfg))
 	case "qwen":
 		s.coreManager.RegisterExecutor(executor.NewQwenExecutor(s.cfg))
 	case "iflow":
 		s.coreManager.RegisterExecutor(executor.NewIFlowExecutor(s.cfg))
+	case "cerebras":
+		s.coreManager.RegisterExecutor(executor.NewCerebrasExecutor(s.cfg))
+	case "azure-openai":
+		s.coreManager.RegisterExecutor(executor.NewAzureOpenAIExecutor(s.cfg))
+	case "ollama":
+		s.coreManager.RegisterExecutor(executor.NewOllamaExecutor(s.cfg))
+	case "openrouter":
+		s.coreManager.RegisterExecutor(executor.NewOpenRouterExecutor(s.cfg))
+	case "mistral":
+		s.coreManager.RegisterExecutor(executor.NewMistralExecutor(s.cfg))
+	case "groq":
+		s.coreManager.RegisterExecutor(executor.NewGroqExecutor(s.cfg))
+	case "deepseek":
+		s.coreManager.RegisterExecutor(executor.NewDeepSeekExecutor(s.cfg))
+	case "cohere":
+		s.coreManager.RegisterExecutor(executor.NewCohereExecutor(s.cfg))
+	case "cloudflare":
+		s.coreManager.RegisterExecutor(executor.NewCloudflareExecutor(s.cfg))
+	case "vertex-express":
+		s.coreManager.RegisterExecutor(executor.NewVertexExpressExecutor(s.cfg))
 	case "github-copilot":
 		s.coreManager.RegisterExecutor(executor.NewGitHubCopilotExecutor(s.cfg))
 	}
@@ -393,6 +424,25 @@ func (s *Service) Run(ctx context.Context) error {
 
 	usage.StartDefault(ctx)
 
+	if backend := strings.TrimSpace(s.cfg.UsageStorage.Backend); backend != "" {
+		usageStore, errStore := internalusage.OpenStore(ctx, backend, s.cfg.UsageStorage.DSN)
+		if errStore != nil {
+			log.Errorf("failed to initialize usage store: %v", errStore)
+		} else {
+			s.usageStore = usageStore
+			internalusage.SetActiveStore(usageStore)
+			usage.RegisterPlugin(internalusage.NewStorePlugin(usageStore))
+			log.Infof("usage store started with %s backend", backend)
+		}
+	}
+
+	// Report duplicate translator registrations (e.g. an out-of-tree plugin shadowing
+	// a built-in pair). The last registration wins, so surface it once at startup.
+	for _, conflict := range sdktranslator.Conflicts() {
+		log.Warnf("translator registry: format pair %s -> %s registered %d times; the last registration wins",
+			conflict.From, conflict.To, conflict.Count)
+	}
+
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 	defer func() {
@@ -475,10 +525,25 @@ func (s *Service) Run(ctx context.Context) error {
 	time.Sleep(100 * time.Millisecond)
 	fmt.Printf("API server started successfully on: %s:%d\n", s.cfg.Host, s.cfg.Port)
 
+	if grpcAddr := strings.TrimSpace(s.cfg.GRPCAddress); grpcAddr != "" {
+		s.grpcServer = grpcserver.New(s.coreManager, s.cfg, s.accessManager)
+		go func(addr string) {
+			if errServe := s.grpcServer.Serve(addr); errServe != nil {
+				log.Errorf("gRPC server on %s stopped: %v", addr, errServe)
+			}
+		}(grpcAddr)
+	}
+
 	if s.hooks.OnAfterStart != nil {
 		s.hooks.OnAfterStart(s)
 	}
 
+	executor.StartConnectionWarmup(ctx, func() *config.Config {
+		s.cfgMu.RLock()
+		defer s.cfgMu.RUnlock()
+		return s.cfg
+	})
+
 	var watcherWrapper *WatcherWrapper
 	reloadCallback := func(newCfg *config.Config) {
 		previousStrategy := ""
@@ -556,8 +621,15 @@ func (s *Service) Run(ctx context.Context) error {
 		interval := 15 * time.Minute
 		s.coreManager.StartAutoRefresh(context.Background(), interval)
 		log.Infof("core auth auto-refresh started (interval=%s)", interval)
+		// Pay provider cold-start costs (token minting, project lookups,
+		// near-expiry refreshes) in the background before the first request.
+		go s.coreManager.Prewarm(ctx)
 	}
 
+	// Periodically re-fetch provider model lists that are discovered at
+	// runtime (iflow) so newly launched models appear without a restart.
+	go s.startDynamicModelRefresh(ctx)
+
 	select {
 	case <-ctx.Done():
 		log.Debug("service context cancelled, shutting down...")
@@ -567,6 +639,45 @@ func (s *Service) Run(ctx context.Context) error {
 	}
 }
 
+// dynamicModelProviders lists providers whose model lists are fetched from the
+// upstream at registration time instead of coming from the static catalog.
+var dynamicModelProviders = map[string]struct{}{
+	"iflow":      {},
+	"ollama":     {},
+	"openrouter": {},
+	"groq":       {},
+	"deepseek":   {},
+	"cohere":     {},
+	"cloudflare": {},
+}
+
+// startDynamicModelRefresh re-registers models for dynamic-list providers on a
+// fixed interval until the context is cancelled, so newly launched upstream
+// models become routable without a restart.
+func (s *Service) startDynamicModelRefresh(ctx context.Context) {
+	if s == nil || s.coreManager == nil {
+		return
+	}
+	ticker := time.NewTicker(3 * time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, a := range s.coreManager.List() {
+				if a == nil || a.Disabled {
+					continue
+				}
+				if _, ok := dynamicModelProviders[strings.ToLower(strings.TrimSpace(a.Provider))]; !ok {
+					continue
+				}
+				s.registerModelsForAuth(a)
+			}
+		}
+	}
+}
+
 // Shutdown gracefully stops background workers and the HTTP server.
 // It ensures all resources are properly cleaned up and connections are closed.
 // The shutdown is idempotent and can be called multiple times safely.
@@ -612,6 +723,9 @@ func (s *Service) Shutdown(ctx context.Context) error {
 			s.authQueueStop()
 			s.authQueueStop = nil
 		}
+		if s.grpcServer != nil {
+			s.grpcServer.Stop(ctx)
+		}
 
 		// no legacy clients to persist
 
@@ -627,6 +741,14 @@ func (s *Service) Shutdown(ctx context.Context) error {
 		}
 
 		usage.StopDefault()
+
+		if s.usageStore != nil {
+			internalusage.SetActiveStore(nil)
+			if err := s.usageStore.Close(); err != nil {
+				log.Errorf("error closing usage store: %v", err)
+			}
+			s.usageStore = nil
+		}
 	})
 	return shutdownErr
 }
@@ -735,11 +857,91 @@ func (s *Service) registerModelsForAuth(a *coreauth.Auth) {
 			}
 		}
 		models = applyExcludedModels(models, excluded)
+	case "cerebras":
+		if entry := s.resolveConfigCerebrasKey(a); entry != nil {
+			if len(entry.Models) > 0 {
+				models = buildCerebrasConfigModels(entry)
+			}
+			if authKind == "apikey" {
+				excluded = entry.ExcludedModels
+			}
+		}
+		models = applyExcludedModels(models, excluded)
+	case "mistral":
+		if entry := s.resolveConfigMistralKey(a); entry != nil {
+			if len(entry.Models) > 0 {
+				models = buildMistralConfigModels(entry)
+			}
+			if authKind == "apikey" {
+				excluded = entry.ExcludedModels
+			}
+		}
+		models = applyExcludedModels(models, excluded)
+	case "azure-openai":
+		// Azure serves only the deployments configured on the resource.
+		if entry := s.resolveConfigAzureOpenAIKey(a); entry != nil {
+			models = buildAzureOpenAIConfigModels(entry)
+			if authKind == "apikey" {
+				excluded = entry.ExcludedModels
+			}
+		}
+		models = applyExcludedModels(models, excluded)
+	case "vertex-express":
+		// Express mode serves the same Gemini models as regular Vertex AI.
+		models = registry.GetGeminiVertexModels()
+		if entry := s.resolveConfigVertexExpressKey(a); entry != nil {
+			if len(entry.Models) > 0 {
+				models = buildVertexExpressConfigModels(entry)
+			}
+			if authKind == "apikey" {
+				excluded = entry.ExcludedModels
+			}
+		}
+		models = applyExcludedModels(models, excluded)
 	case "qwen":
-		models = registry.GetQwenModels()
+		region := ""
+		if a.Attributes != nil {
+			region = a.Attributes["region"]
+		}
+		models = registry.GetQwenModelsForRegion(region)
 		models = applyExcludedModels(models, excluded)
 	case "iflow":
-		models = registry.GetIFlowModels()
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchIFlowModels(ctx, a, s.cfg)
+		cancel()
+		if len(models) == 0 {
+			models = registry.GetIFlowModels()
+		}
+		models = applyExcludedModels(models, excluded)
+	case "ollama":
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchOllamaModels(ctx, a, s.cfg)
+		cancel()
+		models = applyExcludedModels(models, excluded)
+	case "openrouter":
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchOpenRouterModels(ctx, a, s.cfg)
+		cancel()
+		models = applyExcludedModels(models, excluded)
+	case "groq":
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchGroqModels(ctx, a, s.cfg)
+		cancel()
+		models = applyExcludedModels(models, excluded)
+	case "deepseek":
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchDeepSeekModels(ctx, a, s.cfg)
+		cancel()
+		models = applyExcludedModels(models, excluded)
+	case "cohere":
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchCohereModels(ctx, a, s.cfg)
+		cancel()
+		models = applyExcludedModels(models, excluded)
+	case "cloudflare":
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		models = executor.FetchCloudflareModels(ctx, a, s.cfg)
+		cancel()
 		models = applyExcludedModels(models, excluded)
 	case "github-copilot":
 		models = registry.GetGitHubCopilotModels()
@@ -808,6 +1010,29 @@ func (s *Service) registerModelsForAuth(a *coreauth.Auth) {
 							DisplayName: modelID,
 						})
 					}
+					// Gateway names from the rename table are also served by this provider.
+					seenIDs := make(map[string]struct{}, len(ms))
+					for _, m := range ms {
+						seenIDs[strings.ToLower(m.ID)] = struct{}{}
+					}
+					for gateway, upstream := range compat.ModelMappings {
+						gatewayID := strings.TrimSpace(gateway)
+						if gatewayID == "" || strings.TrimSpace(upstream) == "" {
+							continue
+						}
+						if _, dup := seenIDs[strings.ToLower(gatewayID)]; dup {
+							continue
+						}
+						seenIDs[strings.ToLower(gatewayID)] = struct{}{}
+						ms = append(ms, &ModelInfo{
+							ID:          gatewayID,
+							Object:      "model",
+							Created:     time.Now().Unix(),
+							OwnedBy:     compat.Name,
+							Type:        "openai-compatibility",
+							DisplayName: gatewayID,
+						})
+					}
 					// Register and return
 					if len(ms) > 0 {
 						if providerKey == "" {
@@ -966,6 +1191,103 @@ func (s *Service) resolveConfigCodexKey(auth *coreauth.Auth) *config.CodexKey {
 	return nil
 }
 
+func (s *Service) resolveConfigCerebrasKey(auth *coreauth.Auth) *config.CerebrasKey {
+	if auth == nil || s.cfg == nil {
+		return nil
+	}
+	var attrKey, attrBase string
+	if auth.Attributes != nil {
+		attrKey = strings.TrimSpace(auth.Attributes["api_key"])
+		attrBase = strings.TrimSpace(auth.Attributes["base_url"])
+	}
+	for i := range s.cfg.CerebrasKey {
+		entry := &s.cfg.CerebrasKey[i]
+		cfgKey := strings.TrimSpace(entry.APIKey)
+		cfgBase := strings.TrimSpace(entry.BaseURL)
+		if attrKey != "" && strings.EqualFold(cfgKey, attrKey) {
+			if cfgBase == "" || strings.EqualFold(cfgBase, attrBase) {
+				return entry
+			}
+			continue
+		}
+		if attrKey == "" && attrBase != "" && strings.EqualFold(cfgBase, attrBase) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (s *Service) resolveConfigMistralKey(auth *coreauth.Auth) *config.MistralKey {
+	if auth == nil || s.cfg == nil {
+		return nil
+	}
+	var attrKey, attrBase string
+	if auth.Attributes != nil {
+		attrKey = strings.TrimSpace(auth.Attributes["api_key"])
+		attrBase = strings.TrimSpace(auth.Attributes["base_url"])
+	}
+	for i := range s.cfg.MistralKey {
+		entry := &s.cfg.MistralKey[i]
+		cfgKey := strings.TrimSpace(entry.APIKey)
+		cfgBase := strings.TrimSpace(entry.BaseURL)
+		if attrKey != "" && strings.EqualFold(cfgKey, attrKey) {
+			if cfgBase == "" || strings.EqualFold(cfgBase, attrBase) {
+				return entry
+			}
+			continue
+		}
+		if attrKey == "" && attrBase != "" && strings.EqualFold(cfgBase, attrBase) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (s *Service) resolveConfigAzureOpenAIKey(auth *coreauth.Auth) *config.AzureOpenAIKey {
+	if auth == nil || s.cfg == nil {
+		return nil
+	}
+	var attrKey, attrEndpoint string
+	if auth.Attributes != nil {
+		attrKey = strings.TrimSpace(auth.Attributes["api_key"])
+		attrEndpoint = strings.TrimSpace(auth.Attributes["base_url"])
+	}
+	for i := range s.cfg.AzureOpenAIKey {
+		entry := &s.cfg.AzureOpenAIKey[i]
+		if attrKey != "" && strings.EqualFold(strings.TrimSpace(entry.APIKey), attrKey) &&
+			strings.EqualFold(strings.TrimSpace(entry.Endpoint), attrEndpoint) {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (s *Service) resolveConfigVertexExpressKey(auth *coreauth.Auth) *config.VertexExpressKey {
+	if auth == nil || s.cfg == nil {
+		return nil
+	}
+	var attrKey, attrBase string
+	if auth.Attributes != nil {
+		attrKey = strings.TrimSpace(auth.Attributes["api_key"])
+		attrBase = strings.TrimSpace(auth.Attributes["base_url"])
+	}
+	for i := range s.cfg.VertexExpressAPIKey {
+		entry := &s.cfg.VertexExpressAPIKey[i]
+		cfgKey := strings.TrimSpace(entry.APIKey)
+		cfgBase := strings.TrimSpace(entry.BaseURL)
+		if attrKey != "" && strings.EqualFold(cfgKey, attrKey) {
+			if cfgBase == "" || strings.EqualFold(cfgBase, attrBase) {
+				return entry
+			}
+			continue
+		}
+		if attrKey == "" && attrBase != "" && strings.EqualFold(cfgBase, attrBase) {
+			return entry
+		}
+	}
+	return nil
+}
+
 func (s *Service) oauthExcludedModels(provider, authKind string) []string {
 	cfg := s.cfg
 	if cfg == nil {
@@ -984,13 +1306,13 @@ func applyExcludedModels(models []*ModelInfo, excluded []string) []*ModelInfo {
 		return models
 	}
 
-	patterns := make([]string, 0, len(excluded))
+	matchers := make([]excludedModelMatcher, 0, len(excluded))
 	for _, item := range excluded {
-		if trimmed := strings.TrimSpace(item); trimmed != "" {
-			patterns = append(patterns, strings.ToLower(trimmed))
+		if matcher := compileExcludedModelPattern(item); matcher != nil {
+			matchers = append(matchers, matcher)
 		}
 	}
-	if len(patterns) == 0 {
+	if len(matchers) == 0 {
 		return models
 	}
 
@@ -1001,8 +1323,8 @@ func applyExcludedModels(models []*ModelInfo, excluded []string) []*ModelInfo {
 		}
 		modelID := strings.ToLower(strings.TrimSpace(model.ID))
 		blocked := false
-		for _, pattern := range patterns {
-			if matchWildcard(pattern, modelID) {
+		for _, matcher := range matchers {
+			if matcher(modelID) {
 				blocked = true
 				break
 			}
@@ -1014,6 +1336,30 @@ func applyExcludedModels(models []*ModelInfo, excluded []string) []*ModelInfo {
 	return filtered
 }
 
+// excludedModelMatcher reports whether a lowercased model ID is excluded.
+type excludedModelMatcher func(modelID string) bool
+
+// compileExcludedModelPattern builds a matcher for a single excluded-models
+// entry. Entries prefixed with "re:" are treated as case-insensitive regular
+// expressions; anything else uses the wildcard matching from matchWildcard.
+// Invalid regular expressions are logged and ignored.
+func compileExcludedModelPattern(pattern string) excludedModelMatcher {
+	trimmed := strings.TrimSpace(pattern)
+	if trimmed == "" {
+		return nil
+	}
+	if expr, ok := strings.CutPrefix(trimmed, "re:"); ok {
+		re, err := regexp.Compile("(?i)" + expr)
+		if err != nil {
+			log.Warnf("excluded-models: invalid regex pattern %q ignored: %v", trimmed, err)
+			return nil
+		}
+		return re.MatchString
+	}
+	lowered := strings.ToLower(trimmed)
+	return func(modelID string) bool { return matchWildcard(lowered, modelID) }
+}
+
 func applyModelPrefixes(models []*ModelInfo, prefix string, forceModelPrefix bool) []*ModelInfo {
 	trimmedPrefix := strings.TrimSpace(prefix)
 	if trimmedPrefix == "" || len(models) == 0 {
@@ -1156,6 +1502,13 @@ func buildVertexCompatConfigModels(entry *config.VertexCompatKey) []*ModelInfo {
 	return buildConfigModels(entry.Models, "google", "vertex")
 }
 
+func buildVertexExpressConfigModels(entry *config.VertexExpressKey) []*ModelInfo {
+	if entry == nil {
+		return nil
+	}
+	return buildConfigModels(entry.Models, "google", "vertex")
+}
+
 func buildGeminiConfigModels(entry *config.GeminiKey) []*ModelInfo {
 	if entry == nil {
 		return nil
@@ -1177,6 +1530,27 @@ func buildCodexConfigModels(entry *config.CodexKey) []*ModelInfo {
 	return buildConfigModels(entry.Models, "openai", "openai")
 }
 
+func buildCerebrasConfigModels(entry *config.CerebrasKey) []*ModelInfo {
+	if entry == nil {
+		return nil
+	}
+	return buildConfigModels(entry.Models, "cerebras", "cerebras")
+}
+
+func buildMistralConfigModels(entry *config.MistralKey) []*ModelInfo {
+	if entry == nil {
+		return nil
+	}
+	return buildConfigModels(entry.Models, "mistral", "mistral")
+}
+
+func buildAzureOpenAIConfigModels(entry *config.AzureOpenAIKey) []*ModelInfo {
+	if entry == nil {
+		return nil
+	}
+	return buildConfigModels(entry.Deployments, "azure-openai", "azure-openai")
+}
+
 func rewriteModelInfoName(name, oldID, newID string) string {
 	trimmed := strings.TrimSpace(name)
 	if trimmed == "" {
@@ -0,0 +1,57 @@
+package cliproxy
+
+import "testing"
+
+func TestApplyExcludedModels_Patterns(t *testing.T) {
+	models := []*ModelInfo{
+		{ID: "gemini-2.5-pro"},
+		{ID: "gemini-3-pro-preview"},
+		{ID: "gemini-2.5-flash"},
+		{ID: "gpt-5"},
+	}
+
+	cases := []struct {
+		name     string
+		excluded []string
+		want     []string
+	}{
+		{
+			name:     "exact match",
+			excluded: []string{"gpt-5"},
+			want:     []string{"gemini-2.5-pro", "gemini-3-pro-preview", "gemini-2.5-flash"},
+		},
+		{
+			name:     "wildcard suffix",
+			excluded: []string{"*-preview"},
+			want:     []string{"gemini-2.5-pro", "gemini-2.5-flash", "gpt-5"},
+		},
+		{
+			name:     "regex pattern",
+			excluded: []string{"re:.*preview.*"},
+			want:     []string{"gemini-2.5-pro", "gemini-2.5-flash", "gpt-5"},
+		},
+		{
+			name:     "regex anchored",
+			excluded: []string{"re:^gemini-2\\.5-(pro|flash)$"},
+			want:     []string{"gemini-3-pro-preview", "gpt-5"},
+		},
+		{
+			name:     "invalid regex ignored",
+			excluded: []string{"re:["},
+			want:     []string{"gemini-2.5-pro", "gemini-3-pro-preview", "gemini-2.5-flash", "gpt-5"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := applyExcludedModels(models, tc.excluded)
+			if len(got) != len(tc.want) {
+				t.Fatalf("applyExcludedModels() kept %d models, want %d", len(got), len(tc.want))
+			}
+			for i := range got {
+				if got[i].ID != tc.want[i] {
+					t.Fatalf("applyExcludedModels()[%d].ID = %q, want %q", i, got[i].ID, tc.want[i])
+				}
+			}
+		})
+	}
+}
@@ -19,6 +19,11 @@ type Record struct {
 	RequestedAt time.Time
 	Failed      bool
 	Detail      Detail
+	// CostUSD is the estimated request cost in USD derived from the model's
+	// pricing table; zero when the model has no known pricing.
+	CostUSD float64
+	// Tags carries caller-supplied labels forwarded from executor options.
+	Tags map[string]string
 }
 
 // Detail holds the token usage breakdown.
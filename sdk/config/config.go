@@ -13,6 +13,18 @@ type AccessProvider = internalconfig.AccessProvider
 type Config = internalconfig.Config
 
 type StreamingConfig = internalconfig.StreamingConfig
+type ModerationConfig = internalconfig.ModerationConfig
+type StructuredOutputConfig = internalconfig.StructuredOutputConfig
+type ToolValidationConfig = internalconfig.ToolValidationConfig
+type ToolValidationRule = internalconfig.ToolValidationRule
+type VirtualModelRule = internalconfig.VirtualModelRule
+type VirtualModelCandidate = internalconfig.VirtualModelCandidate
+type FallbackChain = internalconfig.FallbackChain
+type UsageStorageConfig = internalconfig.UsageStorageConfig
+type PricingRule = internalconfig.PricingRule
+type ClientBudgetRule = internalconfig.ClientBudgetRule
+type AuditLogConfig = internalconfig.AuditLogConfig
+type RecordingConfig = internalconfig.RecordingConfig
 type TLSConfig = internalconfig.TLSConfig
 type RemoteManagement = internalconfig.RemoteManagement
 type AmpCode = internalconfig.AmpCode
@@ -24,8 +36,22 @@ type PayloadModelRule = internalconfig.PayloadModelRule
 type GeminiKey = internalconfig.GeminiKey
 type CodexKey = internalconfig.CodexKey
 type ClaudeKey = internalconfig.ClaudeKey
+type CerebrasKey = internalconfig.CerebrasKey
+type CerebrasModel = internalconfig.CerebrasModel
+type AzureOpenAIKey = internalconfig.AzureOpenAIKey
+type AzureOpenAIDeployment = internalconfig.AzureOpenAIDeployment
+type OllamaServer = internalconfig.OllamaServer
+type OpenRouterKey = internalconfig.OpenRouterKey
+type MistralKey = internalconfig.MistralKey
+type MistralModel = internalconfig.MistralModel
+type GroqKey = internalconfig.GroqKey
+type DeepSeekKey = internalconfig.DeepSeekKey
+type CohereKey = internalconfig.CohereKey
+type CloudflareKey = internalconfig.CloudflareKey
 type VertexCompatKey = internalconfig.VertexCompatKey
 type VertexCompatModel = internalconfig.VertexCompatModel
+type VertexExpressKey = internalconfig.VertexExpressKey
+type VertexExpressModel = internalconfig.VertexExpressModel
 type OpenAICompatibility = internalconfig.OpenAICompatibility
 type OpenAICompatibilityAPIKey = internalconfig.OpenAICompatibilityAPIKey
 type OpenAICompatibilityModel = internalconfig.OpenAICompatibilityModel
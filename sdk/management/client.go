@@ -0,0 +1,262 @@
+// Package management provides a small Go client for the ModelGate management
+// API (/v0/management). It covers the operations automation and tests need
+// most often — listing and toggling auths, querying usage statistics, and
+// reading or replacing the live configuration — without handcrafted HTTP calls.
+package management
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Client talks to a running gateway's management endpoints.
+type Client struct {
+	baseURL    string
+	key        string
+	httpClient *http.Client
+}
+
+// Option customizes client construction.
+type Option func(*Client)
+
+// WithHTTPClient overrides the underlying HTTP client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// NewClient constructs a management client. baseURL is the gateway root
+// (e.g. "http://127.0.0.1:8317"); key is the management key and is sent as a
+// bearer token.
+func NewClient(baseURL, key string, opts ...Option) (*Client, error) {
+	trimmed := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if trimmed == "" {
+		return nil, fmt.Errorf("management: base URL is required")
+	}
+	if _, err := url.Parse(trimmed); err != nil {
+		return nil, fmt.Errorf("management: invalid base URL: %w", err)
+	}
+	client := &Client{
+		baseURL:    trimmed,
+		key:        strings.TrimSpace(key),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	return client, nil
+}
+
+// AuthFile describes a single auth entry as reported by the gateway.
+type AuthFile struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Provider      string `json:"provider"`
+	Label         string `json:"label"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"status_message"`
+	Disabled      bool   `json:"disabled"`
+	Unavailable   bool   `json:"unavailable"`
+	Email         string `json:"email,omitempty"`
+	Source        string `json:"source,omitempty"`
+}
+
+// ListAuthFiles returns all auth entries known to the gateway.
+func (c *Client) ListAuthFiles(ctx context.Context) ([]AuthFile, error) {
+	var out struct {
+		Files []AuthFile `json:"files"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/v0/management/auth-files", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Files, nil
+}
+
+// SetAuthDisabled enables or disables the auth identified by file name or ID.
+func (c *Client) SetAuthDisabled(ctx context.Context, name string, disabled bool) error {
+	payload := map[string]any{"name": name, "disabled": disabled}
+	return c.do(ctx, http.MethodPatch, "/v0/management/auth-files", payload, nil)
+}
+
+// UsageStatistics holds the usage snapshot returned by the gateway. The
+// snapshot layout is versioned server-side, so it is exposed as raw JSON
+// alongside the aggregate failure counter.
+type UsageStatistics struct {
+	// Usage is the raw statistics snapshot.
+	Usage json.RawMessage `json:"usage"`
+	// FailedRequests is the total number of failed requests.
+	FailedRequests int64 `json:"failed_requests"`
+}
+
+// Usage returns the gateway's in-memory usage statistics snapshot.
+func (c *Client) Usage(ctx context.Context) (*UsageStatistics, error) {
+	out := &UsageStatistics{}
+	if err := c.do(ctx, http.MethodGet, "/v0/management/usage", nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UsageExport returns a complete usage snapshot suitable for backup and later
+// import into another gateway instance.
+func (c *Client) UsageExport(ctx context.Context) ([]byte, error) {
+	return c.doRaw(ctx, http.MethodGet, "/v0/management/usage/export", "", nil)
+}
+
+// UsageImport merges a previously exported usage snapshot into the gateway's
+// in-memory statistics.
+func (c *Client) UsageImport(ctx context.Context, payload []byte) error {
+	_, err := c.doRaw(ctx, http.MethodPost, "/v0/management/usage/import", "application/json", payload)
+	return err
+}
+
+// AuthQuota returns the per-auth remaining-quota report: recent 429/quota
+// signals, tier metadata, budget consumption, and estimated reset times.
+func (c *Client) AuthQuota(ctx context.Context) ([]byte, error) {
+	return c.doRaw(ctx, http.MethodGet, "/v0/management/auth-quota", "", nil)
+}
+
+// LogsPage is one page of server log lines.
+type LogsPage struct {
+	// Lines holds the raw log lines, oldest first.
+	Lines []string `json:"lines"`
+	// LineCount is the number of lines in this page.
+	LineCount int `json:"line-count"`
+	// LatestTimestamp is the unix-millisecond cursor to pass as after on the
+	// next call when tailing.
+	LatestTimestamp int64 `json:"latest-timestamp"`
+}
+
+// Logs returns server log lines newer than the after cursor (unix
+// milliseconds; zero returns from the beginning). limit caps the number of
+// lines when positive.
+func (c *Client) Logs(ctx context.Context, after int64, limit int) (*LogsPage, error) {
+	query := url.Values{}
+	if after > 0 {
+		query.Set("after", fmt.Sprintf("%d", after))
+	}
+	if limit > 0 {
+		query.Set("limit", fmt.Sprintf("%d", limit))
+	}
+	path := "/v0/management/logs"
+	if encoded := query.Encode(); encoded != "" {
+		path += "?" + encoded
+	}
+	out := &LogsPage{}
+	if err := c.do(ctx, http.MethodGet, path, nil, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConfigYAML returns the gateway's current configuration file contents.
+func (c *Client) ConfigYAML(ctx context.Context) ([]byte, error) {
+	return c.doRaw(ctx, http.MethodGet, "/v0/management/config.yaml", "", nil)
+}
+
+// PutConfigYAML replaces the gateway's configuration file. The gateway's file
+// watcher picks up the change, so this doubles as a config reload trigger.
+func (c *Client) PutConfigYAML(ctx context.Context, contents []byte) error {
+	_, err := c.doRaw(ctx, http.MethodPut, "/v0/management/config.yaml", "application/yaml", contents)
+	return err
+}
+
+// ReloadConfig rewrites the current configuration unchanged, forcing the
+// gateway to re-read and re-apply it.
+func (c *Client) ReloadConfig(ctx context.Context) error {
+	contents, err := c.ConfigYAML(ctx)
+	if err != nil {
+		return err
+	}
+	return c.PutConfigYAML(ctx, contents)
+}
+
+// APIError reports a non-2xx management API response.
+type APIError struct {
+	// StatusCode is the HTTP status returned by the gateway.
+	StatusCode int
+	// Message is the error string from the response body, when present.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("management: status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("management: status %d", e.StatusCode)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload any, out any) error {
+	var body []byte
+	contentType := ""
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("management: encode request: %w", err)
+		}
+		body = encoded
+		contentType = "application/json"
+	}
+	data, err := c.doRaw(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("management: decode response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) doRaw(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("management: build request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.key != "" {
+		req.Header.Set("Authorization", "Bearer "+c.key)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("management: request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("management: read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var parsed struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(data, &parsed); err == nil && parsed.Error != "" {
+			apiErr.Message = parsed.Error
+		} else if trimmed := strings.TrimSpace(string(data)); trimmed != "" {
+			apiErr.Message = trimmed
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
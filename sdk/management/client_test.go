@@ -0,0 +1,88 @@
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientListAuthFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0/management/auth-files" || r.Method != http.MethodGet {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Fatalf("Authorization = %q, want bearer key", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"files": []map[string]any{
+				{"id": "a1", "name": "codex.json", "provider": "codex", "disabled": true},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewClient error = %v", err)
+	}
+	files, err := client.ListAuthFiles(context.Background())
+	if err != nil {
+		t.Fatalf("ListAuthFiles error = %v", err)
+	}
+	if len(files) != 1 || files[0].Name != "codex.json" || !files[0].Disabled {
+		t.Fatalf("files = %+v, want single disabled codex.json", files)
+	}
+}
+
+func TestClientSetAuthDisabled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Fatalf("method = %s, want PATCH", r.Method)
+		}
+		var payload struct {
+			Name     string `json:"name"`
+			Disabled bool   `json:"disabled"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("decode payload: %v", err)
+		}
+		if payload.Name != "codex.json" || !payload.Disabled {
+			t.Fatalf("payload = %+v", payload)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"status": "ok"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "secret")
+	if err != nil {
+		t.Fatalf("NewClient error = %v", err)
+	}
+	if err := client.SetAuthDisabled(context.Background(), "codex.json", true); err != nil {
+		t.Fatalf("SetAuthDisabled error = %v", err)
+	}
+}
+
+func TestClientAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid management key"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, "wrong")
+	if err != nil {
+		t.Fatalf("NewClient error = %v", err)
+	}
+	_, err = client.Usage(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("error = %v, want *APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "invalid management key" {
+		t.Fatalf("apiErr = %+v", apiErr)
+	}
+}
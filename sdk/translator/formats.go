@@ -9,4 +9,6 @@ const (
 	FormatGeminiCLI      Format = "gemini-cli"
 	FormatCodex          Format = "codex"
 	FormatAntigravity    Format = "antigravity"
+	FormatCohere         Format = "cohere"
+	FormatOllama         Format = "ollama"
 )
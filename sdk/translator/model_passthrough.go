@@ -0,0 +1,80 @@
+package translator
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// passthroughModelPatterns holds the passthrough-models config list: model
+// name patterns whose requests bypass translation entirely.
+var passthroughModelPatterns atomic.Value // []string
+
+// SetPassthroughModels replaces the raw passthrough model list. Patterns
+// support '*' wildcards and match case-insensitively. Requests for a matching
+// model skip translation here, and the runtime also skips thinking
+// normalization and payload rules, so the body reaches the provider verbatim
+// with only auth injection. It is wired to the passthrough-models config key.
+func SetPassthroughModels(patterns []string) {
+	cleaned := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			cleaned = append(cleaned, strings.ToLower(pattern))
+		}
+	}
+	passthroughModelPatterns.Store(cleaned)
+}
+
+// IsPassthroughModel reports whether a model is configured for raw
+// passthrough. Exposed so runtime helpers outside this package (thinking
+// normalization, payload rules) can honor the same list.
+func IsPassthroughModel(model string) bool {
+	patterns, _ := passthroughModelPatterns.Load().([]string)
+	if len(patterns) == 0 {
+		return false
+	}
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return false
+	}
+	for _, pattern := range patterns {
+		if matchModelPattern(pattern, model) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchModelPattern performs simple glob matching where '*' matches zero or
+// more characters, mirroring the payload rule matcher.
+func matchModelPattern(pattern, model string) bool {
+	if pattern == "" {
+		return false
+	}
+	if pattern == "*" {
+		return true
+	}
+	pi, si := 0, 0
+	starIdx := -1
+	matchIdx := 0
+	for si < len(model) {
+		switch {
+		case pi < len(pattern) && (pattern[pi] == model[si]):
+			pi++
+			si++
+		case pi < len(pattern) && pattern[pi] == '*':
+			starIdx = pi
+			matchIdx = si
+			pi++
+		case starIdx != -1:
+			pi = starIdx + 1
+			matchIdx++
+			si = matchIdx
+		default:
+			return false
+		}
+	}
+	for pi < len(pattern) && pattern[pi] == '*' {
+		pi++
+	}
+	return pi == len(pattern)
+}
@@ -0,0 +1,47 @@
+package translator
+
+import "testing"
+
+func TestIsPassthroughModel(t *testing.T) {
+	if IsPassthroughModel("gpt-4o") {
+		t.Fatalf("matched with no patterns configured")
+	}
+
+	SetPassthroughModels([]string{"grok-*", " My-Custom-Model ", ""})
+	defer SetPassthroughModels(nil)
+
+	cases := []struct {
+		model string
+		want  bool
+	}{
+		{"grok-4", true},
+		{"GROK-4-fast", true},
+		{"my-custom-model", true},
+		{"My-Custom-Model", true},
+		{"gpt-4o", false},
+		{"", false},
+	}
+	for _, tc := range cases {
+		if got := IsPassthroughModel(tc.model); got != tc.want {
+			t.Fatalf("IsPassthroughModel(%q) = %t, want %t", tc.model, got, tc.want)
+		}
+	}
+}
+
+func TestPassthroughModelSkipsTranslation(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(FormatOpenAI, FormatGemini, func(_ string, _ []byte, _ bool) []byte {
+		return []byte(`{"rebuilt":true}`)
+	}, ResponseTransform{})
+
+	SetPassthroughModels([]string{"raw-*"})
+	defer SetPassthroughModels(nil)
+
+	raw := []byte(`{"model":"raw-model","vendor_flag":1}`)
+	if out := reg.TranslateRequest(FormatOpenAI, FormatGemini, "raw-model", raw, false); string(out) != string(raw) {
+		t.Fatalf("passthrough model was translated: %s", out)
+	}
+	if out := reg.TranslateRequest(FormatOpenAI, FormatGemini, "other-model", raw, false); string(out) != `{"rebuilt":true}` {
+		t.Fatalf("non-passthrough model skipped translation: %s", out)
+	}
+}
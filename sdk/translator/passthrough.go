@@ -0,0 +1,71 @@
+package translator
+
+import (
+	"sync/atomic"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// unknownPassthroughEnabled gates copying allowlisted unrecognized request
+// fields through translation. Disabled by default.
+var unknownPassthroughEnabled atomic.Bool
+
+// SetUnknownFieldPassthrough toggles unknown-field passthrough globally.
+// When enabled, top-level request fields that a translator did not map are
+// copied into the translated payload — but only fields on the target format's
+// allowlist, and only when the translator produced nothing at that key. This
+// lets new provider parameters reach the upstream before explicit translator
+// support lands.
+func SetUnknownFieldPassthrough(enabled bool) {
+	unknownPassthroughEnabled.Store(enabled)
+}
+
+// passthroughAllowlist maps a target format to the top-level request keys that
+// are safe to forward verbatim. Keys are only forwarded when the source
+// payload spells them the same way the target schema does, so the lists stick
+// to parameters with a stable meaning across OpenAI-compatible schemas.
+var passthroughAllowlist = map[Format][]string{
+	FormatOpenAI: {
+		"seed",
+		"logit_bias",
+		"logprobs",
+		"top_logprobs",
+		"presence_penalty",
+		"frequency_penalty",
+		"parallel_tool_calls",
+		"service_tier",
+		"user",
+		"n",
+	},
+	FormatClaude: {
+		"top_k",
+		"service_tier",
+		"metadata",
+	},
+}
+
+// applyUnknownFieldPassthrough copies allowlisted source fields that the
+// translator dropped into the translated payload. Fields the translator set
+// (or deliberately mapped elsewhere and left absent with the same name
+// present) are never overwritten.
+func applyUnknownFieldPassthrough(to Format, rawJSON, translated []byte) []byte {
+	keys, ok := passthroughAllowlist[to]
+	if !ok {
+		return translated
+	}
+	out := translated
+	for _, key := range keys {
+		value := gjson.GetBytes(rawJSON, key)
+		if !value.Exists() {
+			continue
+		}
+		if gjson.GetBytes(out, key).Exists() {
+			continue
+		}
+		if updated, err := sjson.SetRawBytes(out, key, []byte(value.Raw)); err == nil {
+			out = updated
+		}
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package translator
+
+import (
+	"testing"
+
+	"github.com/tidwall/gjson"
+)
+
+func TestApplyUnknownFieldPassthrough(t *testing.T) {
+	raw := []byte(`{"model":"m","seed":42,"service_tier":"flex","api_key":"secret"}`)
+
+	out := applyUnknownFieldPassthrough(FormatOpenAI, raw, []byte(`{"model":"m"}`))
+	if got := gjson.GetBytes(out, "seed").Int(); got != 42 {
+		t.Fatalf("seed = %d, want 42", got)
+	}
+	if got := gjson.GetBytes(out, "service_tier").String(); got != "flex" {
+		t.Fatalf("service_tier = %q, want flex", got)
+	}
+	if gjson.GetBytes(out, "api_key").Exists() {
+		t.Fatal("non-allowlisted field was forwarded")
+	}
+
+	// Translator-produced values win over the source payload.
+	out = applyUnknownFieldPassthrough(FormatOpenAI, raw, []byte(`{"model":"m","seed":7}`))
+	if got := gjson.GetBytes(out, "seed").Int(); got != 7 {
+		t.Fatalf("seed = %d, want translator value 7", got)
+	}
+
+	// Formats without an allowlist pass through untouched.
+	out = applyUnknownFieldPassthrough(FormatGemini, raw, []byte(`{"contents":[]}`))
+	if string(out) != `{"contents":[]}` {
+		t.Fatalf("gemini payload changed: %s", out)
+	}
+}
+
+func TestRegistryUnknownFieldPassthrough(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(FormatClaude, FormatOpenAI, func(_ string, _ []byte, _ bool) []byte {
+		return []byte(`{"model":"m"}`)
+	}, ResponseTransform{})
+
+	raw := []byte(`{"model":"m","seed":11}`)
+	if out := reg.TranslateRequest(FormatClaude, FormatOpenAI, "m", raw, false); gjson.GetBytes(out, "seed").Exists() {
+		t.Fatalf("passthrough applied while disabled: %s", out)
+	}
+
+	SetUnknownFieldPassthrough(true)
+	defer SetUnknownFieldPassthrough(false)
+	out := reg.TranslateRequest(FormatClaude, FormatOpenAI, "m", raw, false)
+	if got := gjson.GetBytes(out, "seed").Int(); got != 11 {
+		t.Fatalf("seed = %d, want 11", got)
+	}
+}
@@ -0,0 +1,37 @@
+package translator
+
+import "sync/atomic"
+
+// RequestRecorder observes every request translation performed through the
+// default-registry helpers. It receives the payload handed to the translator
+// and the translated upstream payload.
+type RequestRecorder func(from, to Format, model string, stream bool, rawJSON, translated []byte)
+
+// ResponseRecorder observes every non-stream response translation performed
+// through the default-registry helpers.
+type ResponseRecorder func(from, to Format, model string, originalRequestRawJSON, requestRawJSON, rawJSON []byte, translated string)
+
+var (
+	requestRecorder  atomic.Pointer[RequestRecorder]
+	responseRecorder atomic.Pointer[ResponseRecorder]
+)
+
+// SetRequestRecorder installs fn as the request translation observer. Passing
+// nil removes the current observer.
+func SetRequestRecorder(fn RequestRecorder) {
+	if fn == nil {
+		requestRecorder.Store(nil)
+		return
+	}
+	requestRecorder.Store(&fn)
+}
+
+// SetResponseRecorder installs fn as the non-stream response translation
+// observer. Passing nil removes the current observer.
+func SetResponseRecorder(fn ResponseRecorder) {
+	if fn == nil {
+		responseRecorder.Store(nil)
+		return
+	}
+	responseRecorder.Store(&fn)
+}
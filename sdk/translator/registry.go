@@ -2,6 +2,8 @@ package translator
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -10,21 +12,44 @@ type Registry struct {
 	mu        sync.RWMutex
 	requests  map[Format]map[Format]RequestTransform
 	responses map[Format]map[Format]ResponseTransform
+	// registrations counts how many times each format pair has been registered
+	// so duplicate or conflicting registrations can be reported at startup.
+	registrations map[Format]map[Format]int
+	// streamTransformers run over translated stream chunks in registration order.
+	streamTransformers []StreamTransformer
 }
 
 // NewRegistry constructs an empty translator registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		requests:  make(map[Format]map[Format]RequestTransform),
-		responses: make(map[Format]map[Format]ResponseTransform),
+		requests:      make(map[Format]map[Format]RequestTransform),
+		responses:     make(map[Format]map[Format]ResponseTransform),
+		registrations: make(map[Format]map[Format]int),
 	}
 }
 
 // Register stores request/response transforms between two formats.
+// Re-registering a pair overwrites the previous transforms; the duplicate is
+// recorded and surfaced through Conflicts so it can be reported at startup.
 func (r *Registry) Register(from, to Format, request RequestTransform, response ResponseTransform) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.registerLocked(from, to, request, response)
+}
+
+// RegisterChecked stores transforms for an out-of-tree format pair, returning an
+// error instead of silently overwriting when the pair is already registered.
+func (r *Registry) RegisterChecked(from, to Format, request RequestTransform, response ResponseTransform) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.registrations[from][to] > 0 {
+		return fmt.Errorf("translator: format pair %s -> %s is already registered", from, to)
+	}
+	r.registerLocked(from, to, request, response)
+	return nil
+}
 
+func (r *Registry) registerLocked(from, to Format, request RequestTransform, response ResponseTransform) {
 	if _, ok := r.requests[from]; !ok {
 		r.requests[from] = make(map[Format]RequestTransform)
 	}
@@ -36,17 +61,64 @@ func (r *Registry) Register(from, to Format, request RequestTransform, response
 		r.responses[from] = make(map[Format]ResponseTransform)
 	}
 	r.responses[from][to] = response
+
+	if r.registrations == nil {
+		r.registrations = make(map[Format]map[Format]int)
+	}
+	if _, ok := r.registrations[from]; !ok {
+		r.registrations[from] = make(map[Format]int)
+	}
+	r.registrations[from][to]++
+}
+
+// Conflict describes a format pair that was registered more than once.
+type Conflict struct {
+	// From is the source schema of the duplicated pair.
+	From Format
+	// To is the target schema of the duplicated pair.
+	To Format
+	// Count is the total number of registrations observed for the pair.
+	Count int
+}
+
+// Conflicts returns all format pairs registered more than once, for startup reporting.
+func (r *Registry) Conflicts() []Conflict {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var out []Conflict
+	for from, byTarget := range r.registrations {
+		for to, count := range byTarget {
+			if count > 1 {
+				out = append(out, Conflict{From: from, To: to, Count: count})
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].From != out[j].From {
+			return out[i].From < out[j].From
+		}
+		return out[i].To < out[j].To
+	})
+	return out
 }
 
 // TranslateRequest converts a payload between schemas, returning the original payload
 // if no translator is registered.
 func (r *Registry) TranslateRequest(from, to Format, model string, rawJSON []byte, stream bool) []byte {
+	if sameFormatPassthrough(from, to) || IsPassthroughModel(model) {
+		return rawJSON
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	if byTarget, ok := r.requests[from]; ok {
 		if fn, isOk := byTarget[to]; isOk && fn != nil {
-			return fn(model, rawJSON, stream)
+			translated := fn(model, rawJSON, stream)
+			if unknownPassthroughEnabled.Load() {
+				translated = applyUnknownFieldPassthrough(to, rawJSON, translated)
+			}
+			return translated
 		}
 	}
 	return rawJSON
@@ -70,16 +142,24 @@ func (r *Registry) TranslateStream(ctx context.Context, from, to Format, model s
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	if sameFormatPassthrough(from, to) || IsPassthroughModel(model) {
+		return r.applyStreamTransformers(ctx, from, to, model, passthroughStreamChunk(rawJSON))
+	}
+
 	if byTarget, ok := r.responses[to]; ok {
 		if fn, isOk := byTarget[from]; isOk && fn.Stream != nil {
-			return fn.Stream(ctx, model, originalRequestRawJSON, requestRawJSON, rawJSON, param)
+			return r.applyStreamTransformers(ctx, from, to, model, fn.Stream(ctx, model, originalRequestRawJSON, requestRawJSON, rawJSON, param))
 		}
 	}
-	return []string{string(rawJSON)}
+	return r.applyStreamTransformers(ctx, from, to, model, []string{string(rawJSON)})
 }
 
 // TranslateNonStream applies the registered non-stream response translator.
 func (r *Registry) TranslateNonStream(ctx context.Context, from, to Format, model string, originalRequestRawJSON, requestRawJSON, rawJSON []byte, param *any) string {
+	if sameFormatPassthrough(from, to) || IsPassthroughModel(model) {
+		return string(rawJSON)
+	}
+
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -116,9 +196,23 @@ func Register(from, to Format, request RequestTransform, response ResponseTransf
 	defaultRegistry.Register(from, to, request, response)
 }
 
+// RegisterChecked attaches transforms to the default registry, failing on duplicates.
+func RegisterChecked(from, to Format, request RequestTransform, response ResponseTransform) error {
+	return defaultRegistry.RegisterChecked(from, to, request, response)
+}
+
+// Conflicts reports duplicate registrations on the default registry.
+func Conflicts() []Conflict {
+	return defaultRegistry.Conflicts()
+}
+
 // TranslateRequest is a helper on the default registry.
 func TranslateRequest(from, to Format, model string, rawJSON []byte, stream bool) []byte {
-	return defaultRegistry.TranslateRequest(from, to, model, rawJSON, stream)
+	translated := defaultRegistry.TranslateRequest(from, to, model, rawJSON, stream)
+	if fn := requestRecorder.Load(); fn != nil {
+		(*fn)(from, to, model, stream, rawJSON, translated)
+	}
+	return translated
 }
 
 // HasResponseTransformer inspects the default registry.
@@ -133,7 +227,11 @@ func TranslateStream(ctx context.Context, from, to Format, model string, origina
 
 // TranslateNonStream is a helper on the default registry.
 func TranslateNonStream(ctx context.Context, from, to Format, model string, originalRequestRawJSON, requestRawJSON, rawJSON []byte, param *any) string {
-	return defaultRegistry.TranslateNonStream(ctx, from, to, model, originalRequestRawJSON, requestRawJSON, rawJSON, param)
+	translated := defaultRegistry.TranslateNonStream(ctx, from, to, model, originalRequestRawJSON, requestRawJSON, rawJSON, param)
+	if fn := responseRecorder.Load(); fn != nil {
+		(*fn)(from, to, model, originalRequestRawJSON, requestRawJSON, rawJSON, translated)
+	}
+	return translated
 }
 
 // TranslateTokenCount is a helper on the default registry.
@@ -0,0 +1,39 @@
+package translator
+
+import (
+	"bytes"
+	"sync/atomic"
+)
+
+// sameFormatPassthroughEnabled gates the zero-copy path for requests whose
+// source schema equals the provider's native schema. Disabled by default.
+var sameFormatPassthroughEnabled atomic.Bool
+
+// SetSameFormatPassthrough toggles zero-copy passthrough for same-format
+// traffic. When enabled, a request whose source format equals the target
+// format skips the translate→retranslate round trip entirely: the request
+// body and every response chunk pass through untouched (minus SSE framing).
+// This cuts latency and CPU for OpenAI→OpenAI-compatible providers at the
+// cost of skipping the identity translators' payload normalization.
+func SetSameFormatPassthrough(enabled bool) {
+	sameFormatPassthroughEnabled.Store(enabled)
+}
+
+// sameFormatPassthrough reports whether the zero-copy path applies to a pair.
+func sameFormatPassthrough(from, to Format) bool {
+	return from != "" && from == to && sameFormatPassthroughEnabled.Load()
+}
+
+// passthroughStreamChunk unwraps one upstream SSE line without re-encoding
+// it, mirroring what the identity stream translators do: the data prefix is
+// stripped, terminal [DONE] markers are swallowed, and everything else is
+// forwarded verbatim.
+func passthroughStreamChunk(rawJSON []byte) []string {
+	if bytes.HasPrefix(rawJSON, []byte("data:")) {
+		rawJSON = bytes.TrimSpace(rawJSON[5:])
+	}
+	if bytes.Equal(rawJSON, []byte("[DONE]")) {
+		return []string{}
+	}
+	return []string{string(rawJSON)}
+}
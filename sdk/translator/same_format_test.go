@@ -0,0 +1,60 @@
+package translator
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSameFormatPassthrough(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register(FormatOpenAI, FormatOpenAI, func(_ string, _ []byte, _ bool) []byte {
+		return []byte(`{"rebuilt":true}`)
+	}, ResponseTransform{
+		NonStream: func(_ context.Context, _ string, _, _, rawJSON []byte, _ *any) string {
+			return `{"rebuilt":true}`
+		},
+	})
+
+	raw := []byte(`{"model":"m","seed":1}`)
+	if out := reg.TranslateRequest(FormatOpenAI, FormatOpenAI, "m", raw, false); string(out) != `{"rebuilt":true}` {
+		t.Fatalf("identity translator bypassed while passthrough disabled: %s", out)
+	}
+
+	SetSameFormatPassthrough(true)
+	defer SetSameFormatPassthrough(false)
+
+	if out := reg.TranslateRequest(FormatOpenAI, FormatOpenAI, "m", raw, false); string(out) != string(raw) {
+		t.Fatalf("request not passed through: %s", out)
+	}
+	if out := reg.TranslateNonStream(context.Background(), FormatOpenAI, FormatOpenAI, "m", nil, nil, raw, nil); out != string(raw) {
+		t.Fatalf("non-stream response not passed through: %s", out)
+	}
+	// Cross-format pairs still translate.
+	if out := reg.TranslateRequest(FormatOpenAI, FormatGemini, "m", raw, false); string(out) != string(raw) {
+		t.Fatalf("unregistered cross-format pair changed payload: %s", out)
+	}
+}
+
+func TestPassthroughStreamChunk(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"data line", `data: {"a":1}`, []string{`{"a":1}`}},
+		{"done marker", "data: [DONE]", []string{}},
+		{"event line", "event: ping", []string{"event: ping"}},
+		{"bare json", `{"a":1}`, []string{`{"a":1}`}},
+	}
+	for _, tc := range cases {
+		got := passthroughStreamChunk([]byte(tc.in))
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: got %d chunks, want %d", tc.name, len(got), len(tc.want))
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Fatalf("%s: chunk %d = %q, want %q", tc.name, i, got[i], tc.want[i])
+			}
+		}
+	}
+}
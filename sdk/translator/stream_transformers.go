@@ -0,0 +1,38 @@
+package translator
+
+import "context"
+
+// StreamTransformer rewrites translated stream chunks before they are forwarded
+// downstream. Transformers run after TranslateStream has produced chunks in the
+// client schema, so embedders can rewrite tool names, watermark output, or drop
+// chunks without forking the built-in translators. Returning an empty slice
+// suppresses the chunks entirely.
+type StreamTransformer func(ctx context.Context, from, to Format, model string, chunks []string) []string
+
+// RegisterStreamTransformer appends a stream transformer to the registry.
+// Transformers are applied in registration order to every translated stream chunk.
+func (r *Registry) RegisterStreamTransformer(transformer StreamTransformer) {
+	if transformer == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.streamTransformers = append(r.streamTransformers, transformer)
+}
+
+// applyStreamTransformers runs registered transformers over translated chunks.
+// Callers must hold at least a read lock on the registry.
+func (r *Registry) applyStreamTransformers(ctx context.Context, from, to Format, model string, chunks []string) []string {
+	for _, transformer := range r.streamTransformers {
+		chunks = transformer(ctx, from, to, model, chunks)
+		if len(chunks) == 0 {
+			return chunks
+		}
+	}
+	return chunks
+}
+
+// RegisterStreamTransformer appends a stream transformer to the default registry.
+func RegisterStreamTransformer(transformer StreamTransformer) {
+	defaultRegistry.RegisterStreamTransformer(transformer)
+}
@@ -0,0 +1,47 @@
+package translator
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRegistryStreamTransformers(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(FormatOpenAI, FormatClaude, nil, ResponseTransform{
+		Stream: func(_ context.Context, _ string, _, _, rawJSON []byte, _ *any) []string {
+			return []string{string(rawJSON)}
+		},
+	})
+	registry.RegisterStreamTransformer(func(_ context.Context, _, _ Format, _ string, chunks []string) []string {
+		out := make([]string, 0, len(chunks))
+		for _, chunk := range chunks {
+			out = append(out, strings.ReplaceAll(chunk, "secret", "[redacted]"))
+		}
+		return out
+	})
+
+	var param any
+	chunks := registry.TranslateStream(context.Background(), FormatClaude, FormatOpenAI, "model", nil, nil, []byte("the secret value"), &param)
+	if len(chunks) != 1 || chunks[0] != "the [redacted] value" {
+		t.Fatalf("transformer not applied to registered pair: %v", chunks)
+	}
+
+	// Transformers also run on the passthrough path when no translator is registered.
+	chunks = registry.TranslateStream(context.Background(), FormatGemini, FormatOpenAI, "model", nil, nil, []byte("secret"), &param)
+	if len(chunks) != 1 || chunks[0] != "[redacted]" {
+		t.Fatalf("transformer not applied to passthrough path: %v", chunks)
+	}
+}
+
+func TestRegisterStreamTransformerSuppressChunks(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterStreamTransformer(func(_ context.Context, _, _ Format, _ string, _ []string) []string {
+		return nil
+	})
+	var param any
+	chunks := registry.TranslateStream(context.Background(), FormatClaude, FormatOpenAI, "model", nil, nil, []byte("drop me"), &param)
+	if len(chunks) != 0 {
+		t.Fatalf("expected chunks to be suppressed, got %v", chunks)
+	}
+}